@@ -301,6 +301,7 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         alias: 'revision',
         description: 'Search a revision (branch, commit hash, or tag) instead of the default branch.',
         placeholder: 'branch/commit/tag',
+        discreteValues: () => [...predicateCompletion('rev')],
         singular: true,
     },
     [FilterType.select]: {
@@ -42,6 +42,7 @@ export const PREDICATES: Access[] = [
                     { name: 'key' },
                     { name: 'meta' },
                     { name: 'topic' },
+                    { name: 'symbol' },
                 ],
             },
         ],
@@ -55,7 +56,16 @@ export const PREDICATES: Access[] = [
             },
             {
                 name: 'has',
-                fields: [{ name: 'content' }, { name: 'owner' }],
+                fields: [{ name: 'content' }, { name: 'owner' }, { name: 'symbol' }],
+            },
+        ],
+    },
+    {
+        name: 'rev',
+        fields: [
+            {
+                name: 'at',
+                fields: [{ name: 'time' }],
             },
         ],
     },
@@ -219,6 +229,12 @@ export const predicateCompletion = (field: string): Completion[] => {
                     'Search only inside repositories having ({key}:{value}) pair, or ({key}) with any value or ({key}:) with no value metadata',
                 asSnippet: true,
             },
+            {
+                label: 'has.symbol(...)',
+                insertText: 'has.symbol(kind:${1:function} name:${2})',
+                asSnippet: true,
+                description: 'Search only inside repositories that define a symbol with a matching kind and name',
+            },
         ]
     }
     if (field === 'file') {
@@ -241,6 +257,22 @@ export const predicateCompletion = (field: string): Completion[] => {
                 asSnippet: true,
                 description: 'Search only inside files that have a contributor that matches a pattern',
             },
+            {
+                label: 'has.symbol(...)',
+                insertText: 'has.symbol(kind:${1:function} name:${2})',
+                asSnippet: true,
+                description: 'Search only inside files that define a symbol with a matching kind and name',
+            },
+        ]
+    }
+    if (field === 'rev') {
+        return [
+            {
+                label: 'at.time(...)',
+                insertText: 'at.time(${1:1 month ago})',
+                asSnippet: true,
+                description: 'Search the default branch as it was at a point in time',
+            },
         ]
     }
     return []
@@ -73,6 +73,8 @@ export const CodeMonitorNode: React.FunctionComponent<React.PropsWithChildren<Co
                             return 'Sends Slack notification'
                         case 'MonitorWebhook':
                             return 'Calls webhook'
+                        case 'MonitorIssue':
+                            return 'Opens issue'
                         default:
                             return ''
                     }
@@ -8,8 +8,10 @@ import {
     MonitorEmailPriority,
     type MonitorWebhookInput,
     type MonitorSlackWebhookInput,
+    type MonitorIssueInput,
     type MonitorWebhookFields,
     type MonitorSlackWebhookFields,
+    type MonitorIssueFields,
     type MonitorEmailFields,
 } from '../../graphql-operations'
 
@@ -42,6 +44,15 @@ function convertWebhookAction(action: MonitorWebhookFields): MonitorWebhookInput
     }
 }
 
+function convertIssueAction(action: MonitorIssueFields): MonitorIssueInput {
+    return {
+        enabled: action.enabled,
+        includeResults: action.includeResults,
+        externalService: action.externalService,
+        repository: action.repository,
+    }
+}
+
 export function convertActionsForCreate(
     actions: CodeMonitorFields['actions']['nodes'],
     authenticatedUserId: AuthenticatedUser['id']
@@ -60,6 +71,10 @@ export function convertActionsForCreate(
                 return {
                     webhook: convertWebhookAction(action),
                 }
+            case 'MonitorIssue':
+                return {
+                    issue: convertIssueAction(action),
+                }
         }
     })
 }
@@ -92,6 +107,13 @@ export function convertActionsForUpdate(
                         update: convertWebhookAction(action),
                     },
                 }
+            case 'MonitorIssue':
+                return {
+                    issue: {
+                        id: action.id || null,
+                        update: convertIssueAction(action),
+                    },
+                }
         }
     })
 }
@@ -63,6 +63,18 @@ const MonitorSlackWebhookFragment = gql`
     }
 `
 
+const MonitorIssueFragment = gql`
+    fragment MonitorIssueFields on MonitorIssue {
+        __typename
+        id
+        enabled
+        includeResults
+        externalService
+        repository
+        issueURL
+    }
+`
+
 const CodeMonitorFragment = gql`
     fragment CodeMonitorFields on Monitor {
         id
@@ -80,6 +92,7 @@ const CodeMonitorFragment = gql`
                 ...MonitorEmailFields
                 ...MonitorWebhookFields
                 ...MonitorSlackWebhookFields
+                ...MonitorIssueFields
             }
         }
         owner {
@@ -91,6 +104,7 @@ const CodeMonitorFragment = gql`
     ${MonitorEmailFragment}
     ${MonitorWebhookFragment}
     ${MonitorSlackWebhookFragment}
+    ${MonitorIssueFragment}
 `
 
 const ListCodeMonitorsFragment = gql`
@@ -255,6 +269,14 @@ export const fetchCodeMonitor = (id: string): Observable<FetchCodeMonitorResult>
                                 includeResults
                                 url
                             }
+                            ... on MonitorIssue {
+                                id
+                                enabled
+                                includeResults
+                                externalService
+                                repository
+                                issueURL
+                            }
                         }
                     }
                     trigger {
@@ -57,10 +57,15 @@ export const FormActionArea: React.FunctionComponent<React.PropsWithChildren<Act
         actions.nodes.find(action => action.__typename === 'MonitorWebhook')
     )
 
+    // Issue actions can only be configured through the API, so they are kept as is.
+    const [issueActions] = useState<MonitorAction[]>(() =>
+        actions.nodes.filter(action => action.__typename === 'MonitorIssue')
+    )
+
     // Form is completed if there is at least one action
     useEffect(() => {
-        setActionsCompleted(!!emailAction || !!slackWebhookAction || !!webhookAction)
-    }, [emailAction, setActionsCompleted, slackWebhookAction, webhookAction])
+        setActionsCompleted(!!emailAction || !!slackWebhookAction || !!webhookAction || issueActions.length > 0)
+    }, [emailAction, issueActions, setActionsCompleted, slackWebhookAction, webhookAction])
 
     useEffect(() => {
         const actions: CodeMonitorFields['actions'] = { nodes: [] }
@@ -73,8 +78,9 @@ export const FormActionArea: React.FunctionComponent<React.PropsWithChildren<Act
         if (webhookAction) {
             actions.nodes.push(webhookAction)
         }
+        actions.nodes.push(...issueActions)
         onActionsChange(actions)
-    }, [emailAction, onActionsChange, slackWebhookAction, webhookAction])
+    }, [emailAction, issueActions, onActionsChange, slackWebhookAction, webhookAction])
 
     const showWebhooks = useExperimentalFeatures(features => features.codeMonitoringWebHooks)
 
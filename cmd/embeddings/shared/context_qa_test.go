@@ -15,6 +15,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/embeddings/qa"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings"
 	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -62,6 +63,7 @@ func TestRecall(t *testing.T) {
 			args,
 			getRepoEmbeddingIndex,
 			lookupQueryEmbedding,
+			conftypes.EmbeddingsApproximateSearchConfig{},
 		)
 	}
 
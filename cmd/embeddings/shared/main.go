@@ -70,6 +70,12 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		func(ctx context.Context, repoID api.RepoID, repoName api.RepoName) (*embeddings.RepoEmbeddingIndex, error) {
 			return embeddings.DownloadRepoEmbeddingIndex(ctx, uploadStore, repoID, repoName)
 		},
+		func(ctx context.Context, repoID api.RepoID) (*embeddings.RepoEmbeddingHNSWIndex, error) {
+			if !getApproximateSearchConfig().Enabled {
+				return nil, nil
+			}
+			return embeddings.DownloadIndex[embeddings.RepoEmbeddingHNSWIndex](ctx, uploadStore, string(embeddings.GetRepoEmbeddingHNSWIndexName(repoID)))
+		},
 		config.EmbeddingsCacheSize,
 	)
 	if err != nil {
@@ -77,7 +83,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	}
 
 	// Create HTTP server
-	handler := NewHandler(logger, indexGetter.Get, getQueryEmbedding)
+	handler := NewHandler(logger, indexGetter.Get, getQueryEmbedding, getApproximateSearchConfig)
 	handler = handlePanic(logger, handler)
 	handler = featureflag.Middleware(db.FeatureFlags(), handler)
 	handler = trace.HTTPMiddleware(logger, handler, conf.DefaultClient())
@@ -101,6 +107,7 @@ func NewHandler(
 	logger log.Logger,
 	getRepoEmbeddingIndex getRepoEmbeddingIndexFn,
 	getQueryEmbedding getQueryEmbeddingFn,
+	getApproximateSearchConfig getApproximateSearchConfigFn,
 ) http.Handler {
 	// Initialize the legacy JSON API server
 	mux := http.NewServeMux()
@@ -117,7 +124,7 @@ func NewHandler(
 			return
 		}
 
-		res, err := searchRepoEmbeddingIndexes(r.Context(), args, getRepoEmbeddingIndex, getQueryEmbedding)
+		res, err := searchRepoEmbeddingIndexes(r.Context(), args, getRepoEmbeddingIndex, getQueryEmbedding, getApproximateSearchConfig())
 		if errcode.IsNotFound(err) {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
@@ -156,6 +163,14 @@ func getQueryEmbedding(ctx context.Context, query string) ([]float32, string, er
 	return embeddings.Embeddings, client.GetModelIdentifier(), nil
 }
 
+func getApproximateSearchConfig() conftypes.EmbeddingsApproximateSearchConfig {
+	c := conf.GetEmbeddingsConfig(conf.Get().SiteConfig())
+	if c == nil {
+		return conftypes.EmbeddingsApproximateSearchConfig{}
+	}
+	return c.ApproximateSearch
+}
+
 func mustInitializeFrontendDB(observationCtx *observation.Context) *sql.DB {
 	dsn := conf.GetServiceConnectionValueAndRestartOnChange(func(serviceConnections conftypes.ServiceConnections) string {
 		return serviceConnections.PostgresDSN
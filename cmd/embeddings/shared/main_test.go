@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings"
 	"github.com/sourcegraph/sourcegraph/internal/endpoint"
 )
@@ -87,12 +88,14 @@ func TestEmbeddingsSearch(t *testing.T) {
 		logger,
 		getRepoEmbeddingIndex,
 		getMockQueryEmbedding,
+		getDisabledApproximateSearchConfig,
 	))
 
 	server2 := httptest.NewServer(NewHandler(
 		logger,
 		getRepoEmbeddingIndex,
 		getMockQueryEmbedding,
+		getDisabledApproximateSearchConfig,
 	))
 
 	client := embeddings.NewClient(endpoint.Static(server1.URL, server2.URL), http.DefaultClient)
@@ -280,6 +283,7 @@ func TestEmbeddingModelMismatch(t *testing.T) {
 		logger,
 		getRepoEmbeddingIndex,
 		getQueryEmbedding,
+		getDisabledApproximateSearchConfig,
 	))
 
 	client := embeddings.NewClient(endpoint.Static(server.URL), http.DefaultClient)
@@ -325,3 +329,7 @@ func TestEmbeddingModelMismatch(t *testing.T) {
 		})
 	}
 }
+
+func getDisabledApproximateSearchConfig() conftypes.EmbeddingsApproximateSearchConfig {
+	return conftypes.EmbeddingsApproximateSearchConfig{}
+}
@@ -24,6 +24,10 @@ import (
 
 type downloadRepoEmbeddingIndexFn func(ctx context.Context, repoID api.RepoID, repoName api.RepoName) (*embeddings.RepoEmbeddingIndex, error)
 
+// downloadRepoEmbeddingHNSWIndexFn returns the HNSW graphs stored next to the embedding index
+// of a repository, or nil if approximate search is disabled.
+type downloadRepoEmbeddingHNSWIndexFn func(ctx context.Context, repoID api.RepoID) (*embeddings.RepoEmbeddingHNSWIndex, error)
+
 type repoEmbeddingIndexCacheEntry struct {
 	index      *embeddings.RepoEmbeddingIndex
 	finishedAt time.Time
@@ -127,6 +131,7 @@ func NewCachedEmbeddingIndexGetter(
 	repoStore database.RepoStore,
 	repoEmbeddingJobStore repo.RepoEmbeddingJobsStore,
 	downloadRepoEmbeddingIndex downloadRepoEmbeddingIndexFn,
+	downloadRepoEmbeddingHNSWIndex downloadRepoEmbeddingHNSWIndexFn,
 	cacheSizeBytes uint64,
 ) (*CachedEmbeddingIndexGetter, error) {
 	cache, err := newEmbeddingsIndexCache(cacheSizeBytes)
@@ -134,17 +139,19 @@ func NewCachedEmbeddingIndexGetter(
 		return nil, err
 	}
 	return &CachedEmbeddingIndexGetter{
-		repoStore:                  repoStore,
-		repoEmbeddingJobsStore:     repoEmbeddingJobStore,
-		downloadRepoEmbeddingIndex: downloadRepoEmbeddingIndex,
-		cache:                      cache,
+		repoStore:                      repoStore,
+		repoEmbeddingJobsStore:         repoEmbeddingJobStore,
+		downloadRepoEmbeddingIndex:     downloadRepoEmbeddingIndex,
+		downloadRepoEmbeddingHNSWIndex: downloadRepoEmbeddingHNSWIndex,
+		cache:                          cache,
 	}, nil
 }
 
 type CachedEmbeddingIndexGetter struct {
-	repoStore                  database.RepoStore
-	repoEmbeddingJobsStore     repo.RepoEmbeddingJobsStore
-	downloadRepoEmbeddingIndex downloadRepoEmbeddingIndexFn
+	repoStore                      database.RepoStore
+	repoEmbeddingJobsStore         repo.RepoEmbeddingJobsStore
+	downloadRepoEmbeddingIndex     downloadRepoEmbeddingIndexFn
+	downloadRepoEmbeddingHNSWIndex downloadRepoEmbeddingHNSWIndexFn
 
 	cache *embeddingsIndexCache
 	sf    singleflight.Group
@@ -203,6 +210,28 @@ func (c *CachedEmbeddingIndexGetter) getAndCacheIndex(ctx context.Context, repoI
 	if err != nil {
 		return nil, errors.Wrap(err, "downloading repo embedding index")
 	}
+	c.attachHNSWIndex(ctx, repoID, embeddingIndex)
 	c.cache.Add(embeddings.GetRepoEmbeddingIndexName(repoID), repoEmbeddingIndexCacheEntry{index: embeddingIndex, finishedAt: *finishedAt})
 	return embeddingIndex, nil
 }
+
+// attachHNSWIndex downloads the HNSW graphs of the given index, if any, and attaches them so the
+// index can be searched approximately. The graphs are optional: if they are missing, stale, or
+// fail to download, the index is searched exhaustively instead.
+func (c *CachedEmbeddingIndexGetter) attachHNSWIndex(ctx context.Context, repoID api.RepoID, embeddingIndex *embeddings.RepoEmbeddingIndex) {
+	if c.downloadRepoEmbeddingHNSWIndex == nil {
+		return
+	}
+
+	tr := trace.FromContext(ctx)
+	hnswIndex, err := c.downloadRepoEmbeddingHNSWIndex(ctx, repoID)
+	if err != nil {
+		tr.AddEvent("failed to download HNSW index", trace.Error(err))
+		return
+	}
+	if hnswIndex == nil {
+		return
+	}
+
+	tr.AddEvent("downloaded HNSW index", attribute.Bool("attached", hnswIndex.Attach(embeddingIndex)))
+}
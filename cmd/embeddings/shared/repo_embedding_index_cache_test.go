@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/embeddings"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/background/repo"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 func TestGetCachedRepoEmbeddingIndex(t *testing.T) {
@@ -46,6 +47,7 @@ func TestGetCachedRepoEmbeddingIndex(t *testing.T) {
 				}, nil
 			}
 		},
+		nil,
 		uint64(cacheSize),
 	)
 	if err != nil {
@@ -167,6 +169,7 @@ func TestConcurrentGetCachedRepoEmbeddingIndex(t *testing.T) {
 			time.Sleep(time.Millisecond * 500)
 			return &embeddings.RepoEmbeddingIndex{}, nil
 		},
+		nil,
 		10*1024*1024,
 	)
 	if err != nil {
@@ -190,3 +193,69 @@ func TestConcurrentGetCachedRepoEmbeddingIndex(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestGetCachedRepoEmbeddingIndexHNSW(t *testing.T) {
+	mockRepoEmbeddingJobsStore := repo.NewMockRepoEmbeddingJobsStore()
+	mockRepoStore := dbmocks.NewMockRepoStore()
+
+	finishedAt := time.Now()
+	mockRepoEmbeddingJobsStore.GetLastCompletedRepoEmbeddingJobFunc.SetDefaultHook(func(ctx context.Context, id api.RepoID) (*repo.RepoEmbeddingJob, error) {
+		return &repo.RepoEmbeddingJob{FinishedAt: &finishedAt}, nil
+	})
+
+	newIndex := func(revision api.CommitID) *embeddings.RepoEmbeddingIndex {
+		return &embeddings.RepoEmbeddingIndex{
+			Revision: revision,
+			CodeIndex: embeddings.EmbeddingIndex{
+				Embeddings:      []int8{1, 2, 3, 4},
+				ColumnDimension: 2,
+				RowMetadata:     []embeddings.RepoEmbeddingRowMetadata{{FileName: "a.go"}, {FileName: "b.go"}},
+			},
+		}
+	}
+
+	indexGetter, err := NewCachedEmbeddingIndexGetter(
+		mockRepoStore,
+		mockRepoEmbeddingJobsStore,
+		func(ctx context.Context, _ api.RepoID, _ api.RepoName) (*embeddings.RepoEmbeddingIndex, error) {
+			return newIndex("deadbeef"), nil
+		},
+		func(ctx context.Context, repoID api.RepoID) (*embeddings.RepoEmbeddingHNSWIndex, error) {
+			index := newIndex("deadbeef")
+			params := embeddings.HNSWParameters{M: 4, EfConstruction: 8}
+			switch repoID {
+			case 1:
+				return &embeddings.RepoEmbeddingHNSWIndex{
+					Revision:  index.Revision,
+					CodeIndex: embeddings.BuildHNSWIndex(&index.CodeIndex, params),
+					TextIndex: embeddings.BuildHNSWIndex(&index.TextIndex, params),
+				}, nil
+			case 2:
+				// built from an older revision
+				return &embeddings.RepoEmbeddingHNSWIndex{
+					Revision:  "cafebabe",
+					CodeIndex: embeddings.BuildHNSWIndex(&index.CodeIndex, params),
+					TextIndex: embeddings.BuildHNSWIndex(&index.TextIndex, params),
+				}, nil
+			case 3:
+				return nil, errors.New("not found")
+			default:
+				return nil, nil
+			}
+		},
+		10*1024*1024,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for repoID, wantAttached := range map[api.RepoID]bool{1: true, 2: false, 3: false, 4: false} {
+		index, err := indexGetter.Get(context.Background(), repoID, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if attached := index.CodeIndex.HNSW != nil; attached != wantAttached {
+			t.Errorf("unexpected HNSW index for repo %d. want attached=%v", repoID, wantAttached)
+		}
+	}
+}
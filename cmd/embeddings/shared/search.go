@@ -7,6 +7,7 @@ import (
 	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -20,6 +21,8 @@ const (
 type (
 	getRepoEmbeddingIndexFn func(ctx context.Context, repoID api.RepoID, repoName api.RepoName) (*embeddings.RepoEmbeddingIndex, error)
 	getQueryEmbeddingFn     func(ctx context.Context, model string) ([]float32, string, error)
+
+	getApproximateSearchConfigFn func() conftypes.EmbeddingsApproximateSearchConfig
 )
 
 func searchRepoEmbeddingIndexes(
@@ -27,6 +30,7 @@ func searchRepoEmbeddingIndexes(
 	params embeddings.EmbeddingsSearchParameters,
 	getRepoEmbeddingIndex getRepoEmbeddingIndexFn,
 	getQueryEmbedding getQueryEmbeddingFn,
+	approximateSearch conftypes.EmbeddingsApproximateSearchConfig,
 ) (_ *embeddings.EmbeddingCombinedSearchResults, err error) {
 	tr, ctx := trace.New(ctx, "searchRepoEmbeddingIndexes", params.Attrs()...)
 	defer tr.EndWithErr(&err)
@@ -45,6 +49,9 @@ func searchRepoEmbeddingIndexes(
 	searchOpts := embeddings.SearchOptions{
 		UseDocumentRanks: params.UseDocumentRanks,
 	}
+	if approximateSearch.Enabled {
+		searchOpts.EfSearch = approximateSearch.EfSearch
+	}
 
 	searchRepo := func(repoID api.RepoID, repoName api.RepoName) (codeResults, textResults []embeddings.EmbeddingSearchResult, err error) {
 		tr, ctx := trace.New(ctx, "searchRepo",
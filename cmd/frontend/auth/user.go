@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -143,6 +144,12 @@ func GetAndSaveUser(ctx context.Context, db database.DB, op GetAndSaveUserOp) (n
 		}
 		act.UID = user.ID
 
+		// Sourcegraph operator accounts are hidden from site admins, so we don't
+		// announce them either.
+		if !act.SourcegraphOperator {
+			outbound.EnqueueUserCreated(ctx, logger, db, user)
+		}
+
 		// Schedule a permission sync, since this is new user
 		permssync.SchedulePermsSync(ctx, logger, db, permssync.ScheduleSyncOpts{
 			UserIDs:           []int32{user.ID},
@@ -683,6 +683,7 @@ func (m *mocks) DB() database.DB {
 	db.AuthzFunc.SetDefaultReturn(authzStore)
 	db.EventLogsFunc.SetDefaultReturn(dbmocks.NewMockEventLogStore())
 	db.PermissionSyncJobsFunc.SetDefaultReturn(permsSyncStore)
+	db.OutboundWebhooksFunc.SetDefaultReturn(dbmocks.NewMockOutboundWebhookStore())
 	return db
 }
 
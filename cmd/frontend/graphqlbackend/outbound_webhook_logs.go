@@ -3,15 +3,20 @@ package graphqlbackend
 import (
 	"context"
 	"strconv"
+	"time"
 
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/auth"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/syncx"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 const (
@@ -38,6 +43,7 @@ type OutboundWebhookLogResolver interface {
 	Request(context.Context) (*webhookLogRequestResolver, error)
 	Response(context.Context) (*webhookLogMessageResolver, error)
 	Error(context.Context) (*string, error)
+	Redelivery() bool
 }
 
 type OutboundWebhookJobResolver interface {
@@ -47,6 +53,92 @@ type OutboundWebhookJobResolver interface {
 	Payload(context.Context) (string, error)
 }
 
+type RedeliverOutboundWebhookLogArgs struct {
+	ID              graphql.ID  `json:"id"`
+	OutboundWebhook *graphql.ID `json:"outboundWebhook"`
+}
+
+type ReplayOutboundWebhookEventsArgs struct {
+	OutboundWebhook graphql.ID        `json:"outboundWebhook"`
+	EventType       string            `json:"eventType"`
+	Since           gqlutil.DateTime  `json:"since"`
+	Until           *gqlutil.DateTime `json:"until"`
+}
+
+func (r *schemaResolver) RedeliverOutboundWebhookLog(ctx context.Context, args RedeliverOutboundWebhookLogArgs) (OutboundWebhookJobResolver, error) {
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalOutboundWebhookLogID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	store := outboundWebhookStore(r.db)
+	log, err := store.ToLogStore().GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookID := log.OutboundWebhookID
+	if args.OutboundWebhook != nil {
+		if webhookID, err = unmarshalOutboundWebhookID(*args.OutboundWebhook); err != nil {
+			return nil, err
+		}
+		// Ensure that the webhook exists, so that we return a not found error
+		// rather than a foreign key violation.
+		if _, err := store.GetByID(ctx, webhookID); err != nil {
+			return nil, err
+		}
+	}
+
+	job, err := store.ToJobStore().Redeliver(ctx, log.JobID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return newOutboundWebhookJobResolverFromJob(job), nil
+}
+
+func (r *schemaResolver) ReplayOutboundWebhookEvents(ctx context.Context, args ReplayOutboundWebhookEventsArgs) (int32, error) {
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return 0, err
+	}
+
+	webhookID, err := unmarshalOutboundWebhookID(args.OutboundWebhook)
+	if err != nil {
+		return 0, err
+	}
+
+	opts := database.OutboundWebhookJobReplayOpts{
+		OutboundWebhookID: webhookID,
+		EventType:         args.EventType,
+		Since:             args.Since.Time,
+		Until:             time.Now(),
+	}
+	if args.Until != nil {
+		opts.Until = args.Until.Time
+	}
+	if !opts.Since.Before(opts.Until) {
+		return 0, errors.New("since must be before until")
+	}
+	// Rather than silently replaying nothing, tell the caller when part of the
+	// window has already been deleted by the janitor.
+	retention := outbound.Retention(r.logger, conf.Get())
+	if retainedSince := time.Now().Add(-retention); opts.Since.Before(retainedSince) {
+		return 0, errors.Newf("events are only retained for %s: since must be after %s", retention, retainedSince.UTC().Format(time.RFC3339))
+	}
+
+	store := outboundWebhookStore(r.db)
+	if _, err := store.GetByID(ctx, webhookID); err != nil {
+		return 0, err
+	}
+
+	count, err := store.ToJobStore().Replay(ctx, opts)
+	return int32(count), err
+}
+
 type outboundWebhookLogStatsResolver struct {
 	total, errored int64
 }
@@ -189,6 +281,10 @@ func (r *outboundWebhookLogResolver) Error(ctx context.Context) (*string, error)
 	return &message, nil
 }
 
+func (r *outboundWebhookLogResolver) Redelivery() bool {
+	return r.log.Redelivery
+}
+
 type outboundWebhookJobResolver struct {
 	id  int64
 	job func() (*types.OutboundWebhookJob, error)
@@ -199,12 +295,22 @@ func newOutboundWebhookJobResolver(
 	id int64,
 ) OutboundWebhookJobResolver {
 	return &outboundWebhookJobResolver{
+		id: id,
 		job: syncx.OnceValues(func() (*types.OutboundWebhookJob, error) {
 			return store.GetByID(ctx, id)
 		}),
 	}
 }
 
+func newOutboundWebhookJobResolverFromJob(job *types.OutboundWebhookJob) OutboundWebhookJobResolver {
+	return &outboundWebhookJobResolver{
+		id: job.ID,
+		job: func() (*types.OutboundWebhookJob, error) {
+			return job, nil
+		},
+	}
+}
+
 func (r *outboundWebhookJobResolver) ID() graphql.ID {
 	return marshalOutboundWebhookJobID(r.id)
 }
@@ -248,3 +354,12 @@ func marshalOutboundWebhookJobID(id int64) graphql.ID {
 func marshalOutboundWebhookLogID(id int64) graphql.ID {
 	return relay.MarshalID(outboundWebhookLogIDKind, id)
 }
+
+func unmarshalOutboundWebhookLogID(gql graphql.ID) (id int64, err error) {
+	if kind := relay.UnmarshalKind(gql); kind != outboundWebhookLogIDKind {
+		return 0, errors.Newf("invalid outbound webhook log id of kind %q", kind)
+	}
+
+	err = relay.UnmarshalSpec(gql, &id)
+	return
+}
@@ -5,7 +5,9 @@ import (
 	"testing"
 	"time"
 
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
@@ -77,8 +79,9 @@ func TestOutboundWebhookLogs(t *testing.T) {
 				Method: "POST",
 				URL:    url,
 			}),
-			Response: types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{}),
-			Error:    encryption.NewUnencrypted("bad pipes"),
+			Response:   types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{}),
+			Error:      encryption.NewUnencrypted("bad pipes"),
+			Redelivery: true,
 		},
 	}
 
@@ -150,6 +153,7 @@ func TestOutboundWebhookLogs(t *testing.T) {
 									body
 								}
 								error
+								redelivery
 							}
 							totalCount
 							pageInfo {
@@ -168,7 +172,7 @@ func TestOutboundWebhookLogs(t *testing.T) {
 							{
 								"id": "T3V0Ym91bmRXZWJob29rTG9nOjIw",
 								"job": {
-									"id": "T3V0Ym91bmRXZWJob29rSm9iOjA=",
+									"id": "T3V0Ym91bmRXZWJob29rSm9iOjEw",
 									"eventType": "test:event",
 									"payload": "{\"webhook\": \"body\"}"
 								},
@@ -194,12 +198,13 @@ func TestOutboundWebhookLogs(t *testing.T) {
 									],
 									"body": "\"roger roger\""
 								},
-								"error": null
+								"error": null,
+								"redelivery": false
 							},
 							{
 								"id": "T3V0Ym91bmRXZWJob29rTG9nOjIx",
 								"job": {
-									"id": "T3V0Ym91bmRXZWJob29rSm9iOjA=",
+									"id": "T3V0Ym91bmRXZWJob29rSm9iOjEw",
 									"eventType": "test:event",
 									"payload": "{\"webhook\": \"body\"}"
 								},
@@ -217,7 +222,8 @@ func TestOutboundWebhookLogs(t *testing.T) {
 									"url": "http://example.com/"
 								},
 								"response": null,
-								"error": "bad pipes"
+								"error": "bad pipes",
+								"redelivery": true
 							}
 						],
 						"totalCount": 2,
@@ -234,3 +240,194 @@ func TestOutboundWebhookLogs(t *testing.T) {
 		`,
 	})
 }
+
+func TestSchemaResolver_RedeliverOutboundWebhookLog(t *testing.T) {
+	t.Parallel()
+
+	// Outbound webhook log ID 20.
+	id := "T3V0Ym91bmRXZWJob29rTG9nOjIw"
+
+	query := `
+		mutation RedeliverOutboundWebhookLog($id: ID!, $outboundWebhook: ID) {
+			redeliverOutboundWebhookLog(id: $id, outboundWebhook: $outboundWebhook) {
+				id
+				eventType
+			}
+		}
+	`
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := dbmocks.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"redeliverOutboundWebhookLog"}, &Test{
+			Context:   ctx,
+			Schema:    mustParseGraphQLSchema(t, db),
+			Query:     query,
+			Variables: map[string]any{"id": id},
+		})
+	})
+
+	for name, tc := range map[string]struct {
+		outboundWebhook any
+		wantWebhookID   int64
+	}{
+		"same webhook": {
+			outboundWebhook: nil,
+			wantWebhookID:   1,
+		},
+		"other webhook": {
+			// Outbound webhook ID 2.
+			outboundWebhook: "T3V0Ym91bmRXZWJob29rOjI=",
+			wantWebhookID:   2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			logStore := dbmocks.NewMockOutboundWebhookLogStore()
+			logStore.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int64) (*types.OutboundWebhookLog, error) {
+				assert.EqualValues(t, 20, id)
+				return &types.OutboundWebhookLog{ID: id, JobID: 10, OutboundWebhookID: 1}, nil
+			})
+
+			jobStore := dbmocks.NewMockOutboundWebhookJobStore()
+			jobStore.RedeliverFunc.SetDefaultHook(func(ctx context.Context, jobID, webhookID int64) (*types.OutboundWebhookJob, error) {
+				assert.EqualValues(t, 10, jobID)
+				assert.Equal(t, tc.wantWebhookID, webhookID)
+				return &types.OutboundWebhookJob{
+					ID:                11,
+					EventType:         "test:event",
+					OutboundWebhookID: &webhookID,
+					RedeliveryOfJobID: &jobID,
+				}, nil
+			})
+
+			store := dbmocks.NewMockOutboundWebhookStore()
+			store.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int64) (*types.OutboundWebhook, error) {
+				assert.Equal(t, tc.wantWebhookID, id)
+				return &types.OutboundWebhook{ID: id}, nil
+			})
+			store.ToJobStoreFunc.SetDefaultReturn(jobStore)
+			store.ToLogStoreFunc.SetDefaultReturn(logStore)
+
+			db := dbmocks.NewMockDB()
+			db.OutboundWebhooksFunc.SetDefaultReturn(store)
+			ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+			RunTest(t, &Test{
+				Context:   ctx,
+				Schema:    mustParseGraphQLSchema(t, db),
+				Query:     query,
+				Variables: map[string]any{"id": id, "outboundWebhook": tc.outboundWebhook},
+				ExpectedResult: `
+					{
+						"redeliverOutboundWebhookLog": {
+							"id": "T3V0Ym91bmRXZWJob29rSm9iOjEx",
+							"eventType": "test:event"
+						}
+					}
+				`,
+			})
+
+			mockassert.CalledOnce(t, jobStore.RedeliverFunc)
+		})
+	}
+}
+
+func TestSchemaResolver_ReplayOutboundWebhookEvents(t *testing.T) {
+	t.Parallel()
+
+	query := `
+		mutation ReplayOutboundWebhookEvents($outboundWebhook: ID!, $eventType: String!, $since: DateTime!, $until: DateTime) {
+			replayOutboundWebhookEvents(outboundWebhook: $outboundWebhook, eventType: $eventType, since: $since, until: $until)
+		}
+	`
+	// The window must be within the default retention period of 72 hours.
+	until := time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+	since := until.Add(-time.Hour)
+	variables := map[string]any{
+		// Outbound webhook ID 1.
+		"outboundWebhook": "T3V0Ym91bmRXZWJob29rOjE=",
+		"eventType":       "test:event",
+		"since":           since.Format(time.RFC3339),
+		"until":           until.Format(time.RFC3339),
+	}
+
+	t.Run("not site admin", func(t *testing.T) {
+		t.Parallel()
+
+		db := dbmocks.NewMockDB()
+		ctx, _, _ := fakeUser(t, context.Background(), db, false)
+
+		runMustBeSiteAdminTest(t, []any{"replayOutboundWebhookEvents"}, &Test{
+			Context:   ctx,
+			Schema:    mustParseGraphQLSchema(t, db),
+			Query:     query,
+			Variables: variables,
+		})
+	})
+
+	t.Run("site admin", func(t *testing.T) {
+		t.Parallel()
+
+		jobStore := dbmocks.NewMockOutboundWebhookJobStore()
+		jobStore.ReplayFunc.SetDefaultHook(func(ctx context.Context, opts database.OutboundWebhookJobReplayOpts) (int, error) {
+			assert.Equal(t, database.OutboundWebhookJobReplayOpts{
+				OutboundWebhookID: 1,
+				EventType:         "test:event",
+				Since:             since,
+				Until:             until,
+			}, opts)
+			return 3, nil
+		})
+
+		store := dbmocks.NewMockOutboundWebhookStore()
+		store.GetByIDFunc.SetDefaultReturn(&types.OutboundWebhook{ID: 1}, nil)
+		store.ToJobStoreFunc.SetDefaultReturn(jobStore)
+
+		db := dbmocks.NewMockDB()
+		db.OutboundWebhooksFunc.SetDefaultReturn(store)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		RunTest(t, &Test{
+			Context:   ctx,
+			Schema:    mustParseGraphQLSchema(t, db),
+			Query:     query,
+			Variables: variables,
+			ExpectedResult: `
+				{
+					"replayOutboundWebhookEvents": 3
+				}
+			`,
+		})
+
+		mockassert.CalledOnce(t, jobStore.ReplayFunc)
+	})
+
+	t.Run("outside retention period", func(t *testing.T) {
+		t.Parallel()
+
+		jobStore := dbmocks.NewMockOutboundWebhookJobStore()
+		store := dbmocks.NewMockOutboundWebhookStore()
+		store.GetByIDFunc.SetDefaultReturn(&types.OutboundWebhook{ID: 1}, nil)
+		store.ToJobStoreFunc.SetDefaultReturn(jobStore)
+
+		db := dbmocks.NewMockDB()
+		db.OutboundWebhooksFunc.SetDefaultReturn(store)
+		ctx, _, _ := fakeUser(t, context.Background(), db, true)
+
+		variables := map[string]any{
+			"outboundWebhook": "T3V0Ym91bmRXZWJob29rOjE=",
+			"eventType":       "test:event",
+			"since":           time.Now().Add(-7 * 24 * time.Hour).UTC().Format(time.RFC3339),
+		}
+		r := mustParseGraphQLSchema(t, db).Exec(ctx, query, "", variables)
+		require.Len(t, r.Errors, 1)
+		assert.Contains(t, r.Errors[0].Message, "events are only retained for")
+
+		mockassert.NotCalled(t, jobStore.ReplayFunc)
+	})
+}
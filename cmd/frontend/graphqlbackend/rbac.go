@@ -15,6 +15,7 @@ type RoleResolver interface {
 	System() bool
 	CreatedAt() gqlutil.DateTime
 	Permissions(context.Context, *ListPermissionArgs) (*graphqlutil.ConnectionResolver[PermissionResolver], error)
+	Scopes(context.Context) ([]*RoleScopeResolver, error)
 }
 
 type PermissionResolver interface {
@@ -31,6 +32,7 @@ type RBACResolver interface {
 	CreateRole(ctx context.Context, args *CreateRoleArgs) (RoleResolver, error)
 	SetPermissions(ctx context.Context, args SetPermissionsArgs) (*EmptyResponse, error)
 	SetRoles(ctx context.Context, args *SetRolesArgs) (*EmptyResponse, error)
+	SetRoleScopes(ctx context.Context, args *SetRoleScopesArgs) (*EmptyResponse, error)
 }
 
 type DeleteRoleArgs struct {
@@ -66,6 +68,13 @@ type SetRolesArgs struct {
 	Roles []graphql.ID
 }
 
+type SetRoleScopesArgs struct {
+	Role           graphql.ID
+	Orgs           *[]graphql.ID
+	Repositories   *[]graphql.ID
+	SearchContexts *[]graphql.ID
+}
+
 type ErrIDIsZero struct{}
 
 func (e ErrIDIsZero) Error() string {
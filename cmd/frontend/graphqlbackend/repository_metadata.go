@@ -57,17 +57,17 @@ func (r *schemaResolver) AddRepoMetadata(ctx context.Context, args struct {
 	Value *string
 },
 ) (*EmptyResponse, error) {
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.RepoMetadataWritePermission); err != nil {
+	repoID, err := UnmarshalRepositoryID(args.Repo)
+	if err != nil {
 		return &EmptyResponse{}, err
 	}
 
-	if !featureflag.FromContext(ctx).GetBoolOr("repository-metadata", true) {
-		return nil, featureDisabledError
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.db, rbac.RepoMetadataWritePermission, rbac.Scope{RepoID: repoID}); err != nil {
+		return &EmptyResponse{}, err
 	}
 
-	repoID, err := UnmarshalRepositoryID(args.Repo)
-	if err != nil {
-		return &EmptyResponse{}, err
+	if !featureflag.FromContext(ctx).GetBoolOr("repository-metadata", true) {
+		return nil, featureDisabledError
 	}
 
 	if args.Value != nil && strings.TrimSpace(*args.Value) == "" {
@@ -98,17 +98,17 @@ func (r *schemaResolver) UpdateRepoMetadata(ctx context.Context, args struct {
 	Value *string
 },
 ) (*EmptyResponse, error) {
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.RepoMetadataWritePermission); err != nil {
+	repoID, err := UnmarshalRepositoryID(args.Repo)
+	if err != nil {
 		return &EmptyResponse{}, err
 	}
 
-	if !featureflag.FromContext(ctx).GetBoolOr("repository-metadata", true) {
-		return nil, featureDisabledError
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.db, rbac.RepoMetadataWritePermission, rbac.Scope{RepoID: repoID}); err != nil {
+		return &EmptyResponse{}, err
 	}
 
-	repoID, err := UnmarshalRepositoryID(args.Repo)
-	if err != nil {
-		return &EmptyResponse{}, err
+	if !featureflag.FromContext(ctx).GetBoolOr("repository-metadata", true) {
+		return nil, featureDisabledError
 	}
 
 	if args.Value != nil && strings.TrimSpace(*args.Value) == "" {
@@ -136,17 +136,17 @@ func (r *schemaResolver) DeleteRepoMetadata(ctx context.Context, args struct {
 	Key  string
 },
 ) (*EmptyResponse, error) {
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.RepoMetadataWritePermission); err != nil {
+	repoID, err := UnmarshalRepositoryID(args.Repo)
+	if err != nil {
 		return &EmptyResponse{}, err
 	}
 
-	if !featureflag.FromContext(ctx).GetBoolOr("repository-metadata", true) {
-		return nil, featureDisabledError
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.db, rbac.RepoMetadataWritePermission, rbac.Scope{RepoID: repoID}); err != nil {
+		return &EmptyResponse{}, err
 	}
 
-	repoID, err := UnmarshalRepositoryID(args.Repo)
-	if err != nil {
-		return &EmptyResponse{}, err
+	if !featureflag.FromContext(ctx).GetBoolOr("repository-metadata", true) {
+		return nil, featureDisabledError
 	}
 
 	err = r.db.RepoKVPs().Delete(ctx, repoID, args.Key)
@@ -190,7 +190,8 @@ type RepoMetadataKeysArgs struct {
 }
 
 func (r *repoMetaResolver) Keys(ctx context.Context, args *RepoMetadataKeysArgs) (*graphqlutil.ConnectionResolver[string], error) {
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.RepoMetadataWritePermission); err != nil {
+	// Listing metadata is allowed for users who can write it on at least some repositories.
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.db, rbac.RepoMetadataWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -266,7 +267,8 @@ type RepoMetadataValuesArgs struct {
 }
 
 func (r *repoMetaKeyResolver) Values(ctx context.Context, args *RepoMetadataValuesArgs) (*graphqlutil.ConnectionResolver[string], error) {
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.db, rbac.RepoMetadataWritePermission); err != nil {
+	// Listing metadata is allowed for users who can write it on at least some repositories.
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.db, rbac.RepoMetadataWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
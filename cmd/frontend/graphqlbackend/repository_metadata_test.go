@@ -263,6 +263,10 @@ func TestRepositoryMetadata(t *testing.T) {
 		})
 		require.Error(t, err)
 		require.Equal(t, err, &rbac.ErrNotAuthorized{Permission: string(rbac.RepoMetadataWritePermission)})
+
+		// permissions are checked against the repository being edited
+		history := permissions.GetPermissionForUserFunc.History()
+		require.Equal(t, repo.ID, history[len(history)-1].Arg1.RepoID)
 	})
 
 }
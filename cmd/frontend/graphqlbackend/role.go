@@ -67,6 +67,24 @@ func (r *roleResolver) Permissions(ctx context.Context, args *ListPermissionArgs
 	)
 }
 
+func (r *roleResolver) Scopes(ctx context.Context) ([]*RoleScopeResolver, error) {
+	// 🚨 SECURITY: Only viewable by site admins.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	scopes, err := r.db.RoleScopes().GetByRoleID(ctx, database.GetRoleScopesOpts{RoleID: r.role.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*RoleScopeResolver, 0, len(scopes))
+	for _, scope := range scopes {
+		resolvers = append(resolvers, &RoleScopeResolver{db: r.db, scope: scope})
+	}
+	return resolvers, nil
+}
+
 func (r *roleResolver) CreatedAt() gqlutil.DateTime {
 	return gqlutil.DateTime{Time: r.role.CreatedAt}
 }
@@ -0,0 +1,54 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// RoleScopeResolver resolves one of the organizations, repositories or search contexts
+// a role is restricted to. Exactly one of its fields is non-null.
+type RoleScopeResolver struct {
+	db    database.DB
+	scope *types.RoleScope
+}
+
+func (r *RoleScopeResolver) Org(ctx context.Context) (*OrgResolver, error) {
+	if r.scope.OrgID == 0 {
+		return nil, nil
+	}
+	return OrgByIDInt32(ctx, r.db, r.scope.OrgID)
+}
+
+func (r *RoleScopeResolver) Repository(ctx context.Context) (*RepositoryResolver, error) {
+	if r.scope.RepoID == 0 {
+		return nil, nil
+	}
+	repo, err := r.db.Repos().Get(ctx, r.scope.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	return NewRepositoryResolver(r.db, gitserver.NewClient(), repo), nil
+}
+
+func (r *RoleScopeResolver) SearchContextSpec(ctx context.Context) (*string, error) {
+	if r.scope.SearchContextID == 0 {
+		return nil, nil
+	}
+	// Scopes are only visible to site admins, who may scope a role to a private search
+	// context they cannot otherwise read.
+	searchContexts, err := r.db.SearchContexts().ListSearchContexts(
+		actor.WithInternalActor(ctx),
+		database.ListSearchContextsPageOptions{First: 1},
+		database.ListSearchContextsOptions{IDs: []int64{r.scope.SearchContextID}},
+	)
+	if err != nil || len(searchContexts) == 0 {
+		return nil, err
+	}
+	spec := searchcontexts.GetSearchContextSpec(searchContexts[0])
+	return &spec, nil
+}
@@ -14,6 +14,7 @@ type SearchJobsResolver interface {
 	CreateSearchJob(ctx context.Context, args *CreateSearchJobArgs) (SearchJobResolver, error)
 	CancelSearchJob(ctx context.Context, args *CancelSearchJobArgs) (*EmptyResponse, error)
 	DeleteSearchJob(ctx context.Context, args *DeleteSearchJobArgs) (*EmptyResponse, error)
+	RerunSearchJob(ctx context.Context, args *RerunSearchJobArgs) (SearchJobResolver, error)
 
 	// Queries
 	SearchJobs(ctx context.Context, args *SearchJobsArgs) (*graphqlutil.ConnectionResolver[SearchJobResolver], error)
@@ -44,7 +45,10 @@ type SearchJobResolver interface {
 	StartedAt(ctx context.Context) *gqlutil.DateTime
 	FinishedAt(ctx context.Context) *gqlutil.DateTime
 	URL(ctx context.Context) (*string, error)
+	JSONLinesURL(ctx context.Context) (*string, error)
 	LogURL(ctx context.Context) (*string, error)
+	PreviousSearchJob(ctx context.Context) (SearchJobResolver, error)
+	DiffURL(ctx context.Context) (*string, error)
 	RepoStats(ctx context.Context) (SearchJobStatsResolver, error)
 }
 
@@ -73,6 +77,10 @@ type DeleteSearchJobArgs struct {
 	ID graphql.ID
 }
 
+type RerunSearchJobArgs struct {
+	ID graphql.ID
+}
+
 type RetrySearchJobArgs struct {
 	ID graphql.ID
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -171,7 +172,8 @@ func (r *schemaResolver) DeleteUsers(ctx context.Context, args *struct {
 		})
 	}
 
-	if args.Hard != nil && *args.Hard {
+	hard := args.Hard != nil && *args.Hard
+	if hard {
 		if err := r.db.Users().HardDeleteList(ctx, ids); err != nil {
 			return nil, err
 		}
@@ -181,6 +183,10 @@ func (r *schemaResolver) DeleteUsers(ctx context.Context, args *struct {
 		}
 	}
 
+	for _, user := range users {
+		outbound.EnqueueUserDeleted(ctx, logger, r.db, user, hard)
+	}
+
 	// NOTE: Practically, we don't reuse the ID for any new users, and the situation of left-over pending permissions
 	// is possible but highly unlikely. Therefore, there is no need to roll back user deletion even if this step failed.
 	// This call is purely for the purpose of cleanup.
@@ -278,10 +284,21 @@ func (r *schemaResolver) SetUserIsSiteAdmin(ctx context.Context, args *struct {
 		return nil, errRefuseToSetCurrentUserSiteAdmin
 	}
 
+	// Load the user before changing it, so that we only send a webhook if the
+	// user wasn't a site admin already.
+	affectedUser, err := r.db.Users().GetByID(ctx, affectedUserID)
+	if err != nil {
+		return nil, err
+	}
+
 	if err = r.db.Users().SetIsSiteAdmin(ctx, affectedUserID, args.SiteAdmin); err != nil {
 		return nil, err
 	}
 
+	if args.SiteAdmin && !affectedUser.SiteAdmin {
+		outbound.EnqueueUserSiteAdminGranted(ctx, r.logger, r.db, affectedUser, userResolver.user.ID)
+	}
+
 	eventName = database.SecurityEventNameRoleChangeGranted
 	return &EmptyResponse{}, nil
 }
@@ -128,6 +128,7 @@ func TestDeleteUser(t *testing.T) {
 	db.UserEmailsFunc.SetDefaultReturn(userEmails)
 	db.UserExternalAccountsFunc.SetDefaultReturn(externalAccounts)
 	db.AuthzFunc.SetDefaultReturn(authzStore)
+	db.OutboundWebhooksFunc.SetDefaultReturn(dbmocks.NewMockOutboundWebhookStore())
 
 	// Disable event logging, which is triggered for SOAP users
 	conf.Mock(&conf.Unified{
@@ -382,10 +383,12 @@ func TestSetIsSiteAdmin(t *testing.T) {
 		isSiteAdmin           bool
 		argsUserID            int32
 		argsSiteAdmin         bool
+		wasSiteAdmin          bool
 		result                *EmptyResponse
 		wantErr               error
 		securityLogEventCalls int
 		setIsSiteAdminCalls   int
+		webhookCalls          int
 	}{
 		"authenticated as non-admin": {
 			isSiteAdmin:           false,
@@ -413,6 +416,18 @@ func TestSetIsSiteAdmin(t *testing.T) {
 			wantErr:               nil,
 			securityLogEventCalls: 1,
 			setIsSiteAdminCalls:   1,
+			webhookCalls:          1,
+		},
+		"authenticated as site-admin: promoting a site-admin": {
+			isSiteAdmin:           true,
+			argsUserID:            2,
+			argsSiteAdmin:         true,
+			wasSiteAdmin:          true,
+			result:                &EmptyResponse{},
+			wantErr:               nil,
+			securityLogEventCalls: 1,
+			setIsSiteAdminCalls:   1,
+			webhookCalls:          0,
 		},
 		"authenticated as site-admin: demoting to site-admin": {
 			isSiteAdmin:           true,
@@ -430,6 +445,7 @@ func TestSetIsSiteAdmin(t *testing.T) {
 			users := dbmocks.NewMockUserStore()
 			users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{ID: 1, SiteAdmin: tc.isSiteAdmin}, nil)
 			users.SetIsSiteAdminFunc.SetDefaultReturn(nil)
+			users.GetByIDFunc.SetDefaultReturn(&types.User{ID: tc.argsUserID, SiteAdmin: tc.wasSiteAdmin}, nil)
 
 			securityLogEvents := dbmocks.NewMockSecurityEventLogsStore()
 			securityLogEvents.LogEventFunc.SetDefaultReturn()
@@ -437,6 +453,11 @@ func TestSetIsSiteAdmin(t *testing.T) {
 			db := dbmocks.NewMockDB()
 			db.UsersFunc.SetDefaultReturn(users)
 			db.SecurityEventLogsFunc.SetDefaultReturn(securityLogEvents)
+			webhooks := dbmocks.NewMockOutboundWebhookStore()
+			webhooks.CountFunc.SetDefaultReturn(1, nil)
+			db.OutboundWebhooksFunc.SetDefaultReturn(webhooks)
+			webhookJobs := dbmocks.NewMockOutboundWebhookJobStore()
+			db.OutboundWebhookJobsFunc.SetDefaultReturn(webhookJobs)
 
 			s := newSchemaResolver(db, gitserver.NewClient())
 
@@ -458,6 +479,7 @@ func TestSetIsSiteAdmin(t *testing.T) {
 
 			mockrequire.CalledN(t, securityLogEvents.LogEventFunc, tc.securityLogEventCalls)
 			mockrequire.CalledN(t, users.SetIsSiteAdminFunc, tc.setIsSiteAdminCalls)
+			mockrequire.CalledN(t, webhookJobs.CreateFunc, tc.webhookCalls)
 		})
 	}
 }
@@ -77,6 +77,142 @@ func checkLicense() (*licensing.FeatureBatchChanges, error) {
 	return bcFeature, nil
 }
 
+// checkNamespaceWritePermission returns an error if the current user cannot write batch
+// changes in the given namespace. Roles scoped to organizations only grant the permission
+// in the namespaces of those organizations, so a user namespace requires an unscoped role.
+func checkNamespaceWritePermission(ctx context.Context, db database.DB, namespaceOrgID int32) error {
+	return rbac.CheckCurrentUserHasPermissionInScope(ctx, db, rbac.BatchChangesWritePermission, rbac.Scope{OrgID: namespaceOrgID})
+}
+
+// checkReposWritePermission returns an error if the current user cannot write changesets on
+// all of the given repositories. Roles scoped to repositories or search contexts only grant
+// the permission on their repositories, regardless of the namespace of the batch change.
+func checkReposWritePermission(ctx context.Context, db database.DB, repoIDs []api.RepoID) error {
+	checked := make(map[api.RepoID]struct{}, len(repoIDs))
+	for _, repoID := range repoIDs {
+		if _, ok := checked[repoID]; ok {
+			continue
+		}
+		checked[repoID] = struct{}{}
+
+		if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, db, rbac.BatchChangesWritePermission, rbac.Scope{RepoID: repoID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkBatchChangeWritePermission checks the write permission in the namespace of the
+// given batch change. Missing batch changes are left for the service to report.
+func (r *Resolver) checkBatchChangeWritePermission(ctx context.Context, batchChangeID int64) error {
+	batchChange, err := r.store.GetBatchChange(ctx, store.GetBatchChangeOpts{ID: batchChangeID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return err
+	}
+	return checkNamespaceWritePermission(ctx, r.store.DatabaseDB(), batchChange.NamespaceOrgID)
+}
+
+// checkBatchSpecWritePermission checks the write permission in the namespace of the given
+// batch spec. Missing batch specs are left for the service to report.
+func (r *Resolver) checkBatchSpecWritePermission(ctx context.Context, batchSpecRandID string) error {
+	batchSpec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{RandID: batchSpecRandID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return err
+	}
+	return checkNamespaceWritePermission(ctx, r.store.DatabaseDB(), batchSpec.NamespaceOrgID)
+}
+
+// checkBatchSpecReposWritePermission checks the write permission on the repositories the
+// changeset specs of the given batch spec target. Missing batch specs are left for the
+// service to report.
+func (r *Resolver) checkBatchSpecReposWritePermission(ctx context.Context, batchSpecRandID string) error {
+	batchSpec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{RandID: batchSpecRandID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return err
+	}
+	specs, _, err := r.store.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: batchSpec.ID})
+	if err != nil {
+		return err
+	}
+	return checkReposWritePermission(ctx, r.store.DatabaseDB(), specs.RepoIDs())
+}
+
+// checkChangesetWritePermission checks the write permission on the repository of the given
+// changeset and in the namespace of one of the batch changes it is attached to. Missing
+// changesets are left for the service to report.
+func (r *Resolver) checkChangesetWritePermission(ctx context.Context, changesetID int64) error {
+	changeset, err := r.store.GetChangeset(ctx, store.GetChangesetOpts{ID: changesetID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil
+		}
+		return err
+	}
+	if err := checkReposWritePermission(ctx, r.store.DatabaseDB(), []api.RepoID{changeset.RepoID}); err != nil {
+		return err
+	}
+
+	batchChanges, _, err := r.store.ListBatchChanges(ctx, store.ListBatchChangesOpts{ChangesetID: changesetID})
+	if err != nil {
+		return err
+	}
+
+	var permErr error
+	for _, batchChange := range batchChanges {
+		if permErr = checkNamespaceWritePermission(ctx, r.store.DatabaseDB(), batchChange.NamespaceOrgID); permErr == nil {
+			return nil
+		}
+	}
+	return permErr
+}
+
+// checkBatchSpecWorkspacesWritePermission checks the write permission on the repositories of
+// the given workspaces and in the namespaces of their batch specs. Missing workspaces and
+// batch specs are left for the service to report.
+func (r *Resolver) checkBatchSpecWorkspacesWritePermission(ctx context.Context, workspaceIDs []int64) error {
+	workspaces, _, err := r.store.ListBatchSpecWorkspaces(ctx, store.ListBatchSpecWorkspacesOpts{IDs: workspaceIDs})
+	if err != nil {
+		return err
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(workspaces))
+	for _, workspace := range workspaces {
+		repoIDs = append(repoIDs, workspace.RepoID)
+	}
+	if err := checkReposWritePermission(ctx, r.store.DatabaseDB(), repoIDs); err != nil {
+		return err
+	}
+
+	checked := make(map[int64]struct{}, len(workspaces))
+	for _, workspace := range workspaces {
+		if _, ok := checked[workspace.BatchSpecID]; ok {
+			continue
+		}
+		checked[workspace.BatchSpecID] = struct{}{}
+
+		batchSpec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{ID: workspace.BatchSpecID})
+		if err != nil {
+			if err == store.ErrNoResults {
+				continue
+			}
+			return err
+		}
+		if err := checkNamespaceWritePermission(ctx, r.store.DatabaseDB(), batchSpec.NamespaceOrgID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type batchSpecCreatedArg struct {
 	ChangesetSpecsCount int `json:"changeset_specs_count"`
 }
@@ -420,7 +556,7 @@ func (r *Resolver) CreateBatchChange(ctx context.Context, args *graphqlbackend.C
 	tr, _ := trace.New(ctx, "Resolver.CreateBatchChange", attribute.String("BatchSpec", string(args.BatchSpec)))
 	defer tr.EndWithErr(&err)
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -450,7 +586,7 @@ func (r *Resolver) ApplyBatchChange(ctx context.Context, args *graphqlbackend.Ap
 	tr, ctx := trace.New(ctx, "Resolver.ApplyBatchChange", attribute.String("BatchSpec", string(args.BatchSpec)))
 	defer tr.EndWithErr(&err)
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -502,6 +638,14 @@ func (r *Resolver) applyOrCreateBatchChange(ctx context.Context, args *graphqlba
 		return nil, ErrIDIsZero{}
 	}
 
+	if err := r.checkBatchSpecWritePermission(ctx, opts.BatchSpecRandID); err != nil {
+		return nil, err
+	}
+
+	if err := r.checkBatchSpecReposWritePermission(ctx, opts.BatchSpecRandID); err != nil {
+		return nil, err
+	}
+
 	if batchChangesFeature, licenseErr := checkLicense(); licenseErr == nil {
 		batchSpec, err := r.store.GetBatchSpec(ctx, store.GetBatchSpecOpts{
 			RandID: opts.BatchSpecRandID,
@@ -557,7 +701,7 @@ func (r *Resolver) CreateBatchSpec(ctx context.Context, args *graphqlbackend.Cre
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -576,6 +720,10 @@ func (r *Resolver) CreateBatchSpec(ctx context.Context, args *graphqlbackend.Cre
 		return nil, err
 	}
 
+	if err := checkNamespaceWritePermission(ctx, r.store.DatabaseDB(), opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
 	for _, graphqlID := range args.ChangesetSpecs {
 		randID, err := unmarshalChangesetSpecID(graphqlID)
 		if err != nil {
@@ -612,7 +760,7 @@ func (r *Resolver) CreateChangesetSpec(ctx context.Context, args *graphqlbackend
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -641,7 +789,7 @@ func (r *Resolver) CreateChangesetSpecs(ctx context.Context, args *graphqlbacken
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -679,7 +827,7 @@ func (r *Resolver) MoveBatchChange(ctx context.Context, args *graphqlbackend.Mov
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -692,6 +840,10 @@ func (r *Resolver) MoveBatchChange(ctx context.Context, args *graphqlbackend.Mov
 		return nil, ErrIDIsZero{}
 	}
 
+	if err := r.checkBatchChangeWritePermission(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
 	opts := service.MoveBatchChangeOpts{
 		BatchChangeID: batchChangeID,
 	}
@@ -705,6 +857,10 @@ func (r *Resolver) MoveBatchChange(ctx context.Context, args *graphqlbackend.Mov
 		if err != nil {
 			return nil, err
 		}
+
+		if err := checkNamespaceWritePermission(ctx, r.store.DatabaseDB(), opts.NewNamespaceOrgID); err != nil {
+			return nil, err
+		}
 	}
 
 	svc := service.New(r.store)
@@ -724,7 +880,7 @@ func (r *Resolver) DeleteBatchChange(ctx context.Context, args *graphqlbackend.D
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -737,6 +893,10 @@ func (r *Resolver) DeleteBatchChange(ctx context.Context, args *graphqlbackend.D
 		return nil, ErrIDIsZero{}
 	}
 
+	if err := r.checkBatchChangeWritePermission(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
 	svc := service.New(r.store)
 	// 🚨 SECURITY: DeleteBatchChange checks whether current user is authorized.
 	err = svc.DeleteBatchChange(ctx, batchChangeID)
@@ -1001,7 +1161,7 @@ func (r *Resolver) CloseBatchChange(ctx context.Context, args *graphqlbackend.Cl
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1014,6 +1174,10 @@ func (r *Resolver) CloseBatchChange(ctx context.Context, args *graphqlbackend.Cl
 		return nil, ErrIDIsZero{}
 	}
 
+	if err := r.checkBatchChangeWritePermission(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
 	svc := service.New(r.store)
 	// 🚨 SECURITY: CloseBatchChange checks whether current user is authorized.
 	batchChange, err := svc.CloseBatchChange(ctx, batchChangeID, args.CloseChangesets)
@@ -1061,7 +1225,7 @@ func (r *Resolver) ReenqueueChangeset(ctx context.Context, args *graphqlbackend.
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1074,6 +1238,10 @@ func (r *Resolver) ReenqueueChangeset(ctx context.Context, args *graphqlbackend.
 		return nil, ErrIDIsZero{}
 	}
 
+	if err := r.checkChangesetWritePermission(ctx, changesetID); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: ReenqueueChangeset checks whether the current user is authorized and can administer the changeset.
 	svc := service.New(r.store)
 	changeset, repo, err := svc.ReenqueueChangeset(ctx, changesetID)
@@ -1323,7 +1491,7 @@ func (r *Resolver) DetachChangesets(ctx context.Context, args *graphqlbackend.De
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1332,6 +1500,10 @@ func (r *Resolver) DetachChangesets(ctx context.Context, args *graphqlbackend.De
 		return nil, err
 	}
 
+	if err := r.checkBatchChangeWritePermission(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
 	svc := service.New(r.store)
 	bulkGroupID, err := svc.CreateChangesetJobs(
@@ -1361,7 +1533,7 @@ func (r *Resolver) CreateChangesetComments(ctx context.Context, args *graphqlbac
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1374,6 +1546,10 @@ func (r *Resolver) CreateChangesetComments(ctx context.Context, args *graphqlbac
 		return nil, err
 	}
 
+	if err := r.checkBatchChangeWritePermission(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
 	svc := service.New(r.store)
 	published := btypes.ChangesetPublicationStatePublished
@@ -1408,7 +1584,7 @@ func (r *Resolver) ReenqueueChangesets(ctx context.Context, args *graphqlbackend
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1417,6 +1593,10 @@ func (r *Resolver) ReenqueueChangesets(ctx context.Context, args *graphqlbackend
 		return nil, err
 	}
 
+	if err := r.checkBatchChangeWritePermission(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
 	svc := service.New(r.store)
 	bulkGroupID, err := svc.CreateChangesetJobs(
@@ -1446,7 +1626,7 @@ func (r *Resolver) MergeChangesets(ctx context.Context, args *graphqlbackend.Mer
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1455,6 +1635,10 @@ func (r *Resolver) MergeChangesets(ctx context.Context, args *graphqlbackend.Mer
 		return nil, err
 	}
 
+	if err := r.checkBatchChangeWritePermission(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
 	svc := service.New(r.store)
 	published := btypes.ChangesetPublicationStatePublished
@@ -1487,7 +1671,7 @@ func (r *Resolver) CloseChangesets(ctx context.Context, args *graphqlbackend.Clo
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1496,6 +1680,10 @@ func (r *Resolver) CloseChangesets(ctx context.Context, args *graphqlbackend.Clo
 		return nil, err
 	}
 
+	if err := r.checkBatchChangeWritePermission(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
 	svc := service.New(r.store)
 	published := btypes.ChangesetPublicationStatePublished
@@ -1527,7 +1715,7 @@ func (r *Resolver) PublishChangesets(ctx context.Context, args *graphqlbackend.P
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1536,6 +1724,10 @@ func (r *Resolver) PublishChangesets(ctx context.Context, args *graphqlbackend.P
 		return nil, err
 	}
 
+	if err := r.checkBatchChangeWritePermission(ctx, batchChangeID); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: CreateChangesetJobs checks whether current user is authorized.
 	svc := service.New(r.store)
 	bulkGroupID, err := svc.CreateChangesetJobs(
@@ -1608,7 +1800,7 @@ func (r *Resolver) CreateEmptyBatchChange(ctx context.Context, args *graphqlback
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1619,6 +1811,10 @@ func (r *Resolver) CreateEmptyBatchChange(ctx context.Context, args *graphqlback
 		return nil, err
 	}
 
+	if err := checkNamespaceWritePermission(ctx, r.store.DatabaseDB(), oid); err != nil {
+		return nil, err
+	}
+
 	batchChange, err := svc.CreateEmptyBatchChange(ctx, service.CreateEmptyBatchChangeOpts{
 		NamespaceUserID: uid,
 		NamespaceOrgID:  oid,
@@ -1644,7 +1840,7 @@ func (r *Resolver) UpsertEmptyBatchChange(ctx context.Context, args *graphqlback
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1655,6 +1851,10 @@ func (r *Resolver) UpsertEmptyBatchChange(ctx context.Context, args *graphqlback
 		return nil, err
 	}
 
+	if err := checkNamespaceWritePermission(ctx, r.store.DatabaseDB(), oid); err != nil {
+		return nil, err
+	}
+
 	batchChange, err := svc.UpsertEmptyBatchChange(ctx, service.UpsertEmptyBatchChangeOpts{
 		NamespaceUserID: uid,
 		NamespaceOrgID:  oid,
@@ -1677,7 +1877,7 @@ func (r *Resolver) CreateBatchSpecFromRaw(ctx context.Context, args *graphqlback
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1688,6 +1888,10 @@ func (r *Resolver) CreateBatchSpecFromRaw(ctx context.Context, args *graphqlback
 		return nil, err
 	}
 
+	if err := checkNamespaceWritePermission(ctx, r.store.DatabaseDB(), oid); err != nil {
+		return nil, err
+	}
+
 	bid, err := unmarshalBatchChangeID(args.BatchChange)
 	if err != nil {
 		return nil, err
@@ -1717,7 +1921,7 @@ func (r *Resolver) ExecuteBatchSpec(ctx context.Context, args *graphqlbackend.Ex
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1730,6 +1934,10 @@ func (r *Resolver) ExecuteBatchSpec(ctx context.Context, args *graphqlbackend.Ex
 		return nil, ErrIDIsZero{}
 	}
 
+	if err := r.checkBatchSpecWritePermission(ctx, batchSpecRandID); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: ExecuteBatchSpec checks whether current user is authorized
 	// and has access to namespace.
 	// Right now we also only allow creating batch specs in a user-namespace,
@@ -1786,7 +1994,7 @@ func (r *Resolver) RetryBatchSpecWorkspaceExecution(ctx context.Context, args *g
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1804,6 +2012,10 @@ func (r *Resolver) RetryBatchSpecWorkspaceExecution(ctx context.Context, args *g
 		workspaceIDs = append(workspaceIDs, id)
 	}
 
+	if err := r.checkBatchSpecWorkspacesWritePermission(ctx, workspaceIDs); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: RetryBatchSpecWorkspaces checks whether current user is authorized
 	// and has access to namespace.
 	// Right now we also only allow creating batch specs in a user-namespace,
@@ -1827,7 +2039,7 @@ func (r *Resolver) ReplaceBatchSpecInput(ctx context.Context, args *graphqlbacke
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1840,6 +2052,10 @@ func (r *Resolver) ReplaceBatchSpecInput(ctx context.Context, args *graphqlbacke
 		return nil, ErrIDIsZero{}
 	}
 
+	if err := r.checkBatchSpecWritePermission(ctx, batchSpecRandID); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: ReplaceBatchSpecInput checks whether current user is authorized
 	// and has access to namespace.
 	// Right now we also only allow creating batch specs in a user-namespace,
@@ -1868,7 +2084,7 @@ func (r *Resolver) UpsertBatchSpecInput(ctx context.Context, args *graphqlbacken
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1879,6 +2095,10 @@ func (r *Resolver) UpsertBatchSpecInput(ctx context.Context, args *graphqlbacken
 		return nil, err
 	}
 
+	if err := checkNamespaceWritePermission(ctx, r.store.DatabaseDB(), oid); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: UpsertBatchSpecInput checks whether current user is
 	// authorised and has access to the namespace.
 	//
@@ -1917,7 +2137,7 @@ func (r *Resolver) RetryBatchSpecExecution(ctx context.Context, args *graphqlbac
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 
@@ -1930,6 +2150,10 @@ func (r *Resolver) RetryBatchSpecExecution(ctx context.Context, args *graphqlbac
 		return nil, ErrIDIsZero{}
 	}
 
+	if err := r.checkBatchSpecWritePermission(ctx, batchSpecRandID); err != nil {
+		return nil, err
+	}
+
 	// 🚨 SECURITY: RetryBatchSpecExecution checks whether current user is authorized
 	// and has access to namespace.
 	svc := service.New(r.store)
@@ -1967,7 +2191,7 @@ func (r *Resolver) DeleteBatchSpec(ctx context.Context, args *graphqlbackend.Del
 		return nil, err
 	}
 
-	if err := rbac.CheckCurrentUserHasPermission(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission); err != nil {
+	if err := rbac.CheckCurrentUserHasPermissionInScope(ctx, r.store.DatabaseDB(), rbac.BatchChangesWritePermission, rbac.AnyScope); err != nil {
 		return nil, err
 	}
 	// TODO(ssbc): not implemented
@@ -11,10 +11,12 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/codemonitors"
 	"github.com/sourcegraph/sourcegraph/internal/codemonitors/background"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/gqlutil"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -126,10 +128,29 @@ func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.C
 		return nil, err
 	}
 
+	mode, err := toQueryTriggerMode(args.Trigger.Mode, database.QueryTriggerModeCommits)
+	if err != nil {
+		return nil, err
+	}
+
 	// Snapshot the state of the searched repos when the monitor is created so that
-	// we can distinguish new repos. We run the snapshot outside the transaction because
-	// search requires that the DB handle is not a transaction.
-	resolvedRevisions, err := codemonitors.Snapshot(ctx, r.logger, r.db, args.Trigger.Query)
+	// we can distinguish new repos. Content mode monitors instead remember the
+	// current results so that they only trigger when the results change. We run the
+	// snapshot outside the transaction because search requires that the DB handle is
+	// not a transaction.
+	var (
+		resolvedRevisions map[api.RepoID][]string
+		fingerprints      []database.ResultFingerprint
+	)
+	if mode == database.QueryTriggerModeContent {
+		var complete bool
+		fingerprints, complete, err = codemonitors.SearchContent(ctx, r.logger, r.db, args.Trigger.Query)
+		if err == nil && !complete {
+			err = codemonitors.ErrIncompleteContentResults
+		}
+	} else {
+		resolvedRevisions, err = codemonitors.Snapshot(ctx, r.logger, r.db, args.Trigger.Query)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -149,18 +170,24 @@ func (r *Resolver) CreateCodeMonitor(ctx context.Context, args *graphqlbackend.C
 		}
 
 		// Create trigger.
-		_, err = tx.db.CodeMonitors().CreateQueryTrigger(ctx, m.ID, args.Trigger.Query)
+		_, err = tx.db.CodeMonitors().CreateQueryTrigger(ctx, m.ID, args.Trigger.Query, mode)
 		if err != nil {
 			return err
 		}
 
-		// Save the snapshotted commit IDs
+		// Save the snapshotted commit IDs or results
 		for repoID, commitIDs := range resolvedRevisions {
 			err = tx.db.CodeMonitors().UpsertLastSearched(ctx, m.ID, repoID, commitIDs)
 			if err != nil {
 				return err
 			}
 		}
+		if mode == database.QueryTriggerModeContent {
+			err = tx.db.CodeMonitors().UpsertResultFingerprints(ctx, m.ID, fingerprints)
+			if err != nil {
+				return err
+			}
+		}
 
 		// Create actions.
 		err = tx.createActions(ctx, m.ID, args.Actions)
@@ -298,15 +325,23 @@ func (r *Resolver) createActions(ctx context.Context, monitorID int64, args []*g
 			if err != nil {
 				return err
 			}
+		case a.Issue != nil:
+			issueArgs, err := r.issueActionArgs(ctx, a.Issue)
+			if err != nil {
+				return err
+			}
+			if _, err := r.db.CodeMonitors().CreateIssueAction(ctx, monitorID, issueArgs); err != nil {
+				return err
+			}
 		default:
-			return errors.New("exactly one of Email, Webhook, or SlackWebhook must be set")
+			return errors.New("exactly one of Email, Webhook, SlackWebhook, or Issue must be set")
 		}
 	}
 	return nil
 }
 
 func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []graphql.ID) error {
-	var email, webhook, slackWebhook []int64
+	var email, webhook, slackWebhook, issue []int64
 	for _, id := range ids {
 		var intID int64
 		err := relay.UnmarshalSpec(id, &intID)
@@ -321,8 +356,10 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 			webhook = append(webhook, intID)
 		case monitorActionSlackWebhookKind:
 			slackWebhook = append(slackWebhook, intID)
+		case monitorActionIssueKind:
+			issue = append(issue, intID)
 		default:
-			return errors.New("action IDs must be exactly one of email, webhook, or slack webhook")
+			return errors.New("action IDs must be exactly one of email, webhook, slack webhook, or issue")
 		}
 	}
 
@@ -338,6 +375,10 @@ func (r *Resolver) deleteActions(ctx context.Context, monitorID int64, ids []gra
 		return err
 	}
 
+	if err := r.db.CodeMonitors().DeleteIssueActions(ctx, monitorID, issue...); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -449,7 +490,11 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	if err != nil {
 		return nil, err
 	}
-	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions))
+	issueActions, err := r.db.CodeMonitors().ListIssueActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(emailActions)+len(webhookActions)+len(slackWebhookActions)+len(issueActions))
 	for _, emailAction := range emailActions {
 		ids = append(ids, (&monitorEmail{EmailAction: emailAction}).ID())
 	}
@@ -459,6 +504,9 @@ func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int
 	for _, slackWebhookAction := range slackWebhookActions {
 		ids = append(ids, (&monitorSlackWebhook{SlackWebhookAction: slackWebhookAction}).ID())
 	}
+	for _, issueAction := range issueActions {
+		ids = append(ids, (&monitorIssue{IssueAction: issueAction}).ID())
+	}
 	return ids, nil
 }
 
@@ -503,6 +551,16 @@ func splitActionIDs(args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []grap
 			}
 			toUpdateActions = append(toUpdateActions, a)
 			delete(aMap, *a.SlackWebhook.Id)
+		case a.Issue != nil:
+			if a.Issue.Id == nil {
+				toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{Issue: a.Issue.Update})
+				continue
+			}
+			if _, ok := aMap[*a.Issue.Id]; !ok {
+				return nil, nil, errors.Errorf("unknown ID=%s for action", *a.Issue.Id)
+			}
+			toUpdateActions = append(toUpdateActions, a)
+			delete(aMap, *a.Issue.Id)
 		}
 	}
 
@@ -547,26 +605,48 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, rawDB database.DB, arg
 		return nil, err
 	}
 
+	mode, err := toQueryTriggerMode(args.Trigger.Update.Mode, currentTrigger.Mode)
+	if err != nil {
+		return nil, err
+	}
+
 	// When the query is changed, take a new snapshot of the commits that currently
 	// exist so we know where to start.
-	if currentTrigger.QueryString != args.Trigger.Update.Query {
-		// Snapshot the state of the searched repos when the monitor is created so that
-		// we can distinguish new repos.
-		// NOTE: we use rawDB here because Snapshot requires that the db conn is not a transaction.
-		resolvedRevisions, err := codemonitors.Snapshot(ctx, r.logger, rawDB, args.Trigger.Update.Query)
-		if err != nil {
-			return nil, err
-		}
-		for repoID, commitIDs := range resolvedRevisions {
-			err = r.db.CodeMonitors().UpsertLastSearched(ctx, monitorID, repoID, commitIDs)
+	if currentTrigger.QueryString != args.Trigger.Update.Query || currentTrigger.Mode != mode {
+		if mode == database.QueryTriggerModeContent {
+			// Remember the current results so that the monitor only triggers once
+			// they change.
+			// NOTE: we use rawDB here because search requires that the db conn is not a transaction.
+			fingerprints, complete, err := codemonitors.SearchContent(ctx, r.logger, rawDB, args.Trigger.Update.Query)
 			if err != nil {
 				return nil, err
 			}
+			if !complete {
+				return nil, codemonitors.ErrIncompleteContentResults
+			}
+			err = r.db.CodeMonitors().UpsertResultFingerprints(ctx, monitorID, fingerprints)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			// Snapshot the state of the searched repos when the monitor is created so that
+			// we can distinguish new repos.
+			// NOTE: we use rawDB here because Snapshot requires that the db conn is not a transaction.
+			resolvedRevisions, err := codemonitors.Snapshot(ctx, r.logger, rawDB, args.Trigger.Update.Query)
+			if err != nil {
+				return nil, err
+			}
+			for repoID, commitIDs := range resolvedRevisions {
+				err = r.db.CodeMonitors().UpsertLastSearched(ctx, monitorID, repoID, commitIDs)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 	}
 
 	// Update trigger.
-	err = r.db.CodeMonitors().UpdateQueryTrigger(ctx, triggerID, args.Trigger.Update.Query)
+	err = r.db.CodeMonitors().UpdateQueryTrigger(ctx, triggerID, args.Trigger.Update.Query, mode)
 	if err != nil {
 		return nil, err
 	}
@@ -589,8 +669,10 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, rawDB database.DB, arg
 				return nil, err
 			}
 			err = r.updateSlackWebhookAction(ctx, *action.SlackWebhook)
+		case action.Issue != nil:
+			err = r.updateIssueAction(ctx, *action.Issue)
 		default:
-			err = errors.New("action must be one of email, webhook, or slack webhook")
+			err = errors.New("action must be one of email, webhook, slack webhook, or issue")
 		}
 		if err != nil {
 			return nil, err
@@ -646,6 +728,53 @@ func (r *Resolver) updateSlackWebhookAction(ctx context.Context, args graphqlbac
 	return err
 }
 
+func (r *Resolver) updateIssueAction(ctx context.Context, args graphqlbackend.EditActionIssueArgs) error {
+	var id int64
+	err := relay.UnmarshalSpec(*args.Id, &id)
+	if err != nil {
+		return err
+	}
+
+	issueArgs, err := r.issueActionArgs(ctx, args.Update)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.CodeMonitors().UpdateIssueAction(ctx, id, issueArgs)
+	return err
+}
+
+// issueActionArgs validates the arguments of an issue action. Issue actions use
+// the credentials of a code host connection, so only site admins may configure
+// them.
+func (r *Resolver) issueActionArgs(ctx context.Context, args *graphqlbackend.CreateActionIssueArgs) (*database.IssueActionArgs, error) {
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	externalServiceID, err := graphqlbackend.UnmarshalExternalServiceID(args.ExternalService)
+	if err != nil {
+		return nil, err
+	}
+	svc, err := r.db.ExternalServices().GetByID(ctx, externalServiceID)
+	if err != nil {
+		return nil, err
+	}
+	if svc.Kind != extsvc.KindGitHub && svc.Kind != extsvc.KindGitLab {
+		return nil, errors.Errorf("issue actions require a GitHub or GitLab code host connection, got %s", svc.Kind)
+	}
+	if args.Repository == "" {
+		return nil, errors.New("issue actions require a repository")
+	}
+
+	return &database.IssueActionArgs{
+		Enabled:           args.Enabled,
+		IncludeResults:    args.IncludeResults,
+		ExternalServiceID: externalServiceID,
+		Repository:        args.Repository,
+	}, nil
+}
+
 func (r *Resolver) withTransact(ctx context.Context, f func(*Resolver) error) error {
 	return r.db.WithTransact(ctx, func(tx database.DB) error {
 		return f(&Resolver{
@@ -729,6 +858,7 @@ const (
 	monitorActionEmailKind             = "CodeMonitorActionEmail"
 	monitorActionWebhookKind           = "CodeMonitorActionWebhook"
 	monitorActionSlackWebhookKind      = "CodeMonitorActionSlackWebhook"
+	monitorActionIssueKind             = "CodeMonitorActionIssue"
 	monitorActionEmailEventKind        = "CodeMonitorActionEmailEvent"
 	monitorActionWebhookEventKind      = "CodeMonitorActionWebhookEvent"
 	monitorActionSlackWebhookEventKind = "CodeMonitorActionSlackWebhookEvent"
@@ -824,7 +954,12 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 		return nil, err
 	}
 
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws))
+	is, err := r.db.CodeMonitors().ListIssueActions(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(es)+len(ws)+len(sws)+len(is))
 	for _, e := range es {
 		actions = append(actions, &action{
 			email: &monitorEmail{
@@ -852,6 +987,15 @@ func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, tr
 			},
 		})
 	}
+	for _, i := range is {
+		actions = append(actions, &action{
+			issue: &monitorIssue{
+				Resolver:       r,
+				IssueAction:    i,
+				triggerEventID: triggerEventID,
+			},
+		})
+	}
 
 	totalCount := len(actions)
 	if args.After != nil {
@@ -893,6 +1037,29 @@ func (q *monitorQuery) Query() string {
 	return q.QueryString
 }
 
+func (q *monitorQuery) Mode() string {
+	return queryTriggerModeToEnum[q.QueryTrigger.Mode]
+}
+
+var queryTriggerModeToEnum = map[database.QueryTriggerMode]string{
+	database.QueryTriggerModeCommits: "COMMITS",
+	database.QueryTriggerModeContent: "CONTENT",
+}
+
+// toQueryTriggerMode converts a MonitorTriggerMode enum value to its database
+// representation, returning fallback if it is nil.
+func toQueryTriggerMode(mode *string, fallback database.QueryTriggerMode) (database.QueryTriggerMode, error) {
+	if mode == nil {
+		return fallback, nil
+	}
+	for m, enum := range queryTriggerModeToEnum {
+		if enum == *mode {
+			return m, nil
+		}
+	}
+	return "", errors.Errorf("unknown trigger mode: %s", *mode)
+}
+
 func (q *monitorQuery) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorTriggerEventConnectionResolver, error) {
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
@@ -976,6 +1143,10 @@ func (m *monitorTriggerEvent) Query() *string {
 }
 
 func (m *monitorTriggerEvent) ResultCount() int32 {
+	if d := m.TriggerJob.ResultDiff; d != nil {
+		return int32(len(d.Added) + len(d.Removed))
+	}
+
 	count := 0
 	for _, cm := range m.TriggerJob.SearchResults {
 		count += cm.ResultCount()
@@ -1028,6 +1199,7 @@ type action struct {
 	email        graphqlbackend.MonitorEmailResolver
 	webhook      graphqlbackend.MonitorWebhookResolver
 	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+	issue        graphqlbackend.MonitorIssueResolver
 }
 
 func (a *action) ID() graphql.ID {
@@ -1038,6 +1210,8 @@ func (a *action) ID() graphql.ID {
 		return a.webhook.ID()
 	case a.slackWebhook != nil:
 		return a.slackWebhook.ID()
+	case a.issue != nil:
+		return a.issue.ID()
 	default:
 		panic("action must have a type")
 	}
@@ -1055,6 +1229,10 @@ func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookReso
 	return a.slackWebhook, a.slackWebhook != nil
 }
 
+func (a *action) ToMonitorIssue() (graphqlbackend.MonitorIssueResolver, bool) {
+	return a.issue, a.issue != nil
+}
+
 // Email
 type monitorEmail struct {
 	*Resolver
@@ -1270,6 +1448,70 @@ func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.L
 	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
 }
 
+type monitorIssue struct {
+	*Resolver
+	*database.IssueAction
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int32
+}
+
+func (m *monitorIssue) ID() graphql.ID {
+	return relay.MarshalID(monitorActionIssueKind, m.IssueAction.ID)
+}
+
+func (m *monitorIssue) Enabled() bool {
+	return m.IssueAction.Enabled
+}
+
+func (m *monitorIssue) IncludeResults() bool {
+	return m.IssueAction.IncludeResults
+}
+
+func (m *monitorIssue) ExternalService() graphql.ID {
+	return graphqlbackend.MarshalExternalServiceID(m.IssueAction.ExternalServiceID)
+}
+
+func (m *monitorIssue) Repository() string {
+	return m.IssueAction.Repository
+}
+
+func (m *monitorIssue) IssueURL() *string {
+	return m.IssueAction.ExternalIssueURL
+}
+
+func (m *monitorIssue) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	after, err := unmarshalAfter(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	ajs, err := m.db.CodeMonitors().ListActionJobs(ctx, database.ListActionJobsOpts{
+		IssueID:        pointers.Ptr(int(m.IssueAction.ID)),
+		TriggerEventID: m.triggerEventID,
+		First:          pointers.Ptr(int(args.First)),
+		After:          after,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	totalCount, err := m.db.CodeMonitors().CountActionJobs(ctx, database.ListActionJobsOpts{
+		IssueID:        pointers.Ptr(int(m.IssueAction.ID)),
+		TriggerEventID: m.triggerEventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
+	for i, aj := range ajs {
+		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+	}
+	return &monitorActionEventConnection{events: events, totalCount: int32(totalCount)}, nil
+}
+
 func intPtrToInt64Ptr(i *int) *int64 {
 	if i == nil {
 		return nil
@@ -57,11 +57,7 @@ func (c *completionsResolver) Completions(ctx context.Context, args graphqlbacke
 		Build()
 	defer done()
 
-	client, err := client.Get(
-		completionsConfig.Endpoint,
-		completionsConfig.Provider,
-		completionsConfig.AccessToken,
-	)
+	client, err := client.Get(completionsConfig)
 	if err != nil {
 		return "", errors.Wrap(err, "GetCompletionStreamClient")
 	}
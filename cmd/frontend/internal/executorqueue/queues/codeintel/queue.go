@@ -18,6 +18,7 @@ func QueueHandler(observationCtx *observation.Context, db database.DB, accessTok
 	}
 
 	store := dbworkerstore.New(observationCtx, db.Handle(), autoindexing.IndexWorkerStoreOptions)
+	dbworkerstore.RegisterDeadLetterStore(store)
 
 	return handler.QueueHandler[uploadsshared.Index]{
 		Name:              "codeintel",
@@ -79,7 +79,7 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/scip/upload").Methods("POST").Name(SCIPUpload)
 	base.Path("/scip/upload").Methods("HEAD").Name(SCIPUploadExists)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
-	base.Path("/search/export/{id}.csv").Methods("GET").Name(SearchJobResults)
+	base.Path("/search/export/{id:[0-9]+}.{format:csv|jsonl|diff\\.jsonl}").Methods("GET").Name(SearchJobResults)
 	base.Path("/search/export/{id}.log").Methods("GET").Name(SearchJobLogs)
 	base.Path("/compute/stream").Methods("GET", "POST").Name(ComputeStream)
 	base.Path("/blame/" + routevar.Repo + routevar.RepoRevSuffix + "/stream/{Path:.*}").Methods("GET").Name(GitBlameStream)
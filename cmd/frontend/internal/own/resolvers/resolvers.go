@@ -703,11 +703,11 @@ func (r *ownResolver) AssignOwner(ctx context.Context, args *graphqlbackend.Assi
 	if actor.FromContext(ctx).IsInternal() {
 		return nil, nil
 	}
-	user, err := r.checkAssignedOwnershipPermission(ctx)
+	u, err := unmarshalAssignOwnerArgs(args.Input, userUnmarshalMode)
 	if err != nil {
 		return nil, err
 	}
-	u, err := unmarshalAssignOwnerArgs(args.Input, userUnmarshalMode)
+	user, err := r.checkAssignedOwnershipPermission(ctx, u.RepoID)
 	if err != nil {
 		return nil, err
 	}
@@ -724,11 +724,11 @@ func (r *ownResolver) RemoveAssignedOwner(ctx context.Context, args *graphqlback
 	if actor.FromContext(ctx).IsInternal() {
 		return nil, nil
 	}
-	_, err := r.checkAssignedOwnershipPermission(ctx)
+	u, err := unmarshalAssignOwnerArgs(args.Input, userUnmarshalMode)
 	if err != nil {
 		return nil, err
 	}
-	u, err := unmarshalAssignOwnerArgs(args.Input, userUnmarshalMode)
+	_, err = r.checkAssignedOwnershipPermission(ctx, u.RepoID)
 	if err != nil {
 		return nil, err
 	}
@@ -744,11 +744,11 @@ func (r *ownResolver) AssignTeam(ctx context.Context, args *graphqlbackend.Assig
 	if actor.FromContext(ctx).IsInternal() {
 		return nil, nil
 	}
-	user, err := r.checkAssignedOwnershipPermission(ctx)
+	t, err := unmarshalAssignOwnerArgs(args.Input, teamUnmarshalMode)
 	if err != nil {
 		return nil, err
 	}
-	t, err := unmarshalAssignOwnerArgs(args.Input, teamUnmarshalMode)
+	user, err := r.checkAssignedOwnershipPermission(ctx, t.RepoID)
 	if err != nil {
 		return nil, err
 	}
@@ -765,11 +765,11 @@ func (r *ownResolver) RemoveAssignedTeam(ctx context.Context, args *graphqlbacke
 	if actor.FromContext(ctx).IsInternal() {
 		return nil, nil
 	}
-	_, err := r.checkAssignedOwnershipPermission(ctx)
+	t, err := unmarshalAssignOwnerArgs(args.Input, teamUnmarshalMode)
 	if err != nil {
 		return nil, err
 	}
-	t, err := unmarshalAssignOwnerArgs(args.Input, teamUnmarshalMode)
+	_, err = r.checkAssignedOwnershipPermission(ctx, t.RepoID)
 	if err != nil {
 		return nil, err
 	}
@@ -781,8 +781,9 @@ func (r *ownResolver) RemoveAssignedTeam(ctx context.Context, args *graphqlbacke
 }
 
 // checkAssignedOwnershipPermission checks that the user from the context has
-// `rbac.OwnershipAssignPermission` and returns the user if so.
-func (r *ownResolver) checkAssignedOwnershipPermission(ctx context.Context) (*types.User, error) {
+// `rbac.OwnershipAssignPermission` on the given repository and returns the user
+// if so.
+func (r *ownResolver) checkAssignedOwnershipPermission(ctx context.Context, repoID api.RepoID) (*types.User, error) {
 	// Extracting the user to run an RBAC check and then use their ID as an
 	// `whoAssignedUserID`.
 	user, err := auth.CurrentUser(ctx, r.db)
@@ -793,7 +794,7 @@ func (r *ownResolver) checkAssignedOwnershipPermission(ctx context.Context) (*ty
 		return nil, auth.ErrNotAuthenticated
 	}
 	// Checking if the user has permission to assign an owner.
-	if err := rbac.CheckGivenUserHasPermission(ctx, r.db, user, rbac.OwnershipAssignPermission); err != nil {
+	if err := rbac.CheckGivenUserHasPermissionInScope(ctx, r.db, user, rbac.OwnershipAssignPermission, rbac.Scope{RepoID: repoID}); err != nil {
 		return nil, err
 	}
 	return user, nil
@@ -5,16 +5,21 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
 	"github.com/sourcegraph/log"
 
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/deviceid"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 // Resolver is the GraphQL resolver of all things related to batch changes.
@@ -37,6 +42,13 @@ type setRolesEventArgs struct {
 	RoleIDs []int32 `json:"role_ids"`
 }
 
+type setRoleScopesEventArgs struct {
+	RoleID           int32        `json:"role_id"`
+	OrgIDs           []int32      `json:"org_ids"`
+	RepoIDs          []api.RepoID `json:"repo_ids"`
+	SearchContextIDs []int64      `json:"search_context_ids"`
+}
+
 func New(logger log.Logger, db database.DB) gql.RBACResolver {
 	return &Resolver{logger: logger, db: db}
 }
@@ -172,6 +184,88 @@ func (r *Resolver) SetRoles(ctx context.Context, args *gql.SetRolesArgs) (*gql.E
 	return &gql.EmptyResponse{}, nil
 }
 
+func (r *Resolver) SetRoleScopes(ctx context.Context, args *gql.SetRoleScopesArgs) (*gql.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site administrators can set the scopes of a role.
+	if err := auth.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	roleID, err := gql.UnmarshalRoleID(args.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	if roleID == 0 {
+		return nil, gql.ErrIDIsZero{}
+	}
+
+	opts := database.SetScopesForRoleOpts{RoleID: roleID}
+
+	if args.Orgs != nil {
+		for _, id := range *args.Orgs {
+			orgID, err := gql.UnmarshalOrgID(id)
+			if err != nil {
+				return nil, err
+			}
+			opts.OrgIDs = append(opts.OrgIDs, orgID)
+		}
+	}
+
+	if args.Repositories != nil {
+		for _, id := range *args.Repositories {
+			repoID, err := gql.UnmarshalRepositoryID(id)
+			if err != nil {
+				return nil, err
+			}
+			opts.RepoIDs = append(opts.RepoIDs, repoID)
+		}
+	}
+
+	if args.SearchContexts != nil {
+		for _, id := range *args.SearchContexts {
+			searchContextID, err := r.resolveSearchContextID(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			opts.SearchContextIDs = append(opts.SearchContextIDs, searchContextID)
+		}
+	}
+
+	if err = r.db.RoleScopes().SetScopesForRole(ctx, opts); err != nil {
+		return nil, err
+	}
+
+	eventArgs := &setRoleScopesEventArgs{
+		RoleID:           roleID,
+		OrgIDs:           opts.OrgIDs,
+		RepoIDs:          opts.RepoIDs,
+		SearchContextIDs: opts.SearchContextIDs,
+	}
+	r.logBackendEvent(ctx, "RoleScopesAssignment", eventArgs)
+	return &gql.EmptyResponse{}, nil
+}
+
+// resolveSearchContextID returns the database ID of the search context with the given
+// GraphQL ID. Search contexts are identified by their spec in the GraphQL API.
+func (r *Resolver) resolveSearchContextID(ctx context.Context, id graphql.ID) (int64, error) {
+	var spec string
+	if err := relay.UnmarshalSpec(id, &spec); err != nil {
+		return 0, err
+	}
+
+	searchContext, err := searchcontexts.ResolveSearchContextSpec(ctx, r.db, spec)
+	if err != nil {
+		return 0, err
+	}
+
+	// Auto-defined search contexts, such as the global context, are not stored in the
+	// database and would grant the permissions on all repositories.
+	if searchContext.ID == 0 {
+		return 0, errors.Newf("cannot scope a role to the search context %q", spec)
+	}
+	return searchContext.ID, nil
+}
+
 func (r *Resolver) logBackendEvent(ctx context.Context, eventName string, args any) {
 	a := actor.FromContext(ctx)
 	if a.IsAuthenticated() && !a.IsMockUser() {
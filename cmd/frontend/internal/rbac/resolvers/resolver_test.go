@@ -449,6 +449,96 @@ mutation SetRoles($roles: [ID!]!, $user: ID!) {
 }
 `
 
+func TestSetRoleScopes(t *testing.T) {
+	logger := logtest.Scoped(t)
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx := context.Background()
+	db := database.NewDB(logger, dbtest.NewDB(t))
+
+	uID := createTestUser(t, db, false).ID
+	userCtx := actor.WithActor(ctx, actor.FromMockUser(uID))
+
+	aID := createTestUser(t, db, true).ID
+	adminCtx := actor.WithActor(ctx, actor.FromMockUser(aID))
+
+	s, err := newSchema(db, &Resolver{logger: logger, db: db})
+	require.NoError(t, err)
+
+	role, err := db.Roles().Create(ctx, "TEST-ROLE", false)
+	require.NoError(t, err)
+
+	org, err := db.Orgs().Create(ctx, "the-org", nil)
+	require.NoError(t, err)
+
+	err = db.Repos().Create(ctx, &types.Repo{Name: "github.com/sourcegraph/sourcegraph"})
+	require.NoError(t, err)
+	repo, err := db.Repos().GetByName(ctx, "github.com/sourcegraph/sourcegraph")
+	require.NoError(t, err)
+
+	input := map[string]any{
+		"role":         gql.MarshalRoleID(role.ID),
+		"orgs":         []graphql.ID{gql.MarshalOrgID(org.ID)},
+		"repositories": []graphql.ID{gql.MarshalRepositoryID(repo.ID)},
+	}
+
+	t.Run("as non site-admin", func(t *testing.T) {
+		var response struct{ SetRoleScopes apitest.EmptyResponse }
+		errs := apitest.Exec(userCtx, t, s, input, &response, setRoleScopesQuery)
+
+		require.Len(t, errs, 1)
+		require.ErrorContains(t, errs[0], "must be site admin")
+	})
+
+	t.Run("as site-admin", func(t *testing.T) {
+		t.Run("set scopes", func(t *testing.T) {
+			var response struct{ SetRoleScopes apitest.EmptyResponse }
+			apitest.MustExec(adminCtx, t, s, input, &response, setRoleScopesQuery)
+
+			scopes, err := db.RoleScopes().GetByRoleID(ctx, database.GetRoleScopesOpts{RoleID: role.ID})
+			require.NoError(t, err)
+			require.Len(t, scopes, 2)
+			require.Equal(t, org.ID, scopes[0].OrgID)
+			require.Equal(t, repo.ID, scopes[1].RepoID)
+		})
+
+		t.Run("clear scopes", func(t *testing.T) {
+			input := map[string]any{"role": gql.MarshalRoleID(role.ID)}
+			var response struct{ SetRoleScopes apitest.EmptyResponse }
+			apitest.MustExec(adminCtx, t, s, input, &response, setRoleScopesQuery)
+
+			scopes, err := db.RoleScopes().GetByRoleID(ctx, database.GetRoleScopesOpts{RoleID: role.ID})
+			require.NoError(t, err)
+			require.Empty(t, scopes)
+		})
+
+		t.Run("system role", func(t *testing.T) {
+			userRole, err := db.Roles().Get(ctx, database.GetRoleOpts{Name: string(types.UserSystemRole)})
+			require.NoError(t, err)
+
+			input := map[string]any{
+				"role": gql.MarshalRoleID(userRole.ID),
+				"orgs": []graphql.ID{gql.MarshalOrgID(org.ID)},
+			}
+			var response struct{ SetRoleScopes apitest.EmptyResponse }
+			errs := apitest.Exec(adminCtx, t, s, input, &response, setRoleScopesQuery)
+
+			require.Len(t, errs, 1)
+			require.ErrorContains(t, errs[0], "cannot scope a system role")
+		})
+	})
+}
+
+const setRoleScopesQuery = `
+mutation SetRoleScopes($role: ID!, $orgs: [ID!], $repositories: [ID!]) {
+	setRoleScopes(role: $role, orgs: $orgs, repositories: $repositories) {
+		alwaysNil
+	}
+}
+`
+
 func createUserWithRoles(ctx context.Context, t *testing.T, db database.DB, roles ...*types.Role) *types.User {
 	t.Helper()
 
@@ -33,14 +33,39 @@ func ServeSearchJobDownload(logger log.Logger, svc *service.Service) http.Handle
 			return
 		}
 
-		csvWriterTo, err := svc.GetSearchJobCSVWriterTo(r.Context(), int64(jobID))
-		if err != nil {
-			httpError(w, err)
-			return
-		}
+		// The format is selected by the extension of the download. Routes
+		// without a format variable serve CSV.
+		switch format := mux.Vars(r)["format"]; format {
+		case "", "csv":
+			csvWriterTo, err := svc.GetSearchJobCSVWriterTo(r.Context(), int64(jobID))
+			if err != nil {
+				httpError(w, err)
+				return
+			}
 
-		filename := filenamePrefix(jobID) + ".csv"
-		writeCSV(logger.With(log.Int("jobID", jobID)), w, filename, csvWriterTo)
+			filename := filenamePrefix(jobID) + ".csv"
+			writeCSV(logger.With(log.Int("jobID", jobID)), w, filename, csvWriterTo)
+		case "jsonl":
+			jsonLinesWriterTo, err := svc.GetSearchJobJSONLinesWriterTo(r.Context(), int64(jobID))
+			if err != nil {
+				httpError(w, err)
+				return
+			}
+
+			filename := filenamePrefix(jobID) + ".jsonl"
+			writeJSONLines(logger.With(log.Int("jobID", jobID)), w, filename, jsonLinesWriterTo)
+		case "diff.jsonl":
+			diffWriterTo, err := svc.GetSearchJobDiffWriterTo(r.Context(), int64(jobID))
+			if err != nil {
+				httpError(w, err)
+				return
+			}
+
+			filename := filenamePrefix(jobID) + ".diff.jsonl"
+			writeJSONLines(logger.With(log.Int("jobID", jobID)), w, filename, diffWriterTo)
+		default:
+			http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+		}
 	}
 }
 
@@ -76,11 +101,21 @@ func writeCSV(logger log.Logger, w http.ResponseWriter, filenameNoQuotes string,
 	}
 }
 
+func writeJSONLines(logger log.Logger, w http.ResponseWriter, filenameNoQuotes string, writerTo io.WriterTo) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filenameNoQuotes))
+	w.WriteHeader(200)
+	n, err := writerTo.WriteTo(w)
+	if err != nil {
+		logger.Warn("failed while writing search job json lines response", log.String("filename", filenameNoQuotes), log.Int64("bytesWritten", n), log.Error(err))
+	}
+}
+
 func httpError(w http.ResponseWriter, err error) {
 	switch {
 	case errors.Is(err, auth.ErrMustBeSiteAdminOrSameUser):
 		http.Error(w, err.Error(), http.StatusForbidden)
-	case errors.Is(err, store.ErrNoResults):
+	case errors.Is(err, store.ErrNoResults), errors.Is(err, service.ErrNoPreviousJob):
 		http.Error(w, err.Error(), http.StatusNotFound)
 	default:
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -46,7 +46,7 @@ func TestServeSearchJobDownload(t *testing.T) {
 	svc := service.New(observationCtx, s, mockUploadStore, service.NewSearcherFake())
 
 	router := mux.NewRouter()
-	router.HandleFunc("/{id}.csv", ServeSearchJobDownload(logger, svc))
+	router.HandleFunc("/{id:[0-9]+}.{format:csv|jsonl|diff\\.jsonl}", ServeSearchJobDownload(logger, svc))
 
 	// no job
 	{
@@ -80,6 +80,28 @@ func TestServeSearchJobDownload(t *testing.T) {
 
 		require.Equal(t, http.StatusOK, w.Code)
 		require.Equal(t, "", w.Body.String())
+
+		req, err = http.NewRequest(http.MethodGet, "/1.jsonl", nil)
+		require.NoError(t, err)
+
+		req = req.WithContext(actor.WithActor(context.Background(), &actor.Actor{UID: userID}))
+		w = httptest.NewRecorder()
+		w.Body = &bytes.Buffer{}
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+		require.Equal(t, "", w.Body.String())
+
+		// The job is not a re-run, so there is nothing to compare to.
+		req, err = http.NewRequest(http.MethodGet, "/1.diff.jsonl", nil)
+		require.NoError(t, err)
+
+		req = req.WithContext(actor.WithActor(context.Background(), &actor.Actor{UID: userID}))
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
 	}
 
 	// wrong user
@@ -81,6 +81,17 @@ func (r *searchJobResolver) URL(ctx context.Context) (*string, error) {
 	return nil, nil
 }
 
+func (r *searchJobResolver) JSONLinesURL(ctx context.Context) (*string, error) {
+	if r.Job.State == types.JobStateCompleted {
+		exportPath, err := url.JoinPath(conf.Get().ExternalURL, fmt.Sprintf("/.api/search/export/%d.jsonl", r.Job.ID))
+		if err != nil {
+			return nil, err
+		}
+		return pointers.Ptr(exportPath), nil
+	}
+	return nil, nil
+}
+
 func (r *searchJobResolver) LogURL(ctx context.Context) (*string, error) {
 	if r.Job.State == types.JobStateCompleted {
 		exportPath, err := url.JoinPath(conf.Get().ExternalURL, fmt.Sprintf("/.api/search/export/%d.log", r.Job.ID))
@@ -92,6 +103,28 @@ func (r *searchJobResolver) LogURL(ctx context.Context) (*string, error) {
 	return nil, nil
 }
 
+func (r *searchJobResolver) PreviousSearchJob(ctx context.Context) (graphqlbackend.SearchJobResolver, error) {
+	if r.Job.PreviousJobID == 0 {
+		return nil, nil
+	}
+	job, err := r.svc.GetSearchJob(ctx, r.Job.PreviousJobID)
+	if err != nil {
+		return nil, err
+	}
+	return newSearchJobResolver(r.db, r.svc, job), nil
+}
+
+func (r *searchJobResolver) DiffURL(ctx context.Context) (*string, error) {
+	if r.Job.State == types.JobStateCompleted && r.Job.PreviousJobID != 0 {
+		exportPath, err := url.JoinPath(conf.Get().ExternalURL, fmt.Sprintf("/.api/search/export/%d.diff.jsonl", r.Job.ID))
+		if err != nil {
+			return nil, err
+		}
+		return pointers.Ptr(exportPath), nil
+	}
+	return nil, nil
+}
+
 func (r *searchJobResolver) RepoStats(ctx context.Context) (graphqlbackend.SearchJobStatsResolver, error) {
 	repoRevStats, err := r.svc.GetAggregateRepoRevState(ctx, r.Job.ID)
 	if err != nil {
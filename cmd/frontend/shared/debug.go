@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/cli"
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 )
 
 // gRPCWebUIDebugEndpoints returns debug points that serve the GRPCWebUI instances that target
@@ -40,5 +41,6 @@ func CreateDebugServerEndpoints() []debugserver.Endpoint {
 				_, _ = w.Write(resp)
 			}),
 		},
+		dbworker.DeadLetterDebugEndpoint(),
 	)
 }
@@ -0,0 +1,282 @@
+package internal
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	// catFileMaxIdlePerRepo is the maximum number of idle `git cat-file
+	// --batch` processes kept for a single repository.
+	catFileMaxIdlePerRepo = 2
+
+	// catFileMaxIdle is the maximum number of idle `git cat-file --batch`
+	// processes kept across all repositories.
+	catFileMaxIdle = 64
+
+	// catFileIdleTimeout is how long an idle `git cat-file --batch` process is
+	// kept before it is stopped.
+	catFileIdleTimeout = 30 * time.Second
+
+	// catFileChunkSize is the maximum size of the chunks file contents are
+	// read in.
+	catFileChunkSize = 64 * 1024
+)
+
+// catFileProcess is a long-lived `git cat-file --batch` process for a
+// repository. It reads one object at a time, and must not be used
+// concurrently.
+type catFileProcess struct {
+	repo     api.RepoName
+	dir      os.FileInfo // the repository directory the process was started in
+	cmd      *exec.Cmd
+	stdin    io.WriteCloser
+	stdout   *bufio.Reader
+	lastUsed time.Time
+}
+
+func startCatFileProcess(repo api.RepoName, dir common.GitDir, configure func(*exec.Cmd)) (*catFileProcess, error) {
+	fi, err := os.Stat(dir.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.Command("git", "cat-file", "--batch")
+	dir.Set(cmd)
+	if configure != nil {
+		configure(cmd)
+	}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrap(err, "starting git cat-file")
+	}
+
+	return &catFileProcess{
+		repo:   repo,
+		dir:    fi,
+		cmd:    cmd,
+		stdin:  stdin,
+		stdout: bufio.NewReaderSize(stdout, catFileChunkSize),
+	}, nil
+}
+
+func (p *catFileProcess) close() {
+	_ = p.stdin.Close()
+	_ = p.cmd.Process.Kill()
+	_ = p.cmd.Wait()
+}
+
+// readFile reads the blob at path in commit, and calls send with its contents
+// in chunks. Empty files are sent as a single empty chunk. It returns false if
+// path doesn't exist or isn't a blob. If an error is returned, the process is
+// in an unknown state and must be closed.
+func (p *catFileProcess) readFile(commit, path string, send func(data []byte) error) (found bool, err error) {
+	if _, err := io.WriteString(p.stdin, commit+":"+path+"\n"); err != nil {
+		return false, err
+	}
+
+	line, err := p.stdout.ReadString('\n')
+	if err != nil {
+		return false, err
+	}
+	line = strings.TrimSuffix(line, "\n")
+
+	// "<object> missing" and "<object> ambiguous" are reported for objects
+	// that can't be resolved.
+	if strings.HasSuffix(line, " missing") || strings.HasSuffix(line, " ambiguous") {
+		return false, nil
+	}
+
+	// Otherwise, "<oid> <type> <size>" is followed by the contents of the
+	// object and a newline.
+	fields := strings.Fields(line)
+	if len(fields) != 3 {
+		return false, errors.Errorf("invalid `git cat-file` output: %q", line)
+	}
+	size, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil || size < 0 {
+		return false, errors.Errorf("invalid `git cat-file` size output: %q", line)
+	}
+
+	if fields[1] != "blob" {
+		_, err := p.stdout.Discard(int(size) + 1)
+		return false, err
+	}
+
+	if size == 0 {
+		if err := send([]byte{}); err != nil {
+			return true, err
+		}
+	}
+	buf := make([]byte, catFileChunkSize)
+	for remaining := size; remaining > 0; {
+		chunk := buf
+		if remaining < int64(len(chunk)) {
+			chunk = chunk[:remaining]
+		}
+		if _, err := io.ReadFull(p.stdout, chunk); err != nil {
+			return true, err
+		}
+		remaining -= int64(len(chunk))
+		if err := send(chunk); err != nil {
+			return true, err
+		}
+	}
+
+	// Drop the newline that terminates the object.
+	_, err = p.stdout.Discard(1)
+	return true, err
+}
+
+// catFilePool keeps idle `git cat-file --batch` processes per repository, so
+// that reading files doesn't start a git process per request. Its zero value
+// is ready to use.
+type catFilePool struct {
+	mu      sync.Mutex
+	idle    map[api.RepoName][]*catFileProcess
+	numIdle int
+}
+
+// do calls fn with a `git cat-file --batch` process for the repository in
+// dir, which is returned to the pool afterwards if fn succeeded and reuse is
+// true. configure is called on the command of new processes before they are
+// started. The process is killed if ctx is canceled while fn runs.
+func (cp *catFilePool) do(ctx context.Context, repo api.RepoName, dir common.GitDir, configure func(*exec.Cmd), reuse bool, fn func(*catFileProcess) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var p *catFileProcess
+	if reuse {
+		p = cp.get(repo, dir)
+	}
+	if p == nil {
+		var err error
+		if p, err = startCatFileProcess(repo, dir, configure); err != nil {
+			return err
+		}
+	}
+
+	var killed bool
+	done, exited := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			killed = true
+			_ = p.cmd.Process.Kill()
+		case <-done:
+		}
+	}()
+
+	err := fn(p)
+	close(done)
+	<-exited
+
+	if killed {
+		p.close()
+		return ctx.Err()
+	}
+	if err != nil || !reuse {
+		p.close()
+		return err
+	}
+	cp.put(p)
+	return nil
+}
+
+// get returns an idle process for repo, or nil if there is none. Processes
+// started before the repository directory was replaced, for example by a
+// reclone, are discarded.
+func (cp *catFilePool) get(repo api.RepoName, dir common.GitDir) *catFileProcess {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.reapLocked(time.Now())
+
+	fi, err := os.Stat(dir.Path())
+	for ps := cp.idle[repo]; len(ps) > 0; ps = cp.idle[repo] {
+		p := ps[len(ps)-1]
+		cp.removeLocked(repo, len(ps)-1)
+		if err == nil && os.SameFile(fi, p.dir) {
+			return p
+		}
+		p.close()
+	}
+	return nil
+}
+
+func (cp *catFilePool) put(p *catFileProcess) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	now := time.Now()
+	cp.reapLocked(now)
+
+	if len(cp.idle[p.repo]) >= catFileMaxIdlePerRepo || cp.numIdle >= catFileMaxIdle {
+		p.close()
+		return
+	}
+	if cp.idle == nil {
+		cp.idle = make(map[api.RepoName][]*catFileProcess)
+	}
+	p.lastUsed = now
+	cp.idle[p.repo] = append(cp.idle[p.repo], p)
+	cp.numIdle++
+}
+
+// reapLocked stops the processes that have been idle for longer than
+// catFileIdleTimeout.
+func (cp *catFilePool) reapLocked(now time.Time) {
+	for repo, ps := range cp.idle {
+		for i := len(ps) - 1; i >= 0; i-- {
+			if now.Sub(ps[i].lastUsed) > catFileIdleTimeout {
+				ps[i].close()
+				cp.removeLocked(repo, i)
+			}
+		}
+	}
+}
+
+func (cp *catFilePool) removeLocked(repo api.RepoName, i int) {
+	ps := cp.idle[repo]
+	ps = append(ps[:i], ps[i+1:]...)
+	if len(ps) == 0 {
+		delete(cp.idle, repo)
+	} else {
+		cp.idle[repo] = ps
+	}
+	cp.numIdle--
+}
+
+// closeAll stops all idle processes.
+func (cp *catFilePool) closeAll() {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	for repo, ps := range cp.idle {
+		for _, p := range ps {
+			p.close()
+		}
+		delete(cp.idle, repo)
+	}
+	cp.numIdle = 0
+}
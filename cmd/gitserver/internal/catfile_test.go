@@ -0,0 +1,120 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestCatFilePool(t *testing.T) {
+	root := t.TempDir()
+	runCmd(t, root, "git", "init", ".")
+	writeFile(t, filepath.Join(root, "a.txt"), []byte("hello\nworld\n"))
+	writeFile(t, filepath.Join(root, "empty.txt"), nil)
+	if err := os.Mkdir(filepath.Join(root, "dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(root, "dir", "b.txt"), []byte(strings.Repeat("x", catFileChunkSize+1)))
+	runCmd(t, root, "git", "add", ".")
+	runCmd(t, root, "git", "commit", "-m", "commit")
+	commit := strings.TrimSpace(runCmd(t, root, "git", "rev-parse", "HEAD"))
+
+	const repo = api.RepoName("example.com/repo")
+	dir := common.GitDir(filepath.Join(root, ".git"))
+
+	type file struct {
+		Path     string
+		Data     string
+		NotFound bool
+	}
+	readFiles := func(p *catFileProcess, paths ...string) ([]file, error) {
+		var got []file
+		for _, path := range paths {
+			f := file{Path: path}
+			found, err := p.readFile(commit, path, func(data []byte) error {
+				f.Data += string(data)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			f.NotFound = !found
+			got = append(got, f)
+		}
+		return got, nil
+	}
+
+	var cp catFilePool
+	t.Cleanup(cp.closeAll)
+
+	var first *catFileProcess
+	err := cp.do(context.Background(), repo, dir, nil, true, func(p *catFileProcess) error {
+		first = p
+		got, err := readFiles(p, "a.txt", "missing.txt", "empty.txt", "dir", "dir/b.txt", "a.txt")
+		if err != nil {
+			return err
+		}
+		want := []file{
+			{Path: "a.txt", Data: "hello\nworld\n"},
+			{Path: "missing.txt", NotFound: true},
+			{Path: "empty.txt"},
+			{Path: "dir", NotFound: true},
+			{Path: "dir/b.txt", Data: strings.Repeat("x", catFileChunkSize+1)},
+			{Path: "a.txt", Data: "hello\nworld\n"},
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("unexpected files (-want +got):\n%s", diff)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("reuses idle process", func(t *testing.T) {
+		err := cp.do(context.Background(), repo, dir, nil, true, func(p *catFileProcess) error {
+			if p != first {
+				t.Error("expected idle process to be reused")
+			}
+			_, err := readFiles(p, "a.txt")
+			return err
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("does not reuse process after error", func(t *testing.T) {
+		_ = cp.do(context.Background(), repo, dir, nil, true, func(p *catFileProcess) error {
+			return context.DeadlineExceeded
+		})
+		err := cp.do(context.Background(), repo, dir, nil, true, func(p *catFileProcess) error {
+			if p == first {
+				t.Error("expected new process")
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := cp.do(ctx, repo, dir, nil, true, func(p *catFileProcess) error {
+			_, err := readFiles(p, "a.txt")
+			return err
+		})
+		if err != context.Canceled {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/coldstorage"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
@@ -46,6 +47,10 @@ type JanitorConfig struct {
 	ReposDir        string
 
 	DesiredPercentFree int
+
+	// ColdStorage is where repos removed to free up disk space are offloaded
+	// to. It is nil if cold storage is disabled.
+	ColdStorage *coldstorage.Store
 }
 
 func NewJanitor(ctx context.Context, cfg JanitorConfig, db database.DB, rcf *wrexec.RecordingCommandFactory, cloneRepo cloneRepoFunc, logger log.Logger) goroutine.BackgroundRoutine {
@@ -64,14 +69,15 @@ func NewJanitor(ctx context.Context, cfg JanitorConfig, db database.DB, rcf *wre
 			// best metric we have here today) once our disks are running full.
 			// On customer instances, this worker is useless, because repos are always
 			// managed by an external service connection and they will be recloned
-			// ASAP.
-			if envvar.SourcegraphDotComMode() {
+			// ASAP, unless they have been offloaded to cold storage, in which case
+			// they are restored from there.
+			if envvar.SourcegraphDotComMode() || cfg.ColdStorage != nil {
 				diskSizer := &StatDiskSizer{}
 				logger := logger.Scoped("dotcom-repo-cleaner", "The background janitor process to clean up repos on Sourcegraph.com that haven't been changed in a long time")
 				toFree, err := howManyBytesToFree(logger, cfg.ReposDir, diskSizer, cfg.DesiredPercentFree)
 				if err != nil {
 					logger.Error("ensuring free disk space", log.Error(err))
-				} else if err := freeUpSpace(ctx, logger, db, cfg.ShardID, cfg.ReposDir, diskSizer, cfg.DesiredPercentFree, toFree); err != nil {
+				} else if err := freeUpSpace(ctx, logger, db, rcf, cfg.ColdStorage, cfg.ShardID, cfg.ReposDir, diskSizer, cfg.DesiredPercentFree, toFree); err != nil {
 					logger.Error("error freeing up space", log.Error(err))
 				}
 			}
@@ -286,13 +292,17 @@ func cleanupRepos(
 	collectSizeAndMaybeDeleteWrongShardRepos := func(dir common.GitDir) (done bool, err error) {
 		size := gitserverfs.DirSize(dir.Path("."))
 		name := gitserverfs.RepoNameFromDir(reposDir, dir)
-		repoToSize[name] = size
+		if recordsRepoState(ctx, shardID, name) {
+			repoToSize[name] = size
+		}
 
 		// Record the number and disk usage used of repos that should
 		// not belong on this instance and remove up to SRC_WRONG_SHARD_DELETE_LIMIT in a single Janitor run.
-		addr := addrForRepo(ctx, name, gitServerAddrs)
+		// Replicas of a repo belong on this instance as much as its primary.
+		addrs := addrsForRepo(ctx, name, gitServerAddrs)
+		addr := addrs[0]
 
-		if !hostnameMatch(shardID, addr) {
+		if !hostnameMatchAny(shardID, addrs) {
 			wrongShardRepoCount++
 			wrongShardRepoSize += size
 
@@ -320,13 +330,16 @@ func cleanupRepos(
 		}
 
 		repoName := gitserverfs.RepoNameFromDir(reposDir, dir)
-		err = db.GitserverRepos().LogCorruption(ctx, repoName, fmt.Sprintf("sourcegraph detected corrupt repo: %s", reason), shardID)
-		if err != nil {
-			logger.Warn("failed to log repo corruption", log.String("repo", string(repoName)), log.Error(err))
+		recordState := recordsRepoState(ctx, shardID, repoName)
+		if recordState {
+			err = db.GitserverRepos().LogCorruption(ctx, repoName, fmt.Sprintf("sourcegraph detected corrupt repo: %s", reason), shardID)
+			if err != nil {
+				logger.Warn("failed to log repo corruption", log.String("repo", string(repoName)), log.Error(err))
+			}
 		}
 
 		logger.Info("removing corrupt repo", log.String("repo", string(dir)), log.String("reason", reason))
-		if err := gitserverfs.RemoveRepoDirectory(ctx, logger, db, shardID, reposDir, dir, true); err != nil {
+		if err := gitserverfs.RemoveRepoDirectory(ctx, logger, db, shardID, reposDir, dir, recordState); err != nil {
 			return true, err
 		}
 		reposRemoved.WithLabelValues(reason).Inc()
@@ -676,8 +689,9 @@ func (s *StatDiskSizer) DiskSizeBytes(mountPoint string) (uint64, error) {
 }
 
 // freeUpSpace removes git directories under ReposDir, in order from least
-// recently to most recently used, until it has freed howManyBytesToFree.
-func freeUpSpace(ctx context.Context, logger log.Logger, db database.DB, shardID string, reposDir string, diskSizer DiskSizer, desiredPercentFree int, howManyBytesToFree int64) error {
+// recently to most recently used, until it has freed howManyBytesToFree. If
+// coldStorage is not nil, repos are offloaded to it before they are removed.
+func freeUpSpace(ctx context.Context, logger log.Logger, db database.DB, rcf *wrexec.RecordingCommandFactory, coldStorage *coldstorage.Store, shardID string, reposDir string, diskSizer DiskSizer, desiredPercentFree int, howManyBytesToFree int64) error {
 	if howManyBytesToFree <= 0 {
 		return nil
 	}
@@ -722,7 +736,11 @@ func freeUpSpace(ctx context.Context, logger log.Logger, db database.DB, shardID
 		}
 
 		delta := gitserverfs.DirSize(d.Path("."))
-		if err := gitserverfs.RemoveRepoDirectory(ctx, logger, db, shardID, reposDir, d, true); err != nil {
+		if coldStorage != nil {
+			offloadRepo(ctx, logger, rcf, coldStorage, reposDir, d)
+		}
+		recordState := recordsRepoState(ctx, shardID, gitserverfs.RepoNameFromDir(reposDir, d))
+		if err := gitserverfs.RemoveRepoDirectory(ctx, logger, db, shardID, reposDir, d, recordState); err != nil {
 			return errors.Wrap(err, "removing repo directory")
 		}
 		spaceFreed += delta
@@ -752,6 +770,33 @@ func freeUpSpace(ctx context.Context, logger log.Logger, db database.DB, shardID
 	return nil
 }
 
+// offloadRepo uploads the repo in dir to cold storage before it is removed, so
+// that it can be restored without recloning it from its code host. Failing to
+// offload a repo is not fatal, it is recloned from scratch on its next access
+// instead.
+func offloadRepo(ctx context.Context, logger log.Logger, rcf *wrexec.RecordingCommandFactory, coldStorage *coldstorage.Store, reposDir string, dir common.GitDir) {
+	repo := gitserverfs.RepoNameFromDir(reposDir, dir)
+	logger = logger.With(log.String("repo", string(repo)))
+
+	// Only repos cloned from git remotes can be restored from a bundle, and the
+	// bundle of a partial clone would be missing objects.
+	typ, err := git.GetRepositoryType(rcf, reposDir, dir)
+	if err != nil {
+		logger.Warn("failed to get repository type, not offloading repo to cold storage", log.Error(err))
+		return
+	}
+	if (typ != "" && typ != "git") || git.IsPartialClone(dir) {
+		return
+	}
+
+	size, err := coldStorage.Offload(ctx, repo, dir)
+	if err != nil {
+		logger.Warn("failed to offload repo to cold storage", log.Error(err))
+		return
+	}
+	logger.Info("offloaded repo to cold storage", log.Int64("bundle size", size))
+}
+
 func gitDirModTime(d common.GitDir) (time.Time, error) {
 	head, err := os.Stat(d.Path("HEAD"))
 	if err != nil {
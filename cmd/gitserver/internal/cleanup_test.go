@@ -20,6 +20,7 @@ import (
 
 	"github.com/sourcegraph/log/logtest"
 
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/coldstorage"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
@@ -877,12 +878,12 @@ func isEmptyDir(path string) (bool, error) {
 func TestFreeUpSpace(t *testing.T) {
 	logger := logtest.Scoped(t)
 	t.Run("no error if no space requested and no repos", func(t *testing.T) {
-		if err := freeUpSpace(context.Background(), logger, newMockedGitserverDB(), "test-gitserver", t.TempDir(), &fakeDiskSizer{}, 10, 0); err != nil {
+		if err := freeUpSpace(context.Background(), logger, newMockedGitserverDB(), wrexec.NewNoOpRecordingCommandFactory(), nil, "test-gitserver", t.TempDir(), &fakeDiskSizer{}, 10, 0); err != nil {
 			t.Fatal(err)
 		}
 	})
 	t.Run("error if space requested and no repos", func(t *testing.T) {
-		if err := freeUpSpace(context.Background(), logger, newMockedGitserverDB(), "test-gitserver", t.TempDir(), &fakeDiskSizer{}, 10, 1); err == nil {
+		if err := freeUpSpace(context.Background(), logger, newMockedGitserverDB(), wrexec.NewNoOpRecordingCommandFactory(), nil, "test-gitserver", t.TempDir(), &fakeDiskSizer{}, 10, 1); err == nil {
 			t.Fatal("want error")
 		}
 	})
@@ -912,7 +913,7 @@ func TestFreeUpSpace(t *testing.T) {
 		gr := dbmocks.NewMockGitserverRepoStore()
 		db.GitserverReposFunc.SetDefaultReturn(gr)
 		// Run.
-		if err := freeUpSpace(context.Background(), logger, db, "test-gitserver", rd, &fakeDiskSizer{}, 10, 1000); err != nil {
+		if err := freeUpSpace(context.Background(), logger, db, wrexec.NewNoOpRecordingCommandFactory(), nil, "test-gitserver", rd, &fakeDiskSizer{}, 10, 1000); err != nil {
 			t.Fatal(err)
 		}
 
@@ -932,6 +933,41 @@ func TestFreeUpSpace(t *testing.T) {
 		}
 		require.Equal(t, gr.SetCloneStatusFunc.History()[0].Arg2, types.CloneStatusNotCloned)
 	})
+	t.Run("removed repo gets offloaded to cold storage", func(t *testing.T) {
+		ctx := context.Background()
+		rd := t.TempDir()
+
+		work := t.TempDir()
+		for _, args := range [][]string{
+			{"init", "."},
+			{"-c", "user.name=a", "-c", "user.email=a@a.com", "commit", "--allow-empty", "-m", "foo"},
+			{"clone", "--bare", ".", filepath.Join(rd, "repo1", ".git")},
+		} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = work
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("execution error: %v, output %s", err, out)
+			}
+		}
+
+		coldStorage, err := coldstorage.New(ctx, observation.TestContextTB(t), &coldstorage.Config{
+			Backend:        "filesystem",
+			Bucket:         "gitserver-cold-storage",
+			FilesystemPath: t.TempDir(),
+		})
+		require.NoError(t, err)
+
+		db := dbmocks.NewMockDB()
+		db.GitserverReposFunc.SetDefaultReturn(dbmocks.NewMockGitserverRepoStore())
+		if err := freeUpSpace(ctx, logger, db, wrexec.NewNoOpRecordingCommandFactory(), coldStorage, "test-gitserver", rd, &fakeDiskSizer{}, 10, 1); err != nil {
+			t.Fatal(err)
+		}
+		assertPaths(t, rd, ".tmp")
+
+		restored, err := coldStorage.Restore(ctx, "repo1", common.GitDir(filepath.Join(t.TempDir(), ".git")))
+		require.NoError(t, err)
+		require.True(t, restored)
+	})
 }
 
 func makeFakeRepo(d string, sizeBytes int) error {
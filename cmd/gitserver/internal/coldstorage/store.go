@@ -0,0 +1,207 @@
+// Package coldstorage offloads rarely accessed repositories from gitserver to
+// a blob store, so that they can be restored without recloning them from their
+// code host.
+package coldstorage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	reposOffloaded = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repos_offloaded",
+		Help: "number of repos offloaded to cold storage",
+	})
+	reposRestored = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_repos_restored",
+		Help: "number of repos restored from cold storage",
+	})
+)
+
+// bundleFile is the name of the file a bundle is downloaded to within the git
+// directory it is restored into.
+const bundleFile = "cold-storage.bundle"
+
+// Store offloads repositories to a blob store as git bundles containing all of
+// their refs.
+type Store struct {
+	uploads uploadstore.Store
+}
+
+// New returns the cold storage configured by config, or nil if cold storage is
+// disabled.
+func New(ctx context.Context, observationCtx *observation.Context, config *Config) (*Store, error) {
+	if !config.Enabled() {
+		return nil, nil
+	}
+
+	c := uploadstore.Config{
+		Backend:      config.Backend,
+		ManageBucket: config.ManageBucket,
+		Bucket:       config.Bucket,
+		S3: uploadstore.S3Config{
+			Region:          config.S3Region,
+			Endpoint:        config.S3Endpoint,
+			UsePathStyle:    config.S3UsePathStyle,
+			AccessKeyID:     config.S3AccessKeyID,
+			SecretAccessKey: config.S3SecretAccessKey,
+			SessionToken:    config.S3SessionToken,
+		},
+		GCS: uploadstore.GCSConfig{
+			ProjectID:               config.GCSProjectID,
+			CredentialsFile:         config.GCSCredentialsFile,
+			CredentialsFileContents: config.GCSCredentialsFileContents,
+		},
+		Filesystem: uploadstore.FilesystemConfig{
+			Path: config.FilesystemPath,
+		},
+		Azure: uploadstore.AzureConfig{
+			AccountName: config.AzureAccountName,
+			AccountKey:  config.AzureAccountKey,
+			Endpoint:    config.AzureEndpoint,
+		},
+	}
+	uploads, err := uploadstore.CreateLazy(ctx, c, uploadstore.NewOperations(observationCtx, "gitserver_cold_storage", "uploadstore"))
+	if err != nil {
+		return nil, err
+	}
+	return NewWithUploadStore(uploads), nil
+}
+
+// NewWithUploadStore returns a cold storage that stores bundles in uploads.
+func NewWithUploadStore(uploads uploadstore.Store) *Store {
+	return &Store{uploads: uploads}
+}
+
+// bundleKey returns the key of the bundle of repo.
+func bundleKey(repo api.RepoName) string {
+	return "repos/" + string(protocol.NormalizeRepo(repo)) + ".bundle"
+}
+
+// Offload uploads a bundle of all refs of the repository in dir. It returns
+// the size of the uploaded bundle. The caller is responsible for removing the
+// repository from disk afterwards.
+func (s *Store) Offload(ctx context.Context, repo api.RepoName, dir common.GitDir) (int64, error) {
+	// The bundle is streamed to the blob store, so that offloading doesn't
+	// need any disk space.
+	cmd := exec.CommandContext(ctx, "git", "bundle", "create", "-", "--all")
+	dir.Set(cmd)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return 0, err
+	}
+	if err := cmd.Start(); err != nil {
+		return 0, errors.Wrap(err, "starting git bundle")
+	}
+
+	key := bundleKey(repo)
+	size, uploadErr := s.uploads.Upload(ctx, key, stdout)
+	// Drain the output in case the upload failed early, so that git exits.
+	_, _ = io.Copy(io.Discard, stdout)
+	if err := cmd.Wait(); err != nil {
+		// Don't leave a truncated bundle behind.
+		if uploadErr == nil {
+			_ = s.uploads.Delete(ctx, key)
+		}
+		return 0, errors.Wrapf(executil.WrapCmdError(cmd, err), "creating bundle: %s", stderr.String())
+	}
+	if uploadErr != nil {
+		return 0, errors.Wrap(uploadErr, "uploading bundle")
+	}
+
+	reposOffloaded.Inc()
+	return size, nil
+}
+
+// Restore initializes a bare repository in dir from the bundle of repo. It
+// returns false if repo has not been offloaded. The restored repository only
+// contains the refs it had when it was offloaded, so the caller is expected to
+// fetch from the remote afterwards.
+func (s *Store) Restore(ctx context.Context, repo api.RepoName, dir common.GitDir) (bool, error) {
+	key := bundleKey(repo)
+	ok, err := s.exists(ctx, key)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if err := os.MkdirAll(dir.Path(), os.ModePerm); err != nil {
+		return false, err
+	}
+	cmd := exec.CommandContext(ctx, "git", "init", "--bare", ".")
+	cmd.Dir = dir.Path()
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return false, errors.Wrapf(executil.WrapCmdError(cmd, err), "initializing repository: %s", out)
+	}
+
+	// Unlike creating one, fetching from a bundle requires it to be a file.
+	bundlePath := dir.Path(bundleFile)
+	defer os.Remove(bundlePath)
+	if err := s.download(ctx, key, bundlePath); err != nil {
+		return false, err
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "fetch", "--quiet", bundlePath, "+refs/*:refs/*")
+	dir.Set(cmd)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return false, errors.Wrapf(executil.WrapCmdError(cmd, err), "fetching from bundle: %s", out)
+	}
+
+	reposRestored.Inc()
+	return true, nil
+}
+
+// Delete removes the bundle of repo, if there is one.
+func (s *Store) Delete(ctx context.Context, repo api.RepoName) error {
+	return s.uploads.Delete(ctx, bundleKey(repo))
+}
+
+func (s *Store) exists(ctx context.Context, key string) (bool, error) {
+	it, err := s.uploads.List(ctx, key)
+	if err != nil {
+		return false, err
+	}
+	for it.Next() {
+		if it.Current() == key {
+			return true, nil
+		}
+	}
+	return false, it.Err()
+}
+
+func (s *Store) download(ctx context.Context, key, path string) (err error) {
+	rc, err := s.uploads.Get(ctx, key)
+	if err != nil {
+		return errors.Wrap(err, "getting bundle")
+	}
+	defer rc.Close()
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Append(err, f.Close())
+	}()
+
+	if _, err := io.Copy(f, rc); err != nil {
+		return errors.Wrap(err, "downloading bundle")
+	}
+	return nil
+}
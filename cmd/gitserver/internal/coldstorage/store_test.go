@@ -0,0 +1,107 @@
+package coldstorage
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestStore_OffloadRestore(t *testing.T) {
+	ctx := context.Background()
+	store, err := New(ctx, &observation.TestContext, &Config{
+		Backend:        "filesystem",
+		Bucket:         "gitserver-cold-storage",
+		FilesystemPath: t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	repo := api.RepoName("github.com/sourcegraph/cold")
+	src := makeRepo(t, filepath.Join(t.TempDir(), "src"))
+	dst := common.GitDir(filepath.Join(t.TempDir(), "dst", ".git"))
+
+	// Nothing to restore before the repo is offloaded.
+	restored, err := store.Restore(ctx, repo, dst)
+	require.NoError(t, err)
+	require.False(t, restored)
+
+	size, err := store.Offload(ctx, repo, src)
+	require.NoError(t, err)
+	require.Greater(t, size, int64(0))
+
+	restored, err = store.Restore(ctx, repo, dst)
+	require.NoError(t, err)
+	require.True(t, restored)
+	require.Equal(t, git(t, src, "show-ref"), git(t, dst, "show-ref"))
+	require.NoFileExists(t, dst.Path(bundleFile))
+
+	require.NoError(t, store.Delete(ctx, repo))
+	restored, err = store.Restore(ctx, repo, common.GitDir(filepath.Join(t.TempDir(), ".git")))
+	require.NoError(t, err)
+	require.False(t, restored)
+}
+
+func TestStore_OffloadEmptyRepo(t *testing.T) {
+	ctx := context.Background()
+	store, err := New(ctx, &observation.TestContext, &Config{
+		Backend:        "filesystem",
+		Bucket:         "gitserver-cold-storage",
+		FilesystemPath: t.TempDir(),
+	})
+	require.NoError(t, err)
+
+	dir := common.GitDir(t.TempDir())
+	git(t, dir, "init", "--bare", ".")
+
+	repo := api.RepoName("github.com/sourcegraph/empty")
+	_, err = store.Offload(ctx, repo, dir)
+	require.Error(t, err)
+
+	// A failed offload leaves nothing behind to restore from.
+	restored, err := store.Restore(ctx, repo, common.GitDir(filepath.Join(t.TempDir(), ".git")))
+	require.NoError(t, err)
+	require.False(t, restored)
+}
+
+func TestNew_Disabled(t *testing.T) {
+	store, err := New(context.Background(), &observation.TestContext, &Config{})
+	require.NoError(t, err)
+	require.Nil(t, store)
+}
+
+// makeRepo creates a bare repository in dir with a couple of refs.
+func makeRepo(t *testing.T, dir string) common.GitDir {
+	t.Helper()
+
+	work := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "."},
+		{"-c", "user.name=a", "-c", "user.email=a@a.com", "commit", "--allow-empty", "-m", "foo"},
+		{"tag", "v1"},
+		{"branch", "feature"},
+		{"clone", "--bare", ".", dir},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = work
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	return common.GitDir(dir)
+}
+
+func git(t *testing.T, dir common.GitDir, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	dir.Set(cmd)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/cacert"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/trace" //nolint:staticcheck // OT is deprecated
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/internal/wrexec"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -143,6 +144,32 @@ func RunRemoteGitCommand(ctx context.Context, cmd wrexec.Cmder, configRemoteOpts
 	return b.Bytes(), err
 }
 
+// PromisorRemote is the name of the remote that partial clones lazily fetch
+// missing objects from.
+const PromisorRemote = "origin"
+
+// PromisorRemoteEnv returns the environment variables that set the URL of the
+// promisor remote of a partial clone to remoteURL. The URL is passed in the
+// environment rather than stored in the config of the repository, since it may
+// contain credentials.
+func PromisorRemoteEnv(remoteURL *vcs.URL) []string {
+	return []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=remote." + PromisorRemote + ".url",
+		"GIT_CONFIG_VALUE_0=" + remoteURL.String(),
+	}
+}
+
+// ConfigureLazyFetch configures cmd, which runs in a partial clone, so that git
+// can fetch objects missing from the clone from remoteURL.
+func ConfigureLazyFetch(cmd *exec.Cmd, remoteURL *vcs.URL) {
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, PromisorRemoteEnv(remoteURL)...)
+	configureRemoteGitCommand(cmd, tlsExternal())
+}
+
 // tlsExternal will create a new cache for this gitserer process and store the certificates set in
 // the site config.
 // This creates a long lived
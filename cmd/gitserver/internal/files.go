@@ -0,0 +1,469 @@
+package internal
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	stdlibpath "path"
+	"strconv"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/format/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/accesslog"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// readDirChunkSize is the maximum number of entries sent in a single
+// ReadDirResponse.
+const readDirChunkSize = 1000
+
+func (gs *GRPCServer) ReadFile(req *proto.ReadFileRequest, ss proto.GitserverService_ReadFileServer) error {
+	accesslog.Record(ss.Context(), req.GetRepo(),
+		log.String("commit", req.GetCommit()),
+		log.String("path", req.GetPath()),
+	)
+
+	if err := validateTreeRequest(req.GetRepo(), req.GetCommit(), req.GetPath()); err != nil {
+		return err
+	}
+
+	notFound := false
+	err := gs.readFiles(ss.Context(), req.GetRepo(), req.GetCommit(), []string{req.GetPath()}, func(_ string, data []byte, missing bool) error {
+		if missing {
+			notFound = true
+			return nil
+		}
+		return ss.Send(&proto.ReadFileResponse{Data: data})
+	})
+	if err != nil {
+		return err
+	}
+	if notFound {
+		return gs.fileNotFoundError(req.GetRepo(), req.GetCommit(), req.GetPath())
+	}
+	return nil
+}
+
+func (gs *GRPCServer) BatchReadFile(req *proto.BatchReadFileRequest, ss proto.GitserverService_BatchReadFileServer) error {
+	accesslog.Record(ss.Context(), req.GetRepo(),
+		log.String("commit", req.GetCommit()),
+		log.Strings("paths", req.GetPaths()),
+	)
+
+	if err := validateTreeRequest(req.GetRepo(), req.GetCommit(), req.GetPaths()...); err != nil {
+		return err
+	}
+	if len(req.GetPaths()) == 0 {
+		return nil
+	}
+
+	return gs.readFiles(ss.Context(), req.GetRepo(), req.GetCommit(), req.GetPaths(), func(path string, data []byte, missing bool) error {
+		return ss.Send(&proto.BatchReadFileResponse{
+			Path:     path,
+			Data:     data,
+			NotFound: missing,
+		})
+	})
+}
+
+func (gs *GRPCServer) Stat(ctx context.Context, req *proto.StatRequest) (*proto.StatResponse, error) {
+	accesslog.Record(ctx, req.GetRepo(),
+		log.String("commit", req.GetCommit()),
+		log.String("path", req.GetPath()),
+	)
+
+	if err := validateTreeRequest(req.GetRepo(), req.GetCommit(), req.GetPath()); err != nil {
+		return nil, err
+	}
+
+	path := cleanTreePath(req.GetPath())
+	if path == "" {
+		// Special case root, which is not returned by `git ls-tree`.
+		var out bytes.Buffer
+		err := gs.doExec(ctx, gs.Server.Logger, &protocol.ExecRequest{
+			Repo: api.RepoName(req.GetRepo()),
+			Args: []string{"rev-parse", req.GetCommit() + "^{tree}"},
+		}, "unknown-grpc-client", &out)
+		if err != nil {
+			return nil, err
+		}
+		return &proto.StatResponse{FileInfo: &proto.FileInfo{
+			Mode:    uint32(os.ModeDir),
+			BlobOid: strings.TrimSpace(out.String()),
+		}}, nil
+	}
+
+	fis, err := gs.lsTree(ctx, req.GetRepo(), req.GetCommit(), path, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(fis) == 0 {
+		return nil, gs.fileNotFoundError(req.GetRepo(), req.GetCommit(), req.GetPath())
+	}
+	return &proto.StatResponse{FileInfo: fis[0]}, nil
+}
+
+func (gs *GRPCServer) ReadDir(req *proto.ReadDirRequest, ss proto.GitserverService_ReadDirServer) error {
+	accesslog.Record(ss.Context(), req.GetRepo(),
+		log.String("commit", req.GetCommit()),
+		log.String("path", req.GetPath()),
+		log.Bool("recursive", req.GetRecursive()),
+	)
+
+	if err := validateTreeRequest(req.GetRepo(), req.GetCommit(), req.GetPath()); err != nil {
+		return err
+	}
+
+	// A trailing slash makes `git ls-tree` list the contents of the
+	// directory, rather than the directory itself.
+	path := cleanTreePath(req.GetPath())
+	if path != "" {
+		path += "/"
+	}
+
+	fis, err := gs.lsTree(ss.Context(), req.GetRepo(), req.GetCommit(), path, req.GetRecursive())
+	if err != nil {
+		return err
+	}
+	if len(fis) == 0 && path != "" {
+		return gs.fileNotFoundError(req.GetRepo(), req.GetCommit(), req.GetPath())
+	}
+
+	for len(fis) > 0 {
+		n := len(fis)
+		if n > readDirChunkSize {
+			n = readDirChunkSize
+		}
+		if err := ss.Send(&proto.ReadDirResponse{FileInfo: fis[:n]}); err != nil {
+			return err
+		}
+		fis = fis[n:]
+	}
+	return nil
+}
+
+func (gs *GRPCServer) Blame(req *proto.BlameRequest, ss proto.GitserverService_BlameServer) error {
+	accesslog.Record(ss.Context(), req.GetRepo(),
+		log.String("commit", req.GetCommit()),
+		log.String("path", req.GetPath()),
+	)
+
+	if req.GetRepo() == "" || req.GetPath() == "" {
+		return status.Error(codes.InvalidArgument, "empty repo or path")
+	}
+	if err := git.CheckSpecArgSafety(req.GetCommit()); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	args := []string{"blame", "-w", "--porcelain"}
+	if req.GetIncremental() {
+		args = append(args, "--incremental")
+	}
+	if r := req.GetRange(); r != nil {
+		args = append(args, "-L"+strconv.FormatUint(uint64(r.GetStartLine()), 10)+","+strconv.FormatUint(uint64(r.GetEndLine()), 10))
+	}
+	if req.GetCommit() != "" {
+		args = append(args, req.GetCommit())
+	}
+	args = append(args, "--", req.GetPath())
+
+	execReq := &protocol.ExecRequest{
+		Repo: api.RepoName(req.GetRepo()),
+		Args: args,
+	}
+
+	send := func(h *gitserver.Hunk) error {
+		return ss.Send(&proto.BlameResponse{Hunk: h.ToProto()})
+	}
+
+	if !req.GetIncremental() {
+		// The byte offsets of the hunks can only be computed from the
+		// non-incremental output, which git writes once the whole file has
+		// been blamed anyway.
+		var out bytes.Buffer
+		if err := gs.doExec(ss.Context(), gs.Server.Logger, execReq, "unknown-grpc-client", &out); err != nil {
+			return err
+		}
+		if out.Len() == 0 {
+			return nil
+		}
+		hunks, err := gitserver.ParseGitBlameOutput(out.String())
+		if err != nil {
+			return errors.Wrap(err, "parsing git blame output")
+		}
+		for _, h := range hunks {
+			if err := send(h); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ss.Context())
+	defer cancel()
+
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(gs.doExec(ctx, gs.Server.Logger, execReq, "unknown-grpc-client", pw))
+	}()
+
+	hr := gitserver.NewBlameHunkReader(pr)
+	defer hr.Close()
+
+	for {
+		h, err := hr.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := send(h); err != nil {
+			return err
+		}
+	}
+}
+
+// validateTreeRequest validates the arguments of the RPCs that read from the
+// tree of a commit.
+func validateTreeRequest(repo, commit string, paths ...string) error {
+	if repo == "" {
+		return status.Error(codes.InvalidArgument, "empty repo")
+	}
+	if !gitdomain.IsAbsoluteRevision(commit) {
+		return status.Errorf(codes.InvalidArgument, "commit must be a 40-character commit SHA, got %q", commit)
+	}
+	for _, p := range paths {
+		// Paths are sent to git one per line.
+		if strings.ContainsAny(p, "\n\x00") {
+			return status.Errorf(codes.InvalidArgument, "invalid path %q", p)
+		}
+	}
+	return nil
+}
+
+// cleanTreePath returns path relative to the root of the tree, or the empty
+// string for the root itself.
+func cleanTreePath(path string) string {
+	path = strings.TrimPrefix(stdlibpath.Clean("/"+path), "/")
+	return path
+}
+
+func (gs *GRPCServer) fileNotFoundError(repo, commit, path string) error {
+	s, err := status.New(codes.NotFound, "file not found").WithDetails(&proto.FileNotFoundPayload{
+		Repo:   repo,
+		Commit: commit,
+		Path:   path,
+	})
+	if err != nil {
+		gs.Server.Logger.Error("failed to marshal status", log.Error(err))
+		return err
+	}
+	return s.Err()
+}
+
+// readFiles calls send with the contents of each of paths at commit in order,
+// using a long-lived `git cat-file --batch` process for repo. Large files are
+// sent in several chunks, and empty files as a single empty chunk. Paths that
+// don't exist or aren't blobs are sent once with notFound set.
+func (gs *GRPCServer) readFiles(ctx context.Context, repo, commit string, paths []string, send func(path string, data []byte, notFound bool) error) error {
+	repoName := protocol.NormalizeRepo(api.RepoName(repo))
+	if notFoundPayload, cloned := gs.Server.maybeStartClone(ctx, gs.Server.Logger, repoName); !cloned {
+		return gs.repoNotFoundError(repoName, notFoundPayload)
+	}
+	dir := gitserverfs.RepoDirFromName(gs.Server.ReposDir, repoName)
+
+	// Objects missing from partial clones are fetched from the code host on
+	// demand. The processes doing so are configured with the remote URL,
+	// which can change, so they are not reused.
+	var configure func(*exec.Cmd)
+	partial := git.IsPartialClone(dir)
+	if partial {
+		remoteURL, err := gs.Server.getRemoteURL(ctx, repoName)
+		if err != nil {
+			gs.Server.Logger.Warn("failed to get remote URL, missing objects will not be fetched", log.Error(err))
+		} else {
+			configure = func(cmd *exec.Cmd) { executil.ConfigureLazyFetch(cmd, remoteURL) }
+		}
+	}
+
+	err := gs.Server.catFiles.do(ctx, repoName, dir, configure, !partial, func(p *catFileProcess) error {
+		for _, path := range paths {
+			found, err := p.readFile(commit, path, func(data []byte) error {
+				return send(path, data, false)
+			})
+			if err != nil {
+				return err
+			}
+			if !found {
+				if err := send(path, nil, true); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return status.FromContextError(ctxErr).Err()
+		}
+		return err
+	}
+	return nil
+}
+
+// lsTree returns the entries listed by `git ls-tree` for path at commit.
+func (gs *GRPCServer) lsTree(ctx context.Context, repo, commit, path string, recurse bool) ([]*proto.FileInfo, error) {
+	args := []string{
+		"ls-tree",
+		"--long", // show size
+		"--full-name",
+		"-z",
+		commit,
+	}
+	if recurse {
+		args = append(args, "-r", "-t")
+	}
+	if path != "" {
+		args = append(args, "--", path)
+	}
+
+	var out bytes.Buffer
+	err := gs.doExec(ctx, gs.Server.Logger, &protocol.ExecRequest{
+		Repo: api.RepoName(repo),
+		Args: args,
+	}, "unknown-grpc-client", &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseLsTreeOutput(out.Bytes(), path, func() ([]byte, error) {
+		var out bytes.Buffer
+		err := gs.doExec(ctx, gs.Server.Logger, &protocol.ExecRequest{
+			Repo: api.RepoName(repo),
+			Args: []string{"show", commit + ":.gitmodules"},
+		}, "unknown-grpc-client", &out)
+		return out.Bytes(), err
+	})
+}
+
+// parseLsTreeOutput parses the output of `git ls-tree --long --full-name -z`
+// for path. readGitModules is called at most once, to read the .gitmodules
+// file if the output contains submodules.
+func parseLsTreeOutput(out []byte, path string, readGitModules func() ([]byte, error)) ([]*proto.FileInfo, error) {
+	if len(out) == 0 {
+		return []*proto.FileInfo{}, nil
+	}
+
+	var (
+		gitModules       *config.Config
+		gitModulesLoaded bool
+	)
+	submodule := func(name, oid string) (*proto.GitSubmodule, error) {
+		if !gitModulesLoaded {
+			gitModulesLoaded = true
+			// A missing .gitmodules file is not an error; the submodule
+			// simply has no path or URL.
+			if data, err := readGitModules(); err == nil {
+				var cfg config.Config
+				if err := config.NewDecoder(bytes.NewReader(data)).Decode(&cfg); err != nil {
+					return nil, errors.Errorf("error parsing .gitmodules: %s", err)
+				}
+				gitModules = &cfg
+			}
+		}
+		sm := &proto.GitSubmodule{CommitSha: oid}
+		if gitModules != nil {
+			sm.Path = gitModules.Section("submodule").Subsection(name).Option("path")
+			sm.Url = gitModules.Section("submodule").Subsection(name).Option("url")
+		}
+		return sm, nil
+	}
+
+	trimPath := strings.TrimPrefix(path, "./")
+	lines := strings.Split(string(out), "\x00")
+	fis := make([]*proto.FileInfo, 0, len(lines)-1)
+	for _, line := range lines[:len(lines)-1] { // last entry is empty
+		tabPos := strings.IndexByte(line, '\t')
+		if tabPos == -1 {
+			return nil, errors.Errorf("invalid `git ls-tree` output: %q", out)
+		}
+		info := strings.SplitN(line[:tabPos], " ", 4)
+		name := line[tabPos+1:]
+		if len(name) < len(trimPath) {
+			// This is in a submodule; return the original path, like the
+			// client did when it ran `git ls-tree` itself.
+			name = trimPath
+		}
+
+		if len(info) != 4 {
+			return nil, errors.Errorf("invalid `git ls-tree` output: %q", out)
+		}
+		typ := info[1]
+		sha := info[2]
+		if !gitdomain.IsAbsoluteRevision(sha) {
+			return nil, errors.Errorf("invalid `git ls-tree` SHA output: %q", sha)
+		}
+
+		sizeStr := strings.TrimSpace(info[3])
+		var size int64
+		if sizeStr != "-" {
+			// Size of "-" indicates a dir or submodule.
+			var err error
+			size, err = strconv.ParseInt(sizeStr, 10, 64)
+			if err != nil || size < 0 {
+				return nil, errors.Errorf("invalid `git ls-tree` size output: %q (error: %s)", sizeStr, err)
+			}
+		}
+
+		modeVal, err := strconv.ParseInt(info[0], 8, 32)
+		if err != nil {
+			return nil, err
+		}
+
+		fi := &proto.FileInfo{
+			Name:    name, // full path relative to root (not just basename)
+			Size:    size,
+			BlobOid: sha,
+		}
+		mode := os.FileMode(modeVal)
+		switch typ {
+		case "blob":
+			const gitModeSymlink = 0o20000
+			if mode&gitModeSymlink != 0 {
+				mode = os.ModeSymlink
+			} else {
+				// Regular file.
+				mode = mode | 0o644
+			}
+		case "commit":
+			mode = mode | gitdomain.ModeSubmodule
+			fi.Submodule, err = submodule(name, sha)
+			if err != nil {
+				return nil, err
+			}
+		case "tree":
+			mode = mode | os.ModeDir
+		}
+		fi.Mode = uint32(mode)
+
+		fis = append(fis, fi)
+	}
+
+	return fis, nil
+}
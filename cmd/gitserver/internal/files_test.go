@@ -0,0 +1,86 @@
+package internal
+
+import (
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+)
+
+func TestParseLsTreeOutput(t *testing.T) {
+	out := "100644 blob 3bad331187e39c05c78a9b5e443689f78f4365a7      12\tREADME.md\x00" +
+		"120000 blob 4b825dc642cb6eb9a060e54bf8d69288fbee4904       6\tlink\x00" +
+		"040000 tree e69de29bb2d1d6434b8b29ae775ad8c2e48c5391       -\tsrc\x00" +
+		"160000 commit 5a7e1e5b8a6b2c8b1f1c4fb3f1e4b2f0d5bbd4e3       -\tvendor/lib\x00"
+
+	gitModulesReads := 0
+	readGitModules := func() ([]byte, error) {
+		gitModulesReads++
+		return []byte(`[submodule "vendor/lib"]
+	path = vendor/lib
+	url = https://example.com/lib.git
+`), nil
+	}
+
+	fis, err := parseLsTreeOutput([]byte(out), "", readGitModules)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type entry struct {
+		Name      string
+		Mode      os.FileMode
+		Size      int64
+		BlobOid   string
+		Submodule [3]string
+	}
+	var got []entry
+	for _, fi := range fis {
+		e := entry{
+			Name:    fi.GetName(),
+			Mode:    os.FileMode(fi.GetMode()),
+			Size:    fi.GetSize(),
+			BlobOid: fi.GetBlobOid(),
+		}
+		if sm := fi.GetSubmodule(); sm != nil {
+			e.Submodule = [3]string{sm.GetPath(), sm.GetUrl(), sm.GetCommitSha()}
+		}
+		got = append(got, e)
+	}
+
+	want := []entry{
+		{Name: "README.md", Mode: 0o100644, Size: 12, BlobOid: "3bad331187e39c05c78a9b5e443689f78f4365a7"},
+		{Name: "link", Mode: os.ModeSymlink, Size: 6, BlobOid: "4b825dc642cb6eb9a060e54bf8d69288fbee4904"},
+		{Name: "src", Mode: 0o40000 | os.ModeDir, BlobOid: "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391"},
+		{
+			Name:      "vendor/lib",
+			Mode:      0o160000 | gitdomain.ModeSubmodule,
+			BlobOid:   "5a7e1e5b8a6b2c8b1f1c4fb3f1e4b2f0d5bbd4e3",
+			Submodule: [3]string{"vendor/lib", "https://example.com/lib.git", "5a7e1e5b8a6b2c8b1f1c4fb3f1e4b2f0d5bbd4e3"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected entries (-want +got):\n%s", diff)
+	}
+	if gitModulesReads != 1 {
+		t.Errorf("expected .gitmodules to be read once, got %d", gitModulesReads)
+	}
+
+	t.Run("empty tree", func(t *testing.T) {
+		fis, err := parseLsTreeOutput(nil, "", readGitModules)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(fis) != 0 {
+			t.Errorf("expected no entries, got %v", fis)
+		}
+	})
+
+	t.Run("invalid output", func(t *testing.T) {
+		if _, err := parseLsTreeOutput([]byte("not ls-tree output\x00"), "", readGitModules); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
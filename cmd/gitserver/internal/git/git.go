@@ -83,6 +83,15 @@ func QuickSymbolicRefHead(dir common.GitDir) (string, error) {
 	return string(headRef), nil
 }
 
+// IsPartialClone returns true if dir is a partial clone, which may be missing
+// objects that git fetches from its promisor remote on demand.
+func IsPartialClone(dir common.GitDir) bool {
+	// Packs received from a promisor remote are marked by a .promisor file,
+	// which is cheaper to look for than running `git config`.
+	matches, _ := filepath.Glob(dir.Path("objects", "pack", "*.promisor"))
+	return len(matches) > 0
+}
+
 // QuickRevParseHead best-effort mimics the execution of `git rev-parse HEAD`, but doesn't exec a child process.
 // It just reads the relevant files from the bare git repository directory.
 func QuickRevParseHead(dir common.GitDir) (string, error) {
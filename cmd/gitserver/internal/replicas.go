@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+var replicaHealCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_replica_heal_total",
+	Help: "Incremented each time the replica reconciler clones or fetches a replica of a repo",
+}, []string{"type"})
+
+// replicaMaxLag is how far the last fetch of a replica may lag behind the last
+// fetch of the primary before the replica reconciler fetches it.
+const replicaMaxLag = time.Hour
+
+// fetchRepoFunc fetches an existing clone of a repo from its code host.
+type fetchRepoFunc func(ctx context.Context, repo api.RepoName) error
+
+// NewReplicaReconciler returns a periodic goroutine that heals the replicas
+// stored on this gitserver when repos are replicated to multiple gitservers.
+// Missing replicas are cloned and replicas lagging behind their primary are
+// fetched. At most limit replicas are healed per run, so that a new gitserver
+// instance doesn't clone all of its replicas at once.
+func (s *Server) NewReplicaReconciler(ctx context.Context, interval time.Duration, limit int) goroutine.BackgroundRoutine {
+	logger := s.Logger.Scoped("replicaReconciler", "heals replicas of repos")
+	fetchRepo := func(ctx context.Context, repo api.RepoName) error {
+		return s.doRepoUpdate(ctx, repo, "")
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		actor.WithInternalActor(ctx),
+		goroutine.HandlerFunc(func(ctx context.Context) error {
+			gitServerAddrs := gitserver.NewGitserverAddresses(conf.Get())
+			if gitServerAddrs.ReplicationFactor <= 1 {
+				return nil
+			}
+			return reconcileReplicas(ctx, logger, s.DB, s.Locker, s.Hostname, s.ReposDir, gitServerAddrs, limit, s.CloneRepo, fetchRepo)
+		}),
+		goroutine.WithName("gitserver.replica-reconciler"),
+		goroutine.WithDescription("clones and fetches missing or stale replicas of repos"),
+		goroutine.WithInterval(interval),
+	)
+}
+
+func reconcileReplicas(
+	ctx context.Context,
+	logger log.Logger,
+	db database.DB,
+	locker RepositoryLocker,
+	shardID string,
+	reposDir string,
+	gitServerAddrs gitserver.GitserverAddresses,
+	limit int,
+	cloneRepo cloneRepoFunc,
+	fetchRepo fetchRepoFunc,
+) error {
+	healed := 0
+	options := database.IterateRepoGitserverStatusOptions{
+		BatchSize: 500,
+	}
+	for {
+		repos, nextRepo, err := db.GitserverRepos().IterateRepoGitserverStatus(ctx, options)
+		if err != nil {
+			return err
+		}
+		for _, repo := range repos {
+			if healed >= limit {
+				return nil
+			}
+
+			// Replicas are only healed once the primary has cloned the repo, since
+			// the primary records the state of the repo.
+			if repo.GitserverRepo == nil || repo.CloneStatus != types.CloneStatusCloned {
+				continue
+			}
+			addrs := addrsForRepo(ctx, repo.Name, gitServerAddrs)
+			if !hostnameMatchAny(shardID, addrs[1:]) {
+				continue
+			}
+
+			dir := gitserverfs.RepoDirFromName(reposDir, repo.Name)
+			if _, cloning := locker.Status(dir); cloning {
+				continue
+			}
+
+			if !repoCloned(dir) {
+				healed++
+				replicaHealCounter.WithLabelValues("clone").Inc()
+				if _, err := cloneRepo(ctx, repo.Name, CloneOptions{}); err != nil {
+					logger.Warn("cloning replica", log.String("repo", string(repo.Name)), log.Error(err))
+				}
+				continue
+			}
+
+			lastFetched, err := repoLastFetched(dir)
+			if err != nil || !lastFetched.Before(repo.LastFetched.Add(-replicaMaxLag)) {
+				continue
+			}
+			healed++
+			replicaHealCounter.WithLabelValues("fetch").Inc()
+			if err := fetchRepo(ctx, repo.Name); err != nil {
+				logger.Warn("fetching replica", log.String("repo", string(repo.Name)), log.Error(err))
+			}
+		}
+
+		if nextRepo == 0 {
+			return nil
+		}
+		options.NextCursor = nextRepo
+	}
+}
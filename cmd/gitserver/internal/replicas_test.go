@@ -0,0 +1,88 @@
+package internal
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestReconcileReplicas(t *testing.T) {
+	ctx := context.Background()
+	reposDir := t.TempDir()
+	now := time.Now()
+
+	// createReplica creates a clone of repo that was last fetched at lastFetched.
+	createReplica := func(repo api.RepoName, lastFetched time.Time) {
+		dir := gitserverfs.RepoDirFromName(reposDir, repo)
+		require.NoError(t, os.MkdirAll(dir.Path(), os.ModePerm))
+		require.NoError(t, os.WriteFile(dir.Path("HEAD"), []byte("ref: refs/heads/main"), os.ModePerm))
+		require.NoError(t, os.Chtimes(dir.Path("HEAD"), lastFetched, lastFetched))
+	}
+	createReplica("fresh", now)
+	createReplica("stale", now.Add(-2*replicaMaxLag))
+
+	gitServerAddrs := gitserver.GitserverAddresses{
+		Addresses: []string{"gitserver-1", "gitserver-2", "gitserver-3"},
+		PinnedServers: map[string]string{
+			"missing":      "gitserver-1",
+			"fresh":        "gitserver-1",
+			"stale":        "gitserver-1",
+			"not-cloned":   "gitserver-1",
+			"primary-here": "gitserver-2",
+		},
+		ReplicationFactor: 2,
+	}
+
+	status := func(name api.RepoName, cloneStatus types.CloneStatus) types.RepoGitserverStatus {
+		return types.RepoGitserverStatus{
+			Name: name,
+			GitserverRepo: &types.GitserverRepo{
+				CloneStatus: cloneStatus,
+				LastFetched: now,
+			},
+		}
+	}
+	gitserverRepos := dbmocks.NewMockGitserverRepoStore()
+	gitserverRepos.IterateRepoGitserverStatusFunc.SetDefaultReturn([]types.RepoGitserverStatus{
+		status("missing", types.CloneStatusCloned),
+		status("fresh", types.CloneStatusCloned),
+		status("stale", types.CloneStatusCloned),
+		status("not-cloned", types.CloneStatusNotCloned),
+		status("primary-here", types.CloneStatusCloned),
+	}, 0, nil)
+	db := dbmocks.NewMockDB()
+	db.GitserverReposFunc.SetDefaultReturn(gitserverRepos)
+
+	var cloned, fetched []api.RepoName
+	cloneRepo := func(_ context.Context, repo api.RepoName, _ CloneOptions) (string, error) {
+		cloned = append(cloned, repo)
+		return "", nil
+	}
+	fetchRepo := func(_ context.Context, repo api.RepoName) error {
+		fetched = append(fetched, repo)
+		return nil
+	}
+
+	err := reconcileReplicas(ctx, logtest.Scoped(t), db, NewRepositoryLocker(), "gitserver-2", reposDir, gitServerAddrs, 10, cloneRepo, fetchRepo)
+	require.NoError(t, err)
+	assert.Equal(t, []api.RepoName{"missing"}, cloned)
+	assert.Equal(t, []api.RepoName{"stale"}, fetched)
+
+	// The limit caps the number of replicas healed in a single run.
+	cloned, fetched = nil, nil
+	err = reconcileReplicas(ctx, logtest.Scoped(t), db, NewRepositoryLocker(), "gitserver-2", reposDir, gitServerAddrs, 1, cloneRepo, fetchRepo)
+	require.NoError(t, err)
+	assert.Equal(t, []api.RepoName{"missing"}, cloned)
+	assert.Empty(t, fetched)
+}
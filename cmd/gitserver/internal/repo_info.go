@@ -7,6 +7,7 @@ import (
 
 	"github.com/sourcegraph/log"
 
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/coldstorage"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -56,7 +57,7 @@ func (s *Server) handleRepoDelete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := deleteRepo(r.Context(), s.Logger, s.DB, s.Hostname, s.ReposDir, req.Repo); err != nil {
+	if err := deleteRepo(r.Context(), s.Logger, s.DB, s.ColdStorage, s.Hostname, s.ReposDir, req.Repo); err != nil {
 		s.Logger.Error("failed to delete repository", log.String("repo", string(req.Repo)), log.Error(err))
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
@@ -68,16 +69,29 @@ func deleteRepo(
 	ctx context.Context,
 	logger log.Logger,
 	db database.DB,
+	coldStorage *coldstorage.Store,
 	shardID string,
 	reposDir string,
 	repo api.RepoName,
 ) error {
 	// The repo may be deleted in the database, in this case we need to get the
 	// original name in order to find it on disk
-	err := gitserverfs.RemoveRepoDirectory(ctx, logger, db, shardID, reposDir, gitserverfs.RepoDirFromName(reposDir, api.UndeletedRepoName(repo)), true)
+	recordState := recordsRepoState(ctx, shardID, api.UndeletedRepoName(repo))
+	err := gitserverfs.RemoveRepoDirectory(ctx, logger, db, shardID, reposDir, gitserverfs.RepoDirFromName(reposDir, api.UndeletedRepoName(repo)), recordState)
 	if err != nil {
 		return errors.Wrap(err, "removing repo directory")
 	}
+	if !recordState {
+		return nil
+	}
+	// The repo may have been offloaded to free up disk space, in which case its
+	// bundle would otherwise be kept forever. Replicas share the bundle, so only
+	// the primary deletes it.
+	if coldStorage != nil {
+		if err := coldStorage.Delete(ctx, api.UndeletedRepoName(repo)); err != nil {
+			logger.Warn("failed to delete repo from cold storage", log.String("repo", string(repo)), log.Error(err))
+		}
+	}
 	err = db.GitserverRepos().SetCloneStatus(ctx, repo, types.CloneStatusNotCloned, shardID)
 	if err != nil {
 		return errors.Wrap(err, "setting clone status after delete")
@@ -32,6 +32,7 @@ import (
 	"github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/accesslog"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/coldstorage"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/common"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/git"
@@ -59,6 +60,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/internal/wrexec"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -199,6 +201,15 @@ type Server struct {
 
 	// Perforce is a plugin-like service attached to Server for all things Perforce.
 	Perforce *perforce.Service
+
+	// ColdStorage is where repos that were removed to free up disk space are
+	// restored from when they are cloned again. It is nil if cold storage is
+	// disabled.
+	ColdStorage *coldstorage.Store
+
+	// catFiles keeps the long-lived `git cat-file --batch` processes used to
+	// read files.
+	catFiles catFilePool
 }
 
 type locks struct {
@@ -340,6 +351,34 @@ func addrForRepo(ctx context.Context, repoName api.RepoName, gitServerAddrs gits
 	return gitServerAddrs.AddrForRepo(ctx, filepath.Base(os.Args[0]), repoName)
 }
 
+// addrsForRepo returns the addresses of all gitservers the repo is cloned on,
+// starting with its primary.
+func addrsForRepo(ctx context.Context, repoName api.RepoName, gitServerAddrs gitserver.GitserverAddresses) []string {
+	return gitServerAddrs.AddrsForRepo(ctx, filepath.Base(os.Args[0]), repoName)
+}
+
+// hostnameMatchAny checks whether the hostname matches any of the given addresses.
+func hostnameMatchAny(shardID string, addrs []string) bool {
+	for _, addr := range addrs {
+		if hostnameMatch(shardID, addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// recordsRepoState returns true if the gitserver with the given shard ID records
+// the state of the repo in the gitserver_repos table. With replication, only the
+// primary of a repo does, so that replicas don't overwrite its clone status and
+// shard. Every write to gitserver_repos for a single repo must check it.
+func recordsRepoState(ctx context.Context, shardID string, repo api.RepoName) bool {
+	gitServerAddrs := gitserver.NewGitserverAddresses(conf.Get())
+	if gitServerAddrs.ReplicationFactor <= 1 || len(gitServerAddrs.Addresses) == 0 {
+		return true
+	}
+	return hostnameMatch(shardID, addrForRepo(ctx, repo, gitServerAddrs))
+}
+
 // NewClonePipeline creates a new pipeline that clones repos asynchronously. It
 // creates a producer-consumer pipeline that handles clone requests asychronously.
 func (s *Server) NewClonePipeline(logger log.Logger, cloneQueue *common.Queue[*cloneJob]) goroutine.BackgroundRoutine {
@@ -458,6 +497,7 @@ func (s *Server) Stop() {
 	s.canceled = true
 	s.cancelMu.Unlock()
 	s.wg.Wait()
+	s.catFiles.closeAll()
 }
 
 // serverContext returns a child context tied to the lifecycle of server.
@@ -1164,6 +1204,18 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 	cmd.Unwrap().Stderr = stderrW
 	cmd.Unwrap().Stdin = bytes.NewReader(req.Stdin)
 
+	// Objects missing from partial clones, such as the blobs read by `git
+	// archive`, are fetched from the code host on demand.
+	partial := git.IsPartialClone(dir)
+	if partial {
+		remoteURL, err := s.getRemoteURL(ctx, repoName)
+		if err != nil {
+			logger.Warn("failed to get remote URL, missing objects will not be fetched", log.Error(err))
+		} else {
+			executil.ConfigureLazyFetch(cmd.Unwrap(), remoteURL)
+		}
+	}
+
 	exitStatus, execErr = executil.RunCommand(ctx, cmd)
 
 	status = strconv.Itoa(exitStatus)
@@ -1171,7 +1223,11 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 	stderrN = stderrW.n
 
 	stderr := stderrBuf.String()
-	s.logIfCorrupt(ctx, repoName, dir, stderr)
+	// Failing to fetch a missing object from the code host doesn't mean that a
+	// partial clone is corrupt.
+	if !partial || !strings.Contains(stderr, "from promisor remote") {
+		s.logIfCorrupt(ctx, repoName, dir, stderr)
+	}
 
 	return execStatus{
 		Err:        execErr,
@@ -1242,6 +1298,10 @@ func setLastFetched(ctx context.Context, db database.DB, shardID string, dir com
 
 // setLastErrorNonFatal will set the last_error column for the repo in the gitserver table.
 func (s *Server) setLastErrorNonFatal(ctx context.Context, name api.RepoName, err error) {
+	if !recordsRepoState(ctx, s.Hostname, name) {
+		return
+	}
+
 	var errString string
 	if err != nil {
 		errString = err.Error()
@@ -1253,7 +1313,7 @@ func (s *Server) setLastErrorNonFatal(ctx context.Context, name api.RepoName, er
 }
 
 func (s *Server) logIfCorrupt(ctx context.Context, repo api.RepoName, dir common.GitDir, stderr string) {
-	if checkMaybeCorruptRepo(s.Logger, s.RecordingCommandFactory, repo, s.ReposDir, dir, stderr) {
+	if checkMaybeCorruptRepo(s.Logger, s.RecordingCommandFactory, repo, s.ReposDir, dir, stderr) && recordsRepoState(ctx, s.Hostname, repo) {
 		reason := stderr
 		if err := s.DB.GitserverRepos().LogCorruption(ctx, repo, reason, s.Hostname); err != nil {
 			s.Logger.Warn("failed to log repo corruption", log.String("repo", string(repo)), log.Error(err))
@@ -1430,6 +1490,13 @@ func (s *Server) doClone(
 	defer func() {
 		if err != nil {
 			repoCloneFailedCounter.Inc()
+
+			// The repo already being cloned is not worth notifying anyone about.
+			if !errors.Is(err, os.ErrExist) {
+				// Use a background context in case we failed because the original context failed.
+				redactedErr := errors.New(urlredactor.New(remoteURL).Redact(err.Error()))
+				outbound.EnqueueRepoCloneFailed(context.Background(), logger, s.DB, repo, redactedErr)
+			}
 		}
 	}()
 	if err := s.RPSLimiter.Wait(ctx); err != nil {
@@ -1462,54 +1529,64 @@ func (s *Server) doClone(
 	tmpPath = filepath.Join(tmpPath, ".git")
 	tmp := common.GitDir(tmpPath)
 
+	recordState := recordsRepoState(ctx, s.Hostname, repo)
+
 	// It may already be cloned
-	if !repoCloned(dir) {
+	if !repoCloned(dir) && recordState {
 		if err := s.DB.GitserverRepos().SetCloneStatus(ctx, repo, types.CloneStatusCloning, s.Hostname); err != nil {
 			s.Logger.Warn("Setting clone status in DB", log.Error(err))
 		}
 	}
 	defer func() {
+		if !recordState {
+			return
+		}
 		// Use a background context to ensure we still update the DB even if we time out
 		if err := s.DB.GitserverRepos().SetCloneStatus(context.Background(), repo, cloneStatus(repoCloned(dir), false), s.Hostname); err != nil {
 			s.Logger.Warn("Setting clone status in DB", log.Error(err))
 		}
 	}()
 
-	cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
-	if err != nil {
-		return errors.Wrap(err, "get clone command")
-	}
-	if cmd.Env == nil {
-		cmd.Env = os.Environ()
-	}
+	restored := s.restoreFromColdStorage(ctx, logger, repo, tmp, remoteURL, syncer)
+	if !restored {
+		cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
+		if err != nil {
+			return errors.Wrap(err, "get clone command")
+		}
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
 
-	// see issue #7322: skip LFS content in repositories with Git LFS configured
-	cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
-	logger.Info("cloning repo", log.String("tmp", tmpPath), log.String("dst", dstPath))
+		// see issue #7322: skip LFS content in repositories with Git LFS configured
+		cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
+		logger.Info("cloning repo", log.String("tmp", tmpPath), log.String("dst", dstPath))
 
-	pr, pw := io.Pipe()
-	defer pw.Close()
+		pr, pw := io.Pipe()
+		defer pw.Close()
 
-	redactor := urlredactor.New(remoteURL)
+		redactor := urlredactor.New(remoteURL)
 
-	go readCloneProgress(s.DB, logger, redactor, lock, pr, repo)
+		go readCloneProgress(s.DB, logger, redactor, lock, pr, repo, recordState)
 
-	output, err := executil.RunRemoteGitCommand(ctx, s.RecordingCommandFactory.WrapWithRepoName(ctx, s.Logger, repo, cmd).WithRedactorFunc(redactor.Redact), true, pw)
-	redactedOutput := redactor.Redact(string(output))
-	// best-effort update the output of the clone
-	if err := s.DB.GitserverRepos().SetLastOutput(context.Background(), repo, redactedOutput); err != nil {
-		s.Logger.Warn("Setting last output in DB", log.Error(err))
-	}
+		output, err := executil.RunRemoteGitCommand(ctx, s.RecordingCommandFactory.WrapWithRepoName(ctx, s.Logger, repo, cmd).WithRedactorFunc(redactor.Redact), true, pw)
+		redactedOutput := redactor.Redact(string(output))
+		// best-effort update the output of the clone
+		if recordState {
+			if err := s.DB.GitserverRepos().SetLastOutput(context.Background(), repo, redactedOutput); err != nil {
+				s.Logger.Warn("Setting last output in DB", log.Error(err))
+			}
+		}
 
-	if err != nil {
-		return errors.Wrapf(err, "clone failed. Output: %s", redactedOutput)
+		if err != nil {
+			return errors.Wrapf(err, "clone failed. Output: %s", redactedOutput)
+		}
 	}
 
 	if testRepoCorrupter != nil {
 		testRepoCorrupter(ctx, tmp)
 	}
 
-	if err := postRepoFetchActions(ctx, logger, s.DB, s.Hostname, s.RecordingCommandFactory, s.ReposDir, repo, tmp, remoteURL, syncer); err != nil {
+	if err := postRepoFetchActions(ctx, logger, s.DB, s.Hostname, recordState, s.RecordingCommandFactory, s.ReposDir, repo, tmp, remoteURL, syncer); err != nil {
 		return err
 	}
 
@@ -1531,16 +1608,51 @@ func (s *Server) doClone(
 	logger.Info("repo cloned")
 	repoClonedCounter.Inc()
 
+	if restored {
+		// The bundle is outdated now. The repo is offloaded again once it is
+		// removed to free up disk space.
+		if err := s.ColdStorage.Delete(ctx, repo); err != nil {
+			logger.Warn("failed to delete repo from cold storage", log.Error(err))
+		}
+	}
+
 	s.Perforce.EnqueueChangelistMappingJob(perforce.NewChangelistMappingJob(repo, dir))
 
 	return nil
 }
 
+// restoreFromColdStorage restores repo into tmp if it was offloaded to cold
+// storage, and fetches the changes made on the remote since it was offloaded.
+// It returns false if the repo has to be cloned from scratch instead.
+func (s *Server) restoreFromColdStorage(ctx context.Context, logger log.Logger, repo api.RepoName, tmp common.GitDir, remoteURL *vcs.URL, syncer vcssyncer.VCSSyncer) bool {
+	if s.ColdStorage == nil || syncer.Type() != "git" {
+		return false
+	}
+
+	restored, err := s.ColdStorage.Restore(ctx, repo, tmp)
+	if err == nil && restored {
+		logger.Info("restored repo from cold storage, fetching changes", log.String("tmp", tmp.Path()))
+		if _, err = syncer.Fetch(ctx, remoteURL, repo, tmp, ""); err == nil {
+			return true
+		}
+	}
+	if err != nil {
+		logger.Warn("failed to restore repo from cold storage, cloning it instead", log.Error(err))
+	}
+
+	// Start the clone from scratch in an empty directory.
+	if err := os.RemoveAll(tmp.Path()); err != nil {
+		logger.Warn("failed to clean up repo restored from cold storage", log.Error(err))
+	}
+	return false
+}
+
 func postRepoFetchActions(
 	ctx context.Context,
 	logger log.Logger,
 	db database.DB,
 	shardID string,
+	recordState bool,
 	rcf *wrexec.RecordingCommandFactory,
 	reposDir string,
 	repo api.RepoName,
@@ -1573,6 +1685,10 @@ func postRepoFetchActions(
 		return errors.Wrap(err, "failed to update last changed time")
 	}
 
+	if !recordState {
+		return nil
+	}
+
 	// Successfully updated, best-effort updating of db fetch state based on
 	// disk state.
 	if err := setLastFetched(ctx, db, shardID, dir, repo); err != nil {
@@ -1590,7 +1706,7 @@ func postRepoFetchActions(
 
 // readCloneProgress scans the reader and saves the most recent line of output
 // as the lock status.
-func readCloneProgress(db database.DB, logger log.Logger, redactor *urlredactor.URLRedactor, lock RepositoryLock, pr io.Reader, repo api.RepoName) {
+func readCloneProgress(db database.DB, logger log.Logger, redactor *urlredactor.URLRedactor, lock RepositoryLock, pr io.Reader, repo api.RepoName, recordState bool) {
 	// Use a background context to ensure we still update the DB even if we
 	// time out. IE we intentionally don't take an input ctx.
 	ctx := featureflag.WithFlags(context.Background(), db.FeatureFlags())
@@ -1633,7 +1749,8 @@ func readCloneProgress(db database.DB, logger log.Logger, redactor *urlredactor.
 		// Only write to the database persisted status if line indicates progress
 		// which is recognized by presence of a '%'. We filter these writes not to waste
 		// rate-limit tokens on log lines that would not be relevant to the user.
-		if featureflag.FromContext(ctx).GetBoolOr("clone-progress-logging", false) &&
+		if recordState &&
+			featureflag.FromContext(ctx).GetBoolOr("clone-progress-logging", false) &&
 			strings.Contains(redactedProgress, "%") &&
 			dbWritesLimiter.Allow() {
 			if err := store.SetCloningProgress(ctx, repo, redactedProgress); err != nil {
@@ -1826,9 +1943,12 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 
 	output, err := syncer.Fetch(ctx, remoteURL, repo, dir, revspec)
 	redactedOutput := urlredactor.New(remoteURL).Redact(string(output))
+	recordState := recordsRepoState(ctx, s.Hostname, repo)
 	// best-effort update the output of the fetch
-	if err := s.DB.GitserverRepos().SetLastOutput(context.Background(), repo, redactedOutput); err != nil {
-		s.Logger.Warn("Setting last output in DB", log.Error(err))
+	if recordState {
+		if err := s.DB.GitserverRepos().SetLastOutput(context.Background(), repo, redactedOutput); err != nil {
+			s.Logger.Warn("Setting last output in DB", log.Error(err))
+		}
 	}
 
 	if err != nil {
@@ -1839,7 +1959,7 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 		}
 	}
 
-	return postRepoFetchActions(ctx, logger, s.DB, s.Hostname, s.RecordingCommandFactory, s.ReposDir, repo, dir, remoteURL, syncer)
+	return postRepoFetchActions(ctx, logger, s.DB, s.Hostname, recordState, s.RecordingCommandFactory, s.ReposDir, repo, dir, remoteURL, syncer)
 }
 
 // setHEAD configures git repo defaults (such as what HEAD is) which are
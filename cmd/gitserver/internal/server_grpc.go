@@ -188,17 +188,7 @@ func (gs *GRPCServer) doExec(ctx context.Context, logger log.Logger, req *protoc
 	execStatus, err := gs.Server.exec(ctx, logger, req, userAgent, w)
 	if err != nil {
 		if v := (&NotFoundError{}); errors.As(err, &v) {
-			s, err := status.New(codes.NotFound, "repo not found").WithDetails(&proto.NotFoundPayload{
-				Repo:            string(req.Repo),
-				CloneInProgress: v.Payload.CloneInProgress,
-				CloneProgress:   v.Payload.CloneProgress,
-			})
-			if err != nil {
-				gs.Server.Logger.Error("failed to marshal status", log.Error(err))
-				return err
-			}
-			return s.Err()
-
+			return gs.repoNotFoundError(req.Repo, v.Payload)
 		} else if errors.Is(err, ErrInvalidCommand) {
 			return status.New(codes.InvalidArgument, "invalid command").Err()
 		} else if ctxErr := ctx.Err(); ctxErr != nil {
@@ -232,6 +222,20 @@ func (gs *GRPCServer) doExec(ctx context.Context, logger log.Logger, req *protoc
 
 }
 
+// repoNotFoundError returns the gRPC error for a repo that isn't cloned.
+func (gs *GRPCServer) repoNotFoundError(repo api.RepoName, payload *protocol.NotFoundPayload) error {
+	s, err := status.New(codes.NotFound, "repo not found").WithDetails(&proto.NotFoundPayload{
+		Repo:            string(repo),
+		CloneInProgress: payload.CloneInProgress,
+		CloneProgress:   payload.CloneProgress,
+	})
+	if err != nil {
+		gs.Server.Logger.Error("failed to marshal status", log.Error(err))
+		return err
+	}
+	return s.Err()
+}
+
 func (gs *GRPCServer) GetObject(ctx context.Context, req *proto.GetObjectRequest) (*proto.GetObjectResponse, error) {
 	var internalReq protocol.GetObjectRequest
 	internalReq.FromProto(req)
@@ -333,7 +337,7 @@ func (gs *GRPCServer) RepoCloneProgress(_ context.Context, req *proto.RepoCloneP
 func (gs *GRPCServer) RepoDelete(ctx context.Context, req *proto.RepoDeleteRequest) (*proto.RepoDeleteResponse, error) {
 	repo := req.GetRepo()
 
-	if err := deleteRepo(ctx, gs.Server.Logger, gs.Server.DB, gs.Server.Hostname, gs.Server.ReposDir, api.UndeletedRepoName(api.RepoName(repo))); err != nil {
+	if err := deleteRepo(ctx, gs.Server.Logger, gs.Server.DB, gs.Server.ColdStorage, gs.Server.Hostname, gs.Server.ReposDir, api.UndeletedRepoName(api.RepoName(repo))); err != nil {
 		gs.Server.Logger.Error("failed to delete repository", log.String("repo", repo), log.Error(err))
 		return &proto.RepoDeleteResponse{}, status.Errorf(codes.Internal, "failed to delete repository %s: %s", repo, err)
 	}
@@ -296,6 +296,7 @@ func makeTestServer(ctx context.Context, t *testing.T, repoDir, remote string, d
 		repoStore.GetByNameFunc.SetDefaultReturn(nil, &database.RepoNotFoundErr{})
 
 		mDB.ReposFunc.SetDefaultReturn(repoStore)
+		mDB.OutboundWebhooksFunc.SetDefaultReturn(dbmocks.NewMockOutboundWebhookStore())
 
 		db = mDB
 	}
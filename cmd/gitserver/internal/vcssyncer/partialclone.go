@@ -0,0 +1,72 @@
+package vcssyncer
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/executil"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+var partialCloneMappings = conf.Cached(func() []*schema.PartialCloneMapping {
+	return conf.ExperimentalFeatures().PartialClones
+})
+
+// partialCloneFilter returns the filter to clone remoteURL with, or the empty
+// string if it should be cloned in full.
+func partialCloneFilter(remoteURL *vcs.URL) string {
+	return matchPartialCloneFilter(partialCloneMappings(), remoteURL)
+}
+
+// matchPartialCloneFilter returns the filter of the mapping with the longest
+// domain/path that is a prefix of the domain/path of remoteURL.
+func matchPartialCloneFilter(mappings []*schema.PartialCloneMapping, remoteURL *vcs.URL) string {
+	if len(mappings) == 0 {
+		return ""
+	}
+
+	dp := strings.TrimSuffix(path.Join(strings.ToLower(remoteURL.Host), remoteURL.Path), ".git")
+
+	var filter string
+	longest := -1
+	for _, m := range mappings {
+		prefix := strings.TrimSuffix(m.DomainPath, "/")
+		if dp != prefix && !strings.HasPrefix(dp, prefix+"/") {
+			continue
+		}
+		if len(prefix) > longest {
+			longest = len(prefix)
+			filter = m.Filter
+		}
+	}
+	return filter
+}
+
+// partialCloneFetchCmd returns the command to fetch from remoteURL into a
+// partial clone. If filter is not empty, the repository becomes a partial clone
+// with that filter. Otherwise the filter it was cloned with is used.
+//
+// Unlike other fetches, partial clones must fetch from a named remote, since
+// git records the name of the remote to fetch missing objects from. Its URL is
+// only ever passed in the environment.
+func partialCloneFetchCmd(ctx context.Context, remoteURL *vcs.URL, filter string) *exec.Cmd {
+	args := []string{"fetch", "--progress", "--prune"}
+	if filter != "" {
+		args = append(args, "--filter="+filter)
+	}
+	args = append(args, executil.PromisorRemote)
+	if useRefspecOverrides() {
+		args = append(args, refspecOverrides...)
+	} else {
+		args = append(args, defaultRefspecs...)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(os.Environ(), executil.PromisorRemoteEnv(remoteURL)...)
+	return cmd
+}
@@ -0,0 +1,67 @@
+package vcssyncer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMatchPartialCloneFilter(t *testing.T) {
+	mappings := []*schema.PartialCloneMapping{
+		{DomainPath: "github.com", Filter: "blob:limit=1m"},
+		{DomainPath: "github.com/foo/monorepo", Filter: "blob:none"},
+		{DomainPath: "gitlab.example.com/bar/", Filter: "blob:limit=10k"},
+	}
+
+	tests := []struct {
+		remoteURL string
+		want      string
+	}{
+		{remoteURL: "https://github.com/foo/monorepo", want: "blob:none"},
+		{remoteURL: "https://token@github.com/foo/monorepo.git", want: "blob:none"},
+		{remoteURL: "git@github.com:foo/monorepo.git", want: "blob:none"},
+		{remoteURL: "https://github.com/foo/monorepo-other", want: "blob:limit=1m"},
+		{remoteURL: "https://GitHub.com/baz/qux", want: "blob:limit=1m"},
+		{remoteURL: "https://gitlab.example.com/bar/repo", want: "blob:limit=10k"},
+		{remoteURL: "https://gitlab.example.com/barbaz/repo", want: ""},
+		{remoteURL: "https://bitbucket.org/foo/monorepo", want: ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.remoteURL, func(t *testing.T) {
+			remoteURL, err := vcs.ParseURL(tc.remoteURL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := matchPartialCloneFilter(mappings, remoteURL); got != tc.want {
+				t.Errorf("got filter %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPartialCloneFetchCmd(t *testing.T) {
+	remoteURL, _ := vcs.ParseURL("https://token@github.com/foo/monorepo")
+
+	t.Run("clone", func(t *testing.T) {
+		cmd := partialCloneFetchCmd(context.Background(), remoteURL, "blob:none")
+		want := append([]string{"git", "fetch", "--progress", "--prune", "--filter=blob:none", "origin"}, defaultRefspecs...)
+		if diff := cmp.Diff(want, cmd.Args); diff != "" {
+			t.Errorf("unexpected args (-want +got):\n%s", diff)
+		}
+		if got, want := cmd.Env[len(cmd.Env)-1], "GIT_CONFIG_VALUE_0=https://token@github.com/foo/monorepo"; got != want {
+			t.Errorf("got env %q, want %q", got, want)
+		}
+	})
+
+	t.Run("fetch", func(t *testing.T) {
+		cmd := partialCloneFetchCmd(context.Background(), remoteURL, "")
+		want := append([]string{"git", "fetch", "--progress", "--prune", "origin"}, defaultRefspecs...)
+		if diff := cmp.Diff(want, cmd.Args); diff != "" {
+			t.Errorf("unexpected args (-want +got):\n%s", diff)
+		}
+	})
+}
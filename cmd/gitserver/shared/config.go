@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/coldstorage"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -36,8 +37,15 @@ type Config struct {
 	SyncRepoStateUpdatePerSecond   int
 	BatchLogGlobalConcurrencyLimit int
 
+	ReplicaReconcileInterval time.Duration
+	ReplicaReconcileLimit    int
+
 	JanitorReposDesiredPercentFree int
 	JanitorInterval                time.Duration
+
+	// ColdStorage configures where repos removed to free up disk space are
+	// offloaded to.
+	ColdStorage coldstorage.Config
 }
 
 func (c *Config) Load() {
@@ -74,6 +82,9 @@ func (c *Config) Load() {
 	c.SyncRepoStateUpdatePerSecond = c.GetInt("SRC_REPOS_SYNC_STATE_UPSERT_PER_SEC", "500", "The number of updated rows allowed per second across all gitserver instances")
 	c.BatchLogGlobalConcurrencyLimit = c.GetInt("SRC_BATCH_LOG_GLOBAL_CONCURRENCY_LIMIT", "256", "The maximum number of in-flight Git commands from all /batch-log requests combined")
 
+	c.ReplicaReconcileInterval = c.GetInterval("SRC_REPLICA_RECONCILE_INTERVAL", "5m", "Interval between runs of the reconciler that heals replicas of repos")
+	c.ReplicaReconcileLimit = c.GetInt("SRC_REPLICA_RECONCILE_LIMIT", "100", "The maximum number of replicas cloned or fetched in a single run of the replica reconciler")
+
 	// Align these variables with the 'disk_space_remaining' alerts in monitoring
 	c.JanitorReposDesiredPercentFree = c.GetInt("SRC_REPOS_DESIRED_PERCENT_FREE", "10", "Target percentage of free space on disk.")
 	if c.JanitorReposDesiredPercentFree < 0 {
@@ -84,4 +95,6 @@ func (c *Config) Load() {
 	}
 
 	c.JanitorInterval = c.GetInterval("SRC_REPOS_JANITOR_INTERVAL", "1m", "Interval between cleanup runs")
+
+	c.ColdStorage.Load()
 }
@@ -34,12 +34,21 @@ func TestConfigDefaults(t *testing.T) {
 	if have, want := config.BatchLogGlobalConcurrencyLimit, 256; have != want {
 		t.Errorf("invalid value for BatchLogGlobalConcurrencyLimit: have=%d want=%d", have, want)
 	}
+	if have, want := config.ReplicaReconcileInterval, 5*time.Minute; have != want {
+		t.Errorf("invalid value for ReplicaReconcileInterval: have=%s want=%s", have, want)
+	}
+	if have, want := config.ReplicaReconcileLimit, 100; have != want {
+		t.Errorf("invalid value for ReplicaReconcileLimit: have=%d want=%d", have, want)
+	}
 	if have, want := config.JanitorReposDesiredPercentFree, 10; have != want {
 		t.Errorf("invalid value for JanitorReposDesiredPercentFree: have=%d want=%d", have, want)
 	}
 	if have, want := config.JanitorInterval, time.Minute; have != want {
 		t.Errorf("invalid value for JanitorInterval: have=%s want=%s", have, want)
 	}
+	if config.ColdStorage.Enabled() {
+		t.Errorf("expected cold storage to be disabled by default")
+	}
 }
 
 func TestConfig_PercentFree(t *testing.T) {
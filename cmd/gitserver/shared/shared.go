@@ -19,6 +19,7 @@ import (
 	server "github.com/sourcegraph/sourcegraph/cmd/gitserver/internal"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/accesslog"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/cloneurl"
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/coldstorage"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/gitserverfs"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/perforce"
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/internal/vcssyncer"
@@ -57,6 +58,9 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	if err := config.Validate(); err != nil {
 		return errors.Wrap(err, "failed to validate configuration")
 	}
+	if err := config.ColdStorage.Validate(); err != nil {
+		return errors.Wrap(err, "failed to validate cold storage configuration")
+	}
 
 	// Prepare the file system.
 	if err := gitserverfs.InitGitserverFileSystem(logger, config.ReposDir); err != nil {
@@ -78,6 +82,11 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 
 	authz.DefaultSubRepoPermsChecker = subrepoperms.NewSubRepoPermsClient(db.SubRepoPerms())
 
+	coldStorage, err := coldstorage.New(ctx, observationCtx, &config.ColdStorage)
+	if err != nil {
+		return errors.Wrap(err, "initializing cold storage")
+	}
+
 	// Setup our server megastruct.
 	recordingCommandFactory := wrexec.NewRecordingCommandFactory(nil, 0)
 	cloneQueue := server.NewCloneQueue(observationCtx, list.New())
@@ -111,6 +120,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 			ratelimit.GitRPSLimiterBucketName,
 			ratelimit.NewGlobalRateLimiter(logger, ratelimit.GitRPSLimiterBucketName),
 		),
+		ColdStorage: coldStorage,
 	}
 
 	// Make sure we watch for config updates that affect the recordingCommandFactory.
@@ -155,6 +165,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 			config.SyncRepoStateBatchSize,
 			config.SyncRepoStateUpdatePerSecond,
 		),
+		gitserver.NewReplicaReconciler(ctx, config.ReplicaReconcileInterval, config.ReplicaReconcileLimit),
 	}
 
 	if runtime.GOOS == "windows" {
@@ -170,6 +181,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 					JanitorInterval:    config.JanitorInterval,
 					ReposDir:           config.ReposDir,
 					DesiredPercentFree: config.JanitorReposDesiredPercentFree,
+					ColdStorage:        coldStorage,
 				},
 				db,
 				recordingCommandFactory,
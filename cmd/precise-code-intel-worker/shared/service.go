@@ -8,6 +8,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/service"
 	"github.com/sourcegraph/sourcegraph/internal/symbols"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 )
 
 type svc struct{}
@@ -18,7 +19,7 @@ func (svc) Configure() (env.Config, []debugserver.Endpoint) {
 	symbols.LoadConfig()
 	var config Config
 	config.Load()
-	return &config, nil
+	return &config, []debugserver.Endpoint{dbworker.DeadLetterDebugEndpoint()}
 }
 
 func (svc) Start(ctx context.Context, observationCtx *observation.Context, ready service.ReadyFunc, config env.Config) error {
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -72,23 +73,26 @@ func (h *permsSyncerWorker) Handle(ctx context.Context, _ log.Logger, record *da
 		log.Int("priority", int(record.Priority)),
 	)
 
-	return h.handlePermsSync(ctx, reqType, reqID, record.ID, record.NoPerms, record.InvalidateCaches)
+	return h.handlePermsSync(ctx, reqType, reqID, record)
 }
 
 // handlePermsSync is effectively a sync version of `perms_syncer.syncPerms`
 // which calls `perms_syncer.syncUserPerms` or `perms_syncer.syncRepoPerms`
 // depending on a request type and logs/adds metrics of sync statistics
 // afterwards.
-func (h *permsSyncerWorker) handlePermsSync(ctx context.Context, reqType requestType, reqID int32, recordID int, noPerms, invalidateCaches bool) error {
+func (h *permsSyncerWorker) handlePermsSync(ctx context.Context, reqType requestType, reqID int32, record *database.PermissionSyncJob) error {
 	var err error
 	var result *database.SetPermissionsResult
 	var providerStates database.CodeHostStatusesSet
 
+	recordID := record.ID
+	fetchOpts := authz.FetchPermsOptions{InvalidateCaches: record.InvalidateCaches}
+
 	switch reqType {
 	case requestTypeUser:
-		result, providerStates, err = h.syncer.syncUserPerms(ctx, reqID, noPerms, authz.FetchPermsOptions{InvalidateCaches: invalidateCaches})
+		result, providerStates, err = h.syncer.syncUserPerms(ctx, reqID, record.NoPerms, fetchOpts)
 	case requestTypeRepo:
-		result, providerStates, err = h.syncer.syncRepoPerms(ctx, api.RepoID(reqID), noPerms, authz.FetchPermsOptions{InvalidateCaches: invalidateCaches})
+		result, providerStates, err = h.syncer.syncRepoPerms(ctx, api.RepoID(reqID), record.NoPerms, fetchOpts)
 	default:
 		return errors.Newf("unexpected request type: %q", reqType)
 	}
@@ -110,11 +114,14 @@ func (h *permsSyncerWorker) handlePermsSync(ctx context.Context, reqType request
 
 	// NOTE(naman): here we are saving permissions added, removed and found results
 	// as well as the code host sync status to the job record.
+	syncErr := err
 	if saveErr := h.jobsStore.SaveSyncResult(ctx, recordID, err == nil, result, providerStates); saveErr != nil {
 		err = errors.Append(err, saveErr)
 		h.logger.Error(fmt.Sprintf("failed to save permissions sync job(%d) results", recordID), log.Error(saveErr))
 	}
 
+	outbound.EnqueuePermissionSync(ctx, h.logger, database.NewDBWith(h.logger, h.jobsStore), record, result, syncErr)
+
 	return err
 }
 
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/sourcegraph/sourcegraph/internal/debugserver"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 )
 
 func createDebugServerEndpoints(ready chan struct{}, debugserverEndpoints *LazyDebugserverEndpoint) []debugserver.Endpoint {
@@ -44,5 +45,6 @@ func createDebugServerEndpoints(ready chan struct{}, debugserverEndpoints *LazyD
 				debugserverEndpoints.manualPurgeEndpoint(w, r)
 			}),
 		},
+		dbworker.DeadLetterDebugEndpoint(),
 	}
 }
@@ -59,6 +59,11 @@ type readerGrep struct {
 	// re. It is the output of the longestLiteral function. It is only set if
 	// the regex has an empty LiteralPrefix.
 	literalSubstring []byte
+
+	// trigramLiteral is the lowercased longest literal of re. It is used to
+	// find the files which may contain a match in the trigram index of a zip.
+	// It is nil if the literal contains non-ASCII characters.
+	trigramLiteral []byte
 }
 
 // compile returns a readerGrep for matching p.
@@ -66,6 +71,7 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 	var (
 		re               *regexp.Regexp
 		literalSubstring []byte
+		trigramLiteral   []byte
 	)
 	if p.Pattern != "" {
 		expr := p.Pattern
@@ -108,16 +114,18 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 			return nil, err
 		}
 
+		ast, err := syntax.Parse(expr, syntax.Perl)
+		if err != nil {
+			return nil, err
+		}
+		longest := longestLiteral(ast.Simplify())
+
 		// Only use literalSubstring optimization if the regex engine doesn't
 		// have a prefix to use.
 		if pre, _ := re.LiteralPrefix(); pre == "" {
-			ast, err := syntax.Parse(expr, syntax.Perl)
-			if err != nil {
-				return nil, err
-			}
-			ast = ast.Simplify()
-			literalSubstring = []byte(longestLiteral(ast))
+			literalSubstring = []byte(longest)
 		}
+		trigramLiteral = lowerASCIILiteral(longest)
 	}
 
 	matchPath, err := compilePathPatterns(p.IncludePatterns, p.ExcludePattern, p.PathPatternsAreCaseSensitive)
@@ -130,6 +138,7 @@ func compile(p *protocol.PatternInfo) (*readerGrep, error) {
 		ignoreCase:       !p.IsCaseSensitive,
 		matchPath:        matchPath,
 		literalSubstring: literalSubstring,
+		trigramLiteral:   trigramLiteral,
 	}, nil
 }
 
@@ -141,6 +150,7 @@ func (rg *readerGrep) Copy() *readerGrep {
 		ignoreCase:       rg.ignoreCase,
 		matchPath:        rg.matchPath,
 		literalSubstring: rg.literalSubstring,
+		trigramLiteral:   rg.trigramLiteral,
 	}
 }
 
@@ -285,9 +295,15 @@ func regexSearch(ctx context.Context, rg *readerGrep, zf *zipFile, patternMatche
 	}
 	defer cancel()
 
-	var (
-		files = zf.Files
-	)
+	files := zf.Files
+	if patternMatchesContent && !patternMatchesPaths && !isPatternNegated {
+		// Only files containing the literal of the pattern can match, so the
+		// other files are skipped if the zip has a trigram index.
+		if candidates, ok := zf.trigramCandidates(rg.trigramLiteral); ok {
+			tr.AddEvent("trigram index", attribute.Int("candidates", len(candidates)))
+			files = candidates
+		}
+	}
 
 	if rg.re == nil || (patternMatchesPaths && !patternMatchesContent) {
 		// Fast path for only matching file paths (or with a nil pattern, which matches all files,
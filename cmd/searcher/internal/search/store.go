@@ -93,6 +93,10 @@ type Store struct {
 	// ObservationCtx is used to configure observability in diskcache.
 	ObservationCtx *observation.Context
 
+	// TrigramIndex enables building a trigram index next to each zip in the
+	// cache, which speeds up repeated searches of the same archive.
+	TrigramIndex bool
+
 	// once protects Start
 	once sync.Once
 
@@ -117,6 +121,7 @@ type FilterFunc func(hdr *tar.Header) bool
 func (s *Store) Start() {
 	s.once.Do(func() {
 		s.fetchLimiter = limiter.NewMutable(15)
+		s.zipCache.indexTrigrams = s.TrigramIndex
 		s.cache = diskcache.NewStore(s.Path, "store",
 			diskcache.WithBackgroundTimeout(s.BackgroundTimeout),
 			diskcache.WithBeforeEvict(s.zipCache.delete),
@@ -0,0 +1,356 @@
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"unicode/utf8"
+
+	"github.com/RoaringBitmap/roaring"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/casetransform"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// A trigramIndex maps each trigram in the lowercased contents of the files of
+// a zip to the files that contain it. Searches use it to skip files which
+// cannot contain a literal of the pattern, so that searching an unindexed
+// revision doesn't need to scan every file in its archive.
+//
+// The index is stored next to the zip in the cache directory, so that it is
+// built once and reused by all searches of the zip. It is memory mapped and
+// only the posting lists needed by a search are read.
+//
+// The file format is:
+//
+//	magic       [8]byte
+//	numFiles    uint32
+//	numTrigrams uint32
+//	entries     [numTrigrams]{trigram, offset, length uint32}, sorted by trigram
+//	postings    the serialized roaring bitmaps referenced by the entries
+//
+// Posting lists contain the index of files in zipFile.Files.
+type trigramIndex struct {
+	data        []byte
+	f           *os.File
+	numTrigrams int
+}
+
+// trigramIndexSuffix is appended to the path of a zip to get the path of its
+// trigram index.
+const trigramIndexSuffix = ".trigrams"
+
+var trigramIndexMagic = []byte("SGTRGM01")
+
+const (
+	trigramIndexHeaderLen = 16
+	trigramIndexEntryLen  = 12
+)
+
+// trigramIndexBuilds limits how many indexes are built concurrently, since
+// building an index holds all of its posting lists in memory.
+var trigramIndexBuilds = make(chan struct{}, 2)
+
+// trigramIndexMaxDataSize is the size of the file contents of the largest zip
+// a trigram index is built for. The posting lists held in memory while
+// building an index grow with the size of the contents.
+const trigramIndexMaxDataSize = 128 << 20
+
+var (
+	metricTrigramIndexBuilds = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "searcher_trigram_index_builds",
+		Help: "The total number of trigram indexes built for archives.",
+	})
+	metricTrigramIndexBuildFailed = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "searcher_trigram_index_build_failed",
+		Help: "The total number of trigram indexes that failed to build.",
+	})
+	metricTrigramIndexFilesSkipped = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "searcher_trigram_index_files_skipped",
+		Help: "The total number of files not searched because the trigram index ruled them out.",
+	})
+)
+
+func trigramIndexPath(zipPath string) string {
+	return zipPath + trigramIndexSuffix
+}
+
+func trigramOf(b []byte) uint32 {
+	return uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+}
+
+// openTrigramIndex memory maps the trigram index at path. numFiles is the
+// number of files in the zip the index belongs to.
+func openTrigramIndex(path string, numFiles int) (_ *trigramIndex, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+		}
+	}()
+
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if fi.Size() < trigramIndexHeaderLen {
+		return nil, errors.Errorf("trigram index %s is truncated", path)
+	}
+
+	data, err := mmap(path, f, fi)
+	if err != nil {
+		return nil, err
+	}
+	idx := &trigramIndex{data: data, f: f}
+	if err := idx.validate(numFiles); err != nil {
+		_ = unmap(data)
+		return nil, errors.Wrapf(err, "invalid trigram index %s", path)
+	}
+	return idx, nil
+}
+
+func (idx *trigramIndex) validate(numFiles int) error {
+	if !bytes.Equal(idx.data[:len(trigramIndexMagic)], trigramIndexMagic) {
+		return errors.New("unknown format")
+	}
+	if n := int(binary.LittleEndian.Uint32(idx.data[8:])); n != numFiles {
+		return errors.Errorf("index has %d files, want %d", n, numFiles)
+	}
+	idx.numTrigrams = int(binary.LittleEndian.Uint32(idx.data[12:]))
+	if trigramIndexHeaderLen+idx.numTrigrams*trigramIndexEntryLen > len(idx.data) {
+		return errors.New("truncated entries")
+	}
+	for i := 0; i < idx.numTrigrams; i++ {
+		off, n := idx.postingsRange(i)
+		if off+n > uint64(len(idx.data)) {
+			return errors.New("truncated postings")
+		}
+	}
+	return nil
+}
+
+func (idx *trigramIndex) entry(i int) []byte {
+	start := trigramIndexHeaderLen + i*trigramIndexEntryLen
+	return idx.data[start : start+trigramIndexEntryLen]
+}
+
+func (idx *trigramIndex) postingsRange(i int) (off, n uint64) {
+	e := idx.entry(i)
+	return uint64(binary.LittleEndian.Uint32(e[4:])), uint64(binary.LittleEndian.Uint32(e[8:]))
+}
+
+// postings returns the files containing trigram t.
+func (idx *trigramIndex) postings(t uint32) (*roaring.Bitmap, error) {
+	i := sort.Search(idx.numTrigrams, func(i int) bool {
+		return binary.LittleEndian.Uint32(idx.entry(i)) >= t
+	})
+	if i == idx.numTrigrams || binary.LittleEndian.Uint32(idx.entry(i)) != t {
+		return roaring.New(), nil
+	}
+	// The bitmap is copied out of the mapping, since candidates modifies it.
+	off, n := idx.postingsRange(i)
+	bm := roaring.New()
+	if err := bm.UnmarshalBinary(idx.data[off : off+n]); err != nil {
+		return nil, err
+	}
+	return bm, nil
+}
+
+// candidates returns the files which may contain literal, which must be
+// lowercase. ok is false if literal is too short to use the index.
+func (idx *trigramIndex) candidates(literal []byte) (_ *roaring.Bitmap, ok bool, err error) {
+	if len(literal) < 3 {
+		return nil, false, nil
+	}
+	var result *roaring.Bitmap
+	for i := 0; i+3 <= len(literal); i++ {
+		bm, err := idx.postings(trigramOf(literal[i:]))
+		if err != nil {
+			return nil, false, err
+		}
+		if result == nil {
+			result = bm
+		} else {
+			result.And(bm)
+		}
+		if result.IsEmpty() {
+			break
+		}
+	}
+	return result, true, nil
+}
+
+func (idx *trigramIndex) close() error {
+	err := unmap(idx.data)
+	return errors.Append(err, idx.f.Close())
+}
+
+// buildTrigramIndex writes the trigram index of zf to path.
+func buildTrigramIndex(zf *zipFile, path string) (err error) {
+	postings := map[uint32][]uint32{}
+	buf := make([]byte, zf.MaxLen)
+	for i := range zf.Files {
+		data := zf.DataFor(&zf.Files[i])
+		lower := buf[:len(data)]
+		casetransform.BytesToLowerASCII(lower, data)
+		for j := 0; j+3 <= len(lower); j++ {
+			t := trigramOf(lower[j:])
+			files := postings[t]
+			if len(files) == 0 || files[len(files)-1] != uint32(i) {
+				postings[t] = append(files, uint32(i))
+			}
+		}
+	}
+
+	trigrams := make([]uint32, 0, len(postings))
+	for t := range postings {
+		trigrams = append(trigrams, t)
+	}
+	sort.Slice(trigrams, func(i, j int) bool { return trigrams[i] < trigrams[j] })
+
+	serialized := make([][]byte, len(trigrams))
+	for i, t := range trigrams {
+		bm := roaring.BitmapOf(postings[t]...)
+		bm.RunOptimize()
+		if serialized[i], err = bm.ToBytes(); err != nil {
+			return err
+		}
+		delete(postings, t)
+	}
+
+	// Write to a temporary file first, so that a partially written index is
+	// never opened.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	w := bufio.NewWriter(tmp)
+	header := make([]byte, trigramIndexHeaderLen)
+	copy(header, trigramIndexMagic)
+	binary.LittleEndian.PutUint32(header[8:], uint32(len(zf.Files)))
+	binary.LittleEndian.PutUint32(header[12:], uint32(len(trigrams)))
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+
+	off := trigramIndexHeaderLen + len(trigrams)*trigramIndexEntryLen
+	for _, b := range serialized {
+		off += len(b)
+	}
+	if off > math.MaxUint32 {
+		return errors.Errorf("trigram index is too large: %d bytes", off)
+	}
+
+	off = trigramIndexHeaderLen + len(trigrams)*trigramIndexEntryLen
+	entry := make([]byte, trigramIndexEntryLen)
+	for i, t := range trigrams {
+		binary.LittleEndian.PutUint32(entry, t)
+		binary.LittleEndian.PutUint32(entry[4:], uint32(off))
+		binary.LittleEndian.PutUint32(entry[8:], uint32(len(serialized[i])))
+		if _, err := w.Write(entry); err != nil {
+			return err
+		}
+		off += len(serialized[i])
+	}
+	for _, b := range serialized {
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// loadTrigramIndex sets the trigram index of zf, which is stored at zipPath.
+// If the zip doesn't have an index yet, it is built in the background.
+func loadTrigramIndex(zf *zipFile, zipPath string) {
+	path := trigramIndexPath(zipPath)
+	if idx, err := openTrigramIndex(path, len(zf.Files)); err == nil {
+		zf.index.Store(idx)
+		return
+	}
+
+	if len(zf.Data) > trigramIndexMaxDataSize {
+		return
+	}
+
+	// Keep the zip mapped until the index has been built.
+	zf.wg.Add(1)
+	go func() {
+		defer zf.wg.Done()
+
+		trigramIndexBuilds <- struct{}{}
+		defer func() { <-trigramIndexBuilds }()
+
+		if err := buildTrigramIndex(zf, path); err != nil {
+			metricTrigramIndexBuildFailed.Inc()
+			return
+		}
+		metricTrigramIndexBuilds.Inc()
+
+		if idx, err := openTrigramIndex(path, len(zf.Files)); err == nil {
+			zf.index.Store(idx)
+		}
+	}()
+}
+
+// lowerASCIILiteral returns literal lowercased for looking it up in a trigram
+// index, or nil if it isn't ASCII, since the index only folds ASCII case.
+func lowerASCIILiteral(literal string) []byte {
+	for i := 0; i < len(literal); i++ {
+		if literal[i] >= utf8.RuneSelf {
+			return nil
+		}
+	}
+	b := make([]byte, len(literal))
+	casetransform.BytesToLowerASCII(b, []byte(literal))
+	return b
+}
+
+// trigramCandidates returns the files of zf which may contain literal, which
+// must be lowercase. ok is false if zf has no trigram index yet or the literal
+// is too short to use it.
+func (f *zipFile) trigramCandidates(literal []byte) ([]srcFile, bool) {
+	idx := f.index.Load()
+	if idx == nil {
+		return nil, false
+	}
+	bm, ok, err := idx.candidates(literal)
+	if err != nil || !ok {
+		return nil, false
+	}
+
+	files := make([]srcFile, 0, bm.GetCardinality())
+	it := bm.Iterator()
+	for it.HasNext() {
+		i := int(it.Next())
+		if i >= len(f.Files) {
+			return nil, false
+		}
+		files = append(files, f.Files[i])
+	}
+	metricTrigramIndexFilesSkipped.Add(float64(len(f.Files) - len(files)))
+	return files, true
+}
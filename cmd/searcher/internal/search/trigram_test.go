@@ -0,0 +1,106 @@
+package search
+
+import (
+	"context"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+var trigramTestFiles = map[string]string{
+	"a.go":      "package main\n\nfunc main() { println(\"Hello World\") }\n",
+	"b.go":      "package main\n\n// hello again\n",
+	"c.md":      "# Release notes\n",
+	"empty.txt": "",
+	"ab":        "ab",
+}
+
+func TestTrigramIndex(t *testing.T) {
+	data, err := createZip(trigramTestFiles)
+	require.NoError(t, err)
+	zipPath := tempZipFileOnDisk(t, data)
+	zf, err := readZipFile(zipPath)
+	require.NoError(t, err)
+
+	path := trigramIndexPath(zipPath)
+	require.NoError(t, buildTrigramIndex(zf, path))
+	idx, err := openTrigramIndex(path, len(zf.Files))
+	require.NoError(t, err)
+	t.Cleanup(func() { idx.close() })
+
+	candidates := func(literal string) []string {
+		bm, ok, err := idx.candidates(lowerASCIILiteral(literal))
+		require.NoError(t, err)
+		if !ok {
+			return nil
+		}
+		names := []string{}
+		for _, i := range bm.ToArray() {
+			names = append(names, zf.Files[i].Name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	require.Equal(t, []string{"a.go", "b.go"}, candidates("HELLO"))
+	require.Equal(t, []string{"a.go"}, candidates("hello world"))
+	require.Equal(t, []string{"c.md"}, candidates("release"))
+	require.Equal(t, []string{}, candidates("goodbye"))
+	// Literals shorter than a trigram can't use the index.
+	require.Nil(t, candidates("ab"))
+
+	// An index for a different zip is rejected.
+	_, err = openTrigramIndex(path, len(zf.Files)+1)
+	require.Error(t, err)
+}
+
+func TestLowerASCIILiteral(t *testing.T) {
+	require.Equal(t, []byte("hello world"), lowerASCIILiteral("Hello World"))
+	require.Nil(t, lowerASCIILiteral("grüße"))
+}
+
+func TestRegexSearch_trigramIndex(t *testing.T) {
+	data, err := createZip(trigramTestFiles)
+	require.NoError(t, err)
+	zipPath := tempZipFileOnDisk(t, data)
+
+	cache := zipCache{indexTrigrams: true}
+	zf, err := cache.Get(zipPath)
+	require.NoError(t, err)
+
+	// The index is built in the background.
+	require.Eventually(t, func() bool { return zf.index.Load() != nil }, 10*time.Second, 10*time.Millisecond)
+	require.FileExists(t, trigramIndexPath(zipPath))
+
+	search := func(p protocol.PatternInfo) []string {
+		rg, err := compile(&p)
+		require.NoError(t, err)
+		fms, _, err := regexSearchBatch(context.Background(), rg, zf, 100, true, false, false)
+		require.NoError(t, err)
+		names := []string{}
+		for _, fm := range fms {
+			names = append(names, fm.Path)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	require.Equal(t, []string{"a.go", "b.go"}, search(protocol.PatternInfo{Pattern: "hello"}))
+	require.Equal(t, []string{"a.go"}, search(protocol.PatternInfo{Pattern: "Hello", IsCaseSensitive: true}))
+	require.Equal(t, []string{"a.go"}, search(protocol.PatternInfo{Pattern: `hello\s+world`, IsRegExp: true}))
+	require.Equal(t, []string{}, search(protocol.PatternInfo{Pattern: "goodbye"}))
+
+	zf.Close()
+
+	// The index is removed together with the zip.
+	cache.delete(zipPath, observation.TestTraceLogger(logtest.Scoped(t)))
+	_, err = os.Stat(trigramIndexPath(zipPath))
+	require.True(t, os.IsNotExist(err))
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"sort"
 	"sync"
+	"sync/atomic"
 
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -26,6 +27,10 @@ type zipCache struct {
 	// occurs when a file is being deleted, and files are deleted
 	// when no one has used them for a long time. Nevertheless, take care.)
 	shards [64]zipCacheShard
+
+	// indexTrigrams enables loading, or building if missing, the trigram
+	// index of each zip file when it is added to the cache.
+	indexTrigrams bool
 }
 
 type zipCacheShard struct {
@@ -60,6 +65,9 @@ func (c *zipCache) Get(path string) (*zipFile, error) {
 	if err != nil {
 		return nil, err
 	}
+	if c.indexTrigrams {
+		loadTrigramIndex(zf, path)
+	}
 	shard.m[path] = zf
 	zf.wg.Add(1)
 	return zf, nil
@@ -69,6 +77,15 @@ func (c *zipCache) delete(path string, trace observation.TraceLogger) {
 	shard := c.shardFor(path)
 	shard.mu.Lock()
 	defer shard.mu.Unlock()
+
+	// The trigram index is stored next to the zip, so it is removed with it
+	// even if the zip isn't loaded.
+	defer func() {
+		if err := os.Remove(trigramIndexPath(path)); err != nil && !os.IsNotExist(err) {
+			log.Printf("failed to remove trigram index of %q: %v", path, err)
+		}
+	}()
+
 	zf, ok := shard.m[path]
 	if !ok {
 		// already deleted?!
@@ -76,6 +93,11 @@ func (c *zipCache) delete(path string, trace observation.TraceLogger) {
 	}
 	// Wait for all clients using this zipFile to complete their work.
 	zf.wg.Wait()
+	if idx := zf.index.Load(); idx != nil {
+		if err := idx.close(); err != nil {
+			log.Printf("failed to close trigram index of %q: %v", path, err)
+		}
+	}
 	// Mock zipFiles have nil f. Only try to munmap and close f if it is non-nil.
 	if zf.f != nil {
 		// For now, only log errors here.
@@ -100,6 +122,10 @@ type zipFile struct {
 	Data   []byte
 	f      *os.File
 	wg     sync.WaitGroup // ensures underlying file is not munmap'd or closed while in use
+
+	// index is the trigram index of the zip file. It is nil until the index
+	// has been loaded or built.
+	index atomic.Pointer[trigramIndex]
 }
 
 func readZipFile(path string) (*zipFile, error) {
@@ -47,6 +47,8 @@ var (
 	backgroundTimeout = env.MustGetDuration("PROCESSING_TIMEOUT", 2*time.Hour, "maximum time to spend processing a repository")
 
 	maxTotalPathsLengthRaw = env.Get("MAX_TOTAL_PATHS_LENGTH", "100000", "maximum sum of lengths of all paths in a single call to git archive")
+
+	trigramIndex = env.MustGetBool("SEARCHER_TRIGRAM_INDEX", false, "build a trigram index next to each cached archive to speed up repeated searches of unindexed revisions")
 )
 
 const port = "3181"
@@ -160,6 +162,7 @@ func Start(ctx context.Context, observationCtx *observation.Context, ready servi
 			BackgroundTimeout: backgroundTimeout,
 			Log:               storeObservationCtx.Logger,
 			ObservationCtx:    storeObservationCtx,
+			TrigramIndex:      trigramIndex,
 		},
 
 		Indexed: sharedsearch.Indexed(),
@@ -68,12 +68,19 @@ func (s *store) Search(ctx context.Context, args search.SymbolsParameters) ([]re
 }
 
 func makeSearchConditions(args search.SymbolsParameters) []*sqlf.Query {
-	conditions := make([]*sqlf.Query, 0, 2+len(args.IncludePatterns))
+	conditions := make([]*sqlf.Query, 0, 3+len(args.IncludePatterns))
 	conditions = append(conditions, makeSearchCondition("name", args.Query, args.IsCaseSensitive))
 	conditions = append(conditions, negate(makeSearchCondition("path", args.ExcludePattern, args.IsCaseSensitive)))
 	for _, includePattern := range args.IncludePatterns {
 		conditions = append(conditions, makeSearchCondition("path", includePattern, args.IsCaseSensitive))
 	}
+	if len(args.IncludeKinds) > 0 {
+		kinds := make([]*sqlf.Query, 0, len(args.IncludeKinds))
+		for _, kind := range args.IncludeKinds {
+			kinds = append(kinds, sqlf.Sprintf("%s", strings.ToLower(kind)))
+		}
+		conditions = append(conditions, sqlf.Sprintf("lower(kind) IN (%s)", sqlf.Join(kinds, ",")))
+	}
 
 	filtered := conditions[:0]
 	for _, condition := range conditions {
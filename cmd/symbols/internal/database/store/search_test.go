@@ -1,6 +1,13 @@
 package store
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+)
 
 func TestIsLiteralEquality(t *testing.T) {
 	for _, test := range []struct {
@@ -101,3 +108,17 @@ func TestEscapeGlob(t *testing.T) {
 		}
 	}
 }
+
+func TestMakeSearchConditionsKinds(t *testing.T) {
+	q := sqlf.Join(makeSearchConditions(search.SymbolsParameters{
+		Query:        "^foo$",
+		IncludeKinds: []string{"func", "Method"},
+	}), "AND")
+
+	if want, got := "namelowercase = ? AND lower(kind) IN (?,?)", q.Query(sqlf.SimpleBindVar); want != got {
+		t.Errorf("unexpected query. want=%q got=%q", want, got)
+	}
+	if diff := cmp.Diff([]any{"foo", "func", "method"}, q.Args()); diff != "" {
+		t.Errorf("unexpected args (-want +got):\n%s", diff)
+	}
+}
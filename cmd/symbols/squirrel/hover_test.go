@@ -44,6 +44,16 @@ namespace Foo {
 		}
 	}
 }
+`
+
+	rust := `
+fn main() {
+	// not a comment line
+
+	// comment line 1
+	// comment line 2
+	let x = 5;
+}
 `
 
 	tests := []struct {
@@ -54,6 +64,7 @@ namespace Foo {
 		{"test.java", java, "comment line 1\ncomment line 2\n"},
 		{"test.go", golang, "comment line 1\ncomment line 2\n"},
 		{"test.cs", csharp, "comment line 1\ncomment line 2\n"},
+		{"test.rs", rust, "comment line 1\ncomment line 2\n"},
 	}
 
 	readFile := func(ctx context.Context, path types.RepoCommitPath) ([]byte, error) {
@@ -0,0 +1,386 @@
+package squirrel
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/grafana/regexp"
+	sitter "github.com/smacker/go-tree-sitter"
+	"golang.org/x/mod/modfile"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (s *SquirrelService) getDefGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier":
+		return s.getDefIdentGo(ctx, node)
+
+	case "type_identifier":
+		// Check for a type in another package, e.g. the Builder in strings.Builder
+		parent := node.Parent()
+		if parent != nil && parent.Type() == "qualified_type" {
+			pkg := parent.ChildByFieldName("package")
+			if pkg != nil && nodeId(pkg) != nodeId(node.Node) {
+				return s.getDefInPackageGo(ctx, swapNode(node, pkg), node.Content(node.Contents))
+			}
+		}
+		return s.getDefIdentGo(ctx, node)
+
+	case "package_identifier":
+		parent := node.Parent()
+		if parent != nil && parent.Type() == "import_spec" {
+			path := parent.ChildByFieldName("path")
+			if path == nil {
+				return nil, nil
+			}
+			return s.resolveImportGo(ctx, swapNode(node, getRoot(node.Node)), goStringContents(swapNode(node, path)))
+		}
+		return s.getDefInImportsGo(ctx, swapNode(node, getRoot(node.Node)), node.Content(node.Contents))
+
+	case "field_identifier":
+		// Only fields of imported packages are supported, e.g. the Println in fmt.Println
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "selector_expression" {
+			return nil, nil
+		}
+		operand := parent.ChildByFieldName("operand")
+		if operand == nil || operand.Type() != "identifier" {
+			s.breadcrumb(node, "getDefGo: expected operand of selector_expression to be an identifier")
+			return nil, nil
+		}
+		return s.getDefInPackageGo(ctx, swapNode(node, operand), node.Content(node.Contents))
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) getDefIdentGo(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	ident := node.Content(node.Contents)
+
+	cur := node.Node
+
+	for {
+		prev := cur
+		cur = cur.Parent()
+		if cur == nil {
+			s.breadcrumb(node, "getDefIdentGo: ran out of parents")
+			return nil, nil
+		}
+
+		switch cur.Type() {
+
+		case "source_file":
+			// Top-level declarations can be used before they are declared.
+			found := findNodeInScopeGo(swapNode(node, cur), nil, ident)
+			if found != nil {
+				return found, nil
+			}
+			found, err := s.getDefInImportsGo(ctx, swapNode(node, cur), ident)
+			if err != nil {
+				return nil, err
+			}
+			if found != nil {
+				return found, nil
+			}
+			return s.getDefInCurrentPackageGo(ctx, swapNode(node, cur), ident)
+
+		case "communication_case":
+			// case x := <-ch: ...
+			communication := cur.ChildByFieldName("communication")
+			if communication != nil && communication.Type() == "receive_statement" && nodeId(communication) != nodeId(prev) {
+				found := findIdentGo(swapNode(node, communication.ChildByFieldName("left")), ident)
+				if found != nil {
+					return found, nil
+				}
+			}
+			found := findNodeInScopeGo(swapNode(node, cur), prev, ident)
+			if found != nil {
+				return found, nil
+			}
+			continue
+
+		// Local declarations are only in scope after they are declared.
+		case "block", "statement_list", "expression_case", "type_case", "default_case":
+			found := findNodeInScopeGo(swapNode(node, cur), prev, ident)
+			if found != nil {
+				return found, nil
+			}
+			continue
+
+		case "function_declaration", "method_declaration", "func_literal":
+			for _, field := range []string{"receiver", "type_parameters", "parameters", "result"} {
+				params := cur.ChildByFieldName(field)
+				if params == nil || params.Type() != "parameter_list" && params.Type() != "type_parameter_list" {
+					continue
+				}
+				for _, param := range children(params) {
+					found := findIdentGo(swapNode(node, param), ident)
+					if found != nil {
+						return found, nil
+					}
+				}
+			}
+			continue
+
+		case "for_statement":
+			for _, clause := range children(cur) {
+				switch clause.Type() {
+				case "range_clause":
+					// for i, x := range xs { ... }
+					found := findIdentGo(swapNode(node, clause.ChildByFieldName("left")), ident)
+					if found != nil {
+						return found, nil
+					}
+				case "for_clause":
+					// for i := 0; i < n; i++ { ... }
+					found := findDeclaredGo(swapNode(node, clause.ChildByFieldName("initializer")), ident)
+					if found != nil {
+						return found, nil
+					}
+				}
+			}
+			continue
+
+		case "if_statement", "expression_switch_statement", "type_switch_statement":
+			// if x := f(); x { ... }
+			found := findDeclaredGo(swapNode(node, cur.ChildByFieldName("initializer")), ident)
+			if found != nil {
+				return found, nil
+			}
+			// switch x := y.(type) { ... }
+			found = findIdentGo(swapNode(node, cur.ChildByFieldName("alias")), ident)
+			if found != nil {
+				return found, nil
+			}
+			continue
+
+		// Skip all other nodes
+		default:
+			continue
+		}
+	}
+}
+
+// findNodeInScopeGo looks for a declaration of ident in the statements of the given scope. If before is
+// not nil, only the statements before it are checked.
+func findNodeInScopeGo(scope Node, before *sitter.Node, ident string) *Node {
+	var found *Node
+	for _, child := range children(scope.Node) {
+		if before != nil && nodeId(child) == nodeId(before) {
+			break
+		}
+		// Later declarations shadow earlier ones.
+		if decl := findDeclaredGo(swapNode(scope, child), ident); decl != nil {
+			found = decl
+		}
+	}
+	return found
+}
+
+// findDeclaredGo returns the name of the declaration of ident in the given statement, if any.
+func findDeclaredGo(stmt Node, ident string) *Node {
+	if stmt.Node == nil {
+		return nil
+	}
+
+	switch stmt.Type() {
+	case "short_var_declaration":
+		// x, y := ...
+		return findIdentGo(swapNode(stmt, stmt.ChildByFieldName("left")), ident)
+
+	case "var_declaration", "const_declaration", "var_spec_list":
+		// var x, y int
+		for _, spec := range children(stmt.Node) {
+			if found := findDeclaredGo(swapNode(stmt, spec), ident); found != nil {
+				return found
+			}
+		}
+		return nil
+
+	case "var_spec", "const_spec":
+		return findIdentGo(stmt, ident)
+
+	case "type_declaration":
+		// type T struct { ... }
+		for _, spec := range children(stmt.Node) {
+			name := spec.ChildByFieldName("name")
+			if name != nil && name.Content(stmt.Contents) == ident {
+				return swapNodePtr(stmt, name)
+			}
+		}
+		return nil
+
+	case "function_declaration":
+		// func f() { ... }
+		name := stmt.ChildByFieldName("name")
+		if name != nil && name.Content(stmt.Contents) == ident {
+			return swapNodePtr(stmt, name)
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// findIdentGo returns the first identifier child of the given node named ident, if any.
+func findIdentGo(node Node, ident string) *Node {
+	if node.Node == nil {
+		return nil
+	}
+	for _, child := range children(node.Node) {
+		if child.Type() == "identifier" && child.Content(node.Contents) == ident {
+			return swapNodePtr(node, child)
+		}
+	}
+	return nil
+}
+
+// getDefInPackageGo finds the definition of ident in the package named by pkg, e.g. the Println in
+// fmt.Println.
+func (s *SquirrelService) getDefInPackageGo(ctx context.Context, pkg Node, ident string) (ret *Node, err error) {
+	defer s.onCall(pkg, &Tuple{String(pkg.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	dir, err := s.getDefGo(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+	// The operand is a local variable, and types of variables aren't supported.
+	if dir == nil || dir.Node != nil {
+		return nil, nil
+	}
+	return s.symbolSearchOne(
+		ctx,
+		pkg.RepoCommitPath.Repo,
+		pkg.RepoCommitPath.Commit,
+		[]string{packageFilesPatternGo(dir.RepoCommitPath.Path)},
+		ident,
+	)
+}
+
+// getDefInImportsGo returns the directory of the package imported as ident, if it's in the same
+// repository.
+func (s *SquirrelService) getDefInImportsGo(ctx context.Context, sourceFile Node, ident string) (ret *Node, err error) {
+	defer s.onCall(sourceFile, &Tuple{String(sourceFile.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, spec := range allCaptures("(import_spec) @spec", sourceFile) {
+		pathNode := spec.ChildByFieldName("path")
+		if pathNode == nil {
+			continue
+		}
+		path := goStringContents(swapNode(sourceFile, pathNode))
+
+		name := packageNameGo(path)
+		if alias := spec.ChildByFieldName("name"); alias != nil {
+			// Dot and blank imports don't bind a name.
+			if alias.Type() != "package_identifier" {
+				continue
+			}
+			name = alias.Content(sourceFile.Contents)
+		}
+		if name != ident {
+			continue
+		}
+
+		return s.resolveImportGo(ctx, sourceFile, path)
+	}
+
+	return nil, nil
+}
+
+// getDefInCurrentPackageGo looks for ident in the other files of the package.
+func (s *SquirrelService) getDefInCurrentPackageGo(ctx context.Context, sourceFile Node, ident string) (ret *Node, err error) {
+	defer s.onCall(sourceFile, &Tuple{String(sourceFile.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	return s.symbolSearchOne(
+		ctx,
+		sourceFile.RepoCommitPath.Repo,
+		sourceFile.RepoCommitPath.Commit,
+		[]string{packageFilesPatternGo(filepath.Dir(sourceFile.RepoCommitPath.Path))},
+		ident,
+	)
+}
+
+// resolveImportGo returns the directory of the package with the given import path, if it belongs to the
+// same module as the file that imports it.
+func (s *SquirrelService) resolveImportGo(ctx context.Context, file Node, importPath string) (ret *Node, err error) {
+	defer s.onCall(file, String(importPath), lazyNodeStringer(&ret))()
+
+	moduleDir, modulePath := s.findModuleGo(ctx, file.RepoCommitPath)
+	if modulePath == "" {
+		s.breadcrumb(file, "resolveImportGo: no go.mod found")
+		return nil, nil
+	}
+
+	var dir string
+	switch {
+	case importPath == modulePath:
+		dir = moduleDir
+	case strings.HasPrefix(importPath, modulePath+"/"):
+		dir = filepath.Join(moduleDir, strings.TrimPrefix(importPath, modulePath+"/"))
+	default:
+		// The package is in another repository.
+		return nil, nil
+	}
+
+	return &Node{
+		RepoCommitPath: types.RepoCommitPath{
+			Repo:   file.RepoCommitPath.Repo,
+			Commit: file.RepoCommitPath.Commit,
+			Path:   dir,
+		},
+		Node:     nil,
+		Contents: file.Contents,
+		LangSpec: file.LangSpec,
+	}, nil
+}
+
+// findModuleGo returns the directory and path of the module containing the given file, which is declared
+// in the nearest go.mod.
+func (s *SquirrelService) findModuleGo(ctx context.Context, file types.RepoCommitPath) (dir string, modulePath string) {
+	for cur := filepath.Dir(file.Path); ; cur = filepath.Dir(cur) {
+		contents, err := s.readFile(ctx, types.RepoCommitPath{
+			Repo:   file.Repo,
+			Commit: file.Commit,
+			Path:   filepath.Join(cur, "go.mod"),
+		})
+		if err == nil {
+			return cur, modfile.ModulePath(contents)
+		}
+		if cur == "." || cur == "/" {
+			return "", ""
+		}
+	}
+}
+
+// packageFilesPatternGo returns a pattern matching the files of the package in the given directory.
+func packageFilesPatternGo(dir string) string {
+	if dir == "." || dir == "" {
+		return `^[^/]+\.go$`
+	}
+	return "^" + regexp.QuoteMeta(dir) + `/[^/]+\.go$`
+}
+
+// packageNameGo guesses the name of a package from its import path, skipping major version suffixes.
+func packageNameGo(importPath string) string {
+	components := strings.Split(importPath, "/")
+	name := components[len(components)-1]
+	if len(components) > 1 && majorVersionRegexGo.MatchString(name) {
+		name = components[len(components)-2]
+	}
+	return name
+}
+
+var majorVersionRegexGo = regexp.MustCompile(`^v[0-9]+$`)
+
+func goStringContents(node Node) string {
+	return strings.Trim(node.Content(node.Contents), "\"`")
+}
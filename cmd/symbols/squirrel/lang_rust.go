@@ -0,0 +1,704 @@
+package squirrel
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	sitter "github.com/smacker/go-tree-sitter"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (s *SquirrelService) getDefRust(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier":
+		parent := node.Parent()
+		if parent == nil {
+			return nil, nil
+		}
+
+		// Names in use declarations refer to paths, e.g. the Bar in use crate::foo::{Bar}
+		if hasAncestor(node.Node, "use_declaration") {
+			segments := useSegmentsRust(node)
+			if segments == nil {
+				return nil, nil
+			}
+			return s.resolvePathRust(ctx, swapNode(node, getRoot(node.Node)), segments, true)
+		}
+
+		// Names at the end of a path, e.g. the bar in foo::bar()
+		if parent.Type() == "scoped_identifier" || parent.Type() == "scoped_type_identifier" {
+			name := parent.ChildByFieldName("name")
+			if name != nil && nodeId(name) == nodeId(node.Node) {
+				segments := pathSegmentsRust(swapNode(node, parent))
+				if segments == nil {
+					return nil, nil
+				}
+				return s.resolvePathRust(ctx, swapNode(node, getRoot(node.Node)), segments, true)
+			}
+		}
+
+		return s.getDefIdentRust(ctx, node)
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) getDefIdentRust(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	ident := node.Content(node.Contents)
+
+	cur := node.Node
+
+	for {
+		prev := cur
+		cur = cur.Parent()
+		if cur == nil {
+			s.breadcrumb(node, "getDefIdentRust: ran out of parents")
+			return nil, nil
+		}
+
+		switch cur.Type() {
+
+		case "source_file", "declaration_list":
+			// Items and imports of a module can be used anywhere in it.
+			if cur.Type() == "declaration_list" {
+				parent := cur.Parent()
+				if parent == nil || parent.Type() != "mod_item" {
+					continue
+				}
+			}
+			module := moduleRust{node: swapNode(node, cur), dir: moduleDirRust(swapNode(node, cur))}
+			return s.lookupInModuleRust(ctx, module, ident, true)
+
+		case "block":
+			// Local variables are only in scope after they are declared, and later ones shadow earlier ones.
+			for sibling := prev.PrevNamedSibling(); sibling != nil; sibling = sibling.PrevNamedSibling() {
+				if sibling.Type() != "let_declaration" {
+					continue
+				}
+				if found := findPatternRust(swapNode(node, sibling.ChildByFieldName("pattern")), ident); found != nil {
+					return found, nil
+				}
+			}
+			// Items can be used anywhere in the block.
+			for _, child := range children(cur) {
+				if found := findItemRust(swapNode(node, child), ident); found != nil {
+					return found, nil
+				}
+			}
+			continue
+
+		case "function_item":
+			// fn f(x: i32) { ... }
+			for _, param := range children(cur.ChildByFieldName("parameters")) {
+				if param.Type() != "parameter" {
+					continue
+				}
+				if found := findPatternRust(swapNode(node, param.ChildByFieldName("pattern")), ident); found != nil {
+					return found, nil
+				}
+			}
+			if found := findTypeParameterRust(swapNode(node, cur), ident); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "closure_expression":
+			// |x, y: i32| ...
+			for _, param := range children(cur.ChildByFieldName("parameters")) {
+				if param.Type() == "parameter" {
+					param = param.ChildByFieldName("pattern")
+				}
+				if found := findPatternRust(swapNode(node, param), ident); found != nil {
+					return found, nil
+				}
+			}
+			continue
+
+		case "for_expression":
+			// for x in xs { ... }
+			if value := cur.ChildByFieldName("value"); value != nil && nodeId(value) == nodeId(prev) {
+				continue
+			}
+			if found := findPatternRust(swapNode(node, cur.ChildByFieldName("pattern")), ident); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "match_arm":
+			// Some(x) => ...
+			if found := findPatternRust(swapNode(node, cur.ChildByFieldName("pattern")), ident); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "if_let_expression", "while_let_expression":
+			// if let Some(x) = y { ... }
+			if value := cur.ChildByFieldName("value"); value != nil && nodeId(value) == nodeId(prev) {
+				continue
+			}
+			if found := findPatternRust(swapNode(node, cur.ChildByFieldName("pattern")), ident); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "if_expression", "while_expression":
+			// Newer versions of the grammar represent if let as a let_condition.
+			condition := cur.ChildByFieldName("condition")
+			if condition == nil || nodeId(condition) == nodeId(prev) {
+				continue
+			}
+			conditions := []*sitter.Node{condition}
+			if condition.Type() == "let_chain" {
+				conditions = children(condition)
+			}
+			for _, condition := range conditions {
+				if condition.Type() != "let_condition" {
+					continue
+				}
+				if found := findPatternRust(swapNode(node, condition.ChildByFieldName("pattern")), ident); found != nil {
+					return found, nil
+				}
+			}
+			continue
+
+		case "impl_item", "trait_item", "struct_item", "enum_item", "union_item", "type_item":
+			if found := findTypeParameterRust(swapNode(node, cur), ident); found != nil {
+				return found, nil
+			}
+			continue
+
+		// Skip all other nodes
+		default:
+			continue
+		}
+	}
+}
+
+// moduleRust is a module, which is either a file or an inline mod item.
+type moduleRust struct {
+	// node is the source_file, or the declaration_list of an inline mod item.
+	node Node
+	// dir is the directory of the files of submodules.
+	dir string
+}
+
+// moduleDirRust returns the directory that contains the files of submodules of the given module.
+func moduleDirRust(module Node) string {
+	path := module.RepoCommitPath.Path
+
+	var dir string
+	switch filepath.Base(path) {
+	case "lib.rs", "main.rs", "mod.rs":
+		dir = filepath.Dir(path)
+	default:
+		dir = path[:len(path)-len(filepath.Ext(path))]
+	}
+
+	// Inline modules nest in the directory of the file.
+	names := []string{}
+	for cur := module.Node; cur != nil; cur = cur.Parent() {
+		if cur.Type() != "mod_item" {
+			continue
+		}
+		name := cur.ChildByFieldName("name")
+		if name == nil {
+			continue
+		}
+		names = append([]string{name.Content(module.Contents)}, names...)
+	}
+	return filepath.Join(append([]string{dir}, names...)...)
+}
+
+// loadModuleRust parses the file of the module whose submodules are in dir.
+func (s *SquirrelService) loadModuleRust(ctx context.Context, from Node, dir string) *moduleRust {
+	for _, candidate := range []string{dir + ".rs", filepath.Join(dir, "mod.rs"), filepath.Join(dir, "lib.rs"), filepath.Join(dir, "main.rs")} {
+		root, err := s.parse(ctx, types.RepoCommitPath{
+			Repo:   from.RepoCommitPath.Repo,
+			Commit: from.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if err != nil {
+			continue
+		}
+		return &moduleRust{node: *root, dir: dir}
+	}
+	return nil
+}
+
+// findCrateRootRust returns the directory of the root module of the crate containing the given file,
+// which is the nearest directory with a lib.rs or main.rs.
+func (s *SquirrelService) findCrateRootRust(ctx context.Context, file types.RepoCommitPath) (string, bool) {
+	for dir := filepath.Dir(file.Path); ; dir = filepath.Dir(dir) {
+		for _, name := range []string{"lib.rs", "main.rs"} {
+			_, err := s.readFile(ctx, types.RepoCommitPath{
+				Repo:   file.Repo,
+				Commit: file.Commit,
+				Path:   filepath.Join(dir, name),
+			})
+			if err == nil {
+				return dir, true
+			}
+		}
+		if dir == "." || dir == "/" {
+			return "", false
+		}
+	}
+}
+
+// resolvePathRust finds the item or module a path refers to, e.g. crate::foo::Bar. Paths to other crates
+// are not resolved. Glob imports are only followed if followGlobs is true, which avoids cycles between
+// modules that glob import each other.
+func (s *SquirrelService) resolvePathRust(ctx context.Context, from Node, segments []string, followGlobs bool) (ret *Node, err error) {
+	defer s.onCall(from, String(filepath.Join(segments...)), lazyNodeStringer(&ret))()
+
+	if len(segments) == 0 {
+		return nil, nil
+	}
+
+	current := moduleRust{node: from, dir: moduleDirRust(from)}
+
+	var module *moduleRust
+	switch segments[0] {
+	case "crate":
+		root, ok := s.findCrateRootRust(ctx, from.RepoCommitPath)
+		if !ok {
+			s.breadcrumb(from, "resolvePathRust: could not find crate root")
+			return nil, nil
+		}
+		module = s.loadModuleRust(ctx, from, root)
+		segments = segments[1:]
+	case "self":
+		module = &current
+		segments = segments[1:]
+	case "super":
+		dir := current.dir
+		for len(segments) > 0 && segments[0] == "super" {
+			dir = filepath.Dir(dir)
+			segments = segments[1:]
+		}
+		module = s.loadModuleRust(ctx, from, dir)
+	default:
+		// The path starts with a submodule or an import. Imports are expanded to the path they refer to,
+		// unless it's in another crate.
+		if !hasItemRust(current.node, segments[0]) {
+			for _, binding := range useBindingsInModuleRust(current.node) {
+				if binding.name == nil || binding.name.Content(current.node.Contents) != segments[0] {
+					continue
+				}
+				switch binding.segments[0] {
+				case "crate", "self", "super":
+					expanded := append(append([]string{}, binding.segments...), segments[1:]...)
+					return s.resolvePathRust(ctx, from, expanded, followGlobs)
+				}
+			}
+			return nil, nil
+		}
+		module = &current
+	}
+	if module == nil {
+		return nil, nil
+	}
+	if len(segments) == 0 {
+		return &module.node, nil
+	}
+
+	for i, segment := range segments {
+		if i == len(segments)-1 {
+			return s.lookupInModuleRust(ctx, *module, segment, followGlobs)
+		}
+		module, err = s.lookupModuleInModuleRust(ctx, *module, segment, followGlobs)
+		if err != nil {
+			return nil, err
+		}
+		if module == nil {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// lookupInModuleRust finds the item, submodule, or import named name in the given module. Imports of
+// items in the same crate resolve to the item, and other imports resolve to the imported name.
+func (s *SquirrelService) lookupInModuleRust(ctx context.Context, module moduleRust, name string, followGlobs bool) (ret *Node, err error) {
+	defer s.onCall(module.node, &Tuple{String(module.node.Type()), String(name)}, lazyNodeStringer(&ret))()
+
+	for _, child := range children(module.node.Node) {
+		if child.Type() == "mod_item" {
+			// A mod item without a body refers to a file, and the file is more useful than the declaration.
+			modName := child.ChildByFieldName("name")
+			if modName == nil || modName.Content(module.node.Contents) != name {
+				continue
+			}
+			if child.ChildByFieldName("body") == nil {
+				if sub := s.loadModuleRust(ctx, module.node, filepath.Join(module.dir, name)); sub != nil {
+					return &sub.node, nil
+				}
+			}
+			return swapNodePtr(module.node, modName), nil
+		}
+		if found := findItemRust(swapNode(module.node, child), name); found != nil {
+			return found, nil
+		}
+	}
+
+	// Then check imports, except for globs.
+	for _, binding := range useBindingsInModuleRust(module.node) {
+		if binding.name == nil || binding.name.Content(module.node.Contents) != name {
+			continue
+		}
+		found, err := s.resolvePathRust(ctx, module.node, binding.segments, followGlobs)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+		return swapNodePtr(module.node, binding.name), nil
+	}
+
+	// Finally, check glob imports, e.g. use super::*
+	if !followGlobs {
+		return nil, nil
+	}
+	for _, binding := range useBindingsInModuleRust(module.node) {
+		if binding.name != nil {
+			continue
+		}
+		found, err := s.resolvePathRust(ctx, module.node, append(binding.segments, name), false)
+		if err != nil {
+			return nil, err
+		}
+		if found != nil {
+			return found, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// lookupModuleInModuleRust finds the submodule named name in the given module, including modules that
+// are imported into it.
+func (s *SquirrelService) lookupModuleInModuleRust(ctx context.Context, module moduleRust, name string, followGlobs bool) (ret *moduleRust, err error) {
+	defer s.onCall(module.node, &Tuple{String(module.node.Type()), String(name)}, func() fmt.Stringer {
+		if ret == nil {
+			return String("<nil>")
+		}
+		return String(ret.dir)
+	})()
+
+	found, err := s.lookupInModuleRust(ctx, module, name, followGlobs)
+	if err != nil || found == nil {
+		return nil, err
+	}
+
+	switch found.Type() {
+	case "source_file":
+		return &moduleRust{node: *found, dir: moduleDirRust(*found)}, nil
+	case "identifier":
+		// An inline module: mod foo { ... }
+		item := found.Parent()
+		if item == nil || item.Type() != "mod_item" {
+			return nil, nil
+		}
+		body := item.ChildByFieldName("body")
+		if body == nil {
+			return nil, nil
+		}
+		body2 := swapNode(*found, body)
+		return &moduleRust{node: body2, dir: moduleDirRust(body2)}, nil
+	default:
+		return nil, nil
+	}
+}
+
+// hasItemRust returns true if the module has an item or submodule named ident.
+func hasItemRust(module Node, ident string) bool {
+	for _, child := range children(module.Node) {
+		if findItemRust(swapNode(module, child), ident) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// findItemRust returns the name of the given item if it's named ident.
+func findItemRust(item Node, ident string) *Node {
+	switch item.Type() {
+	case "function_item", "function_signature_item", "struct_item", "enum_item", "union_item", "type_item",
+		"trait_item", "const_item", "static_item", "mod_item", "macro_definition":
+		name := item.ChildByFieldName("name")
+		if name != nil && name.Content(item.Contents) == ident {
+			return swapNodePtr(item, name)
+		}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// findTypeParameterRust looks for a type parameter named ident of the given item.
+func findTypeParameterRust(item Node, ident string) *Node {
+	for _, param := range children(item.ChildByFieldName("type_parameters")) {
+		name := param
+		if param.Type() == "constrained_type_parameter" {
+			name = param.ChildByFieldName("left")
+		}
+		if name != nil && name.Type() == "type_identifier" && name.Content(item.Contents) == ident {
+			return swapNodePtr(item, name)
+		}
+	}
+	return nil
+}
+
+// findPatternRust returns the identifier named ident bound by the given pattern, if any.
+func findPatternRust(pattern Node, ident string) *Node {
+	if pattern.Node == nil {
+		return nil
+	}
+
+	switch pattern.Type() {
+	case "identifier", "shorthand_field_identifier":
+		// x
+		if pattern.Content(pattern.Contents) == ident {
+			return &pattern
+		}
+		return nil
+
+	case "tuple_struct_pattern":
+		// Some(x), but not the Some
+		ty := pattern.ChildByFieldName("type")
+		for _, child := range children(pattern.Node) {
+			if ty != nil && nodeId(child) == nodeId(ty) {
+				continue
+			}
+			if found := findPatternRust(swapNode(pattern, child), ident); found != nil {
+				return found
+			}
+		}
+		return nil
+
+	case "struct_pattern":
+		// Foo { x, y: z }
+		for _, child := range children(pattern.Node) {
+			if child.Type() != "field_pattern" {
+				continue
+			}
+			if found := findPatternRust(swapNode(pattern, child), ident); found != nil {
+				return found
+			}
+		}
+		return nil
+
+	case "field_pattern":
+		if inner := pattern.ChildByFieldName("pattern"); inner != nil {
+			return findPatternRust(swapNode(pattern, inner), ident)
+		}
+		return findPatternRust(swapNode(pattern, pattern.ChildByFieldName("name")), ident)
+
+	case "match_pattern":
+		// x if x > 0, but not the guard
+		if pattern.NamedChildCount() == 0 {
+			return nil
+		}
+		return findPatternRust(swapNode(pattern, pattern.NamedChild(0)), ident)
+
+	case "tuple_pattern", "slice_pattern", "mut_pattern", "ref_pattern", "reference_pattern", "captured_pattern",
+		"or_pattern":
+		// (x, y), [x, y], mut x, ref x, &x, x @ 1..=5, A(x) | B(x)
+		for _, child := range children(pattern.Node) {
+			if found := findPatternRust(swapNode(pattern, child), ident); found != nil {
+				return found
+			}
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// useBindingRust is a name brought into scope by a use declaration.
+type useBindingRust struct {
+	// name is the name in scope, or nil for glob imports.
+	name *sitter.Node
+	// segments is the path of the imported item, or of the module for glob imports.
+	segments []string
+}
+
+// useBindingsInModuleRust returns the names imported by the use declarations of the given module.
+func useBindingsInModuleRust(module Node) []useBindingRust {
+	bindings := []useBindingRust{}
+	for _, child := range children(module.Node) {
+		if child.Type() != "use_declaration" {
+			continue
+		}
+		bindings = append(bindings, useBindingsRust(swapNode(module, child.ChildByFieldName("argument")), nil)...)
+	}
+	return bindings
+}
+
+// useBindingsRust returns the names imported by the given use tree, whose paths start with prefix.
+func useBindingsRust(tree Node, prefix []string) []useBindingRust {
+	if tree.Node == nil {
+		return nil
+	}
+
+	join := func(segments []string) []string {
+		return append(append([]string{}, prefix...), segments...)
+	}
+
+	switch tree.Type() {
+	case "identifier":
+		// use foo;
+		return []useBindingRust{{name: tree.Node, segments: join([]string{tree.Content(tree.Contents)})}}
+
+	case "self":
+		// use foo::{self};
+		if len(prefix) == 0 {
+			return nil
+		}
+		return []useBindingRust{{name: tree.Node, segments: join(nil)}}
+
+	case "scoped_identifier":
+		// use foo::bar;
+		segments := pathSegmentsRust(tree)
+		name := tree.ChildByFieldName("name")
+		if segments == nil || name == nil {
+			return nil
+		}
+		return []useBindingRust{{name: name, segments: join(segments)}}
+
+	case "use_as_clause":
+		// use foo::bar as baz;
+		segments := pathSegmentsRust(swapNode(tree, tree.ChildByFieldName("path")))
+		alias := tree.ChildByFieldName("alias")
+		if segments == nil || alias == nil {
+			return nil
+		}
+		return []useBindingRust{{name: alias, segments: join(segments)}}
+
+	case "use_list":
+		// use {foo, bar};
+		bindings := []useBindingRust{}
+		for _, child := range children(tree.Node) {
+			bindings = append(bindings, useBindingsRust(swapNode(tree, child), prefix)...)
+		}
+		return bindings
+
+	case "scoped_use_list":
+		// use foo::{bar, baz};
+		path := tree.ChildByFieldName("path")
+		segments := []string{}
+		if path != nil {
+			segments = pathSegmentsRust(swapNode(tree, path))
+			if segments == nil {
+				return nil
+			}
+		}
+		return useBindingsRust(swapNode(tree, tree.ChildByFieldName("list")), join(segments))
+
+	case "use_wildcard":
+		// use foo::*;
+		for _, child := range children(tree.Node) {
+			segments := pathSegmentsRust(swapNode(tree, child))
+			if segments == nil {
+				return nil
+			}
+			return []useBindingRust{{name: nil, segments: join(segments)}}
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// useSegmentsRust returns the path referred to by the given name in a use declaration, e.g. crate::foo::Bar
+// for the Bar in use crate::foo::{Bar}.
+func useSegmentsRust(node Node) []string {
+	var segments []string
+
+	isField := func(parent *sitter.Node, field string) bool {
+		child := parent.ChildByFieldName(field)
+		return child != nil && nodeId(child) == nodeId(node.Node)
+	}
+
+	parent := node.Parent()
+	switch {
+	case parent != nil && (parent.Type() == "scoped_identifier" || parent.Type() == "scoped_type_identifier") && isField(parent, "name"):
+		segments = pathSegmentsRust(swapNode(node, parent))
+	case parent != nil && parent.Type() == "use_as_clause" && isField(parent, "alias"):
+		segments = pathSegmentsRust(swapNode(node, parent.ChildByFieldName("path")))
+	default:
+		segments = []string{node.Content(node.Contents)}
+	}
+	if segments == nil {
+		return nil
+	}
+
+	// Prepend the paths of enclosing use lists.
+	prev := node.Node
+	for cur := node.Parent(); cur != nil && cur.Type() != "use_declaration"; cur = cur.Parent() {
+		if cur.Type() == "scoped_use_list" {
+			list := cur.ChildByFieldName("list")
+			path := cur.ChildByFieldName("path")
+			if list != nil && nodeId(list) == nodeId(prev) && path != nil {
+				prefix := pathSegmentsRust(swapNode(node, path))
+				if prefix == nil {
+					return nil
+				}
+				segments = append(prefix, segments...)
+			}
+		}
+		prev = cur
+	}
+
+	return segments
+}
+
+// pathSegmentsRust returns the segments of a simple path like crate::foo::bar, or nil if the path has
+// generics or other unsupported parts.
+func pathSegmentsRust(path Node) []string {
+	if path.Node == nil {
+		return nil
+	}
+
+	switch path.Type() {
+	case "identifier", "type_identifier", "crate", "self", "super":
+		return []string{path.Content(path.Contents)}
+	case "scoped_identifier", "scoped_type_identifier":
+		name := path.ChildByFieldName("name")
+		if name == nil {
+			return nil
+		}
+		prefix := []string{}
+		if inner := path.ChildByFieldName("path"); inner != nil {
+			prefix = pathSegmentsRust(swapNode(path, inner))
+			if prefix == nil {
+				return nil
+			}
+		}
+		return append(prefix, name.Content(path.Contents))
+	default:
+		return nil
+	}
+}
+
+// hasAncestor returns true if the node has an ancestor of the given type.
+func hasAncestor(node *sitter.Node, ty string) bool {
+	for cur := node.Parent(); cur != nil; cur = cur.Parent() {
+		if cur.Type() == ty {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,467 @@
+package squirrel
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// getDefTypeScript finds definitions in TypeScript and JavaScript, which share most of their syntax.
+func (s *SquirrelService) getDefTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	switch node.Type() {
+	case "identifier", "type_identifier", "shorthand_property_identifier":
+		return s.getDefIdentTypeScript(ctx, node)
+
+	case "property_identifier":
+		// Only properties of namespace imports are supported, e.g. the f in ns.f
+		parent := node.Parent()
+		if parent == nil || parent.Type() != "member_expression" {
+			return nil, nil
+		}
+		object := parent.ChildByFieldName("object")
+		if object == nil || object.Type() != "identifier" {
+			s.breadcrumb(node, "getDefTypeScript: expected object of member_expression to be an identifier")
+			return nil, nil
+		}
+		module, err := s.getDefIdentTypeScript(ctx, swapNode(node, object))
+		if err != nil {
+			return nil, err
+		}
+		if module == nil || module.Type() != "program" {
+			return nil, nil
+		}
+		return s.findExportTypeScript(*module, node.Content(node.Contents)), nil
+
+	// No other nodes have a definition
+	default:
+		return nil, nil
+	}
+}
+
+func (s *SquirrelService) getDefIdentTypeScript(ctx context.Context, node Node) (ret *Node, err error) {
+	defer s.onCall(node, String(node.Type()), lazyNodeStringer(&ret))()
+
+	ident := node.Content(node.Contents)
+
+	cur := node.Node
+
+	for {
+		prev := cur
+		cur = cur.Parent()
+		if cur == nil {
+			s.breadcrumb(node, "getDefIdentTypeScript: ran out of parents")
+			return nil, nil
+		}
+
+		switch cur.Type() {
+
+		case "program":
+			found := findNodeInScopeTypeScript(swapNode(node, cur), ident)
+			if found != nil {
+				return found, nil
+			}
+			return s.getDefInImportsTypeScript(ctx, swapNode(node, cur), ident)
+
+		// Function declarations and var are hoisted, so all statements of a block are checked.
+		case "statement_block", "switch_case", "switch_default":
+			found := findNodeInScopeTypeScript(swapNode(node, cur), ident)
+			if found != nil {
+				return found, nil
+			}
+			continue
+
+		case "function", "function_declaration", "generator_function", "generator_function_declaration", "method_definition", "arrow_function":
+			// A function expression can refer to itself by name.
+			if cur.Type() == "function" || cur.Type() == "generator_function" {
+				name := cur.ChildByFieldName("name")
+				if name != nil && name.Content(node.Contents) == ident {
+					return swapNodePtr(node, name), nil
+				}
+			}
+			// x => ...
+			if parameter := cur.ChildByFieldName("parameter"); parameter != nil {
+				if found := findPatternTypeScript(swapNode(node, parameter), ident); found != nil {
+					return found, nil
+				}
+			}
+			// function f(x, y) { ... }
+			for _, param := range children(cur.ChildByFieldName("parameters")) {
+				if found := findPatternTypeScript(swapNode(node, param), ident); found != nil {
+					return found, nil
+				}
+			}
+			if found := findTypeParameterTypeScript(swapNode(node, cur), ident); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "class_declaration", "class", "abstract_class_declaration", "interface_declaration", "type_alias_declaration":
+			if found := findTypeParameterTypeScript(swapNode(node, cur), ident); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "for_statement":
+			// for (let i = 0; ...) ...
+			initializer := cur.ChildByFieldName("initializer")
+			if initializer == nil || nodeId(initializer) == nodeId(prev) {
+				continue
+			}
+			if found := findDeclaredTypeScript(swapNode(node, initializer), ident); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "for_in_statement":
+			// for (const x of xs) ...
+			left := cur.ChildByFieldName("left")
+			if left == nil || nodeId(left) == nodeId(prev) {
+				continue
+			}
+			if found := findPatternTypeScript(swapNode(node, left), ident); found != nil {
+				return found, nil
+			}
+			continue
+
+		case "catch_clause":
+			// catch (e) ...
+			parameter := cur.ChildByFieldName("parameter")
+			if parameter == nil {
+				continue
+			}
+			if found := findPatternTypeScript(swapNode(node, parameter), ident); found != nil {
+				return found, nil
+			}
+			continue
+
+		// Skip all other nodes
+		default:
+			continue
+		}
+	}
+}
+
+// findNodeInScopeTypeScript looks for a declaration of ident in the statements of the given scope.
+func findNodeInScopeTypeScript(scope Node, ident string) *Node {
+	for _, child := range children(scope.Node) {
+		if found := findDeclaredTypeScript(swapNode(scope, child), ident); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// findDeclaredTypeScript returns the name of the declaration of ident in the given statement, if any.
+func findDeclaredTypeScript(stmt Node, ident string) *Node {
+	switch stmt.Type() {
+	case "lexical_declaration", "variable_declaration":
+		// const x = ...
+		for _, declarator := range children(stmt.Node) {
+			if declarator.Type() != "variable_declarator" {
+				continue
+			}
+			if found := findPatternTypeScript(swapNode(stmt, declarator.ChildByFieldName("name")), ident); found != nil {
+				return found
+			}
+		}
+		return nil
+
+	case "function_declaration", "generator_function_declaration", "class_declaration", "abstract_class_declaration",
+		"interface_declaration", "type_alias_declaration", "enum_declaration":
+		// function f() { ... }
+		name := stmt.ChildByFieldName("name")
+		if name != nil && name.Content(stmt.Contents) == ident {
+			return swapNodePtr(stmt, name)
+		}
+		return nil
+
+	case "export_statement":
+		// export const x = ...
+		declaration := stmt.ChildByFieldName("declaration")
+		if declaration == nil {
+			return nil
+		}
+		return findDeclaredTypeScript(swapNode(stmt, declaration), ident)
+
+	default:
+		return nil
+	}
+}
+
+// findPatternTypeScript returns the identifier named ident bound by the given pattern or parameter, if
+// any.
+func findPatternTypeScript(pattern Node, ident string) *Node {
+	if pattern.Node == nil {
+		return nil
+	}
+
+	switch pattern.Type() {
+	case "identifier", "shorthand_property_identifier_pattern", "shorthand_property_identifier":
+		// x
+		if pattern.Content(pattern.Contents) == ident {
+			return &pattern
+		}
+		return nil
+
+	case "required_parameter", "optional_parameter":
+		// x?: number
+		inner := pattern.ChildByFieldName("pattern")
+		if inner == nil {
+			for _, child := range children(pattern.Node) {
+				if child.Type() != "accessibility_modifier" {
+					inner = child
+					break
+				}
+			}
+		}
+		if inner == nil {
+			return nil
+		}
+		return findPatternTypeScript(swapNode(pattern, inner), ident)
+
+	case "assignment_pattern", "object_assignment_pattern":
+		// x = 5
+		return findPatternTypeScript(swapNode(pattern, pattern.ChildByFieldName("left")), ident)
+
+	case "pair_pattern":
+		// { key: x }
+		return findPatternTypeScript(swapNode(pattern, pattern.ChildByFieldName("value")), ident)
+
+	case "rest_pattern", "object_pattern", "array_pattern":
+		// ...x, { x, y }, [x, y]
+		for _, child := range children(pattern.Node) {
+			if found := findPatternTypeScript(swapNode(pattern, child), ident); found != nil {
+				return found
+			}
+		}
+		return nil
+
+	default:
+		return nil
+	}
+}
+
+// findTypeParameterTypeScript looks for a type parameter named ident of the given declaration.
+func findTypeParameterTypeScript(decl Node, ident string) *Node {
+	for _, param := range children(decl.ChildByFieldName("type_parameters")) {
+		name := param.ChildByFieldName("name")
+		if name != nil && name.Content(decl.Contents) == ident {
+			return swapNodePtr(decl, name)
+		}
+	}
+	return nil
+}
+
+// getDefInImportsTypeScript looks for ident in the imports of the program. Names imported from modules
+// in the same repository resolve to their exported declaration, and other imports resolve to the
+// imported name.
+func (s *SquirrelService) getDefInImportsTypeScript(ctx context.Context, program Node, ident string) (ret *Node, err error) {
+	defer s.onCall(program, &Tuple{String(program.Type()), String(ident)}, lazyNodeStringer(&ret))()
+
+	for _, stmt := range children(program.Node) {
+		if stmt.Type() != "import_statement" {
+			continue
+		}
+		source := stmt.ChildByFieldName("source")
+		if source == nil {
+			continue
+		}
+
+		for _, clause := range children(stmt) {
+			if clause.Type() != "import_clause" {
+				continue
+			}
+
+			for _, binding := range children(clause) {
+				switch binding.Type() {
+				case "identifier":
+					// import f from "./f"
+					if binding.Content(program.Contents) != ident {
+						continue
+					}
+					module := s.resolveModuleTypeScript(ctx, program, stringContentsTypeScript(swapNode(program, source)))
+					if module != nil {
+						if found := s.findExportTypeScript(*module, "default"); found != nil {
+							return found, nil
+						}
+					}
+					return swapNodePtr(program, binding), nil
+
+				case "namespace_import":
+					// import * as ns from "./ns"
+					for _, name := range children(binding) {
+						if name.Type() != "identifier" || name.Content(program.Contents) != ident {
+							continue
+						}
+						module := s.resolveModuleTypeScript(ctx, program, stringContentsTypeScript(swapNode(program, source)))
+						if module != nil {
+							return module, nil
+						}
+						return swapNodePtr(program, name), nil
+					}
+
+				case "named_imports":
+					// import { f, g as h } from "./f"
+					for _, specifier := range children(binding) {
+						if specifier.Type() != "import_specifier" {
+							continue
+						}
+						name := specifier.ChildByFieldName("name")
+						if name == nil {
+							continue
+						}
+						local := name
+						if alias := specifier.ChildByFieldName("alias"); alias != nil {
+							local = alias
+						}
+						if local.Content(program.Contents) != ident {
+							continue
+						}
+						module := s.resolveModuleTypeScript(ctx, program, stringContentsTypeScript(swapNode(program, source)))
+						if module != nil {
+							if found := s.findExportTypeScript(*module, name.Content(program.Contents)); found != nil {
+								return found, nil
+							}
+						}
+						return swapNodePtr(program, local), nil
+					}
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// findExportTypeScript returns the declaration exported as name from the given module, or the default
+// export if name is "default".
+func (s *SquirrelService) findExportTypeScript(module Node, name string) (ret *Node) {
+	defer s.onCall(module, &Tuple{String(module.Type()), String(name)}, lazyNodeStringer(&ret))()
+
+	for _, stmt := range children(module.Node) {
+		if stmt.Type() != "export_statement" {
+			continue
+		}
+
+		isDefault := false
+		for i := 0; i < int(stmt.ChildCount()); i++ {
+			if stmt.Child(i).Type() == "default" {
+				isDefault = true
+			}
+		}
+
+		declaration := stmt.ChildByFieldName("declaration")
+
+		if name == "default" {
+			if !isDefault {
+				continue
+			}
+			// export default function f() { ... }
+			if declaration != nil {
+				if declName := declaration.ChildByFieldName("name"); declName != nil {
+					return swapNodePtr(module, declName)
+				}
+				return swapNodePtr(module, declaration)
+			}
+			value := stmt.ChildByFieldName("value")
+			if value != nil {
+				// export default f
+				if value.Type() == "identifier" {
+					if found := findNodeInScopeTypeScript(module, value.Content(module.Contents)); found != nil {
+						return found
+					}
+				}
+				// export default class C { ... }
+				if valueName := value.ChildByFieldName("name"); valueName != nil {
+					return swapNodePtr(module, valueName)
+				}
+			}
+			return swapNodePtr(module, stmt)
+		}
+
+		if isDefault {
+			continue
+		}
+
+		// export function f() { ... }
+		if declaration != nil {
+			if found := findDeclaredTypeScript(swapNode(module, declaration), name); found != nil {
+				return found
+			}
+			continue
+		}
+
+		// export { f, g as h }
+		for _, clause := range children(stmt) {
+			if clause.Type() != "export_clause" {
+				continue
+			}
+			for _, specifier := range children(clause) {
+				specifierName := specifier.ChildByFieldName("name")
+				if specifierName == nil {
+					continue
+				}
+				exported := specifierName
+				if alias := specifier.ChildByFieldName("alias"); alias != nil {
+					exported = alias
+				}
+				if exported.Content(module.Contents) != name {
+					continue
+				}
+				if found := findNodeInScopeTypeScript(module, specifierName.Content(module.Contents)); found != nil {
+					return found
+				}
+				return swapNodePtr(module, specifierName)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveModuleTypeScript parses the module imported with the given specifier. Only relative imports
+// are resolved, since other modules aren't in the same repository.
+func (s *SquirrelService) resolveModuleTypeScript(ctx context.Context, from Node, specifier string) *Node {
+	if !strings.HasPrefix(specifier, "./") && !strings.HasPrefix(specifier, "../") {
+		return nil
+	}
+
+	base := filepath.Join(filepath.Dir(from.RepoCommitPath.Path), specifier)
+
+	candidates := []string{base}
+	// TypeScript allows importing a .ts file with a .js extension.
+	if ext := filepath.Ext(base); ext == ".js" || ext == ".jsx" {
+		candidates = append(candidates, strings.TrimSuffix(base, ext)+".ts", strings.TrimSuffix(base, ext)+".tsx")
+	}
+	for _, ext := range moduleExtensionsTypeScript {
+		candidates = append(candidates, base+ext)
+	}
+	for _, ext := range moduleExtensionsTypeScript {
+		candidates = append(candidates, filepath.Join(base, "index"+ext))
+	}
+
+	for _, candidate := range candidates {
+		module, err := s.parse(ctx, types.RepoCommitPath{
+			Repo:   from.RepoCommitPath.Repo,
+			Commit: from.RepoCommitPath.Commit,
+			Path:   candidate,
+		})
+		if err != nil {
+			continue
+		}
+		return module
+	}
+
+	s.breadcrumb(from, "resolveModuleTypeScript: could not find module "+specifier)
+	return nil
+}
+
+var moduleExtensionsTypeScript = []string{".ts", ".tsx", ".d.ts", ".js", ".jsx", ".mjs", ".cjs"}
+
+func stringContentsTypeScript(node Node) string {
+	return strings.Trim(node.Content(node.Contents), "\"'`")
+}
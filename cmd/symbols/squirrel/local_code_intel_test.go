@@ -333,6 +333,51 @@ def f(p1, p2 = 3, *p3, **p4)
 		puts e
 	end
 end
+`}, {
+		path: "test.rs",
+		contents: `
+//   vv f.p1 def
+//   vv f.p1 ref
+//            vv f.p2 def
+//            vv f.p2 ref
+fn f(p1: i32, p2: (i32, i32)) {
+	//  v f.x def
+	//  v f.x ref
+	//      vv f.p1 ref
+	let x = p1;
+
+	//   v f.a def
+	//   v f.a ref
+	//      v f.b def
+	//      v f.b ref
+	//           vv f.p2 ref
+	let (a, b) = p2;
+
+	//  v f.g def
+	//  v f.g ref
+	//       v f.y def
+	//       v f.y ref
+	//          v f.y ref
+	//              v f.x ref
+	let g = |y| y + x;
+
+	//  v f.i def
+	//  v f.i ref
+	//          v f.a ref
+	for i in 0..a {
+		g(i) // < "g" f.g ref < "i" f.i ref
+	}
+
+	//    v f.b ref
+	match b {
+		//   v f.n def
+		//   v f.n ref
+		//         v f.g ref
+		//           v f.n ref
+		Some(n) => g(n),
+		None => 0,
+	};
+}
 `},
 	}
 
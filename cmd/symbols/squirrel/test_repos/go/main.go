@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"example.com/squirrel/util"
+)
+
+type Config struct { // < "Config" go.Config def
+	Name string
+}
+
+func main() {
+	//        vvvvvv go.Config ref
+	config := Config{Name: "squirrel"} // < "config" go.main.config def
+
+	//   vvvvvv go.util.Helper ref
+	//          vvvvvv go.main.config ref
+	util.Helper(config.Name) // < "util" util path
+
+	//          vvvv util path
+	//               vvvvvvv go.util.Options ref
+	var options util.Options
+
+	//          vvvvvvv go.sibling ref
+	fmt.Println(sibling(), options)
+
+	//  v go.main.i def
+	//     v go.main.r def
+	for i, r := range config.Name {
+		//          v go.main.i ref
+		//             v go.main.r ref
+		fmt.Println(i, r)
+	}
+
+	// vvv go.main.err def
+	if err := check(); err != nil {
+		//          vvv go.main.err ref
+		fmt.Println(err)
+	}
+
+	//       v go.main.f.s def
+	_ = func(s string) string {
+		//     v go.main.f.s ref
+		return s
+	}
+}
+
+func check() error { // < "check" go.check def
+	return nil
+}
+
+func describe(value interface{}) string {
+	//     vvvvv go.describe.typed def
+	switch typed := value.(type) {
+	case string:
+		//     vvvvv go.describe.typed ref
+		return typed
+	}
+	//     vvvvv go.check ref
+	return check().Error()
+}
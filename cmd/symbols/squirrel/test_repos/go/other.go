@@ -0,0 +1,5 @@
+package main
+
+func sibling() string { // < "sibling" go.sibling def
+	return "sibling"
+}
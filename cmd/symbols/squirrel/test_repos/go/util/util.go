@@ -0,0 +1,8 @@
+package util
+
+type Options struct{} // < "Options" go.util.Options def
+
+func Helper(name string) string { // < "Helper" go.util.Helper def < "name" go.util.Helper.name def
+	//             vvvv go.util.Helper.name ref
+	return "Hi " + name
+}
@@ -0,0 +1,59 @@
+mod shapes;
+mod util;
+
+//               vvvvvvvv rs.util.distance ref
+use crate::util::distance;
+//           vvvvv rs.shapes.Point ref
+//                  vvvvv rs.shapes.Shape ref
+//                           v rs.shapes.Shape ref
+use shapes::{Point, Shape as S};
+
+//     vvv rs.run def
+//         vvvvvv rs.run.points def
+//                   vvvvv rs.shapes.Point ref
+pub fn run(points: &[Point]) -> f64 {
+    //      vvvvv rs.run.total def
+    let mut total = 0.0;
+
+    //  vvvvv rs.run.point def
+    //           vvvvvv rs.run.points ref
+    for point in points {
+        //       vvvvvvvv rs.util.distance ref
+        //                vvvvv rs.run.point ref
+        //                              vvvv rs.util.unit ref
+        total += distance(point, &util::unit());
+    }
+
+    //  vvvvv rs.run.scale def
+    //           v rs.run.k def
+    //                   v rs.run.k ref
+    //                       vvvvv rs.run.total ref
+    let scale = |k: f64| k * total;
+
+    //          vvvv rs.run.last def
+    if let Some(last) = points.last() {
+        //     vvvvv rs.run.scale ref
+        //           vvvv rs.run.last ref
+        return scale(last.x);
+    }
+
+    //    vvvvvv rs.run.points ref
+    match points.first() {
+        //   vvvv rs.run.head def
+        //            vvvvv rs.run.scale ref
+        //                  vvvv rs.run.head ref
+        Some(head) => scale(head.y),
+        None => total,
+    }
+}
+
+//     vvvvvvv rs.measure def
+//             v rs.measure.T def
+//                v rs.shapes.Shape ref
+//                   vvvvv rs.measure.shape def
+//                           v rs.measure.T ref
+pub fn measure<T: S>(shape: &T) -> f64 {
+    //     vvvvv rs.measure.shape ref
+    return shape.area();
+}
+
@@ -0,0 +1,22 @@
+//         vvvvv rs.shapes.Point def
+pub struct Point {
+    pub x: f64,
+    pub y: f64,
+}
+
+//        vvvvv rs.shapes.Shape def
+pub trait Shape {
+    fn area(&self) -> f64;
+}
+
+//   vvvvv rs.shapes.Shape ref
+//             vvvvv rs.shapes.Point ref
+impl Shape for Point {
+    fn area(&self) -> f64 {
+        //  vvv rs.shapes.one def
+        //                     vvvv rs.util.unit ref
+        let one = super::util::unit();
+        //                       vvv rs.shapes.one ref
+        return self.x * self.y * one.x;
+    }
+}
@@ -0,0 +1,41 @@
+//                 vvvvv rs.shapes.Point ref
+use crate::shapes::Point;
+
+//     vvvv rs.util.unit def
+//               vvvvv rs.shapes.Point ref
+pub fn unit() -> Point {
+    //     vvvvv rs.shapes.Point ref
+    return Point { x: 1.0, y: 1.0 };
+}
+
+//     vvvvvvvv rs.util.distance def
+//              v rs.util.distance.a def
+//                         v rs.util.distance.b def
+pub fn distance(a: &Point, b: &Point) -> f64 {
+    //  vv rs.util.distance.dx def
+    //       v rs.util.distance.a ref
+    //             v rs.util.distance.b ref
+    let dx = a.x - b.x;
+    //  vv rs.util.distance.dy def
+    //       v rs.util.distance.a ref
+    //             v rs.util.distance.b ref
+    let dy = a.y - b.y;
+    //      vv rs.util.distance.dx ref
+    //                vv rs.util.distance.dy ref
+    return (dx * dx + dy * dy).sqrt();
+}
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    #[test]
+    fn test_distance() {
+        //  v rs.util.tests.p def
+        //      vvvv rs.util.unit ref
+        let p = unit();
+        //      vvvvvvvv rs.util.distance ref
+        //                v rs.util.tests.p ref
+        let _ = distance(&p, &p);
+    }
+}
@@ -0,0 +1,4 @@
+//              vvvvvv ts.format def
+export function format(s) {
+    return '[' + s + ']'
+}
@@ -0,0 +1,6 @@
+//                      vvvvv ts.greet def
+//                            vvvvv ts.greet.thing def
+export default function greet(thing: object): string {
+    //            vvvvv ts.greet.thing ref
+    return String(thing)
+}
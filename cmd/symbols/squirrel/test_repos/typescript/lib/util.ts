@@ -0,0 +1,9 @@
+//              vvvvvv ts.helper def
+export function helper(n: number): number {
+    return n + 1
+}
+
+//           vvvvvv ts.Widget def
+export class Widget {
+    constructor(size: number) {}
+}
@@ -0,0 +1,54 @@
+import { helper, Widget as W } from './lib/util'
+import * as util from './lib/util'
+import greet from './lib/greet'
+import { format } from './lib/format'
+
+//                   v ts.main.x def
+export function main(x: number): string {
+    //    v ts.main.y def
+    //        vvvvvv ts.helper ref
+    //               v ts.main.x ref
+    const y = helper(x)
+
+    //    vvvvvv ts.main.widget def
+    //                 v ts.Widget ref
+    //                   v ts.main.y ref
+    const widget = new W(y)
+
+    //          vvvvvv ts.helper ref
+    //                      vvvvv ts.greet ref
+    //                            vvvvvv ts.main.widget ref
+    return util.helper(y) + greet(widget)
+}
+
+//              vvvvvvvv ts.describe.describe def
+//                       vvvvv ts.describe.items def
+export function describe(items: string[]): string {
+    //       v ts.describe.i def
+    //              v ts.describe.i ref
+    for (let i = 0; i < items.length; i++) {
+        //                v ts.describe.i ref
+        console.log(items[i])
+    }
+
+    //         vvvv ts.describe.item def
+    //                 vvvvv ts.describe.items ref
+    for (const item of items) {
+        //     vvvvvv ts.format ref
+        //            vvvv ts.describe.item ref
+        return format(item)
+    }
+
+    //                       vvvv ts.describe.item2 def
+    //                               vvvv ts.describe.item2 ref
+    const mapped = items.map(item => item.trim())
+
+    try {
+        //                         vvvvvvvv ts.describe.describe ref
+        return mapped.join(', ') + describe([])
+        //   v ts.describe.e def
+    } catch (e) {
+        //            v ts.describe.e ref
+        return String(e)
+    }
+}
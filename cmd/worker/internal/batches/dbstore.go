@@ -41,7 +41,9 @@ var initReconcilerWorkerStore = memo.NewMemoizedConstructor(func() (dbworkerstor
 		return nil, err
 	}
 
-	return store.NewReconcilerWorkerStore(observationCtx, db.Handle()), nil
+	workerStore := store.NewReconcilerWorkerStore(observationCtx, db.Handle())
+	dbworkerstore.RegisterDeadLetterStore(workerStore)
+	return workerStore, nil
 })
 
 // InitBulkOperationWorkerStore initializes and returns a dbworker.Store instance for the bulk operation processor worker.
@@ -57,7 +59,9 @@ var initBulkOperationWorkerStore = memo.NewMemoizedConstructor(func() (dbworkers
 		return nil, err
 	}
 
-	return store.NewBulkOperationWorkerStore(observationCtx, db.Handle()), nil
+	workerStore := store.NewBulkOperationWorkerStore(observationCtx, db.Handle())
+	dbworkerstore.RegisterDeadLetterStore(workerStore)
+	return workerStore, nil
 })
 
 // InitBatchSpecWorkspaceExecutionWorkerStore initializes and returns a dbworkerstore.Store instance for the batch spec workspace execution worker.
@@ -41,6 +41,7 @@ func (j *lsifuploadstoreExpirer) Routines(_ context.Context, observationCtx *obs
 
 	return []goroutine.BackgroundRoutine{
 		uploadstore.NewExpirer(ctx, uploadStore, lsifuploadstoreExpirerConfigInst.prefix, lsifuploadstoreExpirerConfigInst.maxAge, lsifuploadstoreExpirerConfigInst.interval),
+		lsifuploadstore.NewTTLExpirer(ctx, uploadStore, lsifuploadstoreExpirerConfigInst.LSIFUploadStoreConfig),
 	}, nil
 }
 
@@ -208,10 +208,26 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, record *bgrepo.
 
 	indexName := string(embeddings.GetRepoEmbeddingIndexName(repo.ID))
 	if stats.IsIncremental {
-		return embeddings.UpdateRepoEmbeddingIndex(ctx, h.uploadStore, indexName, previousIndex, repoEmbeddingIndex, toRemove, ranks)
+		err = embeddings.UpdateRepoEmbeddingIndex(ctx, h.uploadStore, indexName, previousIndex, repoEmbeddingIndex, toRemove, ranks)
 	} else {
-		return embeddings.UploadRepoEmbeddingIndex(ctx, h.uploadStore, indexName, repoEmbeddingIndex)
+		err = embeddings.UploadRepoEmbeddingIndex(ctx, h.uploadStore, indexName, repoEmbeddingIndex)
 	}
+	if err != nil {
+		return err
+	}
+
+	hnswIndexName := string(embeddings.GetRepoEmbeddingHNSWIndexName(repo.ID))
+	if !embeddingsConfig.ApproximateSearch.Enabled || stats.IsIncremental {
+		// Building the graph costs far more than an incremental update, so graphs are
+		// only built on full index runs. The previous graph does not match the updated
+		// index, so remove it on a best-effort basis and search the index exactly until
+		// its next full index run.
+		_ = h.uploadStore.Delete(ctx, hnswIndexName)
+		return nil
+	}
+
+	hnswIndex := embed.BuildHNSWIndex(repoEmbeddingIndex, embeddingsConfig.ApproximateSearch)
+	return embeddings.UploadIndex(ctx, h.uploadStore, hnswIndexName, hnswIndex)
 }
 
 func getFileFilterPathPatterns(embeddingsConfig *conftypes.EmbeddingsConfig) (includedFiles, excludedFiles []*paths.GlobPattern) {
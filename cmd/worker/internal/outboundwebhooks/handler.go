@@ -35,14 +35,7 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job *types.Outb
 		log.Stringp("job.scope", job.Scope),
 	)
 
-	webhooks, err := h.store.List(ctx, database.OutboundWebhookListOpts{
-		OutboundWebhookCountOpts: database.OutboundWebhookCountOpts{
-			EventTypes: []database.FilterEventType{{
-				EventType: job.EventType,
-				Scope:     job.Scope,
-			}},
-		},
-	})
+	webhooks, err := h.webhooksForJob(ctx, job)
 	if err != nil {
 		logger.Error("error retrieving outbound webhooks", log.Error(err))
 		return errors.Wrap(err, "retrieving outbound webhooks")
@@ -65,6 +58,28 @@ func (h *handler) Handle(ctx context.Context, logger log.Logger, job *types.Outb
 	return p.Wait()
 }
 
+// webhooksForJob returns the outbound webhooks that the job's payload should
+// be sent to: either the single webhook a redelivery targets, or every webhook
+// subscribed to the job's event type.
+func (h *handler) webhooksForJob(ctx context.Context, job *types.OutboundWebhookJob) ([]*types.OutboundWebhook, error) {
+	if job.OutboundWebhookID != nil {
+		webhook, err := h.store.GetByID(ctx, *job.OutboundWebhookID)
+		if err != nil {
+			return nil, err
+		}
+		return []*types.OutboundWebhook{webhook}, nil
+	}
+
+	return h.store.List(ctx, database.OutboundWebhookListOpts{
+		OutboundWebhookCountOpts: database.OutboundWebhookCountOpts{
+			EventTypes: []database.FilterEventType{{
+				EventType: job.EventType,
+				Scope:     job.Scope,
+			}},
+		},
+	})
+}
+
 func (h *handler) buildWebhookSender(
 	logger log.Logger, job *types.OutboundWebhookJob,
 	webhook *types.OutboundWebhook,
@@ -141,8 +156,9 @@ func (h *handler) sendWebhook(
 			Method: req.Method,
 			URL:    url,
 		}),
-		Response: types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{}),
-		Error:    encryption.NewUnencrypted(""),
+		Response:   types.NewUnencryptedWebhookLogMessage(types.WebhookLogMessage{}),
+		Error:      encryption.NewUnencrypted(""),
+		Redelivery: job.RedeliveryOfJobID != nil,
 	}
 	defer func() {
 		if err := h.logStore.Create(ctx, webhookLog); err != nil {
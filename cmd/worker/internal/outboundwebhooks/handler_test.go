@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
 )
 
 func TestHandler_Handle(t *testing.T) {
@@ -97,6 +98,57 @@ func TestHandler_Handle(t *testing.T) {
 		assert.EqualValues(t, 1, webhooksSeen.count(sadWebhook.ID))
 	})
 
+	t.Run("redelivery", func(t *testing.T) {
+		ctx := context.Background()
+		logger := logtest.Scoped(t)
+
+		payload := []byte(`"test payload"`)
+		server := newMockServer(t, payload, http.StatusOK)
+
+		webhookID := int64(2)
+		job := &types.OutboundWebhookJob{
+			ID:                2,
+			EventType:         "event",
+			Payload:           encryption.NewUnencrypted(string(payload)),
+			OutboundWebhookID: &webhookID,
+			RedeliveryOfJobID: pointers.Ptr(int64(1)),
+		}
+
+		store := dbmocks.NewMockOutboundWebhookStore()
+		store.GetByIDFunc.SetDefaultHook(func(ctx context.Context, id int64) (*types.OutboundWebhook, error) {
+			assert.Equal(t, webhookID, id)
+			return &types.OutboundWebhook{
+				ID:     id,
+				URL:    encryption.NewUnencrypted(server.URL),
+				Secret: encryption.NewUnencrypted("shared secret"),
+			}, nil
+		})
+
+		logStore := dbmocks.NewMockOutboundWebhookLogStore()
+		logStore.CreateFunc.SetDefaultHook(func(ctx context.Context, log *types.OutboundWebhookLog) error {
+			assert.Equal(t, job.ID, log.JobID)
+			assert.Equal(t, webhookID, log.OutboundWebhookID)
+			assert.True(t, log.Redelivery)
+			return nil
+		})
+
+		h := &handler{
+			client:   http.DefaultClient,
+			store:    store,
+			logStore: logStore,
+		}
+
+		err := h.Handle(ctx, logger, job)
+		assert.NoError(t, err)
+
+		// Redeliveries only go to the targeted webhook, regardless of which
+		// webhooks are subscribed to the event type.
+		mockassert.NotCalled(t, store.ListFunc)
+		mockassert.CalledN(t, store.GetByIDFunc, 1)
+		mockassert.CalledN(t, logStore.CreateFunc, 1)
+		assert.EqualValues(t, 1, server.requestCount)
+	})
+
 	t.Run("network failure", func(t *testing.T) {
 		ctx := context.Background()
 		logger := logtest.Scoped(t)
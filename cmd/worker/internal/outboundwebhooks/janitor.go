@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 )
 
 const janitorFrequency = 1 * time.Hour
@@ -20,7 +21,7 @@ func makeJanitor(observationCtx *observation.Context, store database.OutboundWeb
 	return goroutine.NewPeriodicGoroutine(
 		context.Background(),
 		goroutine.HandlerFunc(func(ctx context.Context) error {
-			err := store.DeleteBefore(ctx, time.Now().Add(-1*calculateRetention(observationCtx.Logger, conf.Get())))
+			err := store.DeleteBefore(ctx, time.Now().Add(-1*outbound.Retention(observationCtx.Logger, conf.Get())))
 			if err != nil {
 				observationCtx.Logger.Error("outbound webhook janitor error", log.Error(err))
 			}
@@ -31,19 +32,3 @@ func makeJanitor(observationCtx *observation.Context, store database.OutboundWeb
 		goroutine.WithInterval(janitorFrequency),
 	)
 }
-
-// This matches the documented value in the site configuration schema.
-const defaultRetention = 72 * time.Hour
-
-func calculateRetention(logger log.Logger, c *conf.Unified) time.Duration {
-	if cfg := c.WebhookLogging; cfg != nil {
-		retention, err := time.ParseDuration(cfg.Retention)
-		if err != nil {
-			logger.Warn("invalid webhook log retention period; ignoring", log.String("raw", cfg.Retention), log.Error(err))
-		} else {
-			return retention
-		}
-	}
-
-	return defaultRetention
-}
@@ -2,7 +2,6 @@ package search
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/sourcegraph/log"
@@ -72,10 +71,44 @@ func (h *exhaustiveSearchRepoRevHandler) Handle(ctx context.Context, logger log.
 		return err
 	}
 
-	csvWriter := service.NewBlobstoreCSVWriter(ctx, h.uploadStore, fmt.Sprintf("%d-%d", jobID, record.ID))
+	commit, err := q.ResolveCommit(ctx, repoRev)
+	if err != nil {
+		// We only need the commit to reuse results in re-runs, so we let the
+		// search report the error if the revision doesn't resolve.
+		logger.Warn("failed to resolve commit", log.Error(err))
+		return h.search(ctx, q, repoRev, jobID, record.ID)
+	}
+
+	// If the previous run of this job searched the same commit we reuse its
+	// results, unless an older job only stored them as CSV.
+	previousJobID, previousRecordID, previousCommit, ok, err := h.store.GetPreviousRepoRevisionJob(ctx, record.ID)
+	if err != nil {
+		return err
+	}
+	copied := false
+	if ok && previousCommit == string(commit) {
+		copied, err = service.CopyRepoRevisionResults(ctx, h.uploadStore, previousJobID, previousRecordID, jobID, record.ID)
+		if err != nil {
+			return err
+		}
+	}
+	if !copied {
+		if err := h.search(ctx, q, repoRev, jobID, record.ID); err != nil {
+			return err
+		}
+	}
+
+	// Note: the search resolves the revision again, so in the rare case it
+	// moved since ResolveCommit the results may be from a newer commit. The
+	// next re-run will then search it again, which is harmless.
+	return h.store.SetRepoRevisionJobCommit(ctx, record.ID, string(commit))
+}
+
+func (h *exhaustiveSearchRepoRevHandler) search(ctx context.Context, q service.SearchQuery, repoRev types.RepositoryRevision, jobID, recordID int64) error {
+	jsonLinesWriter := service.NewBlobstoreJSONLinesWriter(ctx, h.uploadStore, service.RepoRevisionJSONLinesPrefix(jobID, recordID))
 
-	err = q.Search(ctx, repoRev, csvWriter)
-	if closeErr := csvWriter.Close(); closeErr != nil {
+	err := q.Search(ctx, repoRev, jsonLinesWriter)
+	if closeErr := jsonLinesWriter.Close(); closeErr != nil {
 		err = errors.Append(err, closeErr)
 	}
 
@@ -25,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/store"
 	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/types"
 	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/lib/iterator"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -113,17 +114,31 @@ func TestExhaustiveSearch(t *testing.T) {
 	// we co-ordinate our workers)
 	{
 		var vals []string
-		for _, v := range bucket {
+		for k, v := range bucket {
+			require.True(strings.HasPrefix(k, "jsonl-"), "only JSON Lines are stored, got key %q", k)
 			vals = append(vals, v)
 		}
 		sort.Strings(vals)
 		require.Equal([]string{
-			"repo,revspec,revision\n1,spec,rev1\n",
-			"repo,revspec,revision\n1,spec,rev2\n",
-			"repo,revspec,revision\n2,spec,rev3\n",
+			"{\"type\":\"path\",\"path\":\"spec\",\"repositoryID\":1,\"repository\":\"1\",\"commit\":\"rev1\"}\n",
+			"{\"type\":\"path\",\"path\":\"spec\",\"repositoryID\":1,\"repository\":\"1\",\"commit\":\"rev2\"}\n",
+			"{\"type\":\"path\",\"path\":\"spec\",\"repositoryID\":2,\"repository\":\"2\",\"commit\":\"rev3\"}\n",
 		}, vals)
 	}
 
+	// Assert that the stored JSON Lines can be downloaded as CSV.
+	{
+		writerTo, err := svc.GetSearchJobCSVWriterTo(userCtx, job.ID)
+		require.NoError(err)
+		var buf bytes.Buffer
+		_, err = writerTo.WriteTo(&buf)
+		require.NoError(err)
+		lines := strings.Split(buf.String(), "\n")
+		// 1 header + 3 rows + 1 newline
+		require.Equal(5, len(lines), fmt.Sprintf("got %q", buf))
+		require.Equal("repository,revision,file_path,match_count,first_match_url", lines[0])
+	}
+
 	// Minor assertion that the job is regarded as finished.
 	{
 		job2, err := svc.GetSearchJob(userCtx, job.ID)
@@ -175,6 +190,40 @@ func TestExhaustiveSearch(t *testing.T) {
 		require.ErrorIs(err, auth.ErrMustBeSiteAdminOrSameUser)
 	}
 
+	// Re-run the job. The fake searcher resolves every revision to itself, so
+	// no commit changed and the re-run reuses all results of the job.
+	var rerunID int64
+	{
+		rerun, err := svc.RerunSearchJob(userCtx, job.ID)
+		require.NoError(err)
+		require.Equal(job.ID, rerun.PreviousJobID)
+		require.Equal(query, rerun.Query)
+		rerunID = rerun.ID
+
+		require.Eventually(func() bool {
+			return !searchJob.hasWork(workerCtx)
+		}, tTimeout(t, 10*time.Second), 10*time.Millisecond)
+
+		var jsonLinesCount int
+		for k := range bucket {
+			if strings.HasPrefix(k, fmt.Sprintf("jsonl-%d-", rerunID)) {
+				jsonLinesCount++
+			}
+		}
+		require.Equal(3, jsonLinesCount)
+
+		writerTo, err := svc.GetSearchJobDiffWriterTo(userCtx, rerunID)
+		require.NoError(err)
+		var buf bytes.Buffer
+		_, err = writerTo.WriteTo(&buf)
+		require.NoError(err)
+		require.Empty(buf.String())
+
+		// The first run has nothing to compare to.
+		_, err = svc.GetSearchJobDiffWriterTo(userCtx, job.ID)
+		require.ErrorIs(err, service.ErrNoPreviousJob)
+	}
+
 	// Assert that cancellation affects the number of rows we expect. This is a bit
 	// counterintuitive at this point because we have already completed the job.
 	// However, cancellation affects the rows independently of the job state.
@@ -187,6 +236,9 @@ func TestExhaustiveSearch(t *testing.T) {
 
 	// Delete should remove the job from the database and the uploadstore.
 	{
+		require.Equal(6, len(bucket))
+		err = svc.DeleteSearchJob(userCtx, rerunID)
+		require.NoError(err)
 		require.Equal(3, len(bucket))
 		err = svc.DeleteSearchJob(userCtx, job.ID)
 		require.NoError(err)
@@ -233,7 +285,7 @@ func tTimeout(t *testing.T, max time.Duration) time.Duration {
 func newMockUploadStore(t *testing.T) (*mocks.MockStore, map[string]string) {
 	t.Helper()
 
-	// Each entry in bucket corresponds to one 1 uploaded csv file.
+	// Each entry in bucket corresponds to one 1 uploaded jsonl file.
 	mu := sync.Mutex{}
 	bucket := make(map[string]string)
 
@@ -251,6 +303,15 @@ func newMockUploadStore(t *testing.T) (*mocks.MockStore, map[string]string) {
 		return int64(len(b)), nil
 	})
 
+	mockStore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if v, ok := bucket[key]; ok {
+			return io.NopCloser(strings.NewReader(v)), nil
+		}
+		return nil, errors.Newf("key %q not found", key)
+	})
+
 	mockStore.DeleteFunc.SetDefaultHook(func(ctx context.Context, key string) error {
 		mu.Lock()
 		delete(bucket, key)
@@ -263,7 +324,9 @@ func newMockUploadStore(t *testing.T) (*mocks.MockStore, map[string]string) {
 		var keys []string
 		mu.Lock()
 		for k := range bucket {
-			keys = append(keys, k)
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
 		}
 		mu.Unlock()
 		return iterator.From(keys), nil
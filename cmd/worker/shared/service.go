@@ -9,6 +9,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/oobmigration/migrations/register"
 	"github.com/sourcegraph/sourcegraph/internal/service"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 )
 
 type svc struct{}
@@ -16,7 +17,7 @@ type svc struct{}
 func (svc) Name() string { return "worker" }
 
 func (svc) Configure() (env.Config, []debugserver.Endpoint) {
-	return LoadConfig(register.RegisterEnterpriseMigrators), nil
+	return LoadConfig(register.RegisterEnterpriseMigrators), []debugserver.Endpoint{dbworker.DeadLetterDebugEndpoint()}
 }
 
 func (svc) Start(ctx context.Context, observationCtx *observation.Context, ready service.ReadyFunc, config env.Config) error {
@@ -35,6 +35,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/suspiciousnames"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -251,6 +252,8 @@ func unsafeSignUp(
 		return errors.New(message), statusCode, nil
 	}
 
+	outbound.EnqueueUserCreated(ctx, logger, db, usr)
+
 	if err = db.Authz().GrantPendingPermissions(ctx, &database.GrantPendingPermissionsArgs{
 		UserID: usr.ID,
 		Perm:   authz.Read,
@@ -446,6 +446,7 @@ func TestHandleSignUp(t *testing.T) {
 		db.UsersFunc.SetDefaultReturn(users)
 		db.AuthzFunc.SetDefaultReturn(authz)
 		db.EventLogsFunc.SetDefaultReturn(eventLogs)
+		db.OutboundWebhooksFunc.SetDefaultReturn(dbmocks.NewMockOutboundWebhookStore())
 
 		gss := dbmocks.NewMockGlobalStateStore()
 		gss.GetFunc.SetDefaultReturn(database.GlobalState{SiteID: "a"}, nil)
@@ -526,6 +527,7 @@ func TestHandleSiteInit(t *testing.T) {
 		db.UsersFunc.SetDefaultReturn(users)
 		db.AuthzFunc.SetDefaultReturn(authz)
 		db.EventLogsFunc.SetDefaultReturn(eventLogs)
+		db.OutboundWebhooksFunc.SetDefaultReturn(dbmocks.NewMockOutboundWebhookStore())
 
 		logger := logtest.NoOp(t)
 		if testing.Verbose() {
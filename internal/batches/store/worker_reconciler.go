@@ -19,6 +19,11 @@ const reconcilerMaxNumRetries = 10
 // makes to process a changeset when it stalls (process crashes, etc.).
 const reconcilerMaxNumResets = 10
 
+// reconcilerMaxConcurrencyPerRepo is the maximum number of changesets of the
+// same repository the reconciler processes at once, so that a busy repository
+// doesn't starve changesets in other repositories.
+const reconcilerMaxConcurrencyPerRepo = 2
+
 var reconcilerWorkerStoreOpts = dbworkerstore.Options[*types.Changeset]{
 	Name:                 "batches_reconciler_worker_store",
 	TableName:            "changesets",
@@ -33,6 +38,9 @@ var reconcilerWorkerStoreOpts = dbworkerstore.Options[*types.Changeset]{
 	// If state is equal, prefer the newer ones.
 	OrderByExpression: sqlf.Sprintf("changesets.reconciler_state = 'errored', changesets.updated_at DESC"),
 
+	ConcurrencyKeyExpression: sqlf.Sprintf("changesets.repo_id"),
+	MaxConcurrencyPerKey:     reconcilerMaxConcurrencyPerRepo,
+
 	StalledMaxAge: 60 * time.Second,
 	MaxNumResets:  reconcilerMaxNumResets,
 
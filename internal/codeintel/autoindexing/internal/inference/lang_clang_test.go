@@ -0,0 +1,35 @@
+package inference
+
+import (
+	"testing"
+)
+
+func TestClangGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "clang compilation database",
+			indexerMap:  map[string]string{"clang": "example.com/scip-clang"},
+			repositoryContents: map[string]string{
+				"compile_commands.json": "",
+				"CMakeLists.txt":        "",
+				"src/CMakeLists.txt":    "",
+			},
+		},
+		generatorTestCase{
+			description: "clang cmake projects",
+			indexerMap:  map[string]string{"clang": "example.com/scip-clang"},
+			repositoryContents: map[string]string{
+				"a/CMakeLists.txt":              "",
+				"a/lib/CMakeLists.txt":          "",
+				"b/CMakeLists.txt":              "",
+				"b/build/compile_commands.json": "",
+			},
+		},
+		generatorTestCase{
+			description: "clang without configured indexer",
+			repositoryContents: map[string]string{
+				"compile_commands.json": "",
+			},
+		},
+	)
+}
@@ -0,0 +1,37 @@
+package inference
+
+import (
+	"testing"
+)
+
+func TestDotNetGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "dotnet solution",
+			indexerMap:  map[string]string{"dotnet": "example.com/scip-dotnet"},
+			repositoryContents: map[string]string{
+				"App.sln":                "",
+				"Legacy.sln":             "",
+				"src/App/App.csproj":     "",
+				"src/Lib/Lib.fsproj":     "",
+				"tests/App.Tests.csproj": "",
+			},
+		},
+		generatorTestCase{
+			description: "dotnet projects without solution",
+			indexerMap:  map[string]string{"dotnet": "example.com/scip-dotnet"},
+			repositoryContents: map[string]string{
+				"a/A.csproj":   "",
+				"b/B.vbproj":   "",
+				"c/c.sln":      "",
+				"c/d/D.csproj": "",
+			},
+		},
+		generatorTestCase{
+			description: "dotnet without configured indexer",
+			repositoryContents: map[string]string{
+				"App.sln": "",
+			},
+		},
+	)
+}
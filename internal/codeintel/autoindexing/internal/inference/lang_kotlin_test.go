@@ -0,0 +1,32 @@
+package inference
+
+import (
+	"testing"
+)
+
+func TestKotlinGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "kotlin project with Gradle Kotlin DSL",
+			repositoryContents: map[string]string{
+				"settings.gradle.kts":                    "",
+				"app/build.gradle.kts":                   "",
+				"app/src/main/kotlin/com/example/App.kt": "",
+			},
+		},
+		generatorTestCase{
+			description: "kotlin project with only Gradle Kotlin DSL settings",
+			repositoryContents: map[string]string{
+				"settings.gradle.kts":                "",
+				"src/main/kotlin/com/example/App.kt": "",
+			},
+		},
+		generatorTestCase{
+			description: "kotlin project with Gradle Kotlin DSL but no sources",
+			repositoryContents: map[string]string{
+				"settings.gradle.kts": "",
+				"build.gradle.kts":    "",
+			},
+		},
+	)
+}
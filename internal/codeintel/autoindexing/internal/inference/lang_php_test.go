@@ -0,0 +1,25 @@
+package inference
+
+import (
+	"testing"
+)
+
+func TestPHPGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "php composer projects",
+			indexerMap:  map[string]string{"php": "example.com/scip-php"},
+			repositoryContents: map[string]string{
+				"composer.json":            "",
+				"composer.lock":            "",
+				"packages/a/composer.json": "",
+			},
+		},
+		generatorTestCase{
+			description: "php without configured indexer",
+			repositoryContents: map[string]string{
+				"composer.json": "",
+			},
+		},
+	)
+}
@@ -0,0 +1,28 @@
+package inference
+
+import (
+	"testing"
+)
+
+func TestScalaGenerator(t *testing.T) {
+	testGenerators(t,
+		generatorTestCase{
+			description: "scala project with sbt",
+			repositoryContents: map[string]string{
+				"build.sbt":                                  "",
+				"project/build.properties":                   "",
+				"src/main/scala/com/example/Main.scala":      "",
+				"core/src/main/scala/com/example/Core.scala": "",
+			},
+		},
+		generatorTestCase{
+			description: "nested scala projects with sbt",
+			repositoryContents: map[string]string{
+				"a/build.sbt":                          "",
+				"a/src/main/scala/com/example/A.scala": "",
+				"b/build.sbt":                          "",
+				"b/src/main/scala/com/example/B.scala": "",
+			},
+		},
+	)
+}
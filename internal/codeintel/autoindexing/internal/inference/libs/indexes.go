@@ -15,6 +15,10 @@ var Indexes = indexesAPI{}
 
 type indexesAPI struct{}
 
+// Languages without a default indexer, such as clang, dotnet and php, are only indexed once a
+// site admin configures an image for them in codeIntelAutoIndexing.indexerMap. Default indexers
+// are always pinned to a digest in defaultIndexerSHAs, so add an indexer here only once its
+// published image has been verified and pinned by update-shas.sh.
 var defaultIndexers = map[string]string{
 	"go":         "sourcegraph/scip-go",
 	"java":       "sourcegraph/scip-java",
@@ -49,22 +53,32 @@ func DefaultIndexerForLang(language string) (string, bool) {
 	return fmt.Sprintf("%s@%s", indexer, sha), true
 }
 
+// indexerForLang returns the indexer configured for the given language in the site config,
+// falling back to the default indexer of the language.
+func indexerForLang(language string) (string, bool) {
+	if indexer, ok := conf.SiteConfig().CodeIntelAutoIndexingIndexerMap[language]; ok {
+		return indexer, true
+	}
+
+	return DefaultIndexerForLang(language)
+}
+
 func (api indexesAPI) LuaAPI() map[string]lua.LGFunction {
 	return map[string]lua.LGFunction{
 		"get": util.WrapLuaFunction(func(state *lua.LState) error {
 			language := state.CheckString(1)
 
-			if indexer, ok := conf.SiteConfig().CodeIntelAutoIndexingIndexerMap[language]; ok {
-				state.Push(luar.New(state, indexer))
-				return nil
-			}
-
-			if indexer, ok := DefaultIndexerForLang(language); ok {
+			if indexer, ok := indexerForLang(language); ok {
 				state.Push(luar.New(state, indexer))
 				return nil
 			}
 
 			return errors.Newf("no indexer is registered for %q", language)
 		}),
+		"has": util.WrapLuaFunction(func(state *lua.LState) error {
+			_, ok := indexerForLang(state.CheckString(1))
+			state.Push(lua.LBool(ok))
+			return nil
+		}),
 	}
 }
@@ -0,0 +1,96 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+local indexes = require "sg.autoindex.indexes"
+local outfile = "index.scip"
+
+local exclude_paths = pattern.new_path_combine(shared.exclude_paths, {
+  pattern.new_path_segment "third_party",
+  pattern.new_path_segment "vendor",
+})
+
+-- Returns true if dir is root or a descendant of root.
+local is_within = function(dir, root)
+  return root == "" or dir == root or string.sub(dir, 1, #root + 1) == root .. "/"
+end
+
+-- Returns true if dir is nested within any of the given roots.
+local is_nested = function(dir, roots)
+  for root in pairs(roots) do
+    if root ~= dir and is_within(dir, root) then
+      return true
+    end
+  end
+  return false
+end
+
+-- Returns true if any of the given roots is dir or is nested within dir.
+local contains_any = function(dir, roots)
+  for root in pairs(roots) do
+    if is_within(root, dir) then
+      return true
+    end
+  end
+  return false
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_basename "compile_commands.json",
+    pattern.new_path_basename "CMakeLists.txt",
+    pattern.new_path_exclude(exclude_paths),
+  },
+
+  -- Invoked when compile_commands.json or CMakeLists.txt files exist
+  generate = function(_, paths)
+    -- There is no default clang indexer, so jobs are only inferred once one is configured
+    if not indexes.has "clang" then
+      return {}
+    end
+    local indexer = indexes.get "clang"
+
+    local compdb_roots = {}
+    local cmake_roots = {}
+    for i = 1, #paths do
+      if path.basename(paths[i]) == "compile_commands.json" then
+        compdb_roots[path.dirname(paths[i])] = true
+      else
+        cmake_roots[path.dirname(paths[i])] = true
+      end
+    end
+
+    local jobs = {}
+
+    -- A checked-in compilation database already lists every translation unit
+    for root in pairs(compdb_roots) do
+      table.insert(jobs, {
+        steps = {},
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-clang", "--compdb-path=compile_commands.json" },
+        outfile = outfile,
+      })
+    end
+
+    -- Otherwise, generate one with CMake. Nested CMakeLists.txt files are added
+    -- to their parent project via add_subdirectory and can't be built on their own,
+    -- so only outermost projects without a compilation database are indexed.
+    for root in pairs(cmake_roots) do
+      if not is_nested(root, cmake_roots) and not is_nested(root, compdb_roots) and not contains_any(root, compdb_roots) then
+        table.insert(jobs, {
+          steps = {},
+          local_steps = { "cmake -B build -DCMAKE_EXPORT_COMPILE_COMMANDS=ON" },
+          root = root,
+          indexer = indexer,
+          indexer_args = { "scip-clang", "--compdb-path=build/compile_commands.json" },
+          outfile = outfile,
+        })
+      end
+    end
+
+    return jobs
+  end,
+}
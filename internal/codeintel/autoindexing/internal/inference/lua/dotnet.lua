@@ -0,0 +1,89 @@
+local path = require "path"
+local recognizer = require "sg.autoindex.recognizer"
+local pattern = require "sg.autoindex.patterns"
+
+local shared = require "sg.autoindex.shared"
+
+local indexes = require "sg.autoindex.indexes"
+local outfile = "index.scip"
+
+-- Returns true if dir is nested within any of the given roots.
+local is_nested = function(dir, roots)
+  for root in pairs(roots) do
+    if root == "" or string.sub(dir, 1, #root + 1) == root .. "/" then
+      return true
+    end
+  end
+  return false
+end
+
+-- Returns a map from each directory to the lexicographically first of the given files within it.
+local first_by_dir = function(paths)
+  local by_dir = {}
+  for i = 1, #paths do
+    local dir = path.dirname(paths[i])
+    local name = path.basename(paths[i])
+    if by_dir[dir] == nil or name < by_dir[dir] then
+      by_dir[dir] = name
+    end
+  end
+  return by_dir
+end
+
+return recognizer.new_path_recognizer {
+  patterns = {
+    pattern.new_path_extension "sln",
+    pattern.new_path_extension "csproj",
+    pattern.new_path_extension "fsproj",
+    pattern.new_path_extension "vbproj",
+    pattern.new_path_exclude(shared.exclude_paths),
+  },
+
+  -- Invoked when solution or project files exist
+  generate = function(_, paths)
+    -- There is no default dotnet indexer, so jobs are only inferred once one is configured
+    if not indexes.has "dotnet" then
+      return {}
+    end
+    local indexer = indexes.get "dotnet"
+
+    local solution_paths = {}
+    local project_paths = {}
+    for i = 1, #paths do
+      if string.sub(paths[i], -4) == ".sln" then
+        table.insert(solution_paths, paths[i])
+      else
+        table.insert(project_paths, paths[i])
+      end
+    end
+
+    local jobs = {}
+
+    -- A solution builds all of the projects it references
+    local solutions = first_by_dir(solution_paths)
+    for root, name in pairs(solutions) do
+      table.insert(jobs, {
+        steps = {},
+        root = root,
+        indexer = indexer,
+        indexer_args = { "scip-dotnet", "index", name },
+        outfile = outfile,
+      })
+    end
+
+    -- Projects outside of any solution directory are indexed on their own
+    for root, name in pairs(first_by_dir(project_paths)) do
+      if solutions[root] == nil and not is_nested(root, solutions) then
+        table.insert(jobs, {
+          steps = {},
+          root = root,
+          indexer = indexer,
+          indexer_args = { "scip-dotnet", "index", name },
+          outfile = outfile,
+        })
+      end
+    end
+
+    return jobs
+  end,
+}
@@ -15,7 +15,9 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"gopkg.in/yaml.v3"
 
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestMain(m *testing.M) {
@@ -126,6 +128,7 @@ type generatorTestCase struct {
 	description        string
 	overrideScript     string
 	repositoryContents map[string]string
+	indexerMap         map[string]string
 }
 
 func testGenerators(t *testing.T, testCases ...generatorTestCase) {
@@ -136,6 +139,13 @@ func testGenerators(t *testing.T, testCases ...generatorTestCase) {
 
 func testGenerator(t *testing.T, testCase generatorTestCase) {
 	t.Run(testCase.description, func(t *testing.T) {
+		if testCase.indexerMap != nil {
+			conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				CodeIntelAutoIndexingIndexerMap: testCase.indexerMap,
+			}})
+			t.Cleanup(func() { conf.Mock(nil) })
+		}
+
 		service := testService(t, testCase.repositoryContents)
 
 		result, err := service.InferIndexJobs(
@@ -15,6 +15,11 @@ type operations struct {
 	getReferences          *observation.Operation
 	getImplementations     *observation.Operation
 	getPrototypes          *observation.Operation
+	getIncomingCalls       *observation.Operation
+	getOutgoingCalls       *observation.Operation
+	getSupertypes          *observation.Operation
+	getSubtypes            *observation.Operation
+	getRenamePreview       *observation.Operation
 	getDiagnostics         *observation.Operation
 	getHover               *observation.Operation
 	getDefinitions         *observation.Operation
@@ -49,6 +54,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 		getReferences:          op("getReferences"),
 		getImplementations:     op("getImplementations"),
 		getPrototypes:          op("getPrototypes"),
+		getIncomingCalls:       op("getIncomingCalls"),
+		getOutgoingCalls:       op("getOutgoingCalls"),
+		getSupertypes:          op("getSupertypes"),
+		getSubtypes:            op("getSubtypes"),
+		getRenamePreview:       op("getRenamePreview"),
 		getDiagnostics:         op("getDiagnostics"),
 		getHover:               op("getHover"),
 		getDefinitions:         op("getDefinitions"),
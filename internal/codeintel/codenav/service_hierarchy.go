@@ -0,0 +1,695 @@
+package codenav
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/collections"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// definitionsPerHierarchySymbol bounds the number of definitions fetched per symbol when the
+// definitions of several symbols are looked up at once. A symbol has more than one definition
+// when it's defined by several indexes, e.g. for different commits of a dependency.
+const definitionsPerHierarchySymbol = 5
+
+// GetIncomingCalls returns the functions calling the function at the given position. The
+// callers are found through the references of the function, so like GetReferences this
+// follows monikers into other repositories. Pages are computed over references, so a caller
+// with call sites on several pages is returned once per page.
+//
+// Beyond the first level, the callers of each caller are found through the references of its
+// symbol. Each caller is expanded at most once per request, so recursive calls end the
+// hierarchy. See findEnclosingDefinition for how callers are determined from call sites.
+func (s *Service) GetIncomingCalls(
+	ctx context.Context,
+	args HierarchyRequestArgs,
+	requestState RequestState,
+	cursor Cursor,
+) (_ []HierarchyCall, nextCursor Cursor, err error) {
+	references, nextCursor, err := s.gatherLocations(
+		ctx, args.PositionalRequestArgs, requestState, cursor,
+
+		s.operations.getIncomingCalls, // operation
+		"references",                  // tableName
+		true,                          // includeReferencingIndexes
+		LocationExtractorFunc(s.lsifstore.ExtractReferenceLocationsFromPosition),
+	)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	documents := newHierarchyDocumentCache(s)
+
+	calls, err := s.getIncomingCalls(ctx, args.RequestArgs, requestState, documents, references)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	if err := expandHierarchyCalls(calls, args.Depth-1, map[string]struct{}{}, func(item HierarchyItem) ([]HierarchyCall, error) {
+		references, _, err := s.gatherLocationsBySymbolNames(
+			ctx, args.RequestArgs, requestState, Cursor{},
+
+			s.operations.getIncomingCalls, // operation
+			"references",                  // tableName
+			true,                          // includeReferencingIndexes
+			[]string{item.Symbol},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.getIncomingCalls(ctx, args.RequestArgs, requestState, documents, references)
+	}); err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return calls, nextCursor, nil
+}
+
+// getIncomingCalls groups the given references by the function they occur in.
+func (s *Service) getIncomingCalls(ctx context.Context, args RequestArgs, requestState RequestState, documents *hierarchyDocumentCache, references []shared.UploadLocation) ([]HierarchyCall, error) {
+	var calls []HierarchyCall
+	callIndexByCaller := map[string]int{}
+	for _, reference := range references {
+		document, rng, ok, err := documents.getIndexed(ctx, args, requestState, reference)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		caller, ok := findEnclosingDefinition(document, rng)
+		if !ok {
+			// e.g. a reference in the initializer of a global variable
+			continue
+		}
+
+		key := fmt.Sprintf("%d:%s", reference.Dump.ID, caller.Symbol)
+		if i, ok := callIndexByCaller[key]; ok {
+			calls[i].Ranges = append(calls[i].Ranges, reference)
+			continue
+		}
+
+		location, err := s.getHierarchyItemLocation(ctx, args, requestState, reference.Dump, reference.Path, caller.Range)
+		if err != nil {
+			return nil, err
+		}
+
+		callIndexByCaller[key] = len(calls)
+		calls = append(calls, HierarchyCall{
+			Item:   HierarchyItem{Symbol: caller.Symbol, Location: location},
+			Ranges: []shared.UploadLocation{reference},
+		})
+	}
+
+	return calls, nil
+}
+
+// GetOutgoingCalls returns the functions called by the function at the given position. The
+// calls are read from the body of the definition of the function, which may live in another
+// repository. Callees without a precise definition, e.g. in a dependency that isn't indexed,
+// are omitted.
+//
+// Beyond the first level, the callees of each callee are read from the body of its definition.
+// Each callee is expanded at most once per request, so recursive calls end the hierarchy. See
+// findOutgoingCalls for how the body of a function is determined.
+func (s *Service) GetOutgoingCalls(
+	ctx context.Context,
+	args HierarchyRequestArgs,
+	requestState RequestState,
+) (_ []HierarchyCall, err error) {
+	definitions, _, err := s.gatherLocations(
+		ctx, args.PositionalRequestArgs, requestState, Cursor{},
+
+		s.operations.getOutgoingCalls, // operation
+		"definitions",                 // tableName
+		false,                         // includeReferencingIndexes
+		LocationExtractorFunc(s.lsifstore.ExtractDefinitionLocationsFromPosition),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := newHierarchyDocumentCache(s)
+
+	calls, err := s.getOutgoingCalls(ctx, args.RequestArgs, requestState, documents, definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := expandHierarchyCalls(calls, args.Depth-1, map[string]struct{}{}, func(item HierarchyItem) ([]HierarchyCall, error) {
+		return s.getOutgoingCalls(ctx, args.RequestArgs, requestState, documents, []shared.UploadLocation{item.Location})
+	}); err != nil {
+		return nil, err
+	}
+
+	return calls, nil
+}
+
+// getOutgoingCalls returns the calls made by the functions defined at the given locations, up
+// to args.Limit callees. Callees defined in the same document as their caller are resolved
+// directly; the definitions of all other callees are looked up at once.
+func (s *Service) getOutgoingCalls(ctx context.Context, args RequestArgs, requestState RequestState, documents *hierarchyDocumentCache, definitions []shared.UploadLocation) ([]HierarchyCall, error) {
+	type outgoingCall struct {
+		caller    shared.UploadLocation
+		callSites outgoingCallSites
+	}
+
+	var outgoingCalls []outgoingCall
+	calleeLocations := map[string]shared.UploadLocation{}
+	unresolvedCallees := collections.NewSet[string]()
+
+outer:
+	for _, definition := range definitions {
+		document, rng, ok, err := documents.getIndexed(ctx, args, requestState, definition)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		occurrence, ok := findDefinition(document, rng)
+		if !ok {
+			continue
+		}
+
+		for _, callSites := range findOutgoingCalls(document, occurrence) {
+			if len(outgoingCalls) >= args.Limit {
+				break outer
+			}
+			outgoingCalls = append(outgoingCalls, outgoingCall{caller: definition, callSites: callSites})
+
+			if _, ok := calleeLocations[callSites.symbol]; ok {
+				continue
+			}
+			calleeDefinition, ok := findDefinitionBySymbol(document, callSites.symbol)
+			if !ok {
+				unresolvedCallees.Add(callSites.symbol)
+				continue
+			}
+
+			location, err := s.getHierarchyItemLocation(ctx, args, requestState, definition.Dump, definition.Path, calleeDefinition.Range)
+			if err != nil {
+				return nil, err
+			}
+			calleeLocations[callSites.symbol] = location
+			unresolvedCallees.Remove(callSites.symbol)
+		}
+	}
+
+	if len(unresolvedCallees) > 0 {
+		symbols := unresolvedCallees.Values()
+		sort.Strings(symbols)
+
+		items, err := s.getDefinitionsBySymbolNames(ctx, args, requestState, documents, symbols)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if _, ok := calleeLocations[item.Symbol]; !ok {
+				calleeLocations[item.Symbol] = item.Location
+			}
+		}
+	}
+
+	calls := make([]HierarchyCall, 0, len(outgoingCalls))
+	for _, call := range outgoingCalls {
+		location, ok := calleeLocations[call.callSites.symbol]
+		if !ok {
+			continue
+		}
+
+		ranges := make([]shared.UploadLocation, 0, len(call.callSites.ranges))
+		for _, r := range call.callSites.ranges {
+			uploadLocation, err := s.getHierarchyItemLocation(ctx, args, requestState, call.caller.Dump, call.caller.Path, r)
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, uploadLocation)
+		}
+
+		calls = append(calls, HierarchyCall{
+			Item:   HierarchyItem{Symbol: call.callSites.symbol, Location: location},
+			Ranges: ranges,
+		})
+	}
+
+	return calls, nil
+}
+
+// GetSupertypes returns the types (or methods) the type at the given position implements,
+// as recorded by the implementation relationships of its SCIP symbol.
+//
+// Beyond the first level, the supertypes of each supertype are read from the relationships
+// recorded in the document defining it. Each supertype is expanded at most once per request.
+func (s *Service) GetSupertypes(
+	ctx context.Context,
+	args HierarchyRequestArgs,
+	requestState RequestState,
+	cursor Cursor,
+) (_ []HierarchyItem, nextCursor Cursor, err error) {
+	locations, nextCursor, err := s.gatherLocations(
+		ctx, args.PositionalRequestArgs, requestState, cursor,
+
+		s.operations.getSupertypes, // operation
+		"definitions",              // N.B.: we're looking for definitions of interfaces
+		false,                      // includeReferencingIndexes
+		LocationExtractorFunc(s.lsifstore.ExtractPrototypeLocationsFromPosition),
+	)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	documents := newHierarchyDocumentCache(s)
+
+	items, err := s.getHierarchyItems(ctx, args.RequestArgs, requestState, documents, locations)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	if err := expandHierarchyItems(items, args.Depth-1, map[string]struct{}{}, func(item HierarchyItem) ([]HierarchyItem, error) {
+		document, _, ok, err := documents.getIndexed(ctx, args.RequestArgs, requestState, item.Location)
+		if err != nil || !ok {
+			return nil, err
+		}
+
+		symbols := findImplementedSymbols(document, item.Symbol)
+		if len(symbols) == 0 {
+			return nil, nil
+		}
+
+		return s.getDefinitionsBySymbolNames(ctx, args.RequestArgs, requestState, documents, symbols)
+	}); err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return items, nextCursor, nil
+}
+
+// GetSubtypes returns the types (or methods) implementing the type at the given position.
+// Like GetImplementations this follows monikers into other repositories.
+//
+// Beyond the first level, the subtypes of each subtype are found through the implementations
+// of its symbol. Each subtype is expanded at most once per request.
+func (s *Service) GetSubtypes(
+	ctx context.Context,
+	args HierarchyRequestArgs,
+	requestState RequestState,
+	cursor Cursor,
+) (_ []HierarchyItem, nextCursor Cursor, err error) {
+	locations, nextCursor, err := s.gatherLocations(
+		ctx, args.PositionalRequestArgs, requestState, cursor,
+
+		s.operations.getSubtypes, // operation
+		"implementations",        // tableName
+		true,                     // includeReferencingIndexes
+		LocationExtractorFunc(s.lsifstore.ExtractImplementationLocationsFromPosition),
+	)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	documents := newHierarchyDocumentCache(s)
+
+	items, err := s.getHierarchyItems(ctx, args.RequestArgs, requestState, documents, locations)
+	if err != nil {
+		return nil, Cursor{}, err
+	}
+
+	if err := expandHierarchyItems(items, args.Depth-1, map[string]struct{}{}, func(item HierarchyItem) ([]HierarchyItem, error) {
+		locations, _, err := s.gatherLocationsBySymbolNames(
+			ctx, args.RequestArgs, requestState, Cursor{},
+
+			s.operations.getSubtypes, // operation
+			"implementations",        // tableName
+			true,                     // includeReferencingIndexes
+			[]string{item.Symbol},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.getHierarchyItems(ctx, args.RequestArgs, requestState, documents, locations)
+	}); err != nil {
+		return nil, Cursor{}, err
+	}
+
+	return items, nextCursor, nil
+}
+
+// expandHierarchyCalls sets the children of the given calls to the calls returned by next for
+// their items, recursively until depth levels have been added. Each symbol is expanded only
+// once, as recorded in expanded; later occurrences of the symbol (e.g. recursive calls) are
+// left as leaves.
+func expandHierarchyCalls(calls []HierarchyCall, depth int, expanded map[string]struct{}, next func(item HierarchyItem) ([]HierarchyCall, error)) error {
+	if depth <= 0 {
+		return nil
+	}
+
+	for i := range calls {
+		if _, ok := expanded[calls[i].Item.Symbol]; ok {
+			continue
+		}
+		expanded[calls[i].Item.Symbol] = struct{}{}
+
+		children, err := next(calls[i].Item)
+		if err != nil {
+			return err
+		}
+		if err := expandHierarchyCalls(children, depth-1, expanded, next); err != nil {
+			return err
+		}
+		calls[i].Children = children
+	}
+
+	return nil
+}
+
+// expandHierarchyItems is expandHierarchyCalls for the items of a type hierarchy.
+func expandHierarchyItems(items []HierarchyItem, depth int, expanded map[string]struct{}, next func(item HierarchyItem) ([]HierarchyItem, error)) error {
+	if depth <= 0 {
+		return nil
+	}
+
+	for i := range items {
+		if _, ok := expanded[items[i].Symbol]; ok {
+			continue
+		}
+		expanded[items[i].Symbol] = struct{}{}
+
+		children, err := next(items[i])
+		if err != nil {
+			return err
+		}
+		if err := expandHierarchyItems(children, depth-1, expanded, next); err != nil {
+			return err
+		}
+		items[i].Children = children
+	}
+
+	return nil
+}
+
+// getHierarchyItems pairs each of the given definition locations with the symbol defined
+// there. Locations without a definition occurrence are dropped.
+func (s *Service) getHierarchyItems(ctx context.Context, args RequestArgs, requestState RequestState, documents *hierarchyDocumentCache, locations []shared.UploadLocation) ([]HierarchyItem, error) {
+	items := make([]HierarchyItem, 0, len(locations))
+	for _, location := range locations {
+		document, rng, ok, err := documents.getIndexed(ctx, args, requestState, location)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+
+		occurrence, ok := findDefinition(document, rng)
+		if !ok {
+			continue
+		}
+
+		items = append(items, HierarchyItem{Symbol: occurrence.Symbol, Location: location})
+	}
+
+	return items, nil
+}
+
+// getDefinitionsBySymbolNames looks up the definitions of the given symbols with a single
+// request and pairs them with their symbols. A symbol may be defined more than once.
+func (s *Service) getDefinitionsBySymbolNames(ctx context.Context, args RequestArgs, requestState RequestState, documents *hierarchyDocumentCache, symbols []string) ([]HierarchyItem, error) {
+	args.Limit = len(symbols) * definitionsPerHierarchySymbol
+	locations, err := s.GetDefinitionsBySymbolNames(ctx, args, requestState, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getHierarchyItems(ctx, args, requestState, documents, locations)
+}
+
+// getHierarchyItemLocation converts a SCIP range within the given upload document into a
+// location in the requested commit.
+func (s *Service) getHierarchyItemLocation(ctx context.Context, args RequestArgs, requestState RequestState, dump uploadsshared.Dump, path string, scipRange []int32) (shared.UploadLocation, error) {
+	location, _, err := s.getUploadLocation(ctx, args, requestState, dump, shared.Location{
+		DumpID: dump.ID,
+		Path:   strings.TrimPrefix(path, dump.Root),
+		Range:  convertSCIPRange(scipRange),
+	})
+	return location, err
+}
+
+// getIndexedRange translates the range of a location in the requested commit back into the
+// commit of its upload. This is the inverse of getSourceRange.
+func (s *Service) getIndexedRange(ctx context.Context, args RequestArgs, requestState RequestState, location shared.UploadLocation) (shared.Range, bool, error) {
+	if location.Dump.RepositoryID != args.RepositoryID || location.TargetCommit == location.Dump.Commit {
+		return location.TargetRange, true, nil
+	}
+
+	_, indexedRange, ok, err := requestState.GitTreeTranslator.GetTargetCommitRangeFromSourceRange(ctx, location.Dump.Commit, location.Path, location.TargetRange, false)
+	if err != nil {
+		return shared.Range{}, false, errors.Wrap(err, "gitTreeTranslator.GetTargetCommitRangeFromSourceRange")
+	}
+	return indexedRange, ok, nil
+}
+
+// hierarchyDocumentCache caches the SCIP documents read while resolving a hierarchy, as
+// most locations of a single request share a handful of documents.
+type hierarchyDocumentCache struct {
+	s         *Service
+	documents map[string]*scip.Document
+}
+
+func newHierarchyDocumentCache(s *Service) *hierarchyDocumentCache {
+	return &hierarchyDocumentCache{s: s, documents: map[string]*scip.Document{}}
+}
+
+// getIndexed returns the document of the given location along with the range of the
+// location within the document. False is returned if the document no longer exists or the
+// range could not be translated into the commit of the upload.
+func (c *hierarchyDocumentCache) getIndexed(ctx context.Context, args RequestArgs, requestState RequestState, location shared.UploadLocation) (*scip.Document, shared.Range, bool, error) {
+	path := strings.TrimPrefix(location.Path, location.Dump.Root)
+	key := fmt.Sprintf("%d:%s", location.Dump.ID, path)
+
+	document, ok := c.documents[key]
+	if !ok {
+		var err error
+		document, err = c.s.lsifstore.SCIPDocument(ctx, location.Dump.ID, path)
+		if err != nil {
+			return nil, shared.Range{}, false, err
+		}
+		c.documents[key] = document
+	}
+	if document == nil {
+		return nil, shared.Range{}, false, nil
+	}
+
+	rng, ok, err := c.s.getIndexedRange(ctx, args, requestState, location)
+	if err != nil || !ok {
+		return nil, shared.Range{}, false, err
+	}
+	return document, rng, true, nil
+}
+
+// findDefinition returns the definition occurrence at the given range.
+func findDefinition(document *scip.Document, rng shared.Range) (*scip.Occurrence, bool) {
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" || !scip.SymbolRole_Definition.Matches(occurrence) {
+			continue
+		}
+		if rangeContainsPosition(convertSCIPRange(occurrence.Range), rng.Start) {
+			return occurrence, true
+		}
+	}
+
+	return nil, false
+}
+
+// findDefinitionBySymbol returns the definition occurrence of the given symbol in the document.
+func findDefinitionBySymbol(document *scip.Document, symbol string) (*scip.Occurrence, bool) {
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == symbol && scip.SymbolRole_Definition.Matches(occurrence) {
+			return occurrence, true
+		}
+	}
+
+	return nil, false
+}
+
+// findImplementedSymbols returns the symbols the given symbol implements, as recorded by the
+// relationships in the document defining the symbol.
+func findImplementedSymbols(document *scip.Document, symbol string) []string {
+	var symbols []string
+	for _, info := range document.Symbols {
+		if info.Symbol != symbol {
+			continue
+		}
+		for _, relationship := range info.Relationships {
+			if relationship.IsImplementation {
+				symbols = append(symbols, relationship.Symbol)
+			}
+		}
+	}
+
+	return symbols
+}
+
+type functionBody struct {
+	definition *scip.Occurrence
+	body       shared.Range
+}
+
+// findFunctionBodies returns the bodies of the functions defined in the given document. The
+// bodies are the enclosing ranges emitted by the indexer. Not all indexers emit enclosing
+// ranges, so for documents without any, each function is instead assumed to extend from its
+// definition up to the next function definition (or the end of the document). This attributes
+// code between two functions, e.g. a global variable initializer, to the preceding function.
+func findFunctionBodies(document *scip.Document) []functionBody {
+	var definitions []*scip.Occurrence
+	hasEnclosingRanges := false
+	for _, occurrence := range document.Occurrences {
+		if len(occurrence.EnclosingRange) > 0 {
+			hasEnclosingRanges = true
+		}
+		if scip.SymbolRole_Definition.Matches(occurrence) && isCallableSymbol(occurrence.Symbol) {
+			definitions = append(definitions, occurrence)
+		}
+	}
+
+	bodies := make([]functionBody, 0, len(definitions))
+	if hasEnclosingRanges {
+		for _, definition := range definitions {
+			if len(definition.EnclosingRange) > 0 {
+				bodies = append(bodies, functionBody{definition: definition, body: convertSCIPRange(definition.EnclosingRange)})
+			}
+		}
+
+		return bodies
+	}
+
+	sort.SliceStable(definitions, func(i, j int) bool {
+		return comparePositions(convertSCIPRange(definitions[i].Range).Start, convertSCIPRange(definitions[j].Range).Start) < 0
+	})
+	for i, definition := range definitions {
+		body := shared.Range{
+			Start: convertSCIPRange(definition.Range).Start,
+			End:   shared.Position{Line: math.MaxInt32},
+		}
+		if i+1 < len(definitions) {
+			body.End = convertSCIPRange(definitions[i+1].Range).Start
+		}
+		bodies = append(bodies, functionBody{definition: definition, body: body})
+	}
+
+	return bodies
+}
+
+// findEnclosingDefinition returns the innermost definition of a function whose body contains
+// the given range. See findFunctionBodies for how bodies are determined.
+func findEnclosingDefinition(document *scip.Document, rng shared.Range) (*scip.Occurrence, bool) {
+	var innermost *functionBody
+	for _, body := range findFunctionBodies(document) {
+		body := body
+		if !rangeContainsPosition(body.body, rng.Start) {
+			continue
+		}
+		if innermost == nil || rangeContainsPosition(innermost.body, body.body.Start) {
+			innermost = &body
+		}
+	}
+
+	if innermost == nil {
+		return nil, false
+	}
+	return innermost.definition, true
+}
+
+type outgoingCallSites struct {
+	symbol string
+	ranges [][]int32
+}
+
+// findOutgoingCalls returns the functions referenced within the body of the given definition,
+// in the order of their first call site. See findFunctionBodies for how bodies are determined.
+// Calls made by nested functions are included when the indexer emits enclosing ranges, as
+// they are usually closures which are invoked by the outer function.
+func findOutgoingCalls(document *scip.Document, definition *scip.Occurrence) []outgoingCallSites {
+	var body shared.Range
+	var ok bool
+	for _, candidate := range findFunctionBodies(document) {
+		if candidate.definition == definition {
+			body, ok = candidate.body, true
+			break
+		}
+	}
+	if !ok {
+		return nil
+	}
+
+	occurrences := make([]*scip.Occurrence, 0, len(document.Occurrences))
+	for _, occurrence := range document.Occurrences {
+		if occurrence.Symbol == "" || scip.SymbolRole_Definition.Matches(occurrence) {
+			continue
+		}
+		if !rangeContainsPosition(body, convertSCIPRange(occurrence.Range).Start) || !isCallableSymbol(occurrence.Symbol) {
+			continue
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return comparePositions(convertSCIPRange(occurrences[i].Range).Start, convertSCIPRange(occurrences[j].Range).Start) < 0
+	})
+
+	var calls []outgoingCallSites
+	callIndexBySymbol := map[string]int{}
+	for _, occurrence := range occurrences {
+		if i, ok := callIndexBySymbol[occurrence.Symbol]; ok {
+			calls[i].ranges = append(calls[i].ranges, occurrence.Range)
+			continue
+		}
+		callIndexBySymbol[occurrence.Symbol] = len(calls)
+		calls = append(calls, outgoingCallSites{symbol: occurrence.Symbol, ranges: [][]int32{occurrence.Range}})
+	}
+
+	return calls
+}
+
+// isCallableSymbol returns true if the given symbol is a global function or method. SCIP
+// uses the method descriptor for both.
+func isCallableSymbol(symbol string) bool {
+	if symbol == "" || scip.IsLocalSymbol(symbol) {
+		return false
+	}
+
+	parsed, err := scip.ParseSymbol(symbol)
+	if err != nil || len(parsed.Descriptors) == 0 {
+		return false
+	}
+
+	return parsed.Descriptors[len(parsed.Descriptors)-1].Suffix == scip.Descriptor_Method
+}
+
+func convertSCIPRange(r []int32) shared.Range {
+	scipRange := scip.NewRange(r)
+
+	return shared.Range{
+		Start: shared.Position{Line: int(scipRange.Start.Line), Character: int(scipRange.Start.Character)},
+		End:   shared.Position{Line: int(scipRange.End.Line), Character: int(scipRange.End.Character)},
+	}
+}
+
+func comparePositions(a, b shared.Position) int {
+	if a.Line != b.Line {
+		return a.Line - b.Line
+	}
+	return a.Character - b.Character
+}
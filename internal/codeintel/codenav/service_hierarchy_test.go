@@ -0,0 +1,197 @@
+package codenav
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+)
+
+const (
+	hierarchyMain   = "scip-go gomod example v1 `example`/main()."
+	hierarchyHelper = "scip-go gomod example v1 `example`/helper()."
+	hierarchyPrint  = "scip-go gomod fmt v1 `fmt`/Println()."
+	hierarchyConfig = "scip-go gomod example v1 `example`/config."
+)
+
+// hierarchyDocument models the following file:
+//
+//	0: var config = helper()
+//	1:
+//	2: func main() {
+//	3:     helper()
+//	4:     fmt.Println(config)
+//	5:     helper()
+//	6: }
+//	7:
+//	8: func helper() {
+//	9:     fmt.Println()
+//	10: }
+func hierarchyDocument() *scip.Document {
+	definition := int32(scip.SymbolRole_Definition)
+
+	return &scip.Document{
+		RelativePath: "main.go",
+		Occurrences: []*scip.Occurrence{
+			{Range: []int32{0, 4, 10}, Symbol: hierarchyConfig, SymbolRoles: definition},
+			{Range: []int32{0, 13, 19}, Symbol: hierarchyHelper},
+			{Range: []int32{2, 5, 9}, Symbol: hierarchyMain, SymbolRoles: definition, EnclosingRange: []int32{2, 0, 6, 1}},
+			{Range: []int32{3, 4, 10}, Symbol: hierarchyHelper},
+			{Range: []int32{4, 8, 15}, Symbol: hierarchyPrint},
+			{Range: []int32{4, 16, 22}, Symbol: hierarchyConfig},
+			{Range: []int32{5, 4, 10}, Symbol: hierarchyHelper},
+			{Range: []int32{8, 5, 11}, Symbol: hierarchyHelper, SymbolRoles: definition, EnclosingRange: []int32{8, 0, 10, 1}},
+			{Range: []int32{9, 8, 15}, Symbol: hierarchyPrint},
+		},
+	}
+}
+
+func TestFindEnclosingDefinition(t *testing.T) {
+	document := hierarchyDocument()
+
+	for _, testCase := range []struct {
+		position shared.Position
+		want     string
+	}{
+		{position: shared.Position{Line: 3, Character: 4}, want: hierarchyMain},
+		{position: shared.Position{Line: 9, Character: 8}, want: hierarchyHelper},
+		// global variable initializer
+		{position: shared.Position{Line: 0, Character: 13}, want: ""},
+	} {
+		occurrence, ok := findEnclosingDefinition(document, shared.Range{Start: testCase.position, End: testCase.position})
+		var got string
+		if ok {
+			got = occurrence.Symbol
+		}
+		if got != testCase.want {
+			t.Errorf("unexpected enclosing definition at %v. want=%q have=%q", testCase.position, testCase.want, got)
+		}
+	}
+}
+
+func TestFindOutgoingCalls(t *testing.T) {
+	document := hierarchyDocument()
+
+	definition, ok := findDefinition(document, shared.Range{Start: shared.Position{Line: 2, Character: 5}})
+	if !ok {
+		t.Fatal("expected definition of main")
+	}
+
+	expected := []outgoingCallSites{
+		{symbol: hierarchyHelper, ranges: [][]int32{{3, 4, 10}, {5, 4, 10}}},
+		{symbol: hierarchyPrint, ranges: [][]int32{{4, 8, 15}}},
+	}
+	if diff := cmp.Diff(expected, findOutgoingCalls(document, definition), cmp.AllowUnexported(outgoingCallSites{})); diff != "" {
+		t.Errorf("unexpected outgoing calls (-want +got):\n%s", diff)
+	}
+
+	// Definitions without an enclosing range have no known body.
+	config, ok := findDefinition(document, shared.Range{Start: shared.Position{Line: 0, Character: 4}})
+	if !ok {
+		t.Fatal("expected definition of config")
+	}
+	if calls := findOutgoingCalls(document, config); len(calls) != 0 {
+		t.Errorf("unexpected outgoing calls: %v", calls)
+	}
+}
+
+func TestFindFunctionBodiesWithoutEnclosingRanges(t *testing.T) {
+	document := hierarchyDocument()
+	for _, occurrence := range document.Occurrences {
+		occurrence.EnclosingRange = nil
+	}
+
+	for _, testCase := range []struct {
+		position shared.Position
+		want     string
+	}{
+		{position: shared.Position{Line: 3, Character: 4}, want: hierarchyMain},
+		{position: shared.Position{Line: 9, Character: 8}, want: hierarchyHelper},
+		// before the first function
+		{position: shared.Position{Line: 0, Character: 13}, want: ""},
+	} {
+		occurrence, ok := findEnclosingDefinition(document, shared.Range{Start: testCase.position, End: testCase.position})
+		var got string
+		if ok {
+			got = occurrence.Symbol
+		}
+		if got != testCase.want {
+			t.Errorf("unexpected enclosing definition at %v. want=%q have=%q", testCase.position, testCase.want, got)
+		}
+	}
+
+	definition, ok := findDefinition(document, shared.Range{Start: shared.Position{Line: 2, Character: 5}})
+	if !ok {
+		t.Fatal("expected definition of main")
+	}
+
+	expected := []outgoingCallSites{
+		{symbol: hierarchyHelper, ranges: [][]int32{{3, 4, 10}, {5, 4, 10}}},
+		{symbol: hierarchyPrint, ranges: [][]int32{{4, 8, 15}}},
+	}
+	if diff := cmp.Diff(expected, findOutgoingCalls(document, definition), cmp.AllowUnexported(outgoingCallSites{})); diff != "" {
+		t.Errorf("unexpected outgoing calls (-want +got):\n%s", diff)
+	}
+}
+
+func TestExpandHierarchyCalls(t *testing.T) {
+	// main calls helper, which calls itself and fmt.Println. The recursive call to helper is
+	// left as a leaf.
+	callees := map[string][]string{
+		hierarchyMain:   {hierarchyHelper},
+		hierarchyHelper: {hierarchyHelper, hierarchyPrint},
+	}
+	var expandedSymbols []string
+	next := func(item HierarchyItem) ([]HierarchyCall, error) {
+		expandedSymbols = append(expandedSymbols, item.Symbol)
+
+		var calls []HierarchyCall
+		for _, symbol := range callees[item.Symbol] {
+			calls = append(calls, HierarchyCall{Item: HierarchyItem{Symbol: symbol}})
+		}
+		return calls, nil
+	}
+
+	calls := []HierarchyCall{{Item: HierarchyItem{Symbol: hierarchyMain}}}
+	if err := expandHierarchyCalls(calls, 3, map[string]struct{}{}, next); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := []HierarchyCall{
+		{
+			Item: HierarchyItem{Symbol: hierarchyMain},
+			Children: []HierarchyCall{
+				{
+					Item: HierarchyItem{Symbol: hierarchyHelper},
+					Children: []HierarchyCall{
+						{Item: HierarchyItem{Symbol: hierarchyHelper}},
+						{Item: HierarchyItem{Symbol: hierarchyPrint}},
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{hierarchyMain, hierarchyHelper, hierarchyPrint}, expandedSymbols); diff != "" {
+		t.Errorf("unexpected expanded symbols (-want +got):\n%s", diff)
+	}
+}
+
+func TestIsCallableSymbol(t *testing.T) {
+	for symbol, want := range map[string]bool{
+		hierarchyMain:   true,
+		hierarchyConfig: false,
+		"scip-go gomod example v1 `example`/Server#Serve().": true,
+		"scip-go gomod example v1 `example`/Server#":         false,
+		"local 1": false,
+		"":        false,
+	} {
+		if got := isCallableSymbol(symbol); got != want {
+			t.Errorf("unexpected isCallableSymbol(%q). want=%v have=%v", symbol, want, got)
+		}
+	}
+}
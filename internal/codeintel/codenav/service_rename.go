@@ -0,0 +1,460 @@
+package codenav
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ErrIllegalRenameName occurs when the new name of a rename is not an identifier.
+var ErrIllegalRenameName = errors.New("illegal new name")
+
+// renameDiffContextLines is the number of unchanged lines surrounding each hunk of a rename patch.
+const renameDiffContextLines = 3
+
+// GetRenamePreview returns the patches renaming the symbol at the given position to newName. The
+// occurrences of the symbol are gathered like GetReferences does, so patches are returned for every
+// repository with an index referencing the symbol, grouped by repository and indexed commit.
+//
+// Each edit replaces the precise range of an occurrence. Occurrences which cannot be edited
+// safely, because the index is stale or the text at the range isn't the name of the symbol, are
+// returned as issues instead. Occurrences in other repositories are only edited if they were
+// indexed at the head of the repository's default branch, since renaming them on an old commit
+// would produce a patch that no longer applies. At most args.Limit occurrences are considered.
+func (s *Service) GetRenamePreview(ctx context.Context, args PositionalRequestArgs, requestState RequestState, newName string) (_ RenamePreview, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getRenamePreview, serviceObserverThreshold, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", args.RepositoryID),
+		attribute.String("commit", args.Commit),
+		attribute.String("path", args.Path),
+		attribute.Int("line", args.Line),
+		attribute.Int("character", args.Character),
+		attribute.Int("limit", args.Limit),
+	}})
+	defer endObservation()
+
+	if !isRenameIdentifier(newName) {
+		return RenamePreview{}, ErrIllegalRenameName
+	}
+
+	locations, truncated, err := s.gatherRenameLocations(ctx, args, requestState)
+	if err != nil {
+		return RenamePreview{}, err
+	}
+	trace.AddEvent("GatherRenameLocations", attribute.Int("numLocations", len(locations)), attribute.Bool("truncated", truncated))
+
+	files := newRenameFileCache(s)
+	oldName, err := files.oldName(ctx, args, locations)
+	if err != nil {
+		return RenamePreview{}, err
+	}
+
+	preview := RenamePreview{
+		OldName:   oldName,
+		NewName:   newName,
+		Truncated: truncated,
+	}
+	if oldName == "" {
+		// We don't know what we're renaming, so none of the occurrences can be checked.
+		for _, location := range locations {
+			preview.Issues = append(preview.Issues, RenameIssue{Location: location, Reason: RenameIssueTextMismatch})
+		}
+		return preview, nil
+	}
+
+	type patchKey struct {
+		repositoryID int
+		commit       string
+	}
+	editsByPatch := map[patchKey]map[string][]shared.UploadLocation{}
+	var patches []patchKey
+
+	heads := map[int]api.CommitID{}
+	for _, location := range locations {
+		if location.Dump.RepositoryID == args.RepositoryID && location.TargetCommit != args.Commit {
+			// The range is relative to the indexed commit and couldn't be adjusted
+			preview.Issues = append(preview.Issues, RenameIssue{Location: location, Reason: RenameIssueStale})
+			continue
+		}
+		if location.Dump.RepositoryID != args.RepositoryID {
+			head, ok := heads[location.Dump.RepositoryID]
+			if !ok {
+				if _, head, err = s.gitserver.GetDefaultBranch(ctx, api.RepoName(location.Dump.RepositoryName), true); err != nil {
+					return RenamePreview{}, err
+				}
+				heads[location.Dump.RepositoryID] = head
+			}
+			if head == "" || string(head) != location.TargetCommit {
+				preview.Issues = append(preview.Issues, RenameIssue{Location: location, Reason: RenameIssueStale})
+				continue
+			}
+		}
+
+		content, ok, err := files.get(ctx, location)
+		if err != nil {
+			return RenamePreview{}, err
+		}
+		if !ok {
+			preview.Issues = append(preview.Issues, RenameIssue{Location: location, Reason: RenameIssueMissingFile})
+			continue
+		}
+		if text, ok := textAtRange(content, location.TargetRange); !ok || text != oldName {
+			preview.Issues = append(preview.Issues, RenameIssue{Location: location, Reason: RenameIssueTextMismatch})
+			continue
+		}
+
+		key := patchKey{repositoryID: location.Dump.RepositoryID, commit: location.TargetCommit}
+		if _, ok := editsByPatch[key]; !ok {
+			editsByPatch[key] = map[string][]shared.UploadLocation{}
+			patches = append(patches, key)
+		}
+		editsByPatch[key][location.Path] = append(editsByPatch[key][location.Path], location)
+	}
+
+	for _, key := range patches {
+		editsByPath := editsByPatch[key]
+
+		paths := make([]string, 0, len(editsByPath))
+		for path := range editsByPath {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+
+		patch := RenamePatch{
+			RepositoryID: key.repositoryID,
+			Commit:       key.commit,
+		}
+
+		var diff strings.Builder
+		for _, path := range paths {
+			edits := editsByPath[path]
+			sort.Slice(edits, func(i, j int) bool {
+				return comparePositions(edits[i].TargetRange.Start, edits[j].TargetRange.Start) < 0
+			})
+
+			content, _, err := files.get(ctx, edits[0])
+			if err != nil {
+				return RenamePreview{}, err
+			}
+
+			ranges := make([]shared.Range, 0, len(edits))
+			for _, edit := range edits {
+				// The text at each range was checked above, so the conversion succeeds
+				rng, _ := byteRange(content, edit.TargetRange)
+				ranges = append(ranges, rng)
+			}
+
+			patch.RepositoryName = edits[0].Dump.RepositoryName
+			patch.Edits = append(patch.Edits, edits...)
+			diff.WriteString(renameFileDiff(path, content, ranges, newName))
+		}
+		patch.Diff = diff.String()
+
+		preview.Patches = append(preview.Patches, patch)
+	}
+
+	sort.Slice(preview.Patches, func(i, j int) bool {
+		if preview.Patches[i].RepositoryName != preview.Patches[j].RepositoryName {
+			return preview.Patches[i].RepositoryName < preview.Patches[j].RepositoryName
+		}
+		return preview.Patches[i].Commit < preview.Patches[j].Commit
+	})
+
+	return preview, nil
+}
+
+// gatherRenameLocations returns the distinct definitions and references of the symbol at the given
+// position. The returned flag is true if there may be more than args.Limit such locations.
+func (s *Service) gatherRenameLocations(ctx context.Context, args PositionalRequestArgs, requestState RequestState) ([]shared.UploadLocation, bool, error) {
+	locations, err := s.GetDefinitions(ctx, args, requestState)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var cursor Cursor
+	for len(locations) < args.Limit {
+		pageArgs := args
+		pageArgs.Limit = args.Limit - len(locations)
+
+		references, nextCursor, err := s.GetReferences(ctx, pageArgs, requestState, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		locations = append(locations, references...)
+
+		if nextCursor.Phase == "done" {
+			return deduplicateUploadLocations(locations), false, nil
+		}
+		cursor = nextCursor
+	}
+
+	return deduplicateUploadLocations(locations), true, nil
+}
+
+// deduplicateUploadLocations returns the given locations without repeated ranges, e.g. a definition
+// which is also returned as a reference.
+func deduplicateUploadLocations(locations []shared.UploadLocation) []shared.UploadLocation {
+	type locationKey struct {
+		repositoryID int
+		commit       string
+		path         string
+		rng          shared.Range
+	}
+
+	seen := make(map[locationKey]struct{}, len(locations))
+	deduplicated := locations[:0]
+	for _, location := range locations {
+		key := locationKey{location.Dump.RepositoryID, location.TargetCommit, location.Path, location.TargetRange}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		deduplicated = append(deduplicated, location)
+	}
+
+	return deduplicated
+}
+
+// renameFileCache reads the content of files containing occurrences of a renamed symbol at most once.
+type renameFileCache struct {
+	svc   *Service
+	files map[string]renameFile
+}
+
+type renameFile struct {
+	content []byte
+	ok      bool
+}
+
+func newRenameFileCache(svc *Service) *renameFileCache {
+	return &renameFileCache{svc: svc, files: map[string]renameFile{}}
+}
+
+// get returns the content of the file of the given location at its target commit. The returned flag
+// is false if the file does not exist at that commit.
+func (c *renameFileCache) get(ctx context.Context, location shared.UploadLocation) ([]byte, bool, error) {
+	key := fmt.Sprintf("%d:%s:%s", location.Dump.RepositoryID, location.TargetCommit, location.Path)
+	if file, ok := c.files[key]; ok {
+		return file.content, file.ok, nil
+	}
+
+	content, err := c.svc.gitserver.ReadFile(ctx, api.RepoName(location.Dump.RepositoryName), api.CommitID(location.TargetCommit), location.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, false, err
+	}
+
+	file := renameFile{content: content, ok: err == nil}
+	c.files[key] = file
+	return file.content, file.ok, nil
+}
+
+// oldName returns the current name of the renamed symbol, which is the text of the occurrence at
+// the requested position. If that occurrence couldn't be adjusted to the requested commit, the text
+// of the first readable definition or reference is used instead. An empty string is returned if no
+// occurrence can be read.
+func (c *renameFileCache) oldName(ctx context.Context, args PositionalRequestArgs, locations []shared.UploadLocation) (string, error) {
+	position := shared.Position{Line: args.Line, Character: args.Character}
+
+	var fallback string
+	for _, location := range locations {
+		isRequested := location.Dump.RepositoryID == args.RepositoryID &&
+			location.TargetCommit == args.Commit &&
+			location.Path == args.Path &&
+			rangeContainsPosition(location.TargetRange, position)
+		if !isRequested && fallback != "" {
+			continue
+		}
+
+		content, ok, err := c.get(ctx, location)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			continue
+		}
+		text, ok := textAtRange(content, location.TargetRange)
+		if !ok || text == "" {
+			continue
+		}
+
+		if isRequested {
+			return text, nil
+		}
+		fallback = text
+	}
+
+	return fallback, nil
+}
+
+// isRenameIdentifier returns true if name is a plausible identifier in most languages.
+func isRenameIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for i, r := range name {
+		if r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r)) {
+			continue
+		}
+		return false
+	}
+
+	return true
+}
+
+// textAtRange returns the text of content within the given single-line range, whose characters
+// are UTF-16 code unit offsets within the line. The returned flag is false if the range is not
+// within content.
+func textAtRange(content []byte, rng shared.Range) (string, bool) {
+	rng, ok := byteRange(content, rng)
+	if !ok {
+		return "", false
+	}
+
+	line := splitLines(content)[rng.Start.Line]
+	return line[rng.Start.Character:rng.End.Character], true
+}
+
+// byteRange converts the characters of the given single-line range from UTF-16 code unit offsets
+// to byte offsets within the line. Like LSIF, the SCIP indexes we read don't record a position
+// encoding, and indexers report characters as UTF-16 code units as the language server protocol
+// does. The returned flag is false if the range is not within content.
+func byteRange(content []byte, rng shared.Range) (shared.Range, bool) {
+	if rng.Start.Line != rng.End.Line || rng.Start.Character > rng.End.Character {
+		return shared.Range{}, false
+	}
+
+	lines := splitLines(content)
+	if rng.Start.Line < 0 || rng.Start.Line >= len(lines) {
+		return shared.Range{}, false
+	}
+	line := strings.TrimSuffix(lines[rng.Start.Line], "\n")
+
+	start, ok := utf16ToByteOffset(line, rng.Start.Character)
+	if !ok {
+		return shared.Range{}, false
+	}
+	end, ok := utf16ToByteOffset(line, rng.End.Character)
+	if !ok {
+		return shared.Range{}, false
+	}
+
+	rng.Start.Character = start
+	rng.End.Character = end
+	return rng, true
+}
+
+// utf16ToByteOffset returns the byte offset of the given UTF-16 code unit offset within line. The
+// returned flag is false if the offset is negative, past the end of the line, or splits a
+// surrogate pair.
+func utf16ToByteOffset(line string, offset int) (int, bool) {
+	units := 0
+	for i, r := range line {
+		if units == offset {
+			return i, true
+		}
+		if units > offset {
+			return 0, false
+		}
+		if r >= 0x10000 {
+			// Encoded as a surrogate pair
+			units += 2
+		} else {
+			units++
+		}
+	}
+	if units == offset {
+		return len(line), true
+	}
+
+	return 0, false
+}
+
+// splitLines splits content after each newline. The last line does not end with a newline if
+// the file doesn't.
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// renameFileDiff returns a unified diff of the file at path replacing each of the given ranges by
+// newName. The ranges must be single-line, sorted and not overlapping.
+func renameFileDiff(path string, content []byte, ranges []shared.Range, newName string) string {
+	lines := splitLines(content)
+
+	// Replace from the end of each line so earlier offsets remain valid
+	changed := map[int]string{}
+	var changedLines []int
+	for i := len(ranges) - 1; i >= 0; i-- {
+		rng := ranges[i]
+		line, ok := changed[rng.Start.Line]
+		if !ok {
+			line = lines[rng.Start.Line]
+			changedLines = append(changedLines, rng.Start.Line)
+		}
+		changed[rng.Start.Line] = line[:rng.Start.Character] + newName + line[rng.End.Character:]
+	}
+	sort.Ints(changedLines)
+
+	// Group changed lines into hunks, merging hunks whose context overlaps
+	type hunk struct{ start, end int }
+	var hunks []hunk
+	for _, line := range changedLines {
+		start := line - renameDiffContextLines
+		if start < 0 {
+			start = 0
+		}
+		end := line + renameDiffContextLines + 1
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		if n := len(hunks); n > 0 && start <= hunks[n-1].end {
+			hunks[n-1].end = end
+			continue
+		}
+		hunks = append(hunks, hunk{start: start, end: end})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "diff --git a/%s b/%s\n", path, path)
+	fmt.Fprintf(&b, "--- a/%s\n", path)
+	fmt.Fprintf(&b, "+++ b/%s\n", path)
+
+	for _, h := range hunks {
+		// Renames only change lines in place, so both sides have the same extent
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", h.start+1, h.end-h.start, h.start+1, h.end-h.start)
+
+		for i := h.start; i < h.end; i++ {
+			if newLine, ok := changed[i]; ok {
+				writeDiffLine(&b, '-', lines[i])
+				writeDiffLine(&b, '+', newLine)
+			} else {
+				writeDiffLine(&b, ' ', lines[i])
+			}
+		}
+	}
+
+	return b.String()
+}
+
+func writeDiffLine(b *strings.Builder, prefix byte, line string) {
+	b.WriteByte(prefix)
+	b.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		b.WriteString("\n\\ No newline at end of file\n")
+	}
+}
@@ -0,0 +1,121 @@
+package codenav
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/internal/codeintel/uploads/shared"
+)
+
+func TestRenameFileDiff(t *testing.T) {
+	content := "package main\n\nfunc helper() {}\n\nfunc main() {\n\thelper()\n\thelper(); helper()\n}\n\n\n\n\n\n\n\nvar x = helper"
+	ranges := []shared.Range{
+		{Start: shared.Position{Line: 2, Character: 5}, End: shared.Position{Line: 2, Character: 11}},
+		{Start: shared.Position{Line: 5, Character: 1}, End: shared.Position{Line: 5, Character: 7}},
+		{Start: shared.Position{Line: 6, Character: 1}, End: shared.Position{Line: 6, Character: 7}},
+		{Start: shared.Position{Line: 6, Character: 11}, End: shared.Position{Line: 6, Character: 17}},
+		{Start: shared.Position{Line: 15, Character: 8}, End: shared.Position{Line: 15, Character: 14}},
+	}
+
+	expected := "" +
+		"diff --git a/main.go b/main.go\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1,10 +1,10 @@\n" +
+		" package main\n" +
+		" \n" +
+		"-func helper() {}\n" +
+		"+func assist() {}\n" +
+		" \n" +
+		" func main() {\n" +
+		"-\thelper()\n" +
+		"+\tassist()\n" +
+		"-\thelper(); helper()\n" +
+		"+\tassist(); assist()\n" +
+		" }\n" +
+		" \n" +
+		" \n" +
+		"@@ -13,4 +13,4 @@\n" +
+		" \n" +
+		" \n" +
+		" \n" +
+		"-var x = helper\n" +
+		"\\ No newline at end of file\n" +
+		"+var x = assist\n" +
+		"\\ No newline at end of file\n"
+
+	if diff := cmp.Diff(expected, renameFileDiff("main.go", []byte(content), ranges, "assist")); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%s", diff)
+	}
+}
+
+func TestTextAtRange(t *testing.T) {
+	content := []byte("package main\r\n\nfunc helper() {}\n// é 😀 helper")
+
+	for _, testCase := range []struct {
+		rng    shared.Range
+		want   string
+		wantOK bool
+	}{
+		{rng: shared.Range{Start: shared.Position{Line: 0, Character: 8}, End: shared.Position{Line: 0, Character: 12}}, want: "main", wantOK: true},
+		{rng: shared.Range{Start: shared.Position{Line: 2, Character: 5}, End: shared.Position{Line: 2, Character: 11}}, want: "helper", wantOK: true},
+		// characters are UTF-16 code units
+		{rng: shared.Range{Start: shared.Position{Line: 3, Character: 8}, End: shared.Position{Line: 3, Character: 14}}, want: "helper", wantOK: true},
+		// splits a surrogate pair
+		{rng: shared.Range{Start: shared.Position{Line: 3, Character: 6}, End: shared.Position{Line: 3, Character: 14}}},
+		// past the end of the line
+		{rng: shared.Range{Start: shared.Position{Line: 1, Character: 0}, End: shared.Position{Line: 1, Character: 3}}},
+		{rng: shared.Range{Start: shared.Position{Line: 3, Character: 8}, End: shared.Position{Line: 3, Character: 15}}},
+		// past the end of the file
+		{rng: shared.Range{Start: shared.Position{Line: 4, Character: 0}, End: shared.Position{Line: 4, Character: 1}}},
+		// multi-line
+		{rng: shared.Range{Start: shared.Position{Line: 0, Character: 0}, End: shared.Position{Line: 2, Character: 1}}},
+	} {
+		text, ok := textAtRange(content, testCase.rng)
+		if text != testCase.want || ok != testCase.wantOK {
+			t.Errorf("unexpected text at %v. want=%q,%v have=%q,%v", testCase.rng, testCase.want, testCase.wantOK, text, ok)
+		}
+	}
+}
+
+func TestIsRenameIdentifier(t *testing.T) {
+	for name, want := range map[string]bool{
+		"helper":      true,
+		"_helper2":    true,
+		"$scope":      true,
+		"héllo":       true,
+		"":            false,
+		"2helper":     false,
+		"helper()":    false,
+		"pkg.Helper":  false,
+		"help er":     false,
+		"helper\nfoo": false,
+	} {
+		if got := isRenameIdentifier(name); got != want {
+			t.Errorf("unexpected isRenameIdentifier(%q). want=%v have=%v", name, want, got)
+		}
+	}
+}
+
+func TestDeduplicateUploadLocations(t *testing.T) {
+	dump1 := uploadsshared.Dump{ID: 1, RepositoryID: 50}
+	dump2 := uploadsshared.Dump{ID: 2, RepositoryID: 50}
+	rng1 := shared.Range{Start: shared.Position{Line: 1, Character: 2}, End: shared.Position{Line: 1, Character: 5}}
+	rng2 := shared.Range{Start: shared.Position{Line: 3, Character: 2}, End: shared.Position{Line: 3, Character: 5}}
+
+	locations := []shared.UploadLocation{
+		{Dump: dump1, Path: "a.go", TargetCommit: "deadbeef", TargetRange: rng1},
+		{Dump: dump1, Path: "a.go", TargetCommit: "deadbeef", TargetRange: rng2},
+		// same range from another upload of the repository
+		{Dump: dump2, Path: "a.go", TargetCommit: "deadbeef", TargetRange: rng1},
+		{Dump: dump1, Path: "b.go", TargetCommit: "deadbeef", TargetRange: rng1},
+		{Dump: dump1, Path: "a.go", TargetCommit: "cafebabe", TargetRange: rng1},
+	}
+
+	expected := []shared.UploadLocation{locations[0], locations[1], locations[3], locations[4]}
+	if diff := cmp.Diff(expected, deduplicateUploadLocations(locations)); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+}
@@ -14,6 +14,11 @@ type CodeNavService interface {
 	GetImplementations(ctx context.Context, args codenav.PositionalRequestArgs, requestState codenav.RequestState, cursor codenav.Cursor) (_ []shared.UploadLocation, nextCursor codenav.Cursor, err error)
 	GetPrototypes(ctx context.Context, args codenav.PositionalRequestArgs, requestState codenav.RequestState, cursor codenav.Cursor) (_ []shared.UploadLocation, nextCursor codenav.Cursor, err error)
 	GetDefinitions(ctx context.Context, args codenav.PositionalRequestArgs, requestState codenav.RequestState) (_ []shared.UploadLocation, err error)
+	GetIncomingCalls(ctx context.Context, args codenav.HierarchyRequestArgs, requestState codenav.RequestState, cursor codenav.Cursor) (_ []codenav.HierarchyCall, nextCursor codenav.Cursor, err error)
+	GetOutgoingCalls(ctx context.Context, args codenav.HierarchyRequestArgs, requestState codenav.RequestState) (_ []codenav.HierarchyCall, err error)
+	GetSupertypes(ctx context.Context, args codenav.HierarchyRequestArgs, requestState codenav.RequestState, cursor codenav.Cursor) (_ []codenav.HierarchyItem, nextCursor codenav.Cursor, err error)
+	GetSubtypes(ctx context.Context, args codenav.HierarchyRequestArgs, requestState codenav.RequestState, cursor codenav.Cursor) (_ []codenav.HierarchyItem, nextCursor codenav.Cursor, err error)
+	GetRenamePreview(ctx context.Context, args codenav.PositionalRequestArgs, requestState codenav.RequestState, newName string) (_ codenav.RenamePreview, err error)
 	GetDiagnostics(ctx context.Context, args codenav.PositionalRequestArgs, requestState codenav.RequestState) (diagnosticsAtUploads []codenav.DiagnosticAtUpload, _ int, err error)
 	GetRanges(ctx context.Context, args codenav.PositionalRequestArgs, requestState codenav.RequestState, startLine, endLine int) (adjustedRanges []codenav.AdjustedCodeIntelligenceRange, err error)
 	GetStencil(ctx context.Context, args codenav.PositionalRequestArgs, requestState codenav.RequestState) (adjustedRanges []shared.Range, err error)
@@ -20,6 +20,11 @@ type operations struct {
 	references      *observation.Operation
 	implementations *observation.Operation
 	prototypes      *observation.Operation
+	incomingCalls   *observation.Operation
+	outgoingCalls   *observation.Operation
+	supertypes      *observation.Operation
+	subtypes        *observation.Operation
+	renamePreview   *observation.Operation
 	diagnostics     *observation.Operation
 	stencil         *observation.Operation
 	ranges          *observation.Operation
@@ -50,6 +55,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 		references:      op("References"),
 		implementations: op("Implementations"),
 		prototypes:      op("Prototypes"),
+		incomingCalls:   op("IncomingCalls"),
+		outgoingCalls:   op("OutgoingCalls"),
+		supertypes:      op("Supertypes"),
+		subtypes:        op("Subtypes"),
+		renamePreview:   op("RenamePreview"),
 		diagnostics:     op("Diagnostics"),
 		stencil:         op("Stencil"),
 		ranges:          op("Ranges"),
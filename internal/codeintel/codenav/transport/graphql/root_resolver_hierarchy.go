@@ -0,0 +1,287 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/shared/resolvers/gitresolvers"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+// DefaultHierarchyPageSize is the hierarchy result page size when no limit is supplied.
+const DefaultHierarchyPageSize = 100
+
+// MaximumHierarchyDepth is the maximum number of hierarchy levels returned by a single request.
+const MaximumHierarchyDepth = 5
+
+var ErrIllegalDepth = errors.New("illegal depth")
+
+// IncomingCalls returns the functions calling the function at the given position, along with
+// the call sites within each caller.
+func (r *gitBlobLSIFDataResolver) IncomingCalls(ctx context.Context, args *resolverstubs.LSIFPagedHierarchyQueryArgs) (_ resolverstubs.CallHierarchyConnectionResolver, err error) {
+	requestArgs, cursor, err := r.pagedHierarchyRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.incomingCalls, time.Second, getObservationArgs(requestArgs.PositionalRequestArgs))
+	defer endObservation()
+
+	calls, callsCursor, err := r.codeNavSvc.GetIncomingCalls(ctx, requestArgs, r.requestState, cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetIncomingCalls")
+	}
+
+	var nextCursor string
+	if callsCursor.Phase != "done" {
+		nextCursor = encodeTraversalCursor(callsCursor)
+	}
+
+	return newCallHierarchyConnectionResolver(calls, pointers.NonZeroPtr(nextCursor), r.locationResolver), nil
+}
+
+// OutgoingCalls returns the functions called by the function at the given position, along
+// with the call sites within that function.
+func (r *gitBlobLSIFDataResolver) OutgoingCalls(ctx context.Context, args *resolverstubs.LSIFHierarchyQueryArgs) (_ resolverstubs.CallHierarchyConnectionResolver, err error) {
+	depth, err := hierarchyDepth(args.Depth)
+	if err != nil {
+		return nil, err
+	}
+
+	requestArgs := codenav.HierarchyRequestArgs{
+		PositionalRequestArgs: codenav.PositionalRequestArgs{
+			RequestArgs: codenav.RequestArgs{
+				RepositoryID: r.requestState.RepositoryID,
+				Commit:       r.requestState.Commit,
+				Limit:        DefaultHierarchyPageSize,
+			},
+			Path:      r.requestState.Path,
+			Line:      int(args.Line),
+			Character: int(args.Character),
+		},
+		Depth: depth,
+	}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.outgoingCalls, time.Second, getObservationArgs(requestArgs.PositionalRequestArgs))
+	defer endObservation()
+
+	calls, err := r.codeNavSvc.GetOutgoingCalls(ctx, requestArgs, r.requestState)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetOutgoingCalls")
+	}
+
+	return newCallHierarchyConnectionResolver(calls, nil, r.locationResolver), nil
+}
+
+// Supertypes returns the types the type at the given position implements or extends.
+func (r *gitBlobLSIFDataResolver) Supertypes(ctx context.Context, args *resolverstubs.LSIFPagedHierarchyQueryArgs) (_ resolverstubs.HierarchyItemConnectionResolver, err error) {
+	requestArgs, cursor, err := r.pagedHierarchyRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.supertypes, time.Second, getObservationArgs(requestArgs.PositionalRequestArgs))
+	defer endObservation()
+
+	items, itemsCursor, err := r.codeNavSvc.GetSupertypes(ctx, requestArgs, r.requestState, cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetSupertypes")
+	}
+
+	var nextCursor string
+	if itemsCursor.Phase != "done" {
+		nextCursor = encodeTraversalCursor(itemsCursor)
+	}
+
+	return newHierarchyItemConnectionResolver(items, pointers.NonZeroPtr(nextCursor), r.locationResolver), nil
+}
+
+// Subtypes returns the types implementing or extending the type at the given position.
+func (r *gitBlobLSIFDataResolver) Subtypes(ctx context.Context, args *resolverstubs.LSIFPagedHierarchyQueryArgs) (_ resolverstubs.HierarchyItemConnectionResolver, err error) {
+	requestArgs, cursor, err := r.pagedHierarchyRequestArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.subtypes, time.Second, getObservationArgs(requestArgs.PositionalRequestArgs))
+	defer endObservation()
+
+	items, itemsCursor, err := r.codeNavSvc.GetSubtypes(ctx, requestArgs, r.requestState, cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetSubtypes")
+	}
+
+	var nextCursor string
+	if itemsCursor.Phase != "done" {
+		nextCursor = encodeTraversalCursor(itemsCursor)
+	}
+
+	return newHierarchyItemConnectionResolver(items, pointers.NonZeroPtr(nextCursor), r.locationResolver), nil
+}
+
+// pagedHierarchyRequestArgs converts the arguments of a paged hierarchy request into request
+// arguments and the traversal cursor given from a previous response (or a fresh one).
+func (r *gitBlobLSIFDataResolver) pagedHierarchyRequestArgs(args *resolverstubs.LSIFPagedHierarchyQueryArgs) (codenav.HierarchyRequestArgs, codenav.Cursor, error) {
+	limit := int(pointers.Deref(args.First, DefaultHierarchyPageSize))
+	if limit <= 0 {
+		return codenav.HierarchyRequestArgs{}, codenav.Cursor{}, ErrIllegalLimit
+	}
+
+	depth, err := hierarchyDepth(args.Depth)
+	if err != nil {
+		return codenav.HierarchyRequestArgs{}, codenav.Cursor{}, err
+	}
+
+	rawCursor, err := decodeCursor(args.After)
+	if err != nil {
+		return codenav.HierarchyRequestArgs{}, codenav.Cursor{}, err
+	}
+
+	cursor, err := decodeTraversalCursor(rawCursor)
+	if err != nil {
+		return codenav.HierarchyRequestArgs{}, codenav.Cursor{}, errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
+	}
+
+	return codenav.HierarchyRequestArgs{
+		PositionalRequestArgs: codenav.PositionalRequestArgs{
+			RequestArgs: codenav.RequestArgs{
+				RepositoryID: r.requestState.RepositoryID,
+				Commit:       r.requestState.Commit,
+				Limit:        limit,
+				RawCursor:    rawCursor,
+			},
+			Path:      r.requestState.Path,
+			Line:      int(args.Line),
+			Character: int(args.Character),
+		},
+		Depth: depth,
+	}, cursor, nil
+}
+
+// hierarchyDepth returns the requested number of hierarchy levels, which defaults to one.
+func hierarchyDepth(depth *int32) (int, error) {
+	d := int(pointers.Deref(depth, 1))
+	if d <= 0 || d > MaximumHierarchyDepth {
+		return 0, ErrIllegalDepth
+	}
+
+	return d, nil
+}
+
+//
+//
+
+func newHierarchyItemConnectionResolver(items []codenav.HierarchyItem, cursor *string, locationResolver *gitresolvers.CachedLocationResolver) resolverstubs.HierarchyItemConnectionResolver {
+	return resolverstubs.NewLazyConnectionResolver(func(ctx context.Context) ([]resolverstubs.HierarchyItemResolver, error) {
+		resolvers := make([]resolverstubs.HierarchyItemResolver, 0, len(items))
+		for _, item := range items {
+			resolver, err := resolveHierarchyItem(ctx, locationResolver, item)
+			if err != nil {
+				return nil, err
+			}
+			if resolver == nil {
+				continue
+			}
+
+			resolvers = append(resolvers, resolver)
+		}
+
+		return resolvers, nil
+	}, encodeCursor(cursor))
+}
+
+func newCallHierarchyConnectionResolver(calls []codenav.HierarchyCall, cursor *string, locationResolver *gitresolvers.CachedLocationResolver) resolverstubs.CallHierarchyConnectionResolver {
+	return resolverstubs.NewLazyConnectionResolver(func(ctx context.Context) ([]resolverstubs.CallHierarchyResolver, error) {
+		resolvers := make([]resolverstubs.CallHierarchyResolver, 0, len(calls))
+		for _, call := range calls {
+			resolver, err := resolveCallHierarchy(ctx, locationResolver, call)
+			if err != nil {
+				return nil, err
+			}
+			if resolver == nil {
+				continue
+			}
+
+			resolvers = append(resolvers, resolver)
+		}
+
+		return resolvers, nil
+	}, encodeCursor(cursor))
+}
+
+// resolveHierarchyItem creates a HierarchyItemResolver for the given item. This function may
+// return a nil resolver if the commit of the item's location is not known by gitserver.
+func resolveHierarchyItem(ctx context.Context, locationResolver *gitresolvers.CachedLocationResolver, item codenav.HierarchyItem) (resolverstubs.HierarchyItemResolver, error) {
+	location, err := resolveLocation(ctx, locationResolver, item.Location)
+	if err != nil || location == nil {
+		return nil, err
+	}
+
+	children := make([]resolverstubs.HierarchyItemResolver, 0, len(item.Children))
+	for _, child := range item.Children {
+		resolver, err := resolveHierarchyItem(ctx, locationResolver, child)
+		if err != nil {
+			return nil, err
+		}
+		if resolver == nil {
+			continue
+		}
+
+		children = append(children, resolver)
+	}
+
+	return &hierarchyItemResolver{symbol: item.Symbol, location: location, children: children}, nil
+}
+
+// resolveCallHierarchy creates a CallHierarchyResolver for the given call. This function may
+// return a nil resolver if the commit of the call's item is not known by gitserver.
+func resolveCallHierarchy(ctx context.Context, locationResolver *gitresolvers.CachedLocationResolver, call codenav.HierarchyCall) (resolverstubs.CallHierarchyResolver, error) {
+	item, err := resolveHierarchyItem(ctx, locationResolver, call.Item)
+	if err != nil || item == nil {
+		return nil, err
+	}
+
+	fromRanges := make([]resolverstubs.RangeResolver, 0, len(call.Ranges))
+	for _, location := range call.Ranges {
+		fromRanges = append(fromRanges, newRangeResolver(convertRange(location.TargetRange)))
+	}
+
+	children := make([]resolverstubs.CallHierarchyResolver, 0, len(call.Children))
+	for _, child := range call.Children {
+		resolver, err := resolveCallHierarchy(ctx, locationResolver, child)
+		if err != nil {
+			return nil, err
+		}
+		if resolver == nil {
+			continue
+		}
+
+		children = append(children, resolver)
+	}
+
+	return &callHierarchyResolver{item: item, fromRanges: fromRanges, children: children}, nil
+}
+
+type hierarchyItemResolver struct {
+	symbol   string
+	location resolverstubs.LocationResolver
+	children []resolverstubs.HierarchyItemResolver
+}
+
+func (r *hierarchyItemResolver) Symbol() string                           { return r.symbol }
+func (r *hierarchyItemResolver) Location() resolverstubs.LocationResolver { return r.location }
+func (r *hierarchyItemResolver) Children() []resolverstubs.HierarchyItemResolver {
+	return r.children
+}
+
+type callHierarchyResolver struct {
+	item       resolverstubs.HierarchyItemResolver
+	fromRanges []resolverstubs.RangeResolver
+	children   []resolverstubs.CallHierarchyResolver
+}
+
+func (r *callHierarchyResolver) Item() resolverstubs.HierarchyItemResolver { return r.item }
+func (r *callHierarchyResolver) FromRanges() []resolverstubs.RangeResolver { return r.fromRanges }
+func (r *callHierarchyResolver) Children() []resolverstubs.CallHierarchyResolver {
+	return r.children
+}
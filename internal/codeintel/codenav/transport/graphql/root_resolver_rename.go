@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeintel/codenav"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/pointers"
+)
+
+// DefaultRenamePreviewLimit is the maximum number of occurrences considered by a rename preview
+// when no limit is supplied.
+const DefaultRenamePreviewLimit = 1000
+
+// RenamePreview returns the patches renaming the symbol at the given position, grouped by repository.
+func (r *gitBlobLSIFDataResolver) RenamePreview(ctx context.Context, args *resolverstubs.LSIFRenamePreviewArgs) (_ resolverstubs.RenamePreviewResolver, err error) {
+	limit := int(pointers.Deref(args.First, DefaultRenamePreviewLimit))
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	requestArgs := codenav.PositionalRequestArgs{
+		RequestArgs: codenav.RequestArgs{
+			RepositoryID: r.requestState.RepositoryID,
+			Commit:       r.requestState.Commit,
+			Limit:        limit,
+		},
+		Path:      r.requestState.Path,
+		Line:      int(args.Line),
+		Character: int(args.Character),
+	}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.renamePreview, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	preview, err := r.codeNavSvc.GetRenamePreview(ctx, requestArgs, r.requestState, args.NewName)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetRenamePreview")
+	}
+
+	patches := make([]resolverstubs.RenamePatchResolver, 0, len(preview.Patches))
+	for _, patch := range preview.Patches {
+		repository, err := r.locationResolver.Repository(ctx, api.RepoID(patch.RepositoryID))
+		if err != nil {
+			return nil, err
+		}
+		if repository == nil {
+			continue
+		}
+
+		edits, err := resolveLocations(ctx, r.locationResolver, patch.Edits)
+		if err != nil {
+			return nil, err
+		}
+
+		patches = append(patches, &renamePatchResolver{
+			repository: repository,
+			commit:     patch.Commit,
+			diff:       patch.Diff,
+			edits:      edits,
+		})
+	}
+
+	issues := make([]resolverstubs.RenameIssueResolver, 0, len(preview.Issues))
+	for _, issue := range preview.Issues {
+		location, err := resolveLocation(ctx, r.locationResolver, issue.Location)
+		if err != nil {
+			return nil, err
+		}
+		if location == nil {
+			continue
+		}
+
+		issues = append(issues, &renameIssueResolver{location: location, reason: string(issue.Reason)})
+	}
+
+	return &renamePreviewResolver{
+		oldName:   preview.OldName,
+		newName:   preview.NewName,
+		patches:   patches,
+		issues:    issues,
+		truncated: preview.Truncated,
+	}, nil
+}
+
+//
+//
+
+type renamePreviewResolver struct {
+	oldName   string
+	newName   string
+	patches   []resolverstubs.RenamePatchResolver
+	issues    []resolverstubs.RenameIssueResolver
+	truncated bool
+}
+
+func (r *renamePreviewResolver) OldName() string                              { return r.oldName }
+func (r *renamePreviewResolver) NewName() string                              { return r.newName }
+func (r *renamePreviewResolver) Patches() []resolverstubs.RenamePatchResolver { return r.patches }
+func (r *renamePreviewResolver) Issues() []resolverstubs.RenameIssueResolver  { return r.issues }
+func (r *renamePreviewResolver) Truncated() bool                              { return r.truncated }
+
+type renamePatchResolver struct {
+	repository resolverstubs.RepositoryResolver
+	commit     string
+	diff       string
+	edits      []resolverstubs.LocationResolver
+}
+
+func (r *renamePatchResolver) Repository() resolverstubs.RepositoryResolver { return r.repository }
+func (r *renamePatchResolver) Commit() string                               { return r.commit }
+func (r *renamePatchResolver) Diff() string                                 { return r.diff }
+func (r *renamePatchResolver) Edits() []resolverstubs.LocationResolver      { return r.edits }
+
+type renameIssueResolver struct {
+	location resolverstubs.LocationResolver
+	reason   string
+}
+
+func (r *renameIssueResolver) Location() resolverstubs.LocationResolver { return r.location }
+func (r *renameIssueResolver) Reason() string                           { return r.reason }
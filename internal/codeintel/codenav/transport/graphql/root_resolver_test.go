@@ -217,6 +217,187 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 	}
 }
 
+func TestIncomingCalls(t *testing.T) {
+	mockCodeNavService := NewMockCodeNavService()
+	mockRequestState := codenav.RequestState{
+		RepositoryID: 1,
+		Commit:       "deadbeef1",
+		Path:         "/src/main",
+	}
+	mockOperations := newOperations(&observation.TestContext)
+
+	resolver := newGitBlobLSIFDataResolver(
+		mockCodeNavService,
+		nil,
+		mockRequestState,
+		nil,
+		nil,
+		nil,
+		mockOperations,
+	)
+
+	offset := int32(25)
+	mockCallsCursor := codenav.Cursor{Phase: "remote"}
+	encodedCursor := encodeTraversalCursor(mockCallsCursor)
+	mockCursor := base64.StdEncoding.EncodeToString([]byte(encodedCursor))
+
+	depth := int32(3)
+	args := &resolverstubs.LSIFPagedHierarchyQueryArgs{
+		LSIFPagedQueryPositionArgs: resolverstubs.LSIFPagedQueryPositionArgs{
+			LSIFQueryPositionArgs: resolverstubs.LSIFQueryPositionArgs{
+				Line:      10,
+				Character: 15,
+			},
+			PagedConnectionArgs: resolverstubs.PagedConnectionArgs{ConnectionArgs: resolverstubs.ConnectionArgs{First: &offset}, After: &mockCursor},
+		},
+		Depth: &depth,
+	}
+
+	if _, err := resolver.IncomingCalls(context.Background(), args); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockCodeNavService.GetIncomingCallsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockCodeNavService.GetIncomingCallsFunc.History()))
+	}
+	call := mockCodeNavService.GetIncomingCallsFunc.History()[0]
+	if val := call.Arg1; val.Line != 10 || val.Character != 15 {
+		t.Fatalf("unexpected position. want=%v have=%v", "10:15", val)
+	}
+	if val := call.Arg1; val.Limit != 25 {
+		t.Fatalf("unexpected limit. want=%v have=%v", 25, val)
+	}
+	if val := call.Arg1; val.Depth != 3 {
+		t.Fatalf("unexpected depth. want=%v have=%v", 3, val.Depth)
+	}
+	if val := call.Arg3; val.Phase != "remote" {
+		t.Fatalf("unexpected cursor phase. want=%v have=%v", "remote", val.Phase)
+	}
+}
+
+func TestOutgoingCallsIllegalDepth(t *testing.T) {
+	mockCodeNavService := NewMockCodeNavService()
+	mockRequestState := codenav.RequestState{
+		RepositoryID: 1,
+		Commit:       "deadbeef1",
+		Path:         "/src/main",
+	}
+	mockOperations := newOperations(&observation.TestContext)
+
+	resolver := newGitBlobLSIFDataResolver(
+		mockCodeNavService,
+		nil,
+		mockRequestState,
+		nil,
+		nil,
+		nil,
+		mockOperations,
+	)
+
+	for _, depth := range []int32{0, MaximumHierarchyDepth + 1} {
+		depth := depth
+		args := &resolverstubs.LSIFHierarchyQueryArgs{
+			LSIFQueryPositionArgs: resolverstubs.LSIFQueryPositionArgs{Line: 10, Character: 15},
+			Depth:                 &depth,
+		}
+
+		if _, err := resolver.OutgoingCalls(context.Background(), args); err != ErrIllegalDepth {
+			t.Fatalf("unexpected error. want=%q have=%q", ErrIllegalDepth, err)
+		}
+	}
+	if len(mockCodeNavService.GetOutgoingCallsFunc.History()) != 0 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 0, len(mockCodeNavService.GetOutgoingCallsFunc.History()))
+	}
+}
+
+func TestSubtypesDefaultIllegalLimit(t *testing.T) {
+	mockCodeNavService := NewMockCodeNavService()
+	mockRequestState := codenav.RequestState{
+		RepositoryID: 1,
+		Commit:       "deadbeef1",
+		Path:         "/src/main",
+	}
+	mockOperations := newOperations(&observation.TestContext)
+
+	resolver := newGitBlobLSIFDataResolver(
+		mockCodeNavService,
+		nil,
+		mockRequestState,
+		nil,
+		nil,
+		nil,
+		mockOperations,
+	)
+
+	offset := int32(-1)
+	args := &resolverstubs.LSIFPagedHierarchyQueryArgs{
+		LSIFPagedQueryPositionArgs: resolverstubs.LSIFPagedQueryPositionArgs{
+			LSIFQueryPositionArgs: resolverstubs.LSIFQueryPositionArgs{
+				Line:      10,
+				Character: 15,
+			},
+			PagedConnectionArgs: resolverstubs.PagedConnectionArgs{ConnectionArgs: resolverstubs.ConnectionArgs{First: &offset}},
+		},
+	}
+
+	if _, err := resolver.Subtypes(context.Background(), args); err != ErrIllegalLimit {
+		t.Fatalf("unexpected error. want=%q have=%q", ErrIllegalLimit, err)
+	}
+	if len(mockCodeNavService.GetSubtypesFunc.History()) != 0 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 0, len(mockCodeNavService.GetSubtypesFunc.History()))
+	}
+}
+
+func TestRenamePreview(t *testing.T) {
+	mockCodeNavService := NewMockCodeNavService()
+	mockRequestState := codenav.RequestState{
+		RepositoryID: 1,
+		Commit:       "deadbeef1",
+		Path:         "/src/main",
+	}
+	mockOperations := newOperations(&observation.TestContext)
+
+	resolver := newGitBlobLSIFDataResolver(
+		mockCodeNavService,
+		nil,
+		mockRequestState,
+		nil,
+		nil,
+		nil,
+		mockOperations,
+	)
+
+	mockCodeNavService.GetRenamePreviewFunc.SetDefaultReturn(codenav.RenamePreview{OldName: "helper", NewName: "assist", Truncated: true}, nil)
+
+	args := &resolverstubs.LSIFRenamePreviewArgs{
+		Line:      10,
+		Character: 15,
+		NewName:   "assist",
+	}
+
+	preview, err := resolver.RenamePreview(context.Background(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if preview.OldName() != "helper" || !preview.Truncated() {
+		t.Fatalf("unexpected preview. want=%q,%v have=%q,%v", "helper", true, preview.OldName(), preview.Truncated())
+	}
+
+	if len(mockCodeNavService.GetRenamePreviewFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockCodeNavService.GetRenamePreviewFunc.History()))
+	}
+	call := mockCodeNavService.GetRenamePreviewFunc.History()[0]
+	if val := call.Arg1; val.Line != 10 || val.Character != 15 {
+		t.Fatalf("unexpected position. want=%v have=%v", "10:15", val)
+	}
+	if val := call.Arg1; val.Limit != DefaultRenamePreviewLimit {
+		t.Fatalf("unexpected limit. want=%v have=%v", DefaultRenamePreviewLimit, val)
+	}
+	if val := call.Arg3; val != "assist" {
+		t.Fatalf("unexpected new name. want=%v have=%v", "assist", val)
+	}
+}
+
 func TestHover(t *testing.T) {
 	mockCodeNavService := NewMockCodeNavService()
 	mockRequestState := codenav.RequestState{
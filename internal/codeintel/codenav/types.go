@@ -61,6 +61,14 @@ type PositionalRequestArgs struct {
 	Character int
 }
 
+// HierarchyRequestArgs are the arguments of a call or type hierarchy request. Depth is the
+// number of levels of the hierarchy to return, where 1 returns only the direct callers,
+// callees, supertypes or subtypes.
+type HierarchyRequestArgs struct {
+	PositionalRequestArgs
+	Depth int
+}
+
 // DiagnosticAtUpload is a diagnostic from within a particular upload. The adjusted commit denotes
 // the target commit for which the location was adjusted (the originally requested commit).
 type DiagnosticAtUpload struct {
@@ -81,6 +89,76 @@ type AdjustedCodeIntelligenceRange struct {
 	HoverText       string
 }
 
+// HierarchyItem is a node of a call or type hierarchy: a symbol along with the location of
+// its definition, adjusted to the target (originally requested) commit. Clients expand a leaf
+// node by issuing another hierarchy request at its location.
+type HierarchyItem struct {
+	Symbol   string
+	Location shared.UploadLocation
+
+	// Children are the supertypes or subtypes of the item for type hierarchies requested
+	// with a depth greater than one.
+	Children []HierarchyItem
+}
+
+// HierarchyCall is an edge of a call hierarchy. For incoming calls the item is the caller and
+// the ranges are the call sites within the caller. For outgoing calls the item is the callee
+// and the ranges are the call sites within the function the request was made for.
+type HierarchyCall struct {
+	Item   HierarchyItem
+	Ranges []shared.UploadLocation
+
+	// Children are the incoming or outgoing calls of the item for call hierarchies requested
+	// with a depth greater than one.
+	Children []HierarchyCall
+}
+
+// RenamePreview is the set of edits renaming a symbol and every precise occurrence of it, across
+// all repositories with indexes referencing the symbol.
+type RenamePreview struct {
+	OldName string
+	NewName string
+	Patches []RenamePatch
+	Issues  []RenameIssue
+
+	// Truncated is true if the symbol has more occurrences than the requested limit. Only the
+	// occurrences up to the limit are part of the preview.
+	Truncated bool
+}
+
+// RenamePatch holds the edits of a rename within a single repository at a single commit. Diff is
+// a unified diff of the edits which applies cleanly to the commit.
+type RenamePatch struct {
+	RepositoryID   int
+	RepositoryName string
+	Commit         string
+	Edits          []shared.UploadLocation
+	Diff           string
+}
+
+// RenameIssueReason describes why an occurrence of a renamed symbol could not be edited safely.
+type RenameIssueReason string
+
+const (
+	// RenameIssueStale occurs when the occurrence was indexed at another commit than the one
+	// requested and its range could not be adjusted to the requested commit, or when the
+	// occurrence belongs to another repository and was not indexed at the head of its default
+	// branch.
+	RenameIssueStale RenameIssueReason = "STALE"
+	// RenameIssueMissingFile occurs when the indexed file no longer exists at the commit.
+	RenameIssueMissingFile RenameIssueReason = "MISSING_FILE"
+	// RenameIssueTextMismatch occurs when the text at the range of the occurrence is not the
+	// name of the renamed symbol, e.g. a qualified reference.
+	RenameIssueTextMismatch RenameIssueReason = "TEXT_MISMATCH"
+)
+
+// RenameIssue is an occurrence of a renamed symbol which is not part of any patch and needs to
+// be reviewed manually.
+type RenameIssue struct {
+	Location shared.UploadLocation
+	Reason   RenameIssueReason
+}
+
 // Cursor is a struct that holds the state necessary to resume a locations query from a second or
 // subsequent request. This struct is used internally as a request-specific context object that is
 // mutated as the locations request is fulfilled. This struct is serialized to JSON then base64
@@ -33,6 +33,11 @@ type GitBlobLSIFDataResolver interface {
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Prototypes(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	IncomingCalls(ctx context.Context, args *LSIFPagedHierarchyQueryArgs) (CallHierarchyConnectionResolver, error)
+	OutgoingCalls(ctx context.Context, args *LSIFHierarchyQueryArgs) (CallHierarchyConnectionResolver, error)
+	Supertypes(ctx context.Context, args *LSIFPagedHierarchyQueryArgs) (HierarchyItemConnectionResolver, error)
+	Subtypes(ctx context.Context, args *LSIFPagedHierarchyQueryArgs) (HierarchyItemConnectionResolver, error)
+	RenamePreview(ctx context.Context, args *LSIFRenamePreviewArgs) (RenamePreviewResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 	VisibleIndexes(ctx context.Context) (_ *[]PreciseIndexResolver, err error)
 	Snapshot(ctx context.Context, args *struct{ IndexID graphql.ID }) (_ *[]SnapshotDataResolver, err error)
@@ -61,6 +66,23 @@ type LSIFPagedQueryPositionArgs struct {
 	Filter *string
 }
 
+type LSIFHierarchyQueryArgs struct {
+	LSIFQueryPositionArgs
+	Depth *int32
+}
+
+type LSIFPagedHierarchyQueryArgs struct {
+	LSIFPagedQueryPositionArgs
+	Depth *int32
+}
+
+type LSIFRenamePreviewArgs struct {
+	Line      int32
+	Character int32
+	NewName   string
+	First     *int32
+}
+
 type (
 	CodeIntelligenceRangeConnectionResolver = ConnectionResolver[CodeIntelligenceRangeResolver]
 )
@@ -94,6 +116,43 @@ type LocationResolver interface {
 	CanonicalURL() string
 }
 
+type (
+	HierarchyItemConnectionResolver = PagedConnectionResolver[HierarchyItemResolver]
+	CallHierarchyConnectionResolver = PagedConnectionResolver[CallHierarchyResolver]
+)
+
+type HierarchyItemResolver interface {
+	Symbol() string
+	Location() LocationResolver
+	Children() []HierarchyItemResolver
+}
+
+type CallHierarchyResolver interface {
+	Item() HierarchyItemResolver
+	FromRanges() []RangeResolver
+	Children() []CallHierarchyResolver
+}
+
+type RenamePreviewResolver interface {
+	OldName() string
+	NewName() string
+	Patches() []RenamePatchResolver
+	Issues() []RenameIssueResolver
+	Truncated() bool
+}
+
+type RenamePatchResolver interface {
+	Repository() RepositoryResolver
+	Commit() string
+	Diff() string
+	Edits() []LocationResolver
+}
+
+type RenameIssueResolver interface {
+	Location() LocationResolver
+	Reason() string
+}
+
 type HoverResolver interface {
 	Markdown() Markdown
 	Range() RangeResolver
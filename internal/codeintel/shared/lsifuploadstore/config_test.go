@@ -106,6 +106,60 @@ func TestConfigGCS(t *testing.T) {
 	}
 }
 
+func TestConfigAzure(t *testing.T) {
+	env := map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":            "Azure",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ACCOUNT_NAME": "test-account",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ACCOUNT_KEY":  "test-account-key",
+		"PRECISE_CODE_INTEL_UPLOAD_AZURE_ENDPOINT":     "http://azurite:10000/test-account",
+	}
+
+	config := Config{}
+	config.SetMockGetter(mapGetter(env))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.AzureAccountName != "test-account" {
+		t.Errorf("unexpected value for Azure.AccountName. want=%s have=%s", "test-account", config.AzureAccountName)
+	}
+	if config.AzureAccountKey != "test-account-key" {
+		t.Errorf("unexpected value for Azure.AccountKey. want=%s have=%s", "test-account-key", config.AzureAccountKey)
+	}
+	if config.AzureEndpoint != "http://azurite:10000/test-account" {
+		t.Errorf("unexpected value for Azure.Endpoint. want=%s have=%s", "http://azurite:10000/test-account", config.AzureEndpoint)
+	}
+}
+
+func TestConfigFilesystem(t *testing.T) {
+	config := Config{}
+	config.SetMockGetter(mapGetter(map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND": "Filesystem",
+	}))
+	config.Load()
+
+	if err := config.Validate(); err == nil {
+		t.Fatalf("expected validation error without path")
+	}
+
+	config = Config{}
+	config.SetMockGetter(mapGetter(map[string]string{
+		"PRECISE_CODE_INTEL_UPLOAD_BACKEND":         "Filesystem",
+		"PRECISE_CODE_INTEL_UPLOAD_FILESYSTEM_PATH": "/data/uploads",
+	}))
+	config.Load()
+
+	if err := config.Validate(); err != nil {
+		t.Fatalf("unexpected validation error: %s", err)
+	}
+
+	if config.FilesystemPath != "/data/uploads" {
+		t.Errorf("unexpected value for Filesystem.Path. want=%s have=%s", "/data/uploads", config.FilesystemPath)
+	}
+}
+
 func mapGetter(env map[string]string) func(name, defaultValue, description string) string {
 	return func(name, defaultValue, description string) string {
 		if v, ok := env[name]; ok {
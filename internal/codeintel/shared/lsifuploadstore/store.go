@@ -3,12 +3,24 @@ package lsifuploadstore
 import (
 	"context"
 
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 )
 
 func New(ctx context.Context, observationCtx *observation.Context, conf *Config) (uploadstore.Store, error) {
-	c := uploadstore.Config{
+	return uploadstore.CreateLazy(ctx, uploadStoreConfig(conf), uploadstore.NewOperations(observationCtx, "codeintel", "uploadstore"))
+}
+
+// NewTTLExpirer returns a background routine deleting the uploads of the given store that
+// are older than the configured TTL, if the backend of the store has no bucket lifecycle
+// configuration to do so.
+func NewTTLExpirer(ctx context.Context, store uploadstore.Store, conf *Config) goroutine.BackgroundRoutine {
+	return uploadstore.NewTTLExpirer(ctx, store, uploadStoreConfig(conf))
+}
+
+func uploadStoreConfig(conf *Config) uploadstore.Config {
+	return uploadstore.Config{
 		Backend:      conf.Backend,
 		ManageBucket: conf.ManageBucket,
 		Bucket:       conf.Bucket,
@@ -26,7 +38,13 @@ func New(ctx context.Context, observationCtx *observation.Context, conf *Config)
 			CredentialsFile:         conf.GCSCredentialsFile,
 			CredentialsFileContents: conf.GCSCredentialsFileContents,
 		},
+		Filesystem: uploadstore.FilesystemConfig{
+			Path: conf.FilesystemPath,
+		},
+		Azure: uploadstore.AzureConfig{
+			AccountName: conf.AzureAccountName,
+			AccountKey:  conf.AzureAccountKey,
+			Endpoint:    conf.AzureEndpoint,
+		},
 	}
-
-	return uploadstore.CreateLazy(ctx, c, uploadstore.NewOperations(observationCtx, "codeintel", "uploadstore"))
 }
@@ -16,6 +16,7 @@ type CodeIntelIndexer struct {
 // Two indexers with the same language key will be preferred according to the given order.
 var allIndexers = []CodeIntelIndexer{
 	// C++
+	makeInternalIndexer("C++", "scip-clang"),
 	makeInternalIndexer("C++", "lsif-clang"),
 	makeInternalIndexer("C++", "lsif-cpp"),
 
@@ -45,6 +46,7 @@ var allIndexers = []CodeIntelIndexer{
 	makeIndexer("OCaml", "lsif-ocaml", "github.com/rvantonder/lsif-ocaml"),
 
 	// PHP
+	makeIndexer("PHP", "scip-php", "github.com/davidrjenni/scip-php"),
 	makeIndexer("PHP", "lsif-php", "github.com/davidrjenni/lsif-php", "davidrjenni/lsif-php"),
 
 	// Python
@@ -1,8 +1,10 @@
 package background
 
 import (
+	"fmt"
 	"net/url"
 
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 )
 
@@ -18,4 +20,50 @@ type actionArgs struct {
 	Query          string
 	Results        []*result.CommitMatch
 	IncludeResults bool
+
+	// ResultDiff is set instead of Results for content mode monitors.
+	ResultDiff *database.ResultDiff
+}
+
+// resultDiffSummary describes the change in the results of a content mode
+// monitor in a single sentence fragment.
+func resultDiffSummary(diff *database.ResultDiff) string {
+	return fmt.Sprintf(
+		"%d %s appeared and %d %s disappeared (match count went from %d to %d)",
+		len(diff.Added), pluralize("result", len(diff.Added)),
+		len(diff.Removed), pluralize("result", len(diff.Removed)),
+		diff.PreviousCount, diff.CurrentCount,
+	)
+}
+
+type resultDiffEntry struct {
+	// Change is either "Added" or "Removed".
+	Change string
+	database.ResultFingerprint
+}
+
+// truncateResultDiff returns at most maxResults of the added and removed
+// results of diff, added results first, along with the number of results that
+// were left out.
+func truncateResultDiff(diff *database.ResultDiff, maxResults int) (_ []resultDiffEntry, truncatedCount int) {
+	entries := make([]resultDiffEntry, 0, len(diff.Added)+len(diff.Removed))
+	for _, fp := range diff.Added {
+		entries = append(entries, resultDiffEntry{Change: "Added", ResultFingerprint: fp})
+	}
+	for _, fp := range diff.Removed {
+		entries = append(entries, resultDiffEntry{Change: "Removed", ResultFingerprint: fp})
+	}
+
+	if len(entries) <= maxResults {
+		return entries, 0
+	}
+	return entries[:maxResults], len(entries) - maxResults
+}
+
+// String returns the repository and path of the result.
+func (e resultDiffEntry) String() string {
+	if e.Path == "" {
+		return e.Repository
+	}
+	return e.Repository + "/" + e.Path
 }
@@ -41,7 +41,7 @@ var (
 )
 
 var newSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
-	Subject: `{{ if .IsTest }}Test: {{ end }}{{.Priority}}Sourcegraph code monitor {{.Description}} detected {{.TotalCount}} new {{.ResultPluralized}}`,
+	Subject: `{{ if .IsTest }}Test: {{ end }}{{.Priority}}Sourcegraph code monitor {{.Description}} {{ if .ResultDiffSummary }}detected a change in its results{{ else }}detected {{.TotalCount}} new {{.ResultPluralized}}{{ end }}`,
 	Text:    textTemplate,
 	HTML:    htmlTemplate,
 })
@@ -59,6 +59,10 @@ type TemplateDataNewSearchResults struct {
 	TruncatedResultPluralized string
 	DisplayMoreLink           bool
 	IsTest                    bool
+
+	// ResultDiffSummary is set for content mode monitors, whose results are
+	// the changes in the result set of the query.
+	ResultDiffSummary string
 }
 
 func NewTemplateDataForNewSearchResults(args actionArgs, email *database.EmailAction) (d *TemplateDataNewSearchResults, err error) {
@@ -75,6 +79,10 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *database.EmailAc
 		priority = ""
 	}
 
+	if args.ResultDiff != nil {
+		return newTemplateDataForResultDiff(args, priority, searchURL, codeMonitorURL), nil
+	}
+
 	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
 
 	displayResults := make([]*DisplayResult, len(truncatedResults))
@@ -97,6 +105,36 @@ func NewTemplateDataForNewSearchResults(args actionArgs, email *database.EmailAc
 	}, nil
 }
 
+func newTemplateDataForResultDiff(args actionArgs, priority, searchURL, codeMonitorURL string) *TemplateDataNewSearchResults {
+	entries, truncatedCount := truncateResultDiff(args.ResultDiff, 5)
+
+	displayResults := make([]*DisplayResult, len(entries))
+	for i, entry := range entries {
+		displayResults[i] = &DisplayResult{
+			ResultType: entry.Change,
+			RepoName:   entry.Repository,
+			Path:       entry.Path,
+			FileURL:    getFileURL(args.ExternalURL, entry.Repository, entry.Path, utmSourceEmail),
+		}
+	}
+
+	totalCount := len(args.ResultDiff.Added) + len(args.ResultDiff.Removed)
+	return &TemplateDataNewSearchResults{
+		Priority:                  priority,
+		CodeMonitorURL:            codeMonitorURL,
+		SearchURL:                 searchURL,
+		Description:               args.MonitorDescription,
+		IncludeResults:            args.IncludeResults,
+		TruncatedResults:          displayResults,
+		TotalCount:                totalCount,
+		TruncatedCount:            truncatedCount,
+		ResultPluralized:          pluralize("result", totalCount),
+		TruncatedResultPluralized: pluralize("result", truncatedCount),
+		DisplayMoreLink:           args.IncludeResults && truncatedCount > 0,
+		ResultDiffSummary:         resultDiffSummary(args.ResultDiff),
+	}
+}
+
 func NewTestTemplateDataForNewSearchResults(monitorDescription string) *TemplateDataNewSearchResults {
 	return &TemplateDataNewSearchResults{
 		IsTest:                    true,
@@ -152,6 +190,13 @@ func getCommitURL(externalURL *url.URL, repoName, oid, utmSource string) string
 	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/commit/%s", repoName, oid), "", utmSource)
 }
 
+func getFileURL(externalURL *url.URL, repoName, path, utmSource string) string {
+	if path == "" {
+		return sourcegraphURL(externalURL, repoName, "", utmSource)
+	}
+	return sourcegraphURL(externalURL, fmt.Sprintf("%s/-/blob/%s", repoName, path), "", utmSource)
+}
+
 func sourcegraphURL(externalURL *url.URL, path, query, utmSource string) string {
 	// Construct URL to the search query.
 	u := externalURL.ResolveReference(&url.URL{Path: path})
@@ -178,6 +223,11 @@ type DisplayResult struct {
 	RepoName   string
 	CommitID   string
 	Content    string
+
+	// Path and FileURL are set instead of CommitURL, CommitID and Content for
+	// the results of content mode monitors.
+	Path    string
+	FileURL string
 }
 
 func toDisplayResult(result *searchresult.CommitMatch, externalURL *url.URL) *DisplayResult {
@@ -0,0 +1,177 @@
+package background
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// issueClient opens and comments on issues in a single repository on a code host.
+type issueClient interface {
+	CreateIssue(ctx context.Context, title, body string) (number int32, issueURL string, err error)
+	CreateIssueComment(ctx context.Context, number int32, body string) error
+}
+
+// newIssueClient returns an issueClient for the given repository, authenticated
+// with the token of the given code host connection.
+func newIssueClient(ctx context.Context, svc *types.ExternalService, repository string) (issueClient, error) {
+	cfg, err := svc.Configuration(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading code host connection configuration")
+	}
+
+	switch c := cfg.(type) {
+	case *schema.GitHubConnection:
+		if c.Token == "" {
+			return nil, errors.New("the code host connection must be configured with a token to open issues")
+		}
+		owner, name, ok := strings.Cut(repository, "/")
+		if !ok || owner == "" || name == "" {
+			return nil, errors.Newf("invalid GitHub repository %q: expected owner/name", repository)
+		}
+		baseURL, err := url.Parse(c.Url)
+		if err != nil {
+			return nil, err
+		}
+		apiURL, _ := github.APIRoot(extsvc.NormalizeBaseURL(baseURL))
+		client := github.NewV3Client(log.Scoped("codeMonitorIssueAction", ""), svc.URN(), apiURL, &auth.OAuthBearerToken{Token: c.Token}, httpcli.ExternalDoer)
+		return &githubIssueClient{client: client, owner: owner, name: name}, nil
+
+	case *schema.GitLabConnection:
+		if c.Token == "" {
+			return nil, errors.New("the code host connection must be configured with a token to open issues")
+		}
+		baseURL, err := url.Parse(c.Url)
+		if err != nil {
+			return nil, err
+		}
+		client := gitlab.NewClientProvider(svc.URN(), extsvc.NormalizeBaseURL(baseURL), httpcli.ExternalDoer).GetPATClient(c.Token, "")
+		return &gitlabIssueClient{client: client, project: strings.Trim(repository, "/")}, nil
+
+	default:
+		return nil, errors.Newf("code host connections of kind %s do not support issue actions", svc.Kind)
+	}
+}
+
+type githubIssueClient struct {
+	client      *github.V3Client
+	owner, name string
+}
+
+func (c *githubIssueClient) CreateIssue(ctx context.Context, title, body string) (int32, string, error) {
+	issue, err := c.client.CreateIssue(ctx, c.owner, c.name, title, body)
+	if err != nil {
+		return 0, "", err
+	}
+	return int32(issue.Number), issue.HTMLURL, nil
+}
+
+func (c *githubIssueClient) CreateIssueComment(ctx context.Context, number int32, body string) error {
+	return c.client.CreateIssueComment(ctx, c.owner, c.name, int(number), body)
+}
+
+type gitlabIssueClient struct {
+	client  *gitlab.Client
+	project string
+}
+
+func (c *gitlabIssueClient) CreateIssue(ctx context.Context, title, body string) (int32, string, error) {
+	issue, err := c.client.CreateIssue(ctx, c.project, title, body)
+	if err != nil {
+		return 0, "", err
+	}
+	return int32(issue.IID), issue.WebURL, nil
+}
+
+func (c *gitlabIssueClient) CreateIssueComment(ctx context.Context, number int32, body string) error {
+	return c.client.CreateIssueNote(ctx, c.project, gitlab.ID(number), body)
+}
+
+// sendIssueNotification comments on the issue previously opened for the code
+// monitor, or opens a new one if there is none yet. It returns the number and
+// URL of the issue if a new one was opened.
+func sendIssueNotification(ctx context.Context, client issueClient, existingIssue *int32, args actionArgs) (number int32, issueURL string, created bool, err error) {
+	body := issueBody(args)
+	if existingIssue != nil {
+		return 0, "", false, client.CreateIssueComment(ctx, *existingIssue, body)
+	}
+
+	number, issueURL, err = client.CreateIssue(ctx, issueTitle(args), body)
+	if err != nil {
+		return 0, "", false, err
+	}
+	return number, issueURL, true, nil
+}
+
+func issueTitle(args actionArgs) string {
+	return fmt.Sprintf("Sourcegraph code monitor: %s", args.MonitorDescription)
+}
+
+func issueBody(args actionArgs) string {
+	if args.ResultDiff != nil {
+		return issueResultDiffBody(args)
+	}
+
+	var b strings.Builder
+
+	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
+
+	fmt.Fprintf(&b, "%s's Sourcegraph code monitor, **%s**, detected **%d** new matches.\n\n", args.MonitorOwnerName, args.MonitorDescription, totalCount)
+
+	if args.IncludeResults {
+		for _, result := range truncatedResults {
+			resultType := "Message"
+			if result.DiffPreview != nil {
+				resultType = "Diff"
+			}
+			fmt.Fprintf(&b, "%s match: [%s@%s](%s)\n\n",
+				resultType,
+				result.Repo.Name,
+				result.Commit.ID.Short(),
+				getCommitURL(args.ExternalURL, string(result.Repo.Name), string(result.Commit.ID), args.UTMSource),
+			)
+			fmt.Fprintf(&b, "```\n%s\n```\n\n", strings.TrimSuffix(strings.ReplaceAll(truncateMatchContent(result), "```", "\\`\\`\\`"), "\n"))
+		}
+		if truncatedCount > 0 {
+			fmt.Fprintf(&b, "...and [%d more matches](%s).\n\n", truncatedCount, getSearchURL(args.ExternalURL, args.Query, args.UTMSource))
+		}
+	} else {
+		fmt.Fprintf(&b, "[View results](%s)\n\n", getSearchURL(args.ExternalURL, args.Query, args.UTMSource))
+	}
+
+	fmt.Fprintf(&b, "If you are %s, you can [edit your code monitor](%s).\n", args.MonitorOwnerName, getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource))
+	return b.String()
+}
+
+func issueResultDiffBody(args actionArgs) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s's Sourcegraph code monitor, **%s**, detected a change in its results: %s.\n\n", args.MonitorOwnerName, args.MonitorDescription, resultDiffSummary(args.ResultDiff))
+
+	if args.IncludeResults {
+		entries, truncatedCount := truncateResultDiff(args.ResultDiff, 5)
+		for _, entry := range entries {
+			fmt.Fprintf(&b, "- %s: [%s](%s)\n", entry.Change, entry, getFileURL(args.ExternalURL, entry.Repository, entry.Path, args.UTMSource))
+		}
+		if truncatedCount > 0 {
+			fmt.Fprintf(&b, "- ...and %d more changes\n", truncatedCount)
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "[View results](%s)\n\n", getSearchURL(args.ExternalURL, args.Query, args.UTMSource))
+	fmt.Fprintf(&b, "If you are %s, you can [edit your code monitor](%s).\n", args.MonitorOwnerName, getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource))
+	return b.String()
+}
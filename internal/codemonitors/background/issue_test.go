@@ -0,0 +1,90 @@
+package background
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+type fakeIssueClient struct {
+	created  []string
+	comments map[int32][]string
+}
+
+func (c *fakeIssueClient) CreateIssue(_ context.Context, title, _ string) (int32, string, error) {
+	c.created = append(c.created, title)
+	return int32(len(c.created)), "https://github.com/test/test/issues/1", nil
+}
+
+func (c *fakeIssueClient) CreateIssueComment(_ context.Context, number int32, body string) error {
+	if c.comments == nil {
+		c.comments = map[int32][]string{}
+	}
+	c.comments[number] = append(c.comments[number], body)
+	return nil
+}
+
+func TestIssueNotification(t *testing.T) {
+	t.Parallel()
+
+	action := actionArgs{
+		MonitorDescription: "My test monitor",
+		MonitorOwnerName:   "Camden Cheek",
+		MonitorID:          1,
+		ExternalURL:        externalURLMock,
+		UTMSource:          "code-monitor-issue",
+		Query:              "repo:camdentest -file:id_rsa.pub BEGIN",
+		Results:            []*result.CommitMatch{&diffResultMock, &commitResultMock},
+	}
+
+	t.Run("opens an issue the first time", func(t *testing.T) {
+		client := &fakeIssueClient{}
+		number, issueURL, created, err := sendIssueNotification(context.Background(), client, nil, action)
+		require.NoError(t, err)
+		require.True(t, created)
+		require.Equal(t, int32(1), number)
+		require.Equal(t, "https://github.com/test/test/issues/1", issueURL)
+		require.Equal(t, []string{"Sourcegraph code monitor: My test monitor"}, client.created)
+	})
+
+	t.Run("comments on an existing issue", func(t *testing.T) {
+		client := &fakeIssueClient{}
+		existing := int32(7)
+		_, _, created, err := sendIssueNotification(context.Background(), client, &existing, action)
+		require.NoError(t, err)
+		require.False(t, created)
+		require.Empty(t, client.created)
+		require.Len(t, client.comments[7], 1)
+	})
+
+	t.Run("body without results", func(t *testing.T) {
+		body := issueBody(action)
+		require.Contains(t, body, "detected **3** new matches")
+		require.Contains(t, body, "[View results](")
+		require.NotContains(t, body, "```")
+	})
+
+	t.Run("body with results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		body := issueBody(actionCopy)
+		require.Contains(t, body, "Diff match: [github.com/test/test@7815187](")
+		require.Contains(t, body, "Message match: [github.com/test/test@7815187](")
+		require.Contains(t, body, "+matched added")
+		require.NotContains(t, body, "[View results](")
+	})
+
+	t.Run("body with result diff", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		actionCopy.ResultDiff = &resultDiffMock
+		body := issueBody(actionCopy)
+		require.Contains(t, body, "detected a change in its results: 1 result appeared and 1 result disappeared (match count went from 2 to 1)")
+		require.Contains(t, body, "- Added: [github.com/test/test/Dockerfile](https://www.sourcegraph.com/github.com/test/test/-/blob/Dockerfile?utm_source=code-monitor-issue)")
+		require.Contains(t, body, "- Removed: [github.com/test/other/build/Dockerfile](")
+		require.Contains(t, body, "[View results](")
+	})
+}
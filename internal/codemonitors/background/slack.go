@@ -20,11 +20,29 @@ func sendSlackNotification(ctx context.Context, url string, args actionArgs) err
 	return postSlackWebhook(ctx, httpcli.ExternalDoer, url, slackPayload(args))
 }
 
+func newMarkdownSection(s string) slack.Block {
+	return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+}
+
 func slackPayload(args actionArgs) *slack.WebhookMessage {
-	newMarkdownSection := func(s string) slack.Block {
-		return slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", s, false, false), nil, nil)
+	var blocks []slack.Block
+	if args.ResultDiff != nil {
+		blocks = slackResultDiffBlocks(args)
+	} else {
+		blocks = slackResultBlocks(args)
 	}
 
+	blocks = append(blocks,
+		newMarkdownSection(fmt.Sprintf(
+			`If you are %s, you can <%s|edit your code monitor>`,
+			args.MonitorOwnerName,
+			getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+		)),
+	)
+	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+}
+
+func slackResultBlocks(args actionArgs) []slack.Block {
 	truncatedResults, totalCount, truncatedCount := truncateResults(args.Results, 5)
 
 	blocks := []slack.Block{
@@ -66,15 +84,39 @@ func slackPayload(args actionArgs) *slack.WebhookMessage {
 			getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
 		)))
 	}
+	return blocks
+}
 
-	blocks = append(blocks,
+func slackResultDiffBlocks(args actionArgs) []slack.Block {
+	blocks := []slack.Block{
 		newMarkdownSection(fmt.Sprintf(
-			`If you are %s, you can <%s|edit your code monitor>`,
+			"%s's Sourcegraph Code monitor, *%s*, detected a change in its results: %s.",
 			args.MonitorOwnerName,
-			getCodeMonitorURL(args.ExternalURL, args.MonitorID, args.UTMSource),
+			args.MonitorDescription,
+			resultDiffSummary(args.ResultDiff),
 		)),
-	)
-	return &slack.WebhookMessage{Blocks: &slack.Blocks{BlockSet: blocks}}
+	}
+
+	if args.IncludeResults {
+		entries, truncatedCount := truncateResultDiff(args.ResultDiff, 5)
+		for _, entry := range entries {
+			blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+				"%s: <%s|%s>",
+				entry.Change,
+				getFileURL(args.ExternalURL, entry.Repository, entry.Path, args.UTMSource),
+				entry,
+			)))
+		}
+		if truncatedCount > 0 {
+			blocks = append(blocks, newMarkdownSection(fmt.Sprintf("...and %d more changes.", truncatedCount)))
+		}
+	}
+
+	blocks = append(blocks, newMarkdownSection(fmt.Sprintf(
+		"<%s|View results>",
+		getSearchURL(args.ExternalURL, args.Query, args.UTMSource),
+	)))
+	return blocks
 }
 
 func formatCodeBlock(s string) string {
@@ -4,6 +4,7 @@ import (
 	"net/url"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -67,3 +68,20 @@ var longCommitResultMock = result.CommitMatch{
 		}},
 	},
 }
+
+var resultDiffMock = database.ResultDiff{
+	Added: []database.ResultFingerprint{{
+		Fingerprint: "8b1a9953",
+		Repository:  "github.com/test/test",
+		Path:        "Dockerfile",
+		MatchCount:  1,
+	}},
+	Removed: []database.ResultFingerprint{{
+		Fingerprint: "2c26b46b",
+		Repository:  "github.com/test/other",
+		Path:        "build/Dockerfile",
+		MatchCount:  2,
+	}},
+	PreviousCount: 2,
+	CurrentCount:  1,
+}
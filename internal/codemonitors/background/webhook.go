@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -57,10 +58,11 @@ func SendTestWebhook(ctx context.Context, doer httpcli.Doer, description string,
 }
 
 type webhookPayload struct {
-	MonitorDescription string          `json:"monitorDescription"`
-	MonitorURL         string          `json:"monitorURL"`
-	Query              string          `json:"query"`
-	Results            []webhookResult `json:"results,omitempty"`
+	MonitorDescription string             `json:"monitorDescription"`
+	MonitorURL         string             `json:"monitorURL"`
+	Query              string             `json:"query"`
+	Results            []webhookResult    `json:"results,omitempty"`
+	ResultDiff         *webhookResultDiff `json:"resultDiff,omitempty"`
 }
 
 func generateWebhookPayload(args actionArgs) webhookPayload {
@@ -70,13 +72,58 @@ func generateWebhookPayload(args actionArgs) webhookPayload {
 		Query:              args.Query,
 	}
 
-	if args.IncludeResults {
+	if args.ResultDiff != nil {
+		p.ResultDiff = generateResultDiff(args.ResultDiff, args.IncludeResults)
+	} else if args.IncludeResults {
 		p.Results = generateResults(args.Results)
 	}
 
 	return p
 }
 
+type webhookResultDiff struct {
+	AddedCount    int                        `json:"addedCount"`
+	RemovedCount  int                        `json:"removedCount"`
+	PreviousCount int                        `json:"previousCount"`
+	CurrentCount  int                        `json:"currentCount"`
+	Added         []webhookResultFingerprint `json:"added,omitempty"`
+	Removed       []webhookResultFingerprint `json:"removed,omitempty"`
+}
+
+type webhookResultFingerprint struct {
+	Fingerprint string `json:"fingerprint"`
+	Repository  string `json:"repository"`
+	Path        string `json:"path,omitempty"`
+	MatchCount  int    `json:"matchCount"`
+}
+
+func generateResultDiff(diff *database.ResultDiff, includeResults bool) *webhookResultDiff {
+	out := &webhookResultDiff{
+		AddedCount:    len(diff.Added),
+		RemovedCount:  len(diff.Removed),
+		PreviousCount: diff.PreviousCount,
+		CurrentCount:  diff.CurrentCount,
+	}
+	if includeResults {
+		out.Added = generateResultFingerprints(diff.Added)
+		out.Removed = generateResultFingerprints(diff.Removed)
+	}
+	return out
+}
+
+func generateResultFingerprints(in []database.ResultFingerprint) []webhookResultFingerprint {
+	out := make([]webhookResultFingerprint, len(in))
+	for i, fp := range in {
+		out[i] = webhookResultFingerprint{
+			Fingerprint: fp.Fingerprint,
+			Repository:  fp.Repository,
+			Path:        fp.Path,
+			MatchCount:  fp.MatchCount,
+		}
+	}
+	return out
+}
+
 type webhookResult struct {
 	Repository           string   `json:"repository"`
 	Commit               string   `json:"commit"`
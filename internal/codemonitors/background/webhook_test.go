@@ -54,6 +54,29 @@ func TestWebhook(t *testing.T) {
 		autogold.ExpectFile(t, autogold.Raw(j))
 	})
 
+	t.Run("golden with result diff", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.IncludeResults = true
+		actionCopy.ResultDiff = &resultDiffMock
+
+		j, err := json.Marshal(generateWebhookPayload(actionCopy))
+		require.NoError(t, err)
+
+		autogold.ExpectFile(t, autogold.Raw(j))
+	})
+
+	t.Run("result diff without results", func(t *testing.T) {
+		actionCopy := action
+		actionCopy.ResultDiff = &resultDiffMock
+
+		p := generateWebhookPayload(actionCopy)
+		require.Empty(t, p.Results)
+		require.Equal(t, 1, p.ResultDiff.AddedCount)
+		require.Equal(t, 1, p.ResultDiff.RemovedCount)
+		require.Empty(t, p.ResultDiff.Added)
+		require.Empty(t, p.ResultDiff.Removed)
+	})
+
 	t.Run("error is returned", func(t *testing.T) {
 		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			b, err := io.ReadAll(r.Body)
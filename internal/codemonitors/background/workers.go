@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/log"
 
@@ -15,10 +16,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -42,6 +45,8 @@ func newTriggerQueryRunner(ctx context.Context, observationCtx *observation.Cont
 
 	store := createDBWorkerStoreForTriggerJobs(observationCtx, db)
 
+	dbworkerstore.RegisterDeadLetterStore(store)
+
 	worker := dbworker.NewWorker[*database.TriggerJob](ctx, store, &queryRunner{db: db}, options)
 	return worker
 }
@@ -103,6 +108,8 @@ func newActionRunner(ctx context.Context, observationCtx *observation.Context, s
 
 	store := createDBWorkerStoreForActionJobs(observationCtx, s)
 
+	dbworkerstore.RegisterDeadLetterStore(store)
+
 	worker := dbworker.NewWorker[*database.ActionJob](ctx, store, &actionRunner{s}, options)
 	return worker
 }
@@ -134,6 +141,7 @@ func createDBWorkerStoreForTriggerJobs(observationCtx *observation.Context, s ba
 		RetryAfter:        10 * time.Second,
 		MaxNumRetries:     3,
 		OrderByExpression: sqlf.Sprintf("id"),
+		PurgeRecords:      purgeJobs("cm_trigger_jobs"),
 	})
 }
 
@@ -149,9 +157,19 @@ func createDBWorkerStoreForActionJobs(observationCtx *observation.Context, s dat
 		RetryAfter:        10 * time.Second,
 		MaxNumRetries:     3,
 		OrderByExpression: sqlf.Sprintf("id"),
+		PurgeRecords:      purgeJobs("cm_action_jobs"),
 	})
 }
 
+// purgeJobs returns a function deleting the failed jobs of the given table. Jobs are also
+// deleted once they are older than eventRetentionInDays, so nothing depends on them except
+// the action jobs of a trigger job, which are deleted along with it.
+func purgeJobs(tableName string) func(ctx context.Context, tx *basestore.Store, ids []int) error {
+	return func(ctx context.Context, tx *basestore.Store, ids []int) error {
+		return tx.Exec(ctx, sqlf.Sprintf("DELETE FROM %s WHERE id = ANY(%s)", sqlf.Sprintf(tableName), pq.Array(ids)))
+	}
+}
+
 type queryRunner struct {
 	db database.DB
 }
@@ -181,6 +199,10 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 	ctx = actor.WithActor(ctx, actor.FromUser(m.UserID))
 	ctx = featureflag.WithFlags(ctx, r.db.FeatureFlags())
 
+	if q.Mode == database.QueryTriggerModeContent {
+		return r.handleContent(ctx, logger, triggerJob, q, m)
+	}
+
 	results, searchErr := codemonitors.Search(ctx, logger, r.db, q.QueryString, m.ID)
 
 	// Log next_run and latest_result to table cm_queries.
@@ -206,6 +228,91 @@ func (r *queryRunner) Handle(ctx context.Context, logger log.Logger, triggerJob
 		if err != nil {
 			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
 		}
+		outbound.EnqueueCodeMonitorTriggered(ctx, logger, r.db, m, q.QueryString, triggerJob.ID, len(results))
+	}
+	return nil
+}
+
+// handleContent runs the query of a content mode monitor and triggers its
+// actions if the result set differs from the result set of the previous run.
+func (r *queryRunner) handleContent(ctx context.Context, logger log.Logger, triggerJob *database.TriggerJob, q *database.QueryTrigger, m *database.Monitor) error {
+	cm := r.db.CodeMonitors()
+
+	previous, hasPrevious, err := cm.GetResultFingerprints(ctx, m.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetResultFingerprints")
+	}
+
+	current, complete, searchErr := codemonitors.SearchContent(ctx, logger, r.db, q.QueryString)
+
+	// Without fingerprints from a previous run we only record a baseline, since
+	// every result would be reported as added. If the results are incomplete we
+	// can't tell whether a missing result was removed, so we skip the run.
+	var diff *database.ResultDiff
+	if searchErr == nil && complete && hasPrevious {
+		diff = codemonitors.DiffFingerprints(previous, current)
+	}
+
+	// Log next_run and latest_result to table cm_queries.
+	newLatestResult := cm.Clock()()
+	if diff == nil && q.LatestResult != nil {
+		newLatestResult = *q.LatestResult
+	}
+	err = cm.SetQueryTriggerNextRun(ctx, q.ID, cm.Clock()().Add(5*time.Minute), newLatestResult.UTC())
+	if err != nil {
+		return err
+	}
+
+	// After setting the next run, check the error value. We keep the previous
+	// fingerprints so that a failed search isn't reported as every result
+	// disappearing.
+	if searchErr != nil {
+		return errors.Wrap(searchErr, "execute search")
+	}
+
+	if !complete {
+		logger.Warn("skipping incomplete results of content mode code monitor", log.Int64("monitorID", m.ID))
+		err = cm.UpdateTriggerJobWithResultDiff(ctx, triggerJob.ID, q.QueryString, nil)
+		return errors.Wrap(err, "UpdateTriggerJobWithResultDiff")
+	}
+
+	err = storeContentRun(ctx, cm, m.ID, triggerJob.ID, q.QueryString, current, diff)
+	if err != nil {
+		return err
+	}
+
+	if diff != nil {
+		outbound.EnqueueCodeMonitorTriggered(ctx, logger, r.db, m, q.QueryString, triggerJob.ID, len(diff.Added)+len(diff.Removed))
+	}
+	return nil
+}
+
+// storeContentRun stores the fingerprints, the diff and the action jobs of a
+// run of a content mode monitor in one transaction. Otherwise a retry after a
+// partial failure would compare against the new fingerprints and lose the
+// change.
+func storeContentRun(ctx context.Context, cm database.CodeMonitorStore, monitorID int64, triggerJobID int32, queryString string, fingerprints []database.ResultFingerprint, diff *database.ResultDiff) (err error) {
+	tx, err := cm.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	err = tx.UpsertResultFingerprints(ctx, monitorID, fingerprints)
+	if err != nil {
+		return errors.Wrap(err, "UpsertResultFingerprints")
+	}
+
+	err = tx.UpdateTriggerJobWithResultDiff(ctx, triggerJobID, queryString, diff)
+	if err != nil {
+		return errors.Wrap(err, "UpdateTriggerJobWithResultDiff")
+	}
+
+	if diff != nil {
+		_, err := tx.EnqueueActionJobsForMonitor(ctx, monitorID, triggerJobID)
+		if err != nil {
+			return errors.Wrap(err, "store.EnqueueActionJobsForQuery")
+		}
 	}
 	return nil
 }
@@ -223,8 +330,10 @@ func (r *actionRunner) Handle(ctx context.Context, logger log.Logger, j *databas
 		return errors.Wrap(r.handleWebhook(ctx, j), "Webhook")
 	case j.SlackWebhook != nil:
 		return errors.Wrap(r.handleSlackWebhook(ctx, j), "SlackWebhook")
+	case j.Issue != nil:
+		return errors.Wrap(r.handleIssue(ctx, j), "Issue")
 	default:
-		return errors.New("job must be one of type email, webhook, or slack webhook")
+		return errors.New("job must be one of type email, webhook, slack webhook, or issue")
 	}
 }
 
@@ -263,6 +372,7 @@ func (r *actionRunner) handleEmail(ctx context.Context, j *database.ActionJob) e
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ResultDiff:         m.ResultDiff,
 		IncludeResults:     e.IncludeResults,
 	}
 
@@ -316,6 +426,7 @@ func (r *actionRunner) handleWebhook(ctx context.Context, j *database.ActionJob)
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ResultDiff:         m.ResultDiff,
 		IncludeResults:     w.IncludeResults,
 	}
 
@@ -352,12 +463,73 @@ func (r *actionRunner) handleSlackWebhook(ctx context.Context, j *database.Actio
 		Query:              m.Query,
 		MonitorOwnerName:   m.OwnerName,
 		Results:            m.Results,
+		ResultDiff:         m.ResultDiff,
 		IncludeResults:     w.IncludeResults,
 	}
 
 	return sendSlackNotification(ctx, w.URL, args)
 }
 
+// handleIssue doesn't run in a transaction, so that no transaction is held open
+// while we talk to the code host. Once an issue is opened, its number is written
+// in its own statement so that a failure afterwards can't open a duplicate.
+func (r *actionRunner) handleIssue(ctx context.Context, j *database.ActionJob) (err error) {
+	s := r.CodeMonitorStore
+
+	m, err := s.GetActionJobMetadata(ctx, j.ID)
+	if err != nil {
+		return errors.Wrap(err, "GetActionJobMetadata")
+	}
+
+	i, err := s.GetIssueAction(ctx, *j.Issue)
+	if err != nil {
+		return errors.Wrap(err, "GetIssueAction")
+	}
+
+	svc, err := database.NewDBWith(log.Scoped("handleIssue", ""), s).ExternalServices().GetByID(ctx, i.ExternalServiceID)
+	if err != nil {
+		return errors.Wrap(err, "GetExternalService")
+	}
+
+	client, err := newIssueClient(ctx, svc, i.Repository)
+	if err != nil {
+		return err
+	}
+
+	externalURL, err := url.Parse(conf.Get().ExternalURL)
+	if err != nil {
+		return err
+	}
+
+	args := actionArgs{
+		MonitorDescription: m.Description,
+		MonitorID:          i.Monitor,
+		ExternalURL:        externalURL,
+		UTMSource:          "code-monitor-issue",
+		Query:              m.Query,
+		MonitorOwnerName:   m.OwnerName,
+		Results:            m.Results,
+		ResultDiff:         m.ResultDiff,
+		IncludeResults:     i.IncludeResults,
+	}
+
+	number, issueURL, created, err := sendIssueNotification(ctx, client, i.ExternalIssueNumber, args)
+	if err != nil {
+		return err
+	}
+	if !created {
+		return nil
+	}
+
+	// Remember the issue so that the next time this monitor triggers we comment
+	// on it rather than opening another one. The issue exists at this point, so
+	// retrying the job would open a duplicate.
+	if err := s.SetIssueActionExternalIssue(ctx, i.ID, number, issueURL); err != nil {
+		return errcode.MakeNonRetryable(errors.Wrapf(err, "SetIssueActionExternalIssue: opened issue %s", issueURL))
+	}
+	return nil
+}
+
 type StatusCodeError struct {
 	Code   int
 	Status string
@@ -0,0 +1,175 @@
+package codemonitors
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/commit"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var (
+	ErrInvalidContentMonitorQuery = errors.New("code monitors that watch search results for changes cannot use type:diff or type:commit")
+	ErrIncompleteContentResults   = errors.New("code monitors that watch search results for changes need every result, but the search hit a result limit or could not search every repository. Narrow the query or try again")
+)
+
+// SearchContent runs the query of a content mode code monitor and returns a
+// fingerprint for every result. Unlike Search, it does not restrict the search
+// to commits that were added since the last run: the whole result set is
+// returned every time, and it is up to the caller to compare it with the
+// result set of the previous run.
+//
+// It returns false if the result set is incomplete, e.g. because the search hit
+// a limit or timed out in some repositories. An incomplete result set must not
+// be compared with another one, since missing results would be reported as
+// removed.
+func SearchContent(ctx context.Context, logger log.Logger, db database.DB, query string) ([]database.ResultFingerprint, bool, error) {
+	searchClient := client.New(logger, db)
+	inputs, err := searchClient.Plan(
+		ctx,
+		"V3",
+		nil,
+		query,
+		search.Precise,
+		search.Streaming,
+	)
+	if err != nil {
+		return nil, false, errcode.MakeNonRetryable(err)
+	}
+
+	clients := searchClient.JobClients()
+	planJob, err := jobutil.NewPlanJob(inputs, inputs.Plan)
+	if err != nil {
+		return nil, false, errcode.MakeNonRetryable(err)
+	}
+
+	// Diff and commit searches would search the entire history of every repo
+	// on every run. They are handled by Search instead.
+	if job.HasDescendent[*commit.SearchJob](planJob) {
+		return nil, false, errcode.MakeNonRetryable(ErrInvalidContentMonitorQuery)
+	}
+
+	agg := streaming.NewAggregatingStream()
+	_, err = planJob.Run(ctx, clients, agg)
+	if err != nil {
+		return nil, false, err
+	}
+
+	complete := !agg.Stats.IsLimitHit &&
+		agg.Stats.BackendsMissing == 0 &&
+		!agg.Stats.Status.Any(search.RepoStatusCloning|search.RepoStatusMissing|search.RepoStatusTimedout)
+
+	return Fingerprints(agg.Results), complete, nil
+}
+
+// Fingerprints returns a fingerprint for each of the given matches, sorted by
+// fingerprint. A file match is fingerprinted by its repository, path and
+// matched lines, so that editing a matched line is reported as a change while
+// new commits that don't touch the matched lines are not.
+func Fingerprints(matches result.Matches) []database.ResultFingerprint {
+	fingerprints := make([]database.ResultFingerprint, 0, len(matches))
+	seen := make(map[string]struct{}, len(matches))
+	for _, match := range matches {
+		fp := fingerprint(match)
+		if _, ok := seen[fp.Fingerprint]; ok {
+			continue
+		}
+		seen[fp.Fingerprint] = struct{}{}
+		fingerprints = append(fingerprints, fp)
+	}
+
+	sort.Slice(fingerprints, func(i, j int) bool {
+		return fingerprints[i].Fingerprint < fingerprints[j].Fingerprint
+	})
+	return fingerprints
+}
+
+func fingerprint(match result.Match) database.ResultFingerprint {
+	h := sha256.New()
+	write := func(s string) {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+
+	fp := database.ResultFingerprint{
+		Repository: string(match.RepoName().Name),
+		MatchCount: match.ResultCount(),
+	}
+	write(fp.Repository)
+
+	switch m := match.(type) {
+	case *result.FileMatch:
+		fp.Path = m.Path
+		write("file")
+		write(m.Path)
+		for _, cm := range m.ChunkMatches {
+			write(cm.Content)
+		}
+		for _, sm := range m.Symbols {
+			write(sm.Symbol.Name)
+		}
+	case *result.RepoMatch:
+		write("repo")
+	case *result.CommitMatch:
+		write("commit")
+		write(string(m.Commit.ID))
+	default:
+		// Leave out the commit so that the fingerprint is stable across
+		// commits that don't change the match.
+		k := m.Key()
+		fp.Path = k.Path
+		write(k.Path)
+		write(k.OwnerMetadata)
+	}
+
+	fp.Fingerprint = hex.EncodeToString(h.Sum(nil))
+	return fp
+}
+
+// DiffFingerprints compares the fingerprints of two consecutive runs of a
+// content mode code monitor. It returns nil if the result set did not change.
+// Both result sets must be complete, see SearchContent.
+func DiffFingerprints(previous, current []database.ResultFingerprint) *database.ResultDiff {
+	previousSet := make(map[string]struct{}, len(previous))
+	for _, fp := range previous {
+		previousSet[fp.Fingerprint] = struct{}{}
+	}
+	currentSet := make(map[string]struct{}, len(current))
+	for _, fp := range current {
+		currentSet[fp.Fingerprint] = struct{}{}
+	}
+
+	diff := &database.ResultDiff{
+		Added:   []database.ResultFingerprint{},
+		Removed: []database.ResultFingerprint{},
+	}
+	for _, fp := range current {
+		diff.CurrentCount += fp.MatchCount
+		if _, ok := previousSet[fp.Fingerprint]; !ok {
+			diff.Added = append(diff.Added, fp)
+		}
+	}
+	for _, fp := range previous {
+		diff.PreviousCount += fp.MatchCount
+		if _, ok := currentSet[fp.Fingerprint]; !ok {
+			diff.Removed = append(diff.Removed, fp)
+		}
+	}
+
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 {
+		return nil
+	}
+	return diff
+}
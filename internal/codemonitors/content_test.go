@@ -0,0 +1,87 @@
+package codemonitors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFingerprints(t *testing.T) {
+	t.Parallel()
+
+	fileMatch := func(repo, commit, path, content string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{Name: api.RepoName(repo)},
+				CommitID: api.CommitID(commit),
+				Path:     path,
+			},
+			ChunkMatches: result.ChunkMatches{{
+				Content: content,
+				Ranges:  result.Ranges{{Start: result.Location{Offset: 0}, End: result.Location{Offset: 4, Column: 4}}},
+			}},
+		}
+	}
+
+	t.Run("stable across commits", func(t *testing.T) {
+		a := Fingerprints(result.Matches{fileMatch("github.com/test/a", "abc", "Dockerfile", "FROM ubuntu:18.04")})
+		b := Fingerprints(result.Matches{fileMatch("github.com/test/a", "def", "Dockerfile", "FROM ubuntu:18.04")})
+		require.Equal(t, a, b)
+		require.Equal(t, "github.com/test/a", a[0].Repository)
+		require.Equal(t, "Dockerfile", a[0].Path)
+		require.Equal(t, 1, a[0].MatchCount)
+	})
+
+	t.Run("changes with matched content", func(t *testing.T) {
+		a := Fingerprints(result.Matches{fileMatch("github.com/test/a", "abc", "Dockerfile", "FROM ubuntu:18.04")})
+		b := Fingerprints(result.Matches{fileMatch("github.com/test/a", "abc", "Dockerfile", "FROM ubuntu:18.10")})
+		require.NotEqual(t, a[0].Fingerprint, b[0].Fingerprint)
+	})
+
+	t.Run("deduplicates", func(t *testing.T) {
+		fps := Fingerprints(result.Matches{
+			fileMatch("github.com/test/a", "abc", "Dockerfile", "FROM ubuntu:18.04"),
+			fileMatch("github.com/test/a", "abc", "Dockerfile", "FROM ubuntu:18.04"),
+			&result.RepoMatch{Name: "github.com/test/a"},
+		})
+		require.Len(t, fps, 2)
+	})
+}
+
+func TestDiffFingerprints(t *testing.T) {
+	t.Parallel()
+
+	a := database.ResultFingerprint{Fingerprint: "a", Repository: "github.com/test/a", Path: "Dockerfile", MatchCount: 1}
+	b := database.ResultFingerprint{Fingerprint: "b", Repository: "github.com/test/b", Path: "Dockerfile", MatchCount: 2}
+	c := database.ResultFingerprint{Fingerprint: "c", Repository: "github.com/test/c", Path: "Dockerfile", MatchCount: 3}
+
+	t.Run("unchanged", func(t *testing.T) {
+		require.Nil(t, DiffFingerprints([]database.ResultFingerprint{a, b}, []database.ResultFingerprint{a, b}))
+		require.Nil(t, DiffFingerprints(nil, nil))
+	})
+
+	t.Run("added and removed", func(t *testing.T) {
+		diff := DiffFingerprints([]database.ResultFingerprint{a, b}, []database.ResultFingerprint{b, c})
+		require.Equal(t, &database.ResultDiff{
+			Added:         []database.ResultFingerprint{c},
+			Removed:       []database.ResultFingerprint{a},
+			PreviousCount: 3,
+			CurrentCount:  5,
+		}, diff)
+	})
+
+	t.Run("everything removed", func(t *testing.T) {
+		diff := DiffFingerprints([]database.ResultFingerprint{a}, nil)
+		require.Equal(t, &database.ResultDiff{
+			Added:         []database.ResultFingerprint{},
+			Removed:       []database.ResultFingerprint{a},
+			PreviousCount: 1,
+			CurrentCount:  0,
+		}, diff)
+	})
+}
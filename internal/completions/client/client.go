@@ -7,21 +7,24 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/codygateway"
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/fireworks"
 	"github.com/sourcegraph/sourcegraph/internal/completions/client/openai"
+	"github.com/sourcegraph/sourcegraph/internal/completions/client/openaicompatible"
 	"github.com/sourcegraph/sourcegraph/internal/completions/types"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
-func Get(endpoint string, provider conftypes.CompletionsProviderName, accessToken string) (types.CompletionsClient, error) {
-	client, err := getBasic(endpoint, provider, accessToken)
+func Get(config *conftypes.CompletionsConfig) (types.CompletionsClient, error) {
+	client, err := getBasic(config)
 	if err != nil {
 		return nil, err
 	}
 	return newObservedClient(client), nil
 }
 
-func getBasic(endpoint string, provider conftypes.CompletionsProviderName, accessToken string) (types.CompletionsClient, error) {
+func getBasic(config *conftypes.CompletionsConfig) (types.CompletionsClient, error) {
+	endpoint, provider, accessToken := config.Endpoint, config.Provider, config.AccessToken
+
 	switch provider {
 	case conftypes.CompletionsProviderNameAnthropic:
 		return anthropic.NewClient(httpcli.ExternalDoer, endpoint, accessToken), nil
@@ -35,7 +38,25 @@ func getBasic(endpoint string, provider conftypes.CompletionsProviderName, acces
 		return fireworks.NewClient(httpcli.ExternalDoer, endpoint, accessToken), nil
 	case conftypes.CompletionsProviderNameAWSBedrock:
 		return awsbedrock.NewClient(httpcli.ExternalDoer, endpoint, accessToken), nil
+	case conftypes.CompletionsProviderNameOpenAICompatible:
+		return openaicompatible.NewClient(httpcli.ExternalDoer, endpoint, accessToken, maxPromptTokensByModel(config)), nil
 	default:
 		return nil, errors.Newf("unknown completion stream provider: %s", provider)
 	}
 }
+
+// maxPromptTokensByModel returns the maximum number of prompt tokens of each configured model.
+// If a model is configured several times, the lowest limit applies.
+func maxPromptTokensByModel(config *conftypes.CompletionsConfig) map[string]int {
+	limits := make(map[string]int, 3)
+	add := func(model string, maxTokens int) {
+		if current, ok := limits[model]; !ok || maxTokens < current {
+			limits[model] = maxTokens
+		}
+	}
+	add(config.ChatModel, config.ChatModelMaxTokens)
+	add(config.FastChatModel, config.FastChatModelMaxTokens)
+	add(config.CompletionModel, config.CompletionModelMaxTokens)
+
+	return limits
+}
@@ -0,0 +1,232 @@
+package openaicompatible
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/client/openai"
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewClient returns a client for a self-hosted model server exposing the OpenAI API, such as
+// vLLM, the llama.cpp server or Ollama. The endpoint is the base URL of the API, for example
+// "http://localhost:8000/v1". Prompts to the models in maxPromptTokens are truncated to fit
+// within the given number of tokens.
+func NewClient(cli httpcli.Doer, endpoint, accessToken string, maxPromptTokens map[string]int) types.CompletionsClient {
+	return &openAICompatibleClient{
+		cli:             cli,
+		accessToken:     accessToken,
+		endpoint:        strings.TrimSuffix(endpoint, "/"),
+		maxPromptTokens: maxPromptTokens,
+	}
+}
+
+type openAICompatibleClient struct {
+	cli             httpcli.Doer
+	accessToken     string
+	endpoint        string
+	maxPromptTokens map[string]int
+}
+
+func (c *openAICompatibleClient) Complete(
+	ctx context.Context,
+	feature types.CompletionsFeature,
+	requestParams types.CompletionRequestParameters,
+) (*types.CompletionResponse, error) {
+	resp, err := c.makeRequest(ctx, feature, requestParams, false)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response openaiResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Choices) == 0 {
+		// Empty response.
+		return &types.CompletionResponse{}, nil
+	}
+
+	return &types.CompletionResponse{
+		Completion: response.Choices[0].text(feature),
+		StopReason: response.Choices[0].FinishReason,
+	}, nil
+}
+
+func (c *openAICompatibleClient) Stream(
+	ctx context.Context,
+	feature types.CompletionsFeature,
+	requestParams types.CompletionRequestParameters,
+	sendEvent types.SendCompletionEvent,
+) error {
+	resp, err := c.makeRequest(ctx, feature, requestParams, true)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	dec := openai.NewDecoder(resp.Body)
+	var content string
+	for dec.Scan() {
+		if ctx.Err() != nil && ctx.Err() == context.Canceled {
+			return nil
+		}
+
+		data := dec.Data()
+		// Gracefully skip over any data that isn't JSON-like.
+		if !bytes.HasPrefix(data, []byte("{")) {
+			continue
+		}
+
+		var event openaiResponse
+		if err := json.Unmarshal(data, &event); err != nil {
+			return errors.Errorf("failed to decode event payload: %w - body: %s", err, string(data))
+		}
+
+		if len(event.Choices) > 0 {
+			content += event.Choices[0].text(feature)
+			ev := types.CompletionResponse{
+				Completion: content,
+				StopReason: event.Choices[0].FinishReason,
+			}
+			err = sendEvent(ev)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return dec.Err()
+}
+
+// makeRequest sends a request to the chat completions API, or to the completions API for code
+// completions, which are sent as a single raw prompt.
+func (c *openAICompatibleClient) makeRequest(ctx context.Context, feature types.CompletionsFeature, requestParams types.CompletionRequestParameters, stream bool) (*http.Response, error) {
+	if requestParams.TopK < 0 {
+		requestParams.TopK = 0
+	}
+	if requestParams.TopP < 0 {
+		requestParams.TopP = 0
+	}
+
+	payload := requestParameters{
+		Model:       requestParams.Model,
+		Temperature: requestParams.Temperature,
+		TopP:        requestParams.TopP,
+		N:           1,
+		Stream:      stream,
+		MaxTokens:   requestParams.MaxTokensToSample,
+		Stop:        requestParams.StopSequences,
+	}
+
+	messages := truncateMessages(requestParams.Messages, c.maxPromptTokens[requestParams.Model])
+
+	var path string
+	if feature == types.CompletionsFeatureCode {
+		if len(messages) != 1 {
+			return nil, errors.New("Expected to receive exactly one message with the prompt")
+		}
+		path = "/completions"
+		payload.Prompt = messages[0].Text
+	} else {
+		path = "/chat/completions"
+		for i, m := range messages {
+			var role string
+			switch m.Speaker {
+			case types.HUMAN_MESSAGE_SPEAKER:
+				role = "user"
+			case types.ASISSTANT_MESSAGE_SPEAKER:
+				// Clients prime Anthropic models with a trailing empty assistant message.
+				// Chat completions APIs reply as the assistant on their own, and the chat
+				// templates of many open models reject an empty last message.
+				if m.Text == "" && i == len(messages)-1 {
+					continue
+				}
+				role = "assistant"
+			default:
+				return nil, errors.Newf("expected message speaker to be 'human' or 'assistant', got %s", m.Speaker)
+			}
+			payload.Messages = append(payload.Messages, message{
+				Role:    role,
+				Content: m.Text,
+			})
+		}
+	}
+
+	reqBody, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.endpoint+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	// Self-hosted model servers usually don't require authentication.
+	if c.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, types.NewErrStatusNotOK("OpenAI-compatible", resp)
+	}
+
+	return resp, nil
+}
+
+type requestParameters struct {
+	Model       string    `json:"model"`
+	Messages    []message `json:"messages,omitempty"`
+	Prompt      string    `json:"prompt,omitempty"`
+	Temperature float32   `json:"temperature,omitempty"`
+	TopP        float32   `json:"top_p,omitempty"`
+	N           int       `json:"n,omitempty"`
+	Stream      bool      `json:"stream,omitempty"`
+	Stop        []string  `json:"stop,omitempty"`
+	MaxTokens   int       `json:"max_tokens,omitempty"`
+}
+
+type message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openaiChoice struct {
+	// Message is only set for non-streaming chat completions.
+	Message message `json:"message"`
+	// Delta is only set for streaming chat completions.
+	Delta message `json:"delta"`
+	// Text is only set for completions.
+	Text         string `json:"text"`
+	FinishReason string `json:"finish_reason"`
+}
+
+// text returns the generated text of the choice, which depends on the API that was called.
+func (c openaiChoice) text(feature types.CompletionsFeature) string {
+	if feature == types.CompletionsFeatureCode {
+		return c.Text
+	}
+	if c.Delta.Content != "" {
+		return c.Delta.Content
+	}
+	return c.Message.Content
+}
+
+type openaiResponse struct {
+	Model   string         `json:"model"`
+	Choices []openaiChoice `json:"choices"`
+}
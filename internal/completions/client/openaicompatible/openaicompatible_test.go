@@ -0,0 +1,144 @@
+package openaicompatible
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+)
+
+type mockDoer struct {
+	do func(*http.Request) (*http.Response, error)
+}
+
+func (c *mockDoer) Do(r *http.Request) (*http.Response, error) {
+	return c.do(r)
+}
+
+func TestErrStatusNotOK(t *testing.T) {
+	mockClient := NewClient(&mockDoer{
+		func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Body:       io.NopCloser(bytes.NewReader([]byte("oh no, please slow down!"))),
+			}, nil
+		},
+	}, "", "", nil)
+
+	t.Run("Complete", func(t *testing.T) {
+		resp, err := mockClient.Complete(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{})
+		require.Error(t, err)
+		assert.Nil(t, resp)
+
+		autogold.Expect("OpenAI-compatible: unexpected status code 429: oh no, please slow down!").Equal(t, err.Error())
+		_, ok := types.IsErrStatusNotOK(err)
+		assert.True(t, ok)
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		err := mockClient.Stream(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{}, func(event types.CompletionResponse) error { return nil })
+		require.Error(t, err)
+
+		autogold.Expect("OpenAI-compatible: unexpected status code 429: oh no, please slow down!").Equal(t, err.Error())
+		_, ok := types.IsErrStatusNotOK(err)
+		assert.True(t, ok)
+	})
+}
+
+func TestComplete(t *testing.T) {
+	var requests []*http.Request
+	var payloads []requestParameters
+	mockClient := NewClient(&mockDoer{
+		func(r *http.Request) (*http.Response, error) {
+			var payload requestParameters
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+			requests = append(requests, r)
+			payloads = append(payloads, payload)
+
+			body := `{"choices":[{"message":{"role":"assistant","content":"Hello!"},"finish_reason":"stop"}]}`
+			if payload.Prompt != "" {
+				body = `{"choices":[{"text":"return a + b","finish_reason":"length"}]}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		},
+	}, "http://localhost:8000/v1/", "", nil)
+
+	t.Run("chat", func(t *testing.T) {
+		resp, err := mockClient.Complete(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{
+			Model: "codellama/CodeLlama-7b-Instruct-hf",
+			Messages: []types.Message{
+				{Speaker: types.HUMAN_MESSAGE_SPEAKER, Text: "Hi"},
+				{Speaker: types.ASISSTANT_MESSAGE_SPEAKER, Text: ""},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &types.CompletionResponse{Completion: "Hello!", StopReason: "stop"}, resp)
+
+		req := requests[len(requests)-1]
+		assert.Equal(t, "http://localhost:8000/v1/chat/completions", req.URL.String())
+		assert.Empty(t, req.Header.Get("Authorization"))
+		// The trailing empty assistant message is dropped.
+		assert.Equal(t, []message{{Role: "user", Content: "Hi"}}, payloads[len(payloads)-1].Messages)
+	})
+
+	t.Run("code", func(t *testing.T) {
+		resp, err := mockClient.Complete(context.Background(), types.CompletionsFeatureCode, types.CompletionRequestParameters{
+			Model:    "codellama/CodeLlama-7b-hf",
+			Messages: []types.Message{{Speaker: types.HUMAN_MESSAGE_SPEAKER, Text: "func add(a, b int) int {"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, &types.CompletionResponse{Completion: "return a + b", StopReason: "length"}, resp)
+
+		req := requests[len(requests)-1]
+		assert.Equal(t, "http://localhost:8000/v1/completions", req.URL.String())
+		assert.Equal(t, "func add(a, b int) int {", payloads[len(payloads)-1].Prompt)
+	})
+}
+
+func TestStream(t *testing.T) {
+	stream := "" +
+		"data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n" +
+		"data: {\"choices\":[{\"delta\":{\"content\":\"Hello\"}}]}\n\n" +
+		"data: {\"choices\":[{\"delta\":{\"content\":\" world\"},\"finish_reason\":\"stop\"}]}\n\n" +
+		"data: [DONE]\n\n"
+
+	mockClient := NewClient(&mockDoer{
+		func(r *http.Request) (*http.Response, error) {
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(stream)),
+			}, nil
+		},
+	}, "http://localhost:8000/v1", "secret", nil)
+
+	var events []types.CompletionResponse
+	err := mockClient.Stream(context.Background(), types.CompletionsFeatureChat, types.CompletionRequestParameters{
+		Messages: []types.Message{{Speaker: types.HUMAN_MESSAGE_SPEAKER, Text: "Hi"}},
+	}, func(event types.CompletionResponse) error {
+		events = append(events, event)
+		return nil
+	})
+	require.NoError(t, err)
+
+	autogold.Expect([]types.CompletionResponse{
+		{},
+		{Completion: "Hello"},
+		{
+			Completion: "Hello world",
+			StopReason: "stop",
+		},
+	}).Equal(t, events)
+}
@@ -0,0 +1,73 @@
+package openaicompatible
+
+import (
+	"unicode/utf8"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+)
+
+// We cannot run the tokenizers of arbitrary self-hosted models, so prompts are budgeted by
+// their length instead. Most tokenizers produce a token per three to four characters of
+// English or code, so we estimate conservatively.
+const (
+	charsPerToken = 3
+	// tokensPerMessage accounts for the role markers chat templates wrap each message in.
+	tokensPerMessage = 4
+)
+
+// estimateTokens returns an upper estimate of the number of tokens of the given text.
+func estimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+}
+
+func estimatePromptTokens(messages []types.Message) int {
+	tokens := 0
+	for _, m := range messages {
+		tokens += estimateTokens(m.Text) + tokensPerMessage
+	}
+	return tokens
+}
+
+// truncateMessages returns the messages truncated so that their estimated number of tokens is
+// at most maxPromptTokens. The oldest exchanges of human and assistant messages are dropped
+// first, so that the remaining messages still alternate. If that is not enough, text is cut
+// from the start of the oldest remaining messages, as the end of a prompt matters most. A
+// maxPromptTokens of zero or less disables truncation.
+func truncateMessages(messages []types.Message, maxPromptTokens int) []types.Message {
+	if maxPromptTokens <= 0 {
+		return messages
+	}
+
+	for len(messages) > 2 && estimatePromptTokens(messages) > maxPromptTokens {
+		messages = messages[2:]
+	}
+
+	excess := estimatePromptTokens(messages) - maxPromptTokens
+	if excess <= 0 {
+		return messages
+	}
+
+	truncated := make([]types.Message, len(messages))
+	copy(truncated, messages)
+	for i := 0; i < len(truncated) && excess > 0; i++ {
+		text := truncated[i].Text
+		tokens := estimateTokens(text)
+		if tokens <= excess {
+			truncated[i].Text = ""
+			excess -= tokens
+			continue
+		}
+
+		// Drop whole runes from the start of the text.
+		drop := excess * charsPerToken
+		for drop > 0 && len(text) > 0 {
+			_, size := utf8.DecodeRuneInString(text)
+			text = text[size:]
+			drop--
+		}
+		truncated[i].Text = text
+		excess = 0
+	}
+
+	return truncated
+}
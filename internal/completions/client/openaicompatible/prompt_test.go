@@ -0,0 +1,52 @@
+package openaicompatible
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/sourcegraph/sourcegraph/internal/completions/types"
+)
+
+func TestTruncateMessages(t *testing.T) {
+	human := func(text string) types.Message {
+		return types.Message{Speaker: types.HUMAN_MESSAGE_SPEAKER, Text: text}
+	}
+	assistant := func(text string) types.Message {
+		return types.Message{Speaker: types.ASISSTANT_MESSAGE_SPEAKER, Text: text}
+	}
+
+	messages := []types.Message{
+		human(strings.Repeat("a", 300)), // 100 tokens
+		assistant("ok"),                 // 1 token
+		human(strings.Repeat("b", 30)),  // 10 tokens
+		assistant(""),
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		assert.Equal(t, messages, truncateMessages(messages, 0))
+	})
+
+	t.Run("within budget", func(t *testing.T) {
+		assert.Equal(t, messages, truncateMessages(messages, 127))
+	})
+
+	t.Run("drops oldest exchange", func(t *testing.T) {
+		assert.Equal(t, messages[2:], truncateMessages(messages, 126))
+	})
+
+	t.Run("truncates start of text", func(t *testing.T) {
+		truncated := truncateMessages(messages, 15)
+		assert.Equal(t, []types.Message{human(strings.Repeat("b", 21)), assistant("")}, truncated)
+		assert.LessOrEqual(t, estimatePromptTokens(truncated), 15)
+
+		// The given messages are not modified.
+		assert.Equal(t, strings.Repeat("b", 30), messages[2].Text)
+	})
+
+	t.Run("multi-byte runes", func(t *testing.T) {
+		truncated := truncateMessages([]types.Message{human("ééééééééé")}, 6)
+		assert.Equal(t, []types.Message{human("éééééé")}, truncated)
+	})
+}
@@ -49,6 +49,7 @@ func newCompletionsHandler(
 		completionsConfig := conf.GetCompletionsConfig(conf.Get().SiteConfig())
 		if completionsConfig == nil {
 			http.Error(w, "completions are not configured or disabled", http.StatusInternalServerError)
+			return
 		}
 
 		var requestParams types.CodyCompletionRequestParameters
@@ -71,11 +72,7 @@ func newCompletionsHandler(
 			Build()
 		defer done()
 
-		completionClient, err := client.Get(
-			completionsConfig.Endpoint,
-			completionsConfig.Provider,
-			completionsConfig.AccessToken,
-		)
+		completionClient, err := client.Get(completionsConfig)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
@@ -741,12 +741,36 @@ func GetCompletionsConfig(siteConfig schema.SiteConfiguration) (c *conftypes.Com
 		if completionsConfig.CompletionModel == "" {
 			completionsConfig.CompletionModel = "anthropic.claude-instant-v1"
 		}
+	} else if completionsConfig.Provider == string(conftypes.CompletionsProviderNameOpenAICompatible) {
+		// If no endpoint is configured, we cannot know where the model server is. Bail.
+		// Self-hosted model servers usually don't require an access token.
+		if completionsConfig.Endpoint == "" {
+			return nil
+		}
+
+		// If no chat model is set, we cannot know which model is served. Bail.
+		if completionsConfig.ChatModel == "" {
+			return nil
+		}
+
+		// If no fast chat model is set, we fall back to the chat model.
+		if completionsConfig.FastChatModel == "" {
+			completionsConfig.FastChatModel = completionsConfig.ChatModel
+		}
+
+		// If no completions model is set, we fall back to the chat model.
+		if completionsConfig.CompletionModel == "" {
+			completionsConfig.CompletionModel = completionsConfig.ChatModel
+		}
 	}
 
-	// Make sure models are always treated case-insensitive.
-	completionsConfig.ChatModel = strings.ToLower(completionsConfig.ChatModel)
-	completionsConfig.FastChatModel = strings.ToLower(completionsConfig.FastChatModel)
-	completionsConfig.CompletionModel = strings.ToLower(completionsConfig.CompletionModel)
+	// Make sure models are always treated case-insensitive. Model names of self-hosted
+	// model servers are often paths or repository names, which are case-sensitive.
+	if completionsConfig.Provider != string(conftypes.CompletionsProviderNameOpenAICompatible) {
+		completionsConfig.ChatModel = strings.ToLower(completionsConfig.ChatModel)
+		completionsConfig.FastChatModel = strings.ToLower(completionsConfig.FastChatModel)
+		completionsConfig.CompletionModel = strings.ToLower(completionsConfig.CompletionModel)
+	}
 
 	// If after trying to set default we still have not all models configured, completions are
 	// not available.
@@ -923,6 +947,17 @@ func GetEmbeddingsConfig(siteConfig schema.SiteConfiguration) *conftypes.Embeddi
 		// Make sure models are always treated case-insensitive.
 		// TODO: Are model names on azure case insensitive?
 		embeddingsConfig.Model = strings.ToLower(embeddingsConfig.Model)
+	} else if embeddingsConfig.Provider == string(conftypes.EmbeddingsProviderNameOpenAICompatible) {
+		// If no endpoint is configured, we cannot know where the model server is.
+		if embeddingsConfig.Endpoint == "" {
+			return nil
+		}
+
+		// If no model is set, we cannot know which model is served. Model names of
+		// self-hosted model servers are case-sensitive, so they are used as-is.
+		if embeddingsConfig.Model == "" {
+			return nil
+		}
 	} else {
 		// Unknown provider value.
 		return nil
@@ -998,6 +1033,20 @@ func GetEmbeddingsConfig(siteConfig schema.SiteConfiguration) *conftypes.Embeddi
 		}
 	}
 
+	// Default values should match the documented defaults in site.schema.json.
+	computedApproximateSearchConfig := conftypes.EmbeddingsApproximateSearchConfig{
+		Enabled:        false,
+		M:              16,
+		EfConstruction: 100,
+		EfSearch:       64,
+	}
+	if as := embeddingsConfig.ApproximateSearch; as != nil {
+		computedApproximateSearchConfig.Enabled = as.Enabled
+		computedApproximateSearchConfig.M = defaultTo(as.M, computedApproximateSearchConfig.M)
+		computedApproximateSearchConfig.EfConstruction = defaultTo(as.EfConstruction, computedApproximateSearchConfig.EfConstruction)
+		computedApproximateSearchConfig.EfSearch = defaultTo(as.EfSearch, computedApproximateSearchConfig.EfSearch)
+	}
+
 	computedConfig := &conftypes.EmbeddingsConfig{
 		Provider:    conftypes.EmbeddingsProviderName(embeddingsConfig.Provider),
 		AccessToken: embeddingsConfig.AccessToken,
@@ -1012,6 +1061,7 @@ func GetEmbeddingsConfig(siteConfig schema.SiteConfiguration) *conftypes.Embeddi
 		PolicyRepositoryMatchLimit: embeddingsConfig.PolicyRepositoryMatchLimit,
 		ExcludeChunkOnError:        pointers.Deref(embeddingsConfig.ExcludeChunkOnError, true),
 		Qdrant:                     computedQdrantConfig,
+		ApproximateSearch:          computedApproximateSearchConfig,
 	}
 	d, err := time.ParseDuration(embeddingsConfig.MinimumInterval)
 	if err != nil {
@@ -1091,6 +1141,10 @@ func defaultMaxPromptTokens(provider conftypes.CompletionsProviderName, model st
 		}
 		// Fallback for weird values.
 		return 9_000
+	case conftypes.CompletionsProviderNameOpenAICompatible:
+		// We cannot know the context window of a self-hosted model from its name. This
+		// leaves room for the response within the 4k context of most open models.
+		return 3_000
 	}
 
 	// Should be unreachable.
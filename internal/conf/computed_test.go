@@ -654,6 +654,40 @@ func TestGetCompletionsConfig(t *testing.T) {
 				Endpoint:                 "https://api.anthropic.com/v1/complete",
 			},
 		},
+		{
+			name: "OpenAI-compatible provider",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Completions: &schema.Completions{
+					Provider:  "openai-compatible",
+					Endpoint:  "http://localhost:8000/v1",
+					ChatModel: "codellama/CodeLlama-7b-Instruct-hf",
+				},
+			},
+			wantConfig: &conftypes.CompletionsConfig{
+				ChatModel:                "codellama/CodeLlama-7b-Instruct-hf",
+				ChatModelMaxTokens:       3000,
+				FastChatModel:            "codellama/CodeLlama-7b-Instruct-hf",
+				FastChatModelMaxTokens:   3000,
+				CompletionModel:          "codellama/CodeLlama-7b-Instruct-hf",
+				CompletionModelMaxTokens: 3000,
+				Provider:                 "openai-compatible",
+				Endpoint:                 "http://localhost:8000/v1",
+			},
+		},
+		{
+			name: "OpenAI-compatible provider without endpoint",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Completions: &schema.Completions{
+					Provider:  "openai-compatible",
+					ChatModel: "codellama",
+				},
+			},
+			wantDisabled: true,
+		},
 		{
 			name:       "App but no dotcom username",
 			deployType: deploy.App,
@@ -709,6 +743,11 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 			Quantile: 0.98,
 		},
 	}
+	defaultApproximateSearchConfig := conftypes.EmbeddingsApproximateSearchConfig{
+		M:              16,
+		EfConstruction: 100,
+		EfSearch:       64,
+	}
 	zeroConfigDefaultWithLicense := &conftypes.EmbeddingsConfig{
 		Provider:                   "sourcegraph",
 		AccessToken:                licenseAccessToken,
@@ -725,6 +764,7 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 		},
 		ExcludeChunkOnError: true,
 		Qdrant:              defaultQdrantConfig,
+		ApproximateSearch:   defaultApproximateSearchConfig,
 	}
 
 	testCases := []struct {
@@ -832,6 +872,7 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				},
 				ExcludeChunkOnError: true,
 				Qdrant:              defaultQdrantConfig,
+				ApproximateSearch:   defaultApproximateSearchConfig,
 			},
 		},
 		{
@@ -867,6 +908,7 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				},
 				ExcludeChunkOnError: false,
 				Qdrant:              defaultQdrantConfig,
+				ApproximateSearch:   defaultApproximateSearchConfig,
 			},
 		},
 		{
@@ -894,6 +936,7 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				},
 				ExcludeChunkOnError: true,
 				Qdrant:              defaultQdrantConfig,
+				ApproximateSearch:   defaultApproximateSearchConfig,
 			},
 		},
 		{
@@ -933,6 +976,7 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				},
 				ExcludeChunkOnError: true,
 				Qdrant:              defaultQdrantConfig,
+				ApproximateSearch:   defaultApproximateSearchConfig,
 			},
 		},
 		{
@@ -975,6 +1019,85 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				},
 				ExcludeChunkOnError: true,
 				Qdrant:              defaultQdrantConfig,
+				ApproximateSearch:   defaultApproximateSearchConfig,
+			},
+		},
+		{
+			name: "OpenAI-compatible provider",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider:   "openai-compatible",
+					Endpoint:   "http://localhost:11434/v1",
+					Dimensions: 768,
+					Model:      "BAAI/bge-base-en-v1.5",
+				},
+			},
+			wantConfig: &conftypes.EmbeddingsConfig{
+				Provider:                   "openai-compatible",
+				Model:                      "BAAI/bge-base-en-v1.5",
+				Endpoint:                   "http://localhost:11434/v1",
+				Dimensions:                 768,
+				Incremental:                true,
+				MinimumInterval:            24 * time.Hour,
+				MaxCodeEmbeddingsPerRepo:   3_072_000,
+				MaxTextEmbeddingsPerRepo:   512_000,
+				PolicyRepositoryMatchLimit: pointers.Ptr(5000),
+				FileFilters: conftypes.EmbeddingsFileFilters{
+					MaxFileSizeBytes: 1000000,
+				},
+				ExcludeChunkOnError: true,
+				Qdrant:              defaultQdrantConfig,
+				ApproximateSearch:   defaultApproximateSearchConfig,
+			},
+		},
+		{
+			name: "OpenAI-compatible provider without model",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					Provider: "openai-compatible",
+					Endpoint: "http://localhost:11434/v1",
+				},
+			},
+			wantDisabled: true,
+		},
+		{
+			name: "Approximate search enabled",
+			siteConfig: schema.SiteConfiguration{
+				CodyEnabled: pointers.Ptr(true),
+				LicenseKey:  licenseKey,
+				Embeddings: &schema.Embeddings{
+					ApproximateSearch: &schema.ApproximateSearch{
+						Enabled:  true,
+						EfSearch: 200,
+					},
+				},
+			},
+			wantConfig: &conftypes.EmbeddingsConfig{
+				Provider:                   "sourcegraph",
+				AccessToken:                licenseAccessToken,
+				Model:                      "openai/text-embedding-ada-002",
+				Endpoint:                   "https://cody-gateway.sourcegraph.com/v1/embeddings",
+				Dimensions:                 1536,
+				Incremental:                true,
+				MinimumInterval:            24 * time.Hour,
+				MaxCodeEmbeddingsPerRepo:   3_072_000,
+				MaxTextEmbeddingsPerRepo:   512_000,
+				PolicyRepositoryMatchLimit: pointers.Ptr(5000),
+				FileFilters: conftypes.EmbeddingsFileFilters{
+					MaxFileSizeBytes: 1000000,
+				},
+				ExcludeChunkOnError: true,
+				Qdrant:              defaultQdrantConfig,
+				ApproximateSearch: conftypes.EmbeddingsApproximateSearchConfig{
+					Enabled:        true,
+					M:              16,
+					EfConstruction: 100,
+					EfSearch:       200,
+				},
 			},
 		},
 		{
@@ -1002,6 +1125,7 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				},
 				ExcludeChunkOnError: true,
 				Qdrant:              defaultQdrantConfig,
+				ApproximateSearch:   defaultApproximateSearchConfig,
 			},
 		},
 		{
@@ -1042,6 +1166,7 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				},
 				ExcludeChunkOnError: true,
 				Qdrant:              defaultQdrantConfig,
+				ApproximateSearch:   defaultApproximateSearchConfig,
 			},
 		},
 		{
@@ -1070,6 +1195,7 @@ func TestGetEmbeddingsConfig(t *testing.T) {
 				},
 				ExcludeChunkOnError: true,
 				Qdrant:              defaultQdrantConfig,
+				ApproximateSearch:   defaultApproximateSearchConfig,
 			},
 		},
 		{
@@ -22,12 +22,13 @@ type CompletionsConfig struct {
 type CompletionsProviderName string
 
 const (
-	CompletionsProviderNameAnthropic   CompletionsProviderName = "anthropic"
-	CompletionsProviderNameOpenAI      CompletionsProviderName = "openai"
-	CompletionsProviderNameAzureOpenAI CompletionsProviderName = "azure-openai"
-	CompletionsProviderNameSourcegraph CompletionsProviderName = "sourcegraph"
-	CompletionsProviderNameFireworks   CompletionsProviderName = "fireworks"
-	CompletionsProviderNameAWSBedrock  CompletionsProviderName = "aws-bedrock"
+	CompletionsProviderNameAnthropic        CompletionsProviderName = "anthropic"
+	CompletionsProviderNameOpenAI           CompletionsProviderName = "openai"
+	CompletionsProviderNameAzureOpenAI      CompletionsProviderName = "azure-openai"
+	CompletionsProviderNameSourcegraph      CompletionsProviderName = "sourcegraph"
+	CompletionsProviderNameFireworks        CompletionsProviderName = "fireworks"
+	CompletionsProviderNameAWSBedrock       CompletionsProviderName = "aws-bedrock"
+	CompletionsProviderNameOpenAICompatible CompletionsProviderName = "openai-compatible"
 )
 
 type EmbeddingsConfig struct {
@@ -44,6 +45,14 @@ type EmbeddingsConfig struct {
 	PolicyRepositoryMatchLimit *int
 	ExcludeChunkOnError        bool
 	Qdrant                     QdrantConfig
+	ApproximateSearch          EmbeddingsApproximateSearchConfig
+}
+
+type EmbeddingsApproximateSearchConfig struct {
+	Enabled        bool
+	M              int
+	EfConstruction int
+	EfSearch       int
 }
 
 type QdrantConfig struct {
@@ -74,9 +83,10 @@ type QdrantQuantizationConfig struct {
 type EmbeddingsProviderName string
 
 const (
-	EmbeddingsProviderNameOpenAI      EmbeddingsProviderName = "openai"
-	EmbeddingsProviderNameAzureOpenAI EmbeddingsProviderName = "azure-openai"
-	EmbeddingsProviderNameSourcegraph EmbeddingsProviderName = "sourcegraph"
+	EmbeddingsProviderNameOpenAI           EmbeddingsProviderName = "openai"
+	EmbeddingsProviderNameAzureOpenAI      EmbeddingsProviderName = "azure-openai"
+	EmbeddingsProviderNameSourcegraph      EmbeddingsProviderName = "sourcegraph"
+	EmbeddingsProviderNameOpenAICompatible EmbeddingsProviderName = "openai-compatible"
 )
 
 type EmbeddingsFileFilters struct {
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCodeMonitorStoreIssues(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+
+	insertExternalService := func(t *testing.T, db DB) int64 {
+		t.Helper()
+
+		svc := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "GitHub",
+			Config:      extsvc.NewUnencryptedConfig(`{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`),
+		}
+		confGet := func() *conf.Unified { return &conf.Unified{} }
+		require.NoError(t, db.ExternalServices().Create(ctx, confGet, svc))
+		return svc.ID
+	}
+
+	t.Run("CreateThenGet", func(t *testing.T) {
+		t.Parallel()
+
+		db := NewDB(logger, dbtest.NewDB(t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitorsWith(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+		svcID := insertExternalService(t, db)
+
+		action, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, &IssueActionArgs{
+			Enabled:           true,
+			ExternalServiceID: svcID,
+			Repository:        "sourcegraph/security",
+		})
+		require.NoError(t, err)
+		require.Nil(t, action.ExternalIssueNumber)
+
+		got, err := s.GetIssueAction(ctx, action.ID)
+		require.NoError(t, err)
+		require.Equal(t, action, got)
+	})
+
+	t.Run("SetExternalIssueThenUpdate", func(t *testing.T) {
+		t.Parallel()
+
+		db := NewDB(logger, dbtest.NewDB(t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitorsWith(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+		svcID := insertExternalService(t, db)
+
+		args := &IssueActionArgs{Enabled: true, ExternalServiceID: svcID, Repository: "sourcegraph/security"}
+		action, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, args)
+		require.NoError(t, err)
+
+		err = s.SetIssueActionExternalIssue(ctx, action.ID, 42, "https://github.com/sourcegraph/security/issues/42")
+		require.NoError(t, err)
+
+		// Updating without changing the target keeps the issue.
+		args.IncludeResults = true
+		updated, err := s.UpdateIssueAction(ctx, action.ID, args)
+		require.NoError(t, err)
+		require.True(t, updated.IncludeResults)
+		require.NotNil(t, updated.ExternalIssueNumber)
+		require.Equal(t, int32(42), *updated.ExternalIssueNumber)
+
+		// Pointing the action at another repository forgets the issue.
+		args.Repository = "sourcegraph/other"
+		updated, err = s.UpdateIssueAction(ctx, action.ID, args)
+		require.NoError(t, err)
+		require.Equal(t, "sourcegraph/other", updated.Repository)
+		require.Nil(t, updated.ExternalIssueNumber)
+		require.Nil(t, updated.ExternalIssueURL)
+	})
+
+	t.Run("ErrorOnUpdateNonexistent", func(t *testing.T) {
+		t.Parallel()
+
+		db := NewDB(logger, dbtest.NewDB(t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitorsWith(db)
+
+		_, err := s.UpdateIssueAction(ctx, 383838, &IssueActionArgs{Repository: "sourcegraph/security"})
+		require.Error(t, err)
+	})
+
+	t.Run("CreateDeleteCountList", func(t *testing.T) {
+		t.Parallel()
+
+		db := NewDB(logger, dbtest.NewDB(t))
+		_, _, ctx := newTestUser(ctx, t, db)
+		s := CodeMonitorsWith(db)
+		fixtures := s.insertTestMonitor(ctx, t)
+		svcID := insertExternalService(t, db)
+
+		args := &IssueActionArgs{Enabled: true, ExternalServiceID: svcID, Repository: "sourcegraph/security"}
+		action1, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, args)
+		require.NoError(t, err)
+		action2, err := s.CreateIssueAction(ctx, fixtures.monitor.ID, args)
+		require.NoError(t, err)
+
+		count, err := s.CountIssueActions(ctx, fixtures.monitor.ID)
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		err = s.DeleteIssueActions(ctx, fixtures.monitor.ID, action1.ID)
+		require.NoError(t, err)
+
+		_, err = s.GetIssueAction(ctx, action1.ID)
+		require.Error(t, err)
+
+		actions, err := s.ListIssueActions(ctx, ListActionsOpts{MonitorID: &fixtures.monitor.ID})
+		require.NoError(t, err)
+		require.Len(t, actions, 1)
+		require.Equal(t, action2.ID, actions[0].ID)
+	})
+}
@@ -10,10 +10,24 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 )
 
+// QueryTriggerMode determines how the query of a code monitor is evaluated.
+type QueryTriggerMode string
+
+const (
+	// QueryTriggerModeCommits runs a diff or commit search over the commits
+	// added since the previous run and triggers on any new match.
+	QueryTriggerModeCommits QueryTriggerMode = "commits"
+
+	// QueryTriggerModeContent runs an arbitrary search and triggers when its
+	// result set differs from the result set of the previous run.
+	QueryTriggerModeContent QueryTriggerMode = "content"
+)
+
 type QueryTrigger struct {
 	ID           int64
 	Monitor      int64
 	QueryString  string
+	Mode         QueryTriggerMode
 	NextRun      time.Time
 	LatestResult *time.Time
 	CreatedBy    int32
@@ -28,6 +42,7 @@ var queryColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_queries.id"),
 	sqlf.Sprintf("cm_queries.monitor"),
 	sqlf.Sprintf("cm_queries.query"),
+	sqlf.Sprintf("cm_queries.mode"),
 	sqlf.Sprintf("cm_queries.next_run"),
 	sqlf.Sprintf("cm_queries.latest_result"),
 	sqlf.Sprintf("cm_queries.created_by"),
@@ -38,18 +53,19 @@ var queryColumns = []*sqlf.Query{
 
 const createTriggerQueryFmtStr = `
 INSERT INTO cm_queries
-(monitor, query, created_by, created_at, changed_by, changed_at, next_run, latest_result)
-VALUES (%s,%s,%s,%s,%s,%s,%s,%s)
+(monitor, query, mode, created_by, created_at, changed_by, changed_at, next_run, latest_result)
+VALUES (%s,%s,%s,%s,%s,%s,%s,%s,%s)
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) CreateQueryTrigger(ctx context.Context, monitorID int64, query string) (*QueryTrigger, error) {
+func (s *codeMonitorStore) CreateQueryTrigger(ctx context.Context, monitorID int64, query string, mode QueryTriggerMode) (*QueryTrigger, error) {
 	now := s.Now()
 	a := actor.FromContext(ctx)
 	q := sqlf.Sprintf(
 		createTriggerQueryFmtStr,
 		monitorID,
 		query,
+		mode,
 		a.UID,
 		now,
 		a.UID,
@@ -65,6 +81,7 @@ func (s *codeMonitorStore) CreateQueryTrigger(ctx context.Context, monitorID int
 const updateTriggerQueryFmtStr = `
 UPDATE cm_queries
 SET query = %s,
+	mode = %s,
 	changed_by = %s,
 	changed_at = %s,
 	latest_result = %s
@@ -78,7 +95,7 @@ WHERE
 RETURNING %s;
 `
 
-func (s *codeMonitorStore) UpdateQueryTrigger(ctx context.Context, id int64, query string) error {
+func (s *codeMonitorStore) UpdateQueryTrigger(ctx context.Context, id int64, query string, mode QueryTriggerMode) error {
 	now := s.Now()
 	a := actor.FromContext(ctx)
 
@@ -90,6 +107,7 @@ func (s *codeMonitorStore) UpdateQueryTrigger(ctx context.Context, id int64, que
 	q := sqlf.Sprintf(
 		updateTriggerQueryFmtStr,
 		query,
+		mode,
 		a.UID,
 		now,
 		now,
@@ -171,6 +189,7 @@ func scanTriggerQuery(scanner dbutil.Scanner) (*QueryTrigger, error) {
 		&m.ID,
 		&m.Monitor,
 		&m.QueryString,
+		&m.Mode,
 		&m.NextRun,
 		&m.LatestResult,
 		&m.CreatedBy,
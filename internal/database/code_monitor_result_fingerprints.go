@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// ResultFingerprint identifies a single result of a content mode code monitor
+// query. A result with the same fingerprint in two consecutive runs is
+// considered unchanged.
+type ResultFingerprint struct {
+	Fingerprint string `json:"fingerprint"`
+	Repository  string `json:"repository"`
+	Path        string `json:"path,omitempty"`
+	MatchCount  int    `json:"matchCount"`
+}
+
+// ResultDiff describes how the result set of a content mode code monitor query
+// changed between two runs.
+type ResultDiff struct {
+	Added   []ResultFingerprint `json:"added"`
+	Removed []ResultFingerprint `json:"removed"`
+
+	// PreviousCount and CurrentCount are the total number of matches before
+	// and after the change.
+	PreviousCount int `json:"previousCount"`
+	CurrentCount  int `json:"currentCount"`
+}
+
+func (s *codeMonitorStore) UpsertResultFingerprints(ctx context.Context, monitorID int64, fingerprints []ResultFingerprint) error {
+	rawQuery := `
+	INSERT INTO cm_result_fingerprints (monitor_id, fingerprints, updated_at)
+	VALUES (%s, %s, %s)
+	ON CONFLICT (monitor_id) DO UPDATE
+	SET fingerprints = EXCLUDED.fingerprints,
+		updated_at = EXCLUDED.updated_at
+	`
+
+	// Appease non-null constraint on column
+	if fingerprints == nil {
+		fingerprints = []ResultFingerprint{}
+	}
+	fingerprintsJSON, err := json.Marshal(fingerprints)
+	if err != nil {
+		return err
+	}
+	return s.Exec(ctx, sqlf.Sprintf(rawQuery, monitorID, fingerprintsJSON, s.Now()))
+}
+
+// GetResultFingerprints returns the fingerprints stored for the monitor. It
+// returns false if no fingerprints have been stored yet, which is different
+// from a previous run without results.
+func (s *codeMonitorStore) GetResultFingerprints(ctx context.Context, monitorID int64) ([]ResultFingerprint, bool, error) {
+	rawQuery := `
+	SELECT fingerprints
+	FROM cm_result_fingerprints
+	WHERE monitor_id = %s
+	`
+
+	var fingerprintsJSON []byte
+	err := s.QueryRow(ctx, sqlf.Sprintf(rawQuery, monitorID)).Scan(&fingerprintsJSON)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	var fingerprints []ResultFingerprint
+	if err := json.Unmarshal(fingerprintsJSON, &fingerprints); err != nil {
+		return nil, false, err
+	}
+	return fingerprints, true, nil
+}
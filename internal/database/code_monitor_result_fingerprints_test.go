@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestCodeMonitorStoreResultFingerprints(t *testing.T) {
+	t.Parallel()
+
+	logger := logtest.Scoped(t)
+	t.Run("insert get upsert get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewDB(logger, dbtest.NewDB(t))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		// Insert
+		insertFingerprints := []ResultFingerprint{
+			{Fingerprint: "a", Repository: "github.com/test/a", Path: "Dockerfile", MatchCount: 1},
+			{Fingerprint: "b", Repository: "github.com/test/b", Path: "Dockerfile", MatchCount: 2},
+		}
+		err := cm.UpsertResultFingerprints(ctx, fixtures.Monitor.ID, insertFingerprints)
+		require.NoError(t, err)
+
+		// Get
+		fingerprints, ok, err := cm.GetResultFingerprints(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, insertFingerprints, fingerprints)
+
+		// Update
+		updateFingerprints := []ResultFingerprint{
+			{Fingerprint: "c", Repository: "github.com/test/c", MatchCount: 1},
+		}
+		err = cm.UpsertResultFingerprints(ctx, fixtures.Monitor.ID, updateFingerprints)
+		require.NoError(t, err)
+
+		// Get
+		fingerprints, ok, err = cm.GetResultFingerprints(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, updateFingerprints, fingerprints)
+	})
+
+	t.Run("no error for missing get", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewDB(logger, dbtest.NewDB(t))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		fingerprints, ok, err := cm.GetResultFingerprints(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.False(t, ok)
+		require.Empty(t, fingerprints)
+	})
+
+	t.Run("nil fingerprints", func(t *testing.T) {
+		t.Parallel()
+		ctx := context.Background()
+		db := NewDB(logger, dbtest.NewDB(t))
+		fixtures := populateCodeMonitorFixtures(t, db)
+		cm := db.CodeMonitors()
+
+		err := cm.UpsertResultFingerprints(ctx, fixtures.Monitor.ID, nil)
+		require.NoError(t, err)
+
+		// An empty result set is still a stored result set.
+		fingerprints, ok, err := cm.GetResultFingerprints(ctx, fixtures.Monitor.ID)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Empty(t, fingerprints)
+	})
+}
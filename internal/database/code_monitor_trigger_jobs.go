@@ -22,6 +22,10 @@ type TriggerJob struct {
 
 	SearchResults []*result.CommitMatch
 
+	// ResultDiff is set for content mode queries whose result set changed
+	// since the previous run.
+	ResultDiff *ResultDiff
+
 	// Fields demanded for any dbworker.
 	State          string
 	FailureMessage *string
@@ -90,6 +94,28 @@ func (s *codeMonitorStore) UpdateTriggerJobWithResults(ctx context.Context, trig
 	return s.Store.Exec(ctx, sqlf.Sprintf(logSearchFmtStr, queryString, resultsJSON, triggerJobID))
 }
 
+const logResultDiffFmtStr = `
+UPDATE cm_trigger_jobs
+SET query_string = %s,
+    search_results = '[]'::jsonb,
+    result_diff = %s
+WHERE id = %s
+`
+
+// UpdateTriggerJobWithResultDiff records the query and the change in results of
+// a content mode query. diff is nil if the result set did not change.
+func (s *codeMonitorStore) UpdateTriggerJobWithResultDiff(ctx context.Context, triggerJobID int32, queryString string, diff *ResultDiff) error {
+	var diffJSON string
+	if diff != nil {
+		raw, err := json.Marshal(diff)
+		if err != nil {
+			return err
+		}
+		diffJSON = string(raw)
+	}
+	return s.Store.Exec(ctx, sqlf.Sprintf(logResultDiffFmtStr, queryString, dbutil.NullStringColumn(diffJSON), triggerJobID))
+}
+
 const deleteOldJobLogsFmtStr = `
 DELETE FROM cm_trigger_jobs
 WHERE finished_at < (NOW() - (%s * '1 day'::interval));
@@ -151,7 +177,7 @@ func (s *codeMonitorStore) ListQueryTriggerJobs(ctx context.Context, opts ListTr
 const totalCountEventsForQueryIDInt64FmtStr = `
 SELECT COUNT(*)
 FROM cm_trigger_jobs
-WHERE ((state = 'completed' AND jsonb_array_length(search_results) > 0) OR (state != 'completed'))
+WHERE ((state = 'completed' AND (jsonb_array_length(search_results) > 0 OR result_diff IS NOT NULL)) OR (state != 'completed'))
 AND query = %s
 `
 
@@ -178,13 +204,14 @@ func scanTriggerJobs(rows *sql.Rows) ([]*TriggerJob, error) {
 }
 
 func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
-	var resultsJSON []byte
+	var resultsJSON, resultDiffJSON []byte
 	m := &TriggerJob{}
 	err := scanner.Scan(
 		&m.ID,
 		&m.Query,
 		&m.QueryString,
 		&resultsJSON,
+		&resultDiffJSON,
 		&m.State,
 		&m.FailureMessage,
 		&m.StartedAt,
@@ -204,6 +231,12 @@ func ScanTriggerJob(scanner dbutil.Scanner) (*TriggerJob, error) {
 		}
 	}
 
+	if len(resultDiffJSON) > 0 {
+		if err := json.Unmarshal(resultDiffJSON, &m.ResultDiff); err != nil {
+			return nil, err
+		}
+	}
+
 	return m, nil
 }
 
@@ -212,6 +245,7 @@ var TriggerJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_trigger_jobs.query"),
 	sqlf.Sprintf("cm_trigger_jobs.query_string"),
 	sqlf.Sprintf("cm_trigger_jobs.search_results"),
+	sqlf.Sprintf("cm_trigger_jobs.result_diff"),
 	sqlf.Sprintf("cm_trigger_jobs.state"),
 	sqlf.Sprintf("cm_trigger_jobs.failure_message"),
 	sqlf.Sprintf("cm_trigger_jobs.started_at"),
@@ -58,6 +58,7 @@ type DB interface {
 	RepoKVPs() RepoKVPStore
 	RepoPaths() RepoPathStore
 	RolePermissions() RolePermissionStore
+	RoleScopes() RoleScopeStore
 	Roles() RoleStore
 	SavedSearches() SavedSearchStore
 	SearchContexts() SearchContextsStore
@@ -278,6 +279,10 @@ func (d *db) RolePermissions() RolePermissionStore {
 	return RolePermissionsWith(d.Store)
 }
 
+func (d *db) RoleScopes() RoleScopeStore {
+	return RoleScopesWith(d.Store)
+}
+
 func (d *db) Roles() RoleStore {
 	return RolesWith(d.Store)
 }
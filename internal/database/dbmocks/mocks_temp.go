@@ -6213,6 +6213,9 @@ type MockCodeMonitorStore struct {
 	// CountActionJobsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountActionJobs.
 	CountActionJobsFunc *CodeMonitorStoreCountActionJobsFunc
+	// CountIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method CountIssueActions.
+	CountIssueActionsFunc *CodeMonitorStoreCountIssueActionsFunc
 	// CountMonitorsFunc is an instance of a mock function object
 	// controlling the behavior of the method CountMonitors.
 	CountMonitorsFunc *CodeMonitorStoreCountMonitorsFunc
@@ -6231,6 +6234,9 @@ type MockCodeMonitorStore struct {
 	// CreateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateEmailAction.
 	CreateEmailActionFunc *CodeMonitorStoreCreateEmailActionFunc
+	// CreateIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateIssueAction.
+	CreateIssueActionFunc *CodeMonitorStoreCreateIssueActionFunc
 	// CreateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method CreateMonitor.
 	CreateMonitorFunc *CodeMonitorStoreCreateMonitorFunc
@@ -6249,6 +6255,9 @@ type MockCodeMonitorStore struct {
 	// DeleteEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteEmailActions.
 	DeleteEmailActionsFunc *CodeMonitorStoreDeleteEmailActionsFunc
+	// DeleteIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteIssueActions.
+	DeleteIssueActionsFunc *CodeMonitorStoreDeleteIssueActionsFunc
 	// DeleteMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteMonitor.
 	DeleteMonitorFunc *CodeMonitorStoreDeleteMonitorFunc
@@ -6287,6 +6296,9 @@ type MockCodeMonitorStore struct {
 	// GetEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetEmailAction.
 	GetEmailActionFunc *CodeMonitorStoreGetEmailActionFunc
+	// GetIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method GetIssueAction.
+	GetIssueActionFunc *CodeMonitorStoreGetIssueActionFunc
 	// GetLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method GetLastSearched.
 	GetLastSearchedFunc *CodeMonitorStoreGetLastSearchedFunc
@@ -6300,6 +6312,9 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// GetQueryTriggerForMonitor.
 	GetQueryTriggerForMonitorFunc *CodeMonitorStoreGetQueryTriggerForMonitorFunc
+	// GetResultFingerprintsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetResultFingerprints.
+	GetResultFingerprintsFunc *CodeMonitorStoreGetResultFingerprintsFunc
 	// GetSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method GetSlackWebhookAction.
 	GetSlackWebhookActionFunc *CodeMonitorStoreGetSlackWebhookActionFunc
@@ -6318,6 +6333,9 @@ type MockCodeMonitorStore struct {
 	// ListEmailActionsFunc is an instance of a mock function object
 	// controlling the behavior of the method ListEmailActions.
 	ListEmailActionsFunc *CodeMonitorStoreListEmailActionsFunc
+	// ListIssueActionsFunc is an instance of a mock function object
+	// controlling the behavior of the method ListIssueActions.
+	ListIssueActionsFunc *CodeMonitorStoreListIssueActionsFunc
 	// ListMonitorsFunc is an instance of a mock function object controlling
 	// the behavior of the method ListMonitors.
 	ListMonitorsFunc *CodeMonitorStoreListMonitorsFunc
@@ -6340,6 +6358,10 @@ type MockCodeMonitorStore struct {
 	// object controlling the behavior of the method
 	// ResetQueryTriggerTimestamps.
 	ResetQueryTriggerTimestampsFunc *CodeMonitorStoreResetQueryTriggerTimestampsFunc
+	// SetIssueActionExternalIssueFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// SetIssueActionExternalIssue.
+	SetIssueActionExternalIssueFunc *CodeMonitorStoreSetIssueActionExternalIssueFunc
 	// SetQueryTriggerNextRunFunc is an instance of a mock function object
 	// controlling the behavior of the method SetQueryTriggerNextRun.
 	SetQueryTriggerNextRunFunc *CodeMonitorStoreSetQueryTriggerNextRunFunc
@@ -6349,6 +6371,9 @@ type MockCodeMonitorStore struct {
 	// UpdateEmailActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateEmailAction.
 	UpdateEmailActionFunc *CodeMonitorStoreUpdateEmailActionFunc
+	// UpdateIssueActionFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateIssueAction.
+	UpdateIssueActionFunc *CodeMonitorStoreUpdateIssueActionFunc
 	// UpdateMonitorFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateMonitor.
 	UpdateMonitorFunc *CodeMonitorStoreUpdateMonitorFunc
@@ -6361,6 +6386,10 @@ type MockCodeMonitorStore struct {
 	// UpdateSlackWebhookActionFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateSlackWebhookAction.
 	UpdateSlackWebhookActionFunc *CodeMonitorStoreUpdateSlackWebhookActionFunc
+	// UpdateTriggerJobWithResultDiffFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateTriggerJobWithResultDiff.
+	UpdateTriggerJobWithResultDiffFunc *CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc
 	// UpdateTriggerJobWithResultsFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// UpdateTriggerJobWithResults.
@@ -6371,6 +6400,9 @@ type MockCodeMonitorStore struct {
 	// UpsertLastSearchedFunc is an instance of a mock function object
 	// controlling the behavior of the method UpsertLastSearched.
 	UpsertLastSearchedFunc *CodeMonitorStoreUpsertLastSearchedFunc
+	// UpsertResultFingerprintsFunc is an instance of a mock function object
+	// controlling the behavior of the method UpsertResultFingerprints.
+	UpsertResultFingerprintsFunc *CodeMonitorStoreUpsertResultFingerprintsFunc
 }
 
 // NewMockCodeMonitorStore creates a new mock of the CodeMonitorStore
@@ -6388,6 +6420,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: func(context.Context, int64) (r0 int, r1 error) {
+				return
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, *int32) (r0 int32, r1 error) {
 				return
@@ -6418,13 +6455,18 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: func(context.Context, int64, *database.IssueActionArgs) (r0 *database.IssueAction, r1 error) {
+				return
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, database.MonitorArgs) (r0 *database.Monitor, r1 error) {
 				return
 			},
 		},
 		CreateQueryTriggerFunc: &CodeMonitorStoreCreateQueryTriggerFunc{
-			defaultHook: func(context.Context, int64, string) (r0 *database.QueryTrigger, r1 error) {
+			defaultHook: func(context.Context, int64, string, database.QueryTriggerMode) (r0 *database.QueryTrigger, r1 error) {
 				return
 			},
 		},
@@ -6448,6 +6490,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) (r0 error) {
+				return
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) (r0 error) {
 				return
@@ -6508,6 +6555,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: func(context.Context, int64) (r0 *database.IssueAction, r1 error) {
+				return
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) (r0 []string, r1 error) {
 				return
@@ -6528,6 +6580,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		GetResultFingerprintsFunc: &CodeMonitorStoreGetResultFingerprintsFunc{
+			defaultHook: func(context.Context, int64) (r0 []database.ResultFingerprint, r1 bool, r2 error) {
+				return
+			},
+		},
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (r0 *database.SlackWebhookAction, r1 error) {
 				return
@@ -6558,6 +6615,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: func(context.Context, database.ListActionsOpts) (r0 []*database.IssueAction, r1 error) {
+				return
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, database.ListMonitorsOpts) (r0 []*database.Monitor, r1 error) {
 				return
@@ -6593,6 +6655,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		SetIssueActionExternalIssueFunc: &CodeMonitorStoreSetIssueActionExternalIssueFunc{
+			defaultHook: func(context.Context, int64, int32, string) (r0 error) {
+				return
+			},
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: func(context.Context, int64, time.Time, time.Time) (r0 error) {
 				return
@@ -6608,6 +6675,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: func(context.Context, int64, *database.IssueActionArgs) (r0 *database.IssueAction, r1 error) {
+				return
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, database.MonitorArgs) (r0 *database.Monitor, r1 error) {
 				return
@@ -6619,7 +6691,7 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateQueryTriggerFunc: &CodeMonitorStoreUpdateQueryTriggerFunc{
-			defaultHook: func(context.Context, int64, string) (r0 error) {
+			defaultHook: func(context.Context, int64, string, database.QueryTriggerMode) (r0 error) {
 				return
 			},
 		},
@@ -6628,6 +6700,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpdateTriggerJobWithResultDiffFunc: &CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc{
+			defaultHook: func(context.Context, int32, string, *database.ResultDiff) (r0 error) {
+				return
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) (r0 error) {
 				return
@@ -6643,6 +6720,11 @@ func NewMockCodeMonitorStore() *MockCodeMonitorStore {
 				return
 			},
 		},
+		UpsertResultFingerprintsFunc: &CodeMonitorStoreUpsertResultFingerprintsFunc{
+			defaultHook: func(context.Context, int64, []database.ResultFingerprint) (r0 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -6660,6 +6742,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CountActionJobs")
 			},
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: func(context.Context, int64) (int, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CountIssueActions")
+			},
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: func(context.Context, *int32) (int32, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CountMonitors")
@@ -6690,13 +6777,18 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateEmailAction")
 			},
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.CreateIssueAction")
+			},
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: func(context.Context, database.MonitorArgs) (*database.Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateMonitor")
 			},
 		},
 		CreateQueryTriggerFunc: &CodeMonitorStoreCreateQueryTriggerFunc{
-			defaultHook: func(context.Context, int64, string) (*database.QueryTrigger, error) {
+			defaultHook: func(context.Context, int64, string, database.QueryTriggerMode) (*database.QueryTrigger, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.CreateQueryTrigger")
 			},
 		},
@@ -6720,6 +6812,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteEmailActions")
 			},
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: func(context.Context, int64, ...int64) error {
+				panic("unexpected invocation of MockCodeMonitorStore.DeleteIssueActions")
+			},
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: func(context.Context, int64) error {
 				panic("unexpected invocation of MockCodeMonitorStore.DeleteMonitor")
@@ -6780,6 +6877,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetEmailAction")
 			},
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: func(context.Context, int64) (*database.IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetIssueAction")
+			},
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: func(context.Context, int64, api.RepoID) ([]string, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetLastSearched")
@@ -6800,6 +6902,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.GetQueryTriggerForMonitor")
 			},
 		},
+		GetResultFingerprintsFunc: &CodeMonitorStoreGetResultFingerprintsFunc{
+			defaultHook: func(context.Context, int64) ([]database.ResultFingerprint, bool, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.GetResultFingerprints")
+			},
+		},
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: func(context.Context, int64) (*database.SlackWebhookAction, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.GetSlackWebhookAction")
@@ -6830,6 +6937,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ListEmailActions")
 			},
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: func(context.Context, database.ListActionsOpts) ([]*database.IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.ListIssueActions")
+			},
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: func(context.Context, database.ListMonitorsOpts) ([]*database.Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.ListMonitors")
@@ -6865,6 +6977,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.ResetQueryTriggerTimestamps")
 			},
 		},
+		SetIssueActionExternalIssueFunc: &CodeMonitorStoreSetIssueActionExternalIssueFunc{
+			defaultHook: func(context.Context, int64, int32, string) error {
+				panic("unexpected invocation of MockCodeMonitorStore.SetIssueActionExternalIssue")
+			},
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: func(context.Context, int64, time.Time, time.Time) error {
 				panic("unexpected invocation of MockCodeMonitorStore.SetQueryTriggerNextRun")
@@ -6880,6 +6997,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateEmailAction")
 			},
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error) {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateIssueAction")
+			},
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: func(context.Context, int64, database.MonitorArgs) (*database.Monitor, error) {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateMonitor")
@@ -6891,7 +7013,7 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 			},
 		},
 		UpdateQueryTriggerFunc: &CodeMonitorStoreUpdateQueryTriggerFunc{
-			defaultHook: func(context.Context, int64, string) error {
+			defaultHook: func(context.Context, int64, string, database.QueryTriggerMode) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateQueryTrigger")
 			},
 		},
@@ -6900,6 +7022,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateSlackWebhookAction")
 			},
 		},
+		UpdateTriggerJobWithResultDiffFunc: &CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc{
+			defaultHook: func(context.Context, int32, string, *database.ResultDiff) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResultDiff")
+			},
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: func(context.Context, int32, string, []*result.CommitMatch) error {
 				panic("unexpected invocation of MockCodeMonitorStore.UpdateTriggerJobWithResults")
@@ -6915,6 +7042,11 @@ func NewStrictMockCodeMonitorStore() *MockCodeMonitorStore {
 				panic("unexpected invocation of MockCodeMonitorStore.UpsertLastSearched")
 			},
 		},
+		UpsertResultFingerprintsFunc: &CodeMonitorStoreUpsertResultFingerprintsFunc{
+			defaultHook: func(context.Context, int64, []database.ResultFingerprint) error {
+				panic("unexpected invocation of MockCodeMonitorStore.UpsertResultFingerprints")
+			},
+		},
 	}
 }
 
@@ -6929,6 +7061,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		CountActionJobsFunc: &CodeMonitorStoreCountActionJobsFunc{
 			defaultHook: i.CountActionJobs,
 		},
+		CountIssueActionsFunc: &CodeMonitorStoreCountIssueActionsFunc{
+			defaultHook: i.CountIssueActions,
+		},
 		CountMonitorsFunc: &CodeMonitorStoreCountMonitorsFunc{
 			defaultHook: i.CountMonitors,
 		},
@@ -6947,6 +7082,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		CreateEmailActionFunc: &CodeMonitorStoreCreateEmailActionFunc{
 			defaultHook: i.CreateEmailAction,
 		},
+		CreateIssueActionFunc: &CodeMonitorStoreCreateIssueActionFunc{
+			defaultHook: i.CreateIssueAction,
+		},
 		CreateMonitorFunc: &CodeMonitorStoreCreateMonitorFunc{
 			defaultHook: i.CreateMonitor,
 		},
@@ -6965,6 +7103,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		DeleteEmailActionsFunc: &CodeMonitorStoreDeleteEmailActionsFunc{
 			defaultHook: i.DeleteEmailActions,
 		},
+		DeleteIssueActionsFunc: &CodeMonitorStoreDeleteIssueActionsFunc{
+			defaultHook: i.DeleteIssueActions,
+		},
 		DeleteMonitorFunc: &CodeMonitorStoreDeleteMonitorFunc{
 			defaultHook: i.DeleteMonitor,
 		},
@@ -7001,6 +7142,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		GetEmailActionFunc: &CodeMonitorStoreGetEmailActionFunc{
 			defaultHook: i.GetEmailAction,
 		},
+		GetIssueActionFunc: &CodeMonitorStoreGetIssueActionFunc{
+			defaultHook: i.GetIssueAction,
+		},
 		GetLastSearchedFunc: &CodeMonitorStoreGetLastSearchedFunc{
 			defaultHook: i.GetLastSearched,
 		},
@@ -7013,6 +7157,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		GetQueryTriggerForMonitorFunc: &CodeMonitorStoreGetQueryTriggerForMonitorFunc{
 			defaultHook: i.GetQueryTriggerForMonitor,
 		},
+		GetResultFingerprintsFunc: &CodeMonitorStoreGetResultFingerprintsFunc{
+			defaultHook: i.GetResultFingerprints,
+		},
 		GetSlackWebhookActionFunc: &CodeMonitorStoreGetSlackWebhookActionFunc{
 			defaultHook: i.GetSlackWebhookAction,
 		},
@@ -7031,6 +7178,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		ListEmailActionsFunc: &CodeMonitorStoreListEmailActionsFunc{
 			defaultHook: i.ListEmailActions,
 		},
+		ListIssueActionsFunc: &CodeMonitorStoreListIssueActionsFunc{
+			defaultHook: i.ListIssueActions,
+		},
 		ListMonitorsFunc: &CodeMonitorStoreListMonitorsFunc{
 			defaultHook: i.ListMonitors,
 		},
@@ -7052,6 +7202,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		ResetQueryTriggerTimestampsFunc: &CodeMonitorStoreResetQueryTriggerTimestampsFunc{
 			defaultHook: i.ResetQueryTriggerTimestamps,
 		},
+		SetIssueActionExternalIssueFunc: &CodeMonitorStoreSetIssueActionExternalIssueFunc{
+			defaultHook: i.SetIssueActionExternalIssue,
+		},
 		SetQueryTriggerNextRunFunc: &CodeMonitorStoreSetQueryTriggerNextRunFunc{
 			defaultHook: i.SetQueryTriggerNextRun,
 		},
@@ -7061,6 +7214,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		UpdateEmailActionFunc: &CodeMonitorStoreUpdateEmailActionFunc{
 			defaultHook: i.UpdateEmailAction,
 		},
+		UpdateIssueActionFunc: &CodeMonitorStoreUpdateIssueActionFunc{
+			defaultHook: i.UpdateIssueAction,
+		},
 		UpdateMonitorFunc: &CodeMonitorStoreUpdateMonitorFunc{
 			defaultHook: i.UpdateMonitor,
 		},
@@ -7073,6 +7229,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		UpdateSlackWebhookActionFunc: &CodeMonitorStoreUpdateSlackWebhookActionFunc{
 			defaultHook: i.UpdateSlackWebhookAction,
 		},
+		UpdateTriggerJobWithResultDiffFunc: &CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc{
+			defaultHook: i.UpdateTriggerJobWithResultDiff,
+		},
 		UpdateTriggerJobWithResultsFunc: &CodeMonitorStoreUpdateTriggerJobWithResultsFunc{
 			defaultHook: i.UpdateTriggerJobWithResults,
 		},
@@ -7082,6 +7241,9 @@ func NewMockCodeMonitorStoreFrom(i database.CodeMonitorStore) *MockCodeMonitorSt
 		UpsertLastSearchedFunc: &CodeMonitorStoreUpsertLastSearchedFunc{
 			defaultHook: i.UpsertLastSearched,
 		},
+		UpsertResultFingerprintsFunc: &CodeMonitorStoreUpsertResultFingerprintsFunc{
+			defaultHook: i.UpsertResultFingerprints,
+		},
 	}
 }
 
@@ -7295,6 +7457,117 @@ func (c CodeMonitorStoreCountActionJobsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCountIssueActionsFunc describes the behavior when the
+// CountIssueActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCountIssueActionsFunc struct {
+	defaultHook func(context.Context, int64) (int, error)
+	hooks       []func(context.Context, int64) (int, error)
+	history     []CodeMonitorStoreCountIssueActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// CountIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CountIssueActions(v0 context.Context, v1 int64) (int, error) {
+	r0, r1 := m.CountIssueActionsFunc.nextHook()(v0, v1)
+	m.CountIssueActionsFunc.appendCall(CodeMonitorStoreCountIssueActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CountIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCountIssueActionsFunc) SetDefaultHook(hook func(context.Context, int64) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CountIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCountIssueActionsFunc) PushHook(hook func(context.Context, int64) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCountIssueActionsFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCountIssueActionsFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, int64) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCountIssueActionsFunc) nextHook() func(context.Context, int64) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCountIssueActionsFunc) appendCall(r0 CodeMonitorStoreCountIssueActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCountIssueActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCountIssueActionsFunc) History() []CodeMonitorStoreCountIssueActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCountIssueActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCountIssueActionsFuncCall is an object that describes an
+// invocation of method CountIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCountIssueActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCountIssueActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCountIssueActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCountMonitorsFunc describes the behavior when the
 // CountMonitors method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -7965,6 +8238,120 @@ func (c CodeMonitorStoreCreateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreCreateIssueActionFunc describes the behavior when the
+// CreateIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreCreateIssueActionFunc struct {
+	defaultHook func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error)
+	hooks       []func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error)
+	history     []CodeMonitorStoreCreateIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) CreateIssueAction(v0 context.Context, v1 int64, v2 *database.IssueActionArgs) (*database.IssueAction, error) {
+	r0, r1 := m.CreateIssueActionFunc.nextHook()(v0, v1, v2)
+	m.CreateIssueActionFunc.appendCall(CodeMonitorStoreCreateIssueActionFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CreateIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreCreateIssueActionFunc) SetDefaultHook(hook func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateIssueAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreCreateIssueActionFunc) PushHook(hook func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreCreateIssueActionFunc) SetDefaultReturn(r0 *database.IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreCreateIssueActionFunc) PushReturn(r0 *database.IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreCreateIssueActionFunc) nextHook() func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreCreateIssueActionFunc) appendCall(r0 CodeMonitorStoreCreateIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreCreateIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreCreateIssueActionFunc) History() []CodeMonitorStoreCreateIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreCreateIssueActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreCreateIssueActionFuncCall is an object that describes an
+// invocation of method CreateIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreCreateIssueActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *database.IssueActionArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreCreateIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreCreateIssueActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreCreateMonitorFunc describes the behavior when the
 // CreateMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -8079,24 +8466,24 @@ func (c CodeMonitorStoreCreateMonitorFuncCall) Results() []interface{} {
 // CreateQueryTrigger method of the parent MockCodeMonitorStore instance is
 // invoked.
 type CodeMonitorStoreCreateQueryTriggerFunc struct {
-	defaultHook func(context.Context, int64, string) (*database.QueryTrigger, error)
-	hooks       []func(context.Context, int64, string) (*database.QueryTrigger, error)
+	defaultHook func(context.Context, int64, string, database.QueryTriggerMode) (*database.QueryTrigger, error)
+	hooks       []func(context.Context, int64, string, database.QueryTriggerMode) (*database.QueryTrigger, error)
 	history     []CodeMonitorStoreCreateQueryTriggerFuncCall
 	mutex       sync.Mutex
 }
 
 // CreateQueryTrigger delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) CreateQueryTrigger(v0 context.Context, v1 int64, v2 string) (*database.QueryTrigger, error) {
-	r0, r1 := m.CreateQueryTriggerFunc.nextHook()(v0, v1, v2)
-	m.CreateQueryTriggerFunc.appendCall(CodeMonitorStoreCreateQueryTriggerFuncCall{v0, v1, v2, r0, r1})
+func (m *MockCodeMonitorStore) CreateQueryTrigger(v0 context.Context, v1 int64, v2 string, v3 database.QueryTriggerMode) (*database.QueryTrigger, error) {
+	r0, r1 := m.CreateQueryTriggerFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateQueryTriggerFunc.appendCall(CodeMonitorStoreCreateQueryTriggerFuncCall{v0, v1, v2, v3, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the CreateQueryTrigger
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultHook(hook func(context.Context, int64, string) (*database.QueryTrigger, error)) {
+func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultHook(hook func(context.Context, int64, string, database.QueryTriggerMode) (*database.QueryTrigger, error)) {
 	f.defaultHook = hook
 }
 
@@ -8105,7 +8492,7 @@ func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultHook(hook func(contex
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreCreateQueryTriggerFunc) PushHook(hook func(context.Context, int64, string) (*database.QueryTrigger, error)) {
+func (f *CodeMonitorStoreCreateQueryTriggerFunc) PushHook(hook func(context.Context, int64, string, database.QueryTriggerMode) (*database.QueryTrigger, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -8114,19 +8501,19 @@ func (f *CodeMonitorStoreCreateQueryTriggerFunc) PushHook(hook func(context.Cont
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreCreateQueryTriggerFunc) SetDefaultReturn(r0 *database.QueryTrigger, r1 error) {
-	f.SetDefaultHook(func(context.Context, int64, string) (*database.QueryTrigger, error) {
+	f.SetDefaultHook(func(context.Context, int64, string, database.QueryTriggerMode) (*database.QueryTrigger, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreCreateQueryTriggerFunc) PushReturn(r0 *database.QueryTrigger, r1 error) {
-	f.PushHook(func(context.Context, int64, string) (*database.QueryTrigger, error) {
+	f.PushHook(func(context.Context, int64, string, database.QueryTriggerMode) (*database.QueryTrigger, error) {
 		return r0, r1
 	})
 }
 
-func (f *CodeMonitorStoreCreateQueryTriggerFunc) nextHook() func(context.Context, int64, string) (*database.QueryTrigger, error) {
+func (f *CodeMonitorStoreCreateQueryTriggerFunc) nextHook() func(context.Context, int64, string, database.QueryTriggerMode) (*database.QueryTrigger, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -8169,6 +8556,9 @@ type CodeMonitorStoreCreateQueryTriggerFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 database.QueryTriggerMode
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 *database.QueryTrigger
@@ -8180,7 +8570,7 @@ type CodeMonitorStoreCreateQueryTriggerFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreCreateQueryTriggerFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
@@ -8658,6 +9048,124 @@ func (c CodeMonitorStoreDeleteEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreDeleteIssueActionsFunc describes the behavior when the
+// DeleteIssueActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreDeleteIssueActionsFunc struct {
+	defaultHook func(context.Context, int64, ...int64) error
+	hooks       []func(context.Context, int64, ...int64) error
+	history     []CodeMonitorStoreDeleteIssueActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) DeleteIssueActions(v0 context.Context, v1 int64, v2 ...int64) error {
+	r0 := m.DeleteIssueActionsFunc.nextHook()(v0, v1, v2...)
+	m.DeleteIssueActionsFunc.appendCall(CodeMonitorStoreDeleteIssueActionsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) SetDefaultHook(hook func(context.Context, int64, ...int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) PushHook(hook func(context.Context, int64, ...int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, ...int64) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) nextHook() func(context.Context, int64, ...int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) appendCall(r0 CodeMonitorStoreDeleteIssueActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreDeleteIssueActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreDeleteIssueActionsFunc) History() []CodeMonitorStoreDeleteIssueActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreDeleteIssueActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreDeleteIssueActionsFuncCall is an object that describes an
+// invocation of method DeleteIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreDeleteIssueActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is a slice containing the values of the variadic arguments passed
+	// to this method invocation.
+	Arg2 []int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation. The variadic slice argument is flattened in this array such
+// that one positional argument and three variadic arguments would result in
+// a slice of four, not two.
+func (c CodeMonitorStoreDeleteIssueActionsFuncCall) Args() []interface{} {
+	trailing := []interface{}{}
+	for _, val := range c.Arg2 {
+		trailing = append(trailing, val)
+	}
+
+	return append([]interface{}{c.Arg0, c.Arg1}, trailing...)
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreDeleteIssueActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreDeleteMonitorFunc describes the behavior when the
 // DeleteMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -9982,6 +10490,116 @@ func (c CodeMonitorStoreGetEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreGetIssueActionFunc describes the behavior when the
+// GetIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreGetIssueActionFunc struct {
+	defaultHook func(context.Context, int64) (*database.IssueAction, error)
+	hooks       []func(context.Context, int64) (*database.IssueAction, error)
+	history     []CodeMonitorStoreGetIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// GetIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetIssueAction(v0 context.Context, v1 int64) (*database.IssueAction, error) {
+	r0, r1 := m.GetIssueActionFunc.nextHook()(v0, v1)
+	m.GetIssueActionFunc.appendCall(CodeMonitorStoreGetIssueActionFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreGetIssueActionFunc) SetDefaultHook(hook func(context.Context, int64) (*database.IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetIssueAction method of the parent MockCodeMonitorStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *CodeMonitorStoreGetIssueActionFunc) PushHook(hook func(context.Context, int64) (*database.IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetIssueActionFunc) SetDefaultReturn(r0 *database.IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*database.IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetIssueActionFunc) PushReturn(r0 *database.IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64) (*database.IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreGetIssueActionFunc) nextHook() func(context.Context, int64) (*database.IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetIssueActionFunc) appendCall(r0 CodeMonitorStoreGetIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreGetIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreGetIssueActionFunc) History() []CodeMonitorStoreGetIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetIssueActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetIssueActionFuncCall is an object that describes an
+// invocation of method GetIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetIssueActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetIssueActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreGetLastSearchedFunc describes the behavior when the
 // GetLastSearched method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -10428,6 +11046,121 @@ func (c CodeMonitorStoreGetQueryTriggerForMonitorFuncCall) Results() []interface
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreGetResultFingerprintsFunc describes the behavior when the
+// GetResultFingerprints method of the parent MockCodeMonitorStore instance
+// is invoked.
+type CodeMonitorStoreGetResultFingerprintsFunc struct {
+	defaultHook func(context.Context, int64) ([]database.ResultFingerprint, bool, error)
+	hooks       []func(context.Context, int64) ([]database.ResultFingerprint, bool, error)
+	history     []CodeMonitorStoreGetResultFingerprintsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetResultFingerprints delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) GetResultFingerprints(v0 context.Context, v1 int64) ([]database.ResultFingerprint, bool, error) {
+	r0, r1, r2 := m.GetResultFingerprintsFunc.nextHook()(v0, v1)
+	m.GetResultFingerprintsFunc.appendCall(CodeMonitorStoreGetResultFingerprintsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetResultFingerprints method of the parent MockCodeMonitorStore instance
+// is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) SetDefaultHook(hook func(context.Context, int64) ([]database.ResultFingerprint, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetResultFingerprints method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) PushHook(hook func(context.Context, int64) ([]database.ResultFingerprint, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) SetDefaultReturn(r0 []database.ResultFingerprint, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int64) ([]database.ResultFingerprint, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) PushReturn(r0 []database.ResultFingerprint, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int64) ([]database.ResultFingerprint, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) nextHook() func(context.Context, int64) ([]database.ResultFingerprint, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) appendCall(r0 CodeMonitorStoreGetResultFingerprintsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreGetResultFingerprintsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreGetResultFingerprintsFunc) History() []CodeMonitorStoreGetResultFingerprintsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreGetResultFingerprintsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreGetResultFingerprintsFuncCall is an object that describes
+// an invocation of method GetResultFingerprints on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreGetResultFingerprintsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []database.ResultFingerprint
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreGetResultFingerprintsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreGetResultFingerprintsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // CodeMonitorStoreGetSlackWebhookActionFunc describes the behavior when the
 // GetSlackWebhookAction method of the parent MockCodeMonitorStore instance
 // is invoked.
@@ -11082,6 +11815,117 @@ func (c CodeMonitorStoreListEmailActionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreListIssueActionsFunc describes the behavior when the
+// ListIssueActions method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreListIssueActionsFunc struct {
+	defaultHook func(context.Context, database.ListActionsOpts) ([]*database.IssueAction, error)
+	hooks       []func(context.Context, database.ListActionsOpts) ([]*database.IssueAction, error)
+	history     []CodeMonitorStoreListIssueActionsFuncCall
+	mutex       sync.Mutex
+}
+
+// ListIssueActions delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) ListIssueActions(v0 context.Context, v1 database.ListActionsOpts) ([]*database.IssueAction, error) {
+	r0, r1 := m.ListIssueActionsFunc.nextHook()(v0, v1)
+	m.ListIssueActionsFunc.appendCall(CodeMonitorStoreListIssueActionsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListIssueActions
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreListIssueActionsFunc) SetDefaultHook(hook func(context.Context, database.ListActionsOpts) ([]*database.IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListIssueActions method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreListIssueActionsFunc) PushHook(hook func(context.Context, database.ListActionsOpts) ([]*database.IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreListIssueActionsFunc) SetDefaultReturn(r0 []*database.IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.ListActionsOpts) ([]*database.IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreListIssueActionsFunc) PushReturn(r0 []*database.IssueAction, r1 error) {
+	f.PushHook(func(context.Context, database.ListActionsOpts) ([]*database.IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreListIssueActionsFunc) nextHook() func(context.Context, database.ListActionsOpts) ([]*database.IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreListIssueActionsFunc) appendCall(r0 CodeMonitorStoreListIssueActionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreListIssueActionsFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreListIssueActionsFunc) History() []CodeMonitorStoreListIssueActionsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreListIssueActionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreListIssueActionsFuncCall is an object that describes an
+// invocation of method ListIssueActions on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreListIssueActionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.ListActionsOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*database.IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreListIssueActionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreListIssueActionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreListMonitorsFunc describes the behavior when the
 // ListMonitors method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -11844,6 +12688,121 @@ func (c CodeMonitorStoreResetQueryTriggerTimestampsFuncCall) Results() []interfa
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreSetIssueActionExternalIssueFunc describes the behavior
+// when the SetIssueActionExternalIssue method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreSetIssueActionExternalIssueFunc struct {
+	defaultHook func(context.Context, int64, int32, string) error
+	hooks       []func(context.Context, int64, int32, string) error
+	history     []CodeMonitorStoreSetIssueActionExternalIssueFuncCall
+	mutex       sync.Mutex
+}
+
+// SetIssueActionExternalIssue delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) SetIssueActionExternalIssue(v0 context.Context, v1 int64, v2 int32, v3 string) error {
+	r0 := m.SetIssueActionExternalIssueFunc.nextHook()(v0, v1, v2, v3)
+	m.SetIssueActionExternalIssueFunc.appendCall(CodeMonitorStoreSetIssueActionExternalIssueFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SetIssueActionExternalIssue method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreSetIssueActionExternalIssueFunc) SetDefaultHook(hook func(context.Context, int64, int32, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetIssueActionExternalIssue method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreSetIssueActionExternalIssueFunc) PushHook(hook func(context.Context, int64, int32, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreSetIssueActionExternalIssueFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, int32, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreSetIssueActionExternalIssueFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, int32, string) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreSetIssueActionExternalIssueFunc) nextHook() func(context.Context, int64, int32, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreSetIssueActionExternalIssueFunc) appendCall(r0 CodeMonitorStoreSetIssueActionExternalIssueFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreSetIssueActionExternalIssueFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreSetIssueActionExternalIssueFunc) History() []CodeMonitorStoreSetIssueActionExternalIssueFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreSetIssueActionExternalIssueFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreSetIssueActionExternalIssueFuncCall is an object that
+// describes an invocation of method SetIssueActionExternalIssue on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreSetIssueActionExternalIssueFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int32
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreSetIssueActionExternalIssueFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreSetIssueActionExternalIssueFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreSetQueryTriggerNextRunFunc describes the behavior when
 // the SetQueryTriggerNextRun method of the parent MockCodeMonitorStore
 // instance is invoked.
@@ -12178,6 +13137,120 @@ func (c CodeMonitorStoreUpdateEmailActionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpdateIssueActionFunc describes the behavior when the
+// UpdateIssueAction method of the parent MockCodeMonitorStore instance is
+// invoked.
+type CodeMonitorStoreUpdateIssueActionFunc struct {
+	defaultHook func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error)
+	hooks       []func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error)
+	history     []CodeMonitorStoreUpdateIssueActionFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateIssueAction delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateIssueAction(v0 context.Context, v1 int64, v2 *database.IssueActionArgs) (*database.IssueAction, error) {
+	r0, r1 := m.UpdateIssueActionFunc.nextHook()(v0, v1, v2)
+	m.UpdateIssueActionFunc.appendCall(CodeMonitorStoreUpdateIssueActionFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the UpdateIssueAction
+// method of the parent MockCodeMonitorStore instance is invoked and the
+// hook queue is empty.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) SetDefaultHook(hook func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateIssueAction method of the parent MockCodeMonitorStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) PushHook(hook func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) SetDefaultReturn(r0 *database.IssueAction, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) PushReturn(r0 *database.IssueAction, r1 error) {
+	f.PushHook(func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error) {
+		return r0, r1
+	})
+}
+
+func (f *CodeMonitorStoreUpdateIssueActionFunc) nextHook() func(context.Context, int64, *database.IssueActionArgs) (*database.IssueAction, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateIssueActionFunc) appendCall(r0 CodeMonitorStoreUpdateIssueActionFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of CodeMonitorStoreUpdateIssueActionFuncCall
+// objects describing the invocations of this function.
+func (f *CodeMonitorStoreUpdateIssueActionFunc) History() []CodeMonitorStoreUpdateIssueActionFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateIssueActionFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateIssueActionFuncCall is an object that describes an
+// invocation of method UpdateIssueAction on an instance of
+// MockCodeMonitorStore.
+type CodeMonitorStoreUpdateIssueActionFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *database.IssueActionArgs
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *database.IssueAction
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateIssueActionFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateIssueActionFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // CodeMonitorStoreUpdateMonitorFunc describes the behavior when the
 // UpdateMonitor method of the parent MockCodeMonitorStore instance is
 // invoked.
@@ -12410,24 +13483,24 @@ func (c CodeMonitorStoreUpdateMonitorEnabledFuncCall) Results() []interface{} {
 // UpdateQueryTrigger method of the parent MockCodeMonitorStore instance is
 // invoked.
 type CodeMonitorStoreUpdateQueryTriggerFunc struct {
-	defaultHook func(context.Context, int64, string) error
-	hooks       []func(context.Context, int64, string) error
+	defaultHook func(context.Context, int64, string, database.QueryTriggerMode) error
+	hooks       []func(context.Context, int64, string, database.QueryTriggerMode) error
 	history     []CodeMonitorStoreUpdateQueryTriggerFuncCall
 	mutex       sync.Mutex
 }
 
 // UpdateQueryTrigger delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockCodeMonitorStore) UpdateQueryTrigger(v0 context.Context, v1 int64, v2 string) error {
-	r0 := m.UpdateQueryTriggerFunc.nextHook()(v0, v1, v2)
-	m.UpdateQueryTriggerFunc.appendCall(CodeMonitorStoreUpdateQueryTriggerFuncCall{v0, v1, v2, r0})
+func (m *MockCodeMonitorStore) UpdateQueryTrigger(v0 context.Context, v1 int64, v2 string, v3 database.QueryTriggerMode) error {
+	r0 := m.UpdateQueryTriggerFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateQueryTriggerFunc.appendCall(CodeMonitorStoreUpdateQueryTriggerFuncCall{v0, v1, v2, v3, r0})
 	return r0
 }
 
 // SetDefaultHook sets function that is called when the UpdateQueryTrigger
 // method of the parent MockCodeMonitorStore instance is invoked and the
 // hook queue is empty.
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultHook(hook func(context.Context, int64, string) error) {
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultHook(hook func(context.Context, int64, string, database.QueryTriggerMode) error) {
 	f.defaultHook = hook
 }
 
@@ -12436,7 +13509,7 @@ func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultHook(hook func(contex
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushHook(hook func(context.Context, int64, string) error) {
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushHook(hook func(context.Context, int64, string, database.QueryTriggerMode) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -12445,19 +13518,19 @@ func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushHook(hook func(context.Cont
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *CodeMonitorStoreUpdateQueryTriggerFunc) SetDefaultReturn(r0 error) {
-	f.SetDefaultHook(func(context.Context, int64, string) error {
+	f.SetDefaultHook(func(context.Context, int64, string, database.QueryTriggerMode) error {
 		return r0
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *CodeMonitorStoreUpdateQueryTriggerFunc) PushReturn(r0 error) {
-	f.PushHook(func(context.Context, int64, string) error {
+	f.PushHook(func(context.Context, int64, string, database.QueryTriggerMode) error {
 		return r0
 	})
 }
 
-func (f *CodeMonitorStoreUpdateQueryTriggerFunc) nextHook() func(context.Context, int64, string) error {
+func (f *CodeMonitorStoreUpdateQueryTriggerFunc) nextHook() func(context.Context, int64, string, database.QueryTriggerMode) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -12500,6 +13573,9 @@ type CodeMonitorStoreUpdateQueryTriggerFuncCall struct {
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
 	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 database.QueryTriggerMode
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 error
@@ -12508,7 +13584,7 @@ type CodeMonitorStoreUpdateQueryTriggerFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c CodeMonitorStoreUpdateQueryTriggerFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
@@ -12638,6 +13714,121 @@ func (c CodeMonitorStoreUpdateSlackWebhookActionFuncCall) Results() []interface{
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc describes the behavior
+// when the UpdateTriggerJobWithResultDiff method of the parent
+// MockCodeMonitorStore instance is invoked.
+type CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc struct {
+	defaultHook func(context.Context, int32, string, *database.ResultDiff) error
+	hooks       []func(context.Context, int32, string, *database.ResultDiff) error
+	history     []CodeMonitorStoreUpdateTriggerJobWithResultDiffFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateTriggerJobWithResultDiff delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpdateTriggerJobWithResultDiff(v0 context.Context, v1 int32, v2 string, v3 *database.ResultDiff) error {
+	r0 := m.UpdateTriggerJobWithResultDiffFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdateTriggerJobWithResultDiffFunc.appendCall(CodeMonitorStoreUpdateTriggerJobWithResultDiffFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateTriggerJobWithResultDiff method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc) SetDefaultHook(hook func(context.Context, int32, string, *database.ResultDiff) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateTriggerJobWithResultDiff method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc) PushHook(hook func(context.Context, int32, string, *database.ResultDiff) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int32, string, *database.ResultDiff) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int32, string, *database.ResultDiff) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc) nextHook() func(context.Context, int32, string, *database.ResultDiff) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc) appendCall(r0 CodeMonitorStoreUpdateTriggerJobWithResultDiffFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpdateTriggerJobWithResultDiffFuncCall objects describing
+// the invocations of this function.
+func (f *CodeMonitorStoreUpdateTriggerJobWithResultDiffFunc) History() []CodeMonitorStoreUpdateTriggerJobWithResultDiffFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpdateTriggerJobWithResultDiffFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpdateTriggerJobWithResultDiffFuncCall is an object that
+// describes an invocation of method UpdateTriggerJobWithResultDiff on an
+// instance of MockCodeMonitorStore.
+type CodeMonitorStoreUpdateTriggerJobWithResultDiffFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int32
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 *database.ResultDiff
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpdateTriggerJobWithResultDiffFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpdateTriggerJobWithResultDiffFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // CodeMonitorStoreUpdateTriggerJobWithResultsFunc describes the behavior
 // when the UpdateTriggerJobWithResults method of the parent
 // MockCodeMonitorStore instance is invoked.
@@ -12987,6 +14178,118 @@ func (c CodeMonitorStoreUpsertLastSearchedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// CodeMonitorStoreUpsertResultFingerprintsFunc describes the behavior when
+// the UpsertResultFingerprints method of the parent MockCodeMonitorStore
+// instance is invoked.
+type CodeMonitorStoreUpsertResultFingerprintsFunc struct {
+	defaultHook func(context.Context, int64, []database.ResultFingerprint) error
+	hooks       []func(context.Context, int64, []database.ResultFingerprint) error
+	history     []CodeMonitorStoreUpsertResultFingerprintsFuncCall
+	mutex       sync.Mutex
+}
+
+// UpsertResultFingerprints delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockCodeMonitorStore) UpsertResultFingerprints(v0 context.Context, v1 int64, v2 []database.ResultFingerprint) error {
+	r0 := m.UpsertResultFingerprintsFunc.nextHook()(v0, v1, v2)
+	m.UpsertResultFingerprintsFunc.appendCall(CodeMonitorStoreUpsertResultFingerprintsFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpsertResultFingerprints method of the parent MockCodeMonitorStore
+// instance is invoked and the hook queue is empty.
+func (f *CodeMonitorStoreUpsertResultFingerprintsFunc) SetDefaultHook(hook func(context.Context, int64, []database.ResultFingerprint) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpsertResultFingerprints method of the parent MockCodeMonitorStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *CodeMonitorStoreUpsertResultFingerprintsFunc) PushHook(hook func(context.Context, int64, []database.ResultFingerprint) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *CodeMonitorStoreUpsertResultFingerprintsFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int64, []database.ResultFingerprint) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *CodeMonitorStoreUpsertResultFingerprintsFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int64, []database.ResultFingerprint) error {
+		return r0
+	})
+}
+
+func (f *CodeMonitorStoreUpsertResultFingerprintsFunc) nextHook() func(context.Context, int64, []database.ResultFingerprint) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *CodeMonitorStoreUpsertResultFingerprintsFunc) appendCall(r0 CodeMonitorStoreUpsertResultFingerprintsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// CodeMonitorStoreUpsertResultFingerprintsFuncCall objects describing the
+// invocations of this function.
+func (f *CodeMonitorStoreUpsertResultFingerprintsFunc) History() []CodeMonitorStoreUpsertResultFingerprintsFuncCall {
+	f.mutex.Lock()
+	history := make([]CodeMonitorStoreUpsertResultFingerprintsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// CodeMonitorStoreUpsertResultFingerprintsFuncCall is an object that
+// describes an invocation of method UpsertResultFingerprints on an instance
+// of MockCodeMonitorStore.
+type CodeMonitorStoreUpsertResultFingerprintsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []database.ResultFingerprint
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c CodeMonitorStoreUpsertResultFingerprintsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c CodeMonitorStoreUpsertResultFingerprintsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockCodeownersStore is a mock implementation of the CodeownersStore
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/database) used for unit
@@ -15040,6 +16343,9 @@ type MockDB struct {
 	// RolePermissionsFunc is an instance of a mock function object
 	// controlling the behavior of the method RolePermissions.
 	RolePermissionsFunc *DBRolePermissionsFunc
+	// RoleScopesFunc is an instance of a mock function object
+	// controlling the behavior of the method RoleScopes.
+	RoleScopesFunc *DBRoleScopesFunc
 	// RolesFunc is an instance of a mock function object controlling the
 	// behavior of the method Roles.
 	RolesFunc *DBRolesFunc
@@ -15341,6 +16647,11 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		RoleScopesFunc: &DBRoleScopesFunc{
+			defaultHook: func() (r0 database.RoleScopeStore) {
+				return
+			},
+		},
 		RolesFunc: &DBRolesFunc{
 			defaultHook: func() (r0 database.RoleStore) {
 				return
@@ -15678,6 +16989,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.RolePermissions")
 			},
 		},
+		RoleScopesFunc: &DBRoleScopesFunc{
+			defaultHook: func() database.RoleScopeStore {
+				panic("unexpected invocation of MockDB.RoleScopes")
+			},
+		},
 		RolesFunc: &DBRolesFunc{
 			defaultHook: func() database.RoleStore {
 				panic("unexpected invocation of MockDB.Roles")
@@ -15919,6 +17235,9 @@ func NewMockDBFrom(i database.DB) *MockDB {
 		RolePermissionsFunc: &DBRolePermissionsFunc{
 			defaultHook: i.RolePermissions,
 		},
+		RoleScopesFunc: &DBRoleScopesFunc{
+			defaultHook: i.RoleScopes,
+		},
 		RolesFunc: &DBRolesFunc{
 			defaultHook: i.Roles,
 		},
@@ -20772,6 +22091,105 @@ func (c DBRolePermissionsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBRoleScopesFunc describes the behavior when the RoleScopes
+// method of the parent MockDB instance is invoked.
+type DBRoleScopesFunc struct {
+	defaultHook func() database.RoleScopeStore
+	hooks       []func() database.RoleScopeStore
+	history     []DBRoleScopesFuncCall
+	mutex       sync.Mutex
+}
+
+// RoleScopes delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDB) RoleScopes() database.RoleScopeStore {
+	r0 := m.RoleScopesFunc.nextHook()()
+	m.RoleScopesFunc.appendCall(DBRoleScopesFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the RoleScopes
+// method of the parent MockDB instance is invoked and the hook queue is
+// empty.
+func (f *DBRoleScopesFunc) SetDefaultHook(hook func() database.RoleScopeStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RoleScopes method of the parent MockDB instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBRoleScopesFunc) PushHook(hook func() database.RoleScopeStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBRoleScopesFunc) SetDefaultReturn(r0 database.RoleScopeStore) {
+	f.SetDefaultHook(func() database.RoleScopeStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBRoleScopesFunc) PushReturn(r0 database.RoleScopeStore) {
+	f.PushHook(func() database.RoleScopeStore {
+		return r0
+	})
+}
+
+func (f *DBRoleScopesFunc) nextHook() func() database.RoleScopeStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBRoleScopesFunc) appendCall(r0 DBRoleScopesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBRoleScopesFuncCall objects
+// describing the invocations of this function.
+func (f *DBRoleScopesFunc) History() []DBRoleScopesFuncCall {
+	f.mutex.Lock()
+	history := make([]DBRoleScopesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBRoleScopesFuncCall is an object that describes an invocation of
+// method RoleScopes on an instance of MockDB.
+type DBRoleScopesFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RoleScopeStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBRoleScopesFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBRoleScopesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBRolesFunc describes the behavior when the Roles method of the parent
 // MockDB instance is invoked.
 type DBRolesFunc struct {
@@ -45732,6 +47150,12 @@ type MockOutboundWebhookJobStore struct {
 	// QueryFunc is an instance of a mock function object controlling the
 	// behavior of the method Query.
 	QueryFunc *OutboundWebhookJobStoreQueryFunc
+	// RedeliverFunc is an instance of a mock function object controlling the
+	// behavior of the method Redeliver.
+	RedeliverFunc *OutboundWebhookJobStoreRedeliverFunc
+	// ReplayFunc is an instance of a mock function object controlling the
+	// behavior of the method Replay.
+	ReplayFunc *OutboundWebhookJobStoreReplayFunc
 	// WithFunc is an instance of a mock function object controlling the
 	// behavior of the method With.
 	WithFunc *OutboundWebhookJobStoreWithFunc
@@ -45780,6 +47204,16 @@ func NewMockOutboundWebhookJobStore() *MockOutboundWebhookJobStore {
 				return
 			},
 		},
+		RedeliverFunc: &OutboundWebhookJobStoreRedeliverFunc{
+			defaultHook: func(context.Context, int64, int64) (r0 *types.OutboundWebhookJob, r1 error) {
+				return
+			},
+		},
+		ReplayFunc: &OutboundWebhookJobStoreReplayFunc{
+			defaultHook: func(context.Context, database.OutboundWebhookJobReplayOpts) (r0 int, r1 error) {
+				return
+			},
+		},
 		WithFunc: &OutboundWebhookJobStoreWithFunc{
 			defaultHook: func(basestore.ShareableStore) (r0 database.OutboundWebhookJobStore) {
 				return
@@ -45833,6 +47267,16 @@ func NewStrictMockOutboundWebhookJobStore() *MockOutboundWebhookJobStore {
 				panic("unexpected invocation of MockOutboundWebhookJobStore.Query")
 			},
 		},
+		RedeliverFunc: &OutboundWebhookJobStoreRedeliverFunc{
+			defaultHook: func(context.Context, int64, int64) (*types.OutboundWebhookJob, error) {
+				panic("unexpected invocation of MockOutboundWebhookJobStore.Redeliver")
+			},
+		},
+		ReplayFunc: &OutboundWebhookJobStoreReplayFunc{
+			defaultHook: func(context.Context, database.OutboundWebhookJobReplayOpts) (int, error) {
+				panic("unexpected invocation of MockOutboundWebhookJobStore.Replay")
+			},
+		},
 		WithFunc: &OutboundWebhookJobStoreWithFunc{
 			defaultHook: func(basestore.ShareableStore) database.OutboundWebhookJobStore {
 				panic("unexpected invocation of MockOutboundWebhookJobStore.With")
@@ -45872,6 +47316,12 @@ func NewMockOutboundWebhookJobStoreFrom(i database.OutboundWebhookJobStore) *Moc
 		QueryFunc: &OutboundWebhookJobStoreQueryFunc{
 			defaultHook: i.Query,
 		},
+		RedeliverFunc: &OutboundWebhookJobStoreRedeliverFunc{
+			defaultHook: i.Redeliver,
+		},
+		ReplayFunc: &OutboundWebhookJobStoreReplayFunc{
+			defaultHook: i.Replay,
+		},
 		WithFunc: &OutboundWebhookJobStoreWithFunc{
 			defaultHook: i.With,
 		},
@@ -46631,6 +48081,229 @@ func (c OutboundWebhookJobStoreQueryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// OutboundWebhookJobStoreRedeliverFunc describes the behavior when the
+// Redeliver method of the parent MockOutboundWebhookJobStore instance is
+// invoked.
+type OutboundWebhookJobStoreRedeliverFunc struct {
+	defaultHook func(context.Context, int64, int64) (*types.OutboundWebhookJob, error)
+	hooks       []func(context.Context, int64, int64) (*types.OutboundWebhookJob, error)
+	history     []OutboundWebhookJobStoreRedeliverFuncCall
+	mutex       sync.Mutex
+}
+
+// Redeliver delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOutboundWebhookJobStore) Redeliver(v0 context.Context, v1 int64, v2 int64) (*types.OutboundWebhookJob, error) {
+	r0, r1 := m.RedeliverFunc.nextHook()(v0, v1, v2)
+	m.RedeliverFunc.appendCall(OutboundWebhookJobStoreRedeliverFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Redeliver method of
+// the parent MockOutboundWebhookJobStore instance is invoked and the hook
+// queue is empty.
+func (f *OutboundWebhookJobStoreRedeliverFunc) SetDefaultHook(hook func(context.Context, int64, int64) (*types.OutboundWebhookJob, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Redeliver method of the parent MockOutboundWebhookJobStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *OutboundWebhookJobStoreRedeliverFunc) PushHook(hook func(context.Context, int64, int64) (*types.OutboundWebhookJob, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OutboundWebhookJobStoreRedeliverFunc) SetDefaultReturn(r0 *types.OutboundWebhookJob, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64, int64) (*types.OutboundWebhookJob, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OutboundWebhookJobStoreRedeliverFunc) PushReturn(r0 *types.OutboundWebhookJob, r1 error) {
+	f.PushHook(func(context.Context, int64, int64) (*types.OutboundWebhookJob, error) {
+		return r0, r1
+	})
+}
+
+func (f *OutboundWebhookJobStoreRedeliverFunc) nextHook() func(context.Context, int64, int64) (*types.OutboundWebhookJob, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OutboundWebhookJobStoreRedeliverFunc) appendCall(r0 OutboundWebhookJobStoreRedeliverFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OutboundWebhookJobStoreRedeliverFuncCall
+// objects describing the invocations of this function.
+func (f *OutboundWebhookJobStoreRedeliverFunc) History() []OutboundWebhookJobStoreRedeliverFuncCall {
+	f.mutex.Lock()
+	history := make([]OutboundWebhookJobStoreRedeliverFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OutboundWebhookJobStoreRedeliverFuncCall is an object that describes an
+// invocation of method Redeliver on an instance of
+// MockOutboundWebhookJobStore.
+type OutboundWebhookJobStoreRedeliverFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.OutboundWebhookJob
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OutboundWebhookJobStoreRedeliverFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OutboundWebhookJobStoreRedeliverFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// OutboundWebhookJobStoreReplayFunc describes the behavior when the Replay
+// method of the parent MockOutboundWebhookJobStore instance is invoked.
+type OutboundWebhookJobStoreReplayFunc struct {
+	defaultHook func(context.Context, database.OutboundWebhookJobReplayOpts) (int, error)
+	hooks       []func(context.Context, database.OutboundWebhookJobReplayOpts) (int, error)
+	history     []OutboundWebhookJobStoreReplayFuncCall
+	mutex       sync.Mutex
+}
+
+// Replay delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOutboundWebhookJobStore) Replay(v0 context.Context, v1 database.OutboundWebhookJobReplayOpts) (int, error) {
+	r0, r1 := m.ReplayFunc.nextHook()(v0, v1)
+	m.ReplayFunc.appendCall(OutboundWebhookJobStoreReplayFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Replay method of the
+// parent MockOutboundWebhookJobStore instance is invoked and the hook queue
+// is empty.
+func (f *OutboundWebhookJobStoreReplayFunc) SetDefaultHook(hook func(context.Context, database.OutboundWebhookJobReplayOpts) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Replay method of the parent MockOutboundWebhookJobStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *OutboundWebhookJobStoreReplayFunc) PushHook(hook func(context.Context, database.OutboundWebhookJobReplayOpts) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OutboundWebhookJobStoreReplayFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.OutboundWebhookJobReplayOpts) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OutboundWebhookJobStoreReplayFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, database.OutboundWebhookJobReplayOpts) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *OutboundWebhookJobStoreReplayFunc) nextHook() func(context.Context, database.OutboundWebhookJobReplayOpts) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OutboundWebhookJobStoreReplayFunc) appendCall(r0 OutboundWebhookJobStoreReplayFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OutboundWebhookJobStoreReplayFuncCall
+// objects describing the invocations of this function.
+func (f *OutboundWebhookJobStoreReplayFunc) History() []OutboundWebhookJobStoreReplayFuncCall {
+	f.mutex.Lock()
+	history := make([]OutboundWebhookJobStoreReplayFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OutboundWebhookJobStoreReplayFuncCall is an object that describes an
+// invocation of method Replay on an instance of
+// MockOutboundWebhookJobStore.
+type OutboundWebhookJobStoreReplayFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.OutboundWebhookJobReplayOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OutboundWebhookJobStoreReplayFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OutboundWebhookJobStoreReplayFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // OutboundWebhookJobStoreWithFunc describes the behavior when the With
 // method of the parent MockOutboundWebhookJobStore instance is invoked.
 type OutboundWebhookJobStoreWithFunc struct {
@@ -46855,6 +48528,9 @@ type MockOutboundWebhookLogStore struct {
 	// DoneFunc is an instance of a mock function object controlling the
 	// behavior of the method Done.
 	DoneFunc *OutboundWebhookLogStoreDoneFunc
+	// GetByIDFunc is an instance of a mock function object controlling the
+	// behavior of the method GetByID.
+	GetByIDFunc *OutboundWebhookLogStoreGetByIDFunc
 	// HandleFunc is an instance of a mock function object controlling the
 	// behavior of the method Handle.
 	HandleFunc *OutboundWebhookLogStoreHandleFunc
@@ -46892,6 +48568,11 @@ func NewMockOutboundWebhookLogStore() *MockOutboundWebhookLogStore {
 				return
 			},
 		},
+		GetByIDFunc: &OutboundWebhookLogStoreGetByIDFunc{
+			defaultHook: func(context.Context, int64) (r0 *types.OutboundWebhookLog, r1 error) {
+				return
+			},
+		},
 		HandleFunc: &OutboundWebhookLogStoreHandleFunc{
 			defaultHook: func() (r0 basestore.TransactableHandle) {
 				return
@@ -46940,6 +48621,11 @@ func NewStrictMockOutboundWebhookLogStore() *MockOutboundWebhookLogStore {
 				panic("unexpected invocation of MockOutboundWebhookLogStore.Done")
 			},
 		},
+		GetByIDFunc: &OutboundWebhookLogStoreGetByIDFunc{
+			defaultHook: func(context.Context, int64) (*types.OutboundWebhookLog, error) {
+				panic("unexpected invocation of MockOutboundWebhookLogStore.GetByID")
+			},
+		},
 		HandleFunc: &OutboundWebhookLogStoreHandleFunc{
 			defaultHook: func() basestore.TransactableHandle {
 				panic("unexpected invocation of MockOutboundWebhookLogStore.Handle")
@@ -46982,6 +48668,9 @@ func NewMockOutboundWebhookLogStoreFrom(i database.OutboundWebhookLogStore) *Moc
 		DoneFunc: &OutboundWebhookLogStoreDoneFunc{
 			defaultHook: i.Done,
 		},
+		GetByIDFunc: &OutboundWebhookLogStoreGetByIDFunc{
+			defaultHook: i.GetByID,
+		},
 		HandleFunc: &OutboundWebhookLogStoreHandleFunc{
 			defaultHook: i.Handle,
 		},
@@ -47323,6 +49012,116 @@ func (c OutboundWebhookLogStoreDoneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// OutboundWebhookLogStoreGetByIDFunc describes the behavior when the
+// GetByID method of the parent MockOutboundWebhookLogStore instance is
+// invoked.
+type OutboundWebhookLogStoreGetByIDFunc struct {
+	defaultHook func(context.Context, int64) (*types.OutboundWebhookLog, error)
+	hooks       []func(context.Context, int64) (*types.OutboundWebhookLog, error)
+	history     []OutboundWebhookLogStoreGetByIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByID delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockOutboundWebhookLogStore) GetByID(v0 context.Context, v1 int64) (*types.OutboundWebhookLog, error) {
+	r0, r1 := m.GetByIDFunc.nextHook()(v0, v1)
+	m.GetByIDFunc.appendCall(OutboundWebhookLogStoreGetByIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByID method of
+// the parent MockOutboundWebhookLogStore instance is invoked and the hook
+// queue is empty.
+func (f *OutboundWebhookLogStoreGetByIDFunc) SetDefaultHook(hook func(context.Context, int64) (*types.OutboundWebhookLog, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByID method of the parent MockOutboundWebhookLogStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *OutboundWebhookLogStoreGetByIDFunc) PushHook(hook func(context.Context, int64) (*types.OutboundWebhookLog, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *OutboundWebhookLogStoreGetByIDFunc) SetDefaultReturn(r0 *types.OutboundWebhookLog, r1 error) {
+	f.SetDefaultHook(func(context.Context, int64) (*types.OutboundWebhookLog, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *OutboundWebhookLogStoreGetByIDFunc) PushReturn(r0 *types.OutboundWebhookLog, r1 error) {
+	f.PushHook(func(context.Context, int64) (*types.OutboundWebhookLog, error) {
+		return r0, r1
+	})
+}
+
+func (f *OutboundWebhookLogStoreGetByIDFunc) nextHook() func(context.Context, int64) (*types.OutboundWebhookLog, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *OutboundWebhookLogStoreGetByIDFunc) appendCall(r0 OutboundWebhookLogStoreGetByIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of OutboundWebhookLogStoreGetByIDFuncCall
+// objects describing the invocations of this function.
+func (f *OutboundWebhookLogStoreGetByIDFunc) History() []OutboundWebhookLogStoreGetByIDFuncCall {
+	f.mutex.Lock()
+	history := make([]OutboundWebhookLogStoreGetByIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// OutboundWebhookLogStoreGetByIDFuncCall is an object that describes an
+// invocation of method GetByID on an instance of
+// MockOutboundWebhookLogStore.
+type OutboundWebhookLogStoreGetByIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *types.OutboundWebhookLog
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c OutboundWebhookLogStoreGetByIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c OutboundWebhookLogStoreGetByIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // OutboundWebhookLogStoreHandleFunc describes the behavior when the Handle
 // method of the parent MockOutboundWebhookLogStore instance is invoked.
 type OutboundWebhookLogStoreHandleFunc struct {
@@ -64572,6 +66371,645 @@ func (c RolePermissionStoreWithTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockRoleScopeStore is a mock implementation of the
+// RoleScopeStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockRoleScopeStore struct {
+	// GetByRoleIDFunc is an instance of a mock function object controlling
+	// the behavior of the method GetByRoleID.
+	GetByRoleIDFunc *RoleScopeStoreGetByRoleIDFunc
+	// HandleFunc is an instance of a mock function object controlling the
+	// behavior of the method Handle.
+	HandleFunc *RoleScopeStoreHandleFunc
+	// SetScopesForRoleFunc is an instance of a mock function object
+	// controlling the behavior of the method SetScopesForRole.
+	SetScopesForRoleFunc *RoleScopeStoreSetScopesForRoleFunc
+	// WithFunc is an instance of a mock function object controlling the
+	// behavior of the method With.
+	WithFunc *RoleScopeStoreWithFunc
+	// WithTransactFunc is an instance of a mock function object controlling
+	// the behavior of the method WithTransact.
+	WithTransactFunc *RoleScopeStoreWithTransactFunc
+}
+
+// NewMockRoleScopeStore creates a new mock of the RoleScopeStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockRoleScopeStore() *MockRoleScopeStore {
+	return &MockRoleScopeStore{
+		GetByRoleIDFunc: &RoleScopeStoreGetByRoleIDFunc{
+			defaultHook: func(context.Context, database.GetRoleScopesOpts) (r0 []*types.RoleScope, r1 error) {
+				return
+			},
+		},
+		HandleFunc: &RoleScopeStoreHandleFunc{
+			defaultHook: func() (r0 basestore.TransactableHandle) {
+				return
+			},
+		},
+		SetScopesForRoleFunc: &RoleScopeStoreSetScopesForRoleFunc{
+			defaultHook: func(context.Context, database.SetScopesForRoleOpts) (r0 error) {
+				return
+			},
+		},
+		WithFunc: &RoleScopeStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) (r0 database.RoleScopeStore) {
+				return
+			},
+		},
+		WithTransactFunc: &RoleScopeStoreWithTransactFunc{
+			defaultHook: func(context.Context, func(database.RoleScopeStore) error) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockRoleScopeStore creates a new mock of the
+// RoleScopeStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockRoleScopeStore() *MockRoleScopeStore {
+	return &MockRoleScopeStore{
+		GetByRoleIDFunc: &RoleScopeStoreGetByRoleIDFunc{
+			defaultHook: func(context.Context, database.GetRoleScopesOpts) ([]*types.RoleScope, error) {
+				panic("unexpected invocation of MockRoleScopeStore.GetByRoleID")
+			},
+		},
+		HandleFunc: &RoleScopeStoreHandleFunc{
+			defaultHook: func() basestore.TransactableHandle {
+				panic("unexpected invocation of MockRoleScopeStore.Handle")
+			},
+		},
+		SetScopesForRoleFunc: &RoleScopeStoreSetScopesForRoleFunc{
+			defaultHook: func(context.Context, database.SetScopesForRoleOpts) error {
+				panic("unexpected invocation of MockRoleScopeStore.SetScopesForRole")
+			},
+		},
+		WithFunc: &RoleScopeStoreWithFunc{
+			defaultHook: func(basestore.ShareableStore) database.RoleScopeStore {
+				panic("unexpected invocation of MockRoleScopeStore.With")
+			},
+		},
+		WithTransactFunc: &RoleScopeStoreWithTransactFunc{
+			defaultHook: func(context.Context, func(database.RoleScopeStore) error) error {
+				panic("unexpected invocation of MockRoleScopeStore.WithTransact")
+			},
+		},
+	}
+}
+
+// NewMockRoleScopeStoreFrom creates a new mock of the
+// MockRoleScopeStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockRoleScopeStoreFrom(i database.RoleScopeStore) *MockRoleScopeStore {
+	return &MockRoleScopeStore{
+		GetByRoleIDFunc: &RoleScopeStoreGetByRoleIDFunc{
+			defaultHook: i.GetByRoleID,
+		},
+		HandleFunc: &RoleScopeStoreHandleFunc{
+			defaultHook: i.Handle,
+		},
+		SetScopesForRoleFunc: &RoleScopeStoreSetScopesForRoleFunc{
+			defaultHook: i.SetScopesForRole,
+		},
+		WithFunc: &RoleScopeStoreWithFunc{
+			defaultHook: i.With,
+		},
+		WithTransactFunc: &RoleScopeStoreWithTransactFunc{
+			defaultHook: i.WithTransact,
+		},
+	}
+}
+
+// RoleScopeStoreGetByRoleIDFunc describes the behavior when the
+// GetByRoleID method of the parent MockRoleScopeStore instance is
+// invoked.
+type RoleScopeStoreGetByRoleIDFunc struct {
+	defaultHook func(context.Context, database.GetRoleScopesOpts) ([]*types.RoleScope, error)
+	hooks       []func(context.Context, database.GetRoleScopesOpts) ([]*types.RoleScope, error)
+	history     []RoleScopeStoreGetByRoleIDFuncCall
+	mutex       sync.Mutex
+}
+
+// GetByRoleID delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockRoleScopeStore) GetByRoleID(v0 context.Context, v1 database.GetRoleScopesOpts) ([]*types.RoleScope, error) {
+	r0, r1 := m.GetByRoleIDFunc.nextHook()(v0, v1)
+	m.GetByRoleIDFunc.appendCall(RoleScopeStoreGetByRoleIDFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetByRoleID method
+// of the parent MockRoleScopeStore instance is invoked and the hook
+// queue is empty.
+func (f *RoleScopeStoreGetByRoleIDFunc) SetDefaultHook(hook func(context.Context, database.GetRoleScopesOpts) ([]*types.RoleScope, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetByRoleID method of the parent MockRoleScopeStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RoleScopeStoreGetByRoleIDFunc) PushHook(hook func(context.Context, database.GetRoleScopesOpts) ([]*types.RoleScope, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RoleScopeStoreGetByRoleIDFunc) SetDefaultReturn(r0 []*types.RoleScope, r1 error) {
+	f.SetDefaultHook(func(context.Context, database.GetRoleScopesOpts) ([]*types.RoleScope, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RoleScopeStoreGetByRoleIDFunc) PushReturn(r0 []*types.RoleScope, r1 error) {
+	f.PushHook(func(context.Context, database.GetRoleScopesOpts) ([]*types.RoleScope, error) {
+		return r0, r1
+	})
+}
+
+func (f *RoleScopeStoreGetByRoleIDFunc) nextHook() func(context.Context, database.GetRoleScopesOpts) ([]*types.RoleScope, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RoleScopeStoreGetByRoleIDFunc) appendCall(r0 RoleScopeStoreGetByRoleIDFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RoleScopeStoreGetByRoleIDFuncCall
+// objects describing the invocations of this function.
+func (f *RoleScopeStoreGetByRoleIDFunc) History() []RoleScopeStoreGetByRoleIDFuncCall {
+	f.mutex.Lock()
+	history := make([]RoleScopeStoreGetByRoleIDFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RoleScopeStoreGetByRoleIDFuncCall is an object that describes an
+// invocation of method GetByRoleID on an instance of
+// MockRoleScopeStore.
+type RoleScopeStoreGetByRoleIDFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.GetRoleScopesOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*types.RoleScope
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RoleScopeStoreGetByRoleIDFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RoleScopeStoreGetByRoleIDFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// RoleScopeStoreHandleFunc describes the behavior when the Handle
+// method of the parent MockRoleScopeStore instance is invoked.
+type RoleScopeStoreHandleFunc struct {
+	defaultHook func() basestore.TransactableHandle
+	hooks       []func() basestore.TransactableHandle
+	history     []RoleScopeStoreHandleFuncCall
+	mutex       sync.Mutex
+}
+
+// Handle delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRoleScopeStore) Handle() basestore.TransactableHandle {
+	r0 := m.HandleFunc.nextHook()()
+	m.HandleFunc.appendCall(RoleScopeStoreHandleFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Handle method of the
+// parent MockRoleScopeStore instance is invoked and the hook queue is
+// empty.
+func (f *RoleScopeStoreHandleFunc) SetDefaultHook(hook func() basestore.TransactableHandle) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Handle method of the parent MockRoleScopeStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RoleScopeStoreHandleFunc) PushHook(hook func() basestore.TransactableHandle) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RoleScopeStoreHandleFunc) SetDefaultReturn(r0 basestore.TransactableHandle) {
+	f.SetDefaultHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RoleScopeStoreHandleFunc) PushReturn(r0 basestore.TransactableHandle) {
+	f.PushHook(func() basestore.TransactableHandle {
+		return r0
+	})
+}
+
+func (f *RoleScopeStoreHandleFunc) nextHook() func() basestore.TransactableHandle {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RoleScopeStoreHandleFunc) appendCall(r0 RoleScopeStoreHandleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RoleScopeStoreHandleFuncCall objects
+// describing the invocations of this function.
+func (f *RoleScopeStoreHandleFunc) History() []RoleScopeStoreHandleFuncCall {
+	f.mutex.Lock()
+	history := make([]RoleScopeStoreHandleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RoleScopeStoreHandleFuncCall is an object that describes an
+// invocation of method Handle on an instance of MockRoleScopeStore.
+type RoleScopeStoreHandleFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 basestore.TransactableHandle
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RoleScopeStoreHandleFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RoleScopeStoreHandleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RoleScopeStoreSetScopesForRoleFunc describes the behavior when
+// the SetScopesForRole method of the parent MockRoleScopeStore
+// instance is invoked.
+type RoleScopeStoreSetScopesForRoleFunc struct {
+	defaultHook func(context.Context, database.SetScopesForRoleOpts) error
+	hooks       []func(context.Context, database.SetScopesForRoleOpts) error
+	history     []RoleScopeStoreSetScopesForRoleFuncCall
+	mutex       sync.Mutex
+}
+
+// SetScopesForRole delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockRoleScopeStore) SetScopesForRole(v0 context.Context, v1 database.SetScopesForRoleOpts) error {
+	r0 := m.SetScopesForRoleFunc.nextHook()(v0, v1)
+	m.SetScopesForRoleFunc.appendCall(RoleScopeStoreSetScopesForRoleFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// SetScopesForRole method of the parent MockRoleScopeStore
+// instance is invoked and the hook queue is empty.
+func (f *RoleScopeStoreSetScopesForRoleFunc) SetDefaultHook(hook func(context.Context, database.SetScopesForRoleOpts) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetScopesForRole method of the parent MockRoleScopeStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *RoleScopeStoreSetScopesForRoleFunc) PushHook(hook func(context.Context, database.SetScopesForRoleOpts) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RoleScopeStoreSetScopesForRoleFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, database.SetScopesForRoleOpts) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RoleScopeStoreSetScopesForRoleFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, database.SetScopesForRoleOpts) error {
+		return r0
+	})
+}
+
+func (f *RoleScopeStoreSetScopesForRoleFunc) nextHook() func(context.Context, database.SetScopesForRoleOpts) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RoleScopeStoreSetScopesForRoleFunc) appendCall(r0 RoleScopeStoreSetScopesForRoleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// RoleScopeStoreSetScopesForRoleFuncCall objects describing the
+// invocations of this function.
+func (f *RoleScopeStoreSetScopesForRoleFunc) History() []RoleScopeStoreSetScopesForRoleFuncCall {
+	f.mutex.Lock()
+	history := make([]RoleScopeStoreSetScopesForRoleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RoleScopeStoreSetScopesForRoleFuncCall is an object that
+// describes an invocation of method SetScopesForRole on an instance of
+// MockRoleScopeStore.
+type RoleScopeStoreSetScopesForRoleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 database.SetScopesForRoleOpts
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RoleScopeStoreSetScopesForRoleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RoleScopeStoreSetScopesForRoleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RoleScopeStoreWithFunc describes the behavior when the With method
+// of the parent MockRoleScopeStore instance is invoked.
+type RoleScopeStoreWithFunc struct {
+	defaultHook func(basestore.ShareableStore) database.RoleScopeStore
+	hooks       []func(basestore.ShareableStore) database.RoleScopeStore
+	history     []RoleScopeStoreWithFuncCall
+	mutex       sync.Mutex
+}
+
+// With delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockRoleScopeStore) With(v0 basestore.ShareableStore) database.RoleScopeStore {
+	r0 := m.WithFunc.nextHook()(v0)
+	m.WithFunc.appendCall(RoleScopeStoreWithFuncCall{v0, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the With method of the
+// parent MockRoleScopeStore instance is invoked and the hook queue is
+// empty.
+func (f *RoleScopeStoreWithFunc) SetDefaultHook(hook func(basestore.ShareableStore) database.RoleScopeStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// With method of the parent MockRoleScopeStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *RoleScopeStoreWithFunc) PushHook(hook func(basestore.ShareableStore) database.RoleScopeStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RoleScopeStoreWithFunc) SetDefaultReturn(r0 database.RoleScopeStore) {
+	f.SetDefaultHook(func(basestore.ShareableStore) database.RoleScopeStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RoleScopeStoreWithFunc) PushReturn(r0 database.RoleScopeStore) {
+	f.PushHook(func(basestore.ShareableStore) database.RoleScopeStore {
+		return r0
+	})
+}
+
+func (f *RoleScopeStoreWithFunc) nextHook() func(basestore.ShareableStore) database.RoleScopeStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RoleScopeStoreWithFunc) appendCall(r0 RoleScopeStoreWithFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RoleScopeStoreWithFuncCall objects
+// describing the invocations of this function.
+func (f *RoleScopeStoreWithFunc) History() []RoleScopeStoreWithFuncCall {
+	f.mutex.Lock()
+	history := make([]RoleScopeStoreWithFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RoleScopeStoreWithFuncCall is an object that describes an invocation
+// of method With on an instance of MockRoleScopeStore.
+type RoleScopeStoreWithFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 basestore.ShareableStore
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.RoleScopeStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RoleScopeStoreWithFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RoleScopeStoreWithFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RoleScopeStoreWithTransactFunc describes the behavior when the
+// WithTransact method of the parent MockRoleScopeStore instance is
+// invoked.
+type RoleScopeStoreWithTransactFunc struct {
+	defaultHook func(context.Context, func(database.RoleScopeStore) error) error
+	hooks       []func(context.Context, func(database.RoleScopeStore) error) error
+	history     []RoleScopeStoreWithTransactFuncCall
+	mutex       sync.Mutex
+}
+
+// WithTransact delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockRoleScopeStore) WithTransact(v0 context.Context, v1 func(database.RoleScopeStore) error) error {
+	r0 := m.WithTransactFunc.nextHook()(v0, v1)
+	m.WithTransactFunc.appendCall(RoleScopeStoreWithTransactFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the WithTransact method
+// of the parent MockRoleScopeStore instance is invoked and the hook
+// queue is empty.
+func (f *RoleScopeStoreWithTransactFunc) SetDefaultHook(hook func(context.Context, func(database.RoleScopeStore) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// WithTransact method of the parent MockRoleScopeStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *RoleScopeStoreWithTransactFunc) PushHook(hook func(context.Context, func(database.RoleScopeStore) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RoleScopeStoreWithTransactFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, func(database.RoleScopeStore) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RoleScopeStoreWithTransactFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, func(database.RoleScopeStore) error) error {
+		return r0
+	})
+}
+
+func (f *RoleScopeStoreWithTransactFunc) nextHook() func(context.Context, func(database.RoleScopeStore) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RoleScopeStoreWithTransactFunc) appendCall(r0 RoleScopeStoreWithTransactFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RoleScopeStoreWithTransactFuncCall
+// objects describing the invocations of this function.
+func (f *RoleScopeStoreWithTransactFunc) History() []RoleScopeStoreWithTransactFuncCall {
+	f.mutex.Lock()
+	history := make([]RoleScopeStoreWithTransactFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RoleScopeStoreWithTransactFuncCall is an object that describes an
+// invocation of method WithTransact on an instance of
+// MockRoleScopeStore.
+type RoleScopeStoreWithTransactFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 func(database.RoleScopeStore) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RoleScopeStoreWithTransactFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RoleScopeStoreWithTransactFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockRoleStore is a mock implementation of the RoleStore interface (from
 // the package github.com/sourcegraph/sourcegraph/internal/database) used
 // for unit testing.
@@ -141,6 +141,9 @@ func (teams *Teams) matches(team *types.Team, opts database.ListTeamsOpts) bool
 	if opts.RootOnly && team.ParentTeamID != 0 {
 		return false
 	}
+	if opts.OnlySCIMControlled && !team.SCIMControlled {
+		return false
+	}
 	if opts.Search != "" {
 		search := strings.ToLower(opts.Search)
 		name := strings.ToLower(team.Name)
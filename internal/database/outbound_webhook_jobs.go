@@ -28,6 +28,23 @@ type OutboundWebhookJobStore interface {
 	DeleteBefore(ctx context.Context, before time.Time) error
 	GetByID(ctx context.Context, id int64) (*types.OutboundWebhookJob, error)
 	GetLast(ctx context.Context) (*types.OutboundWebhookJob, error)
+
+	// Redeliver creates a job that sends the payload of the job with the given
+	// ID to a single outbound webhook, regardless of the event types the
+	// webhook is subscribed to.
+	Redeliver(ctx context.Context, id int64, outboundWebhookID int64) (*types.OutboundWebhookJob, error)
+
+	// Replay creates a job for every event of the given type that was queued
+	// within the given time window, each of which sends the original payload
+	// to a single outbound webhook. It returns the number of jobs created.
+	Replay(ctx context.Context, opts OutboundWebhookJobReplayOpts) (int, error)
+}
+
+type OutboundWebhookJobReplayOpts struct {
+	OutboundWebhookID int64
+	EventType         string
+	Since             time.Time
+	Until             time.Time
 }
 
 type OutboundWebhookJobNotFoundErr struct{ id *int64 }
@@ -140,6 +157,37 @@ func (s *outboundWebhookJobStore) GetLast(ctx context.Context) (*types.OutboundW
 	return &job, nil
 }
 
+func (s *outboundWebhookJobStore) Redeliver(ctx context.Context, id int64, outboundWebhookID int64) (*types.OutboundWebhookJob, error) {
+	q := sqlf.Sprintf(
+		outboundWebhookJobRedeliverQueryFmtstr,
+		outboundWebhookID,
+		id,
+		sqlf.Join(OutboundWebhookJobColumns, ","),
+	)
+
+	var job types.OutboundWebhookJob
+	if err := s.scanOutboundWebhookJob(&job, s.QueryRow(ctx, q)); err == sql.ErrNoRows {
+		return nil, OutboundWebhookJobNotFoundErr{id: &id}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+func (s *outboundWebhookJobStore) Replay(ctx context.Context, opts OutboundWebhookJobReplayOpts) (int, error) {
+	q := sqlf.Sprintf(
+		outboundWebhookJobReplayQueryFmtstr,
+		opts.OutboundWebhookID,
+		opts.EventType,
+		opts.Since,
+		opts.Until,
+	)
+
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, q))
+	return count, err
+}
+
 func (s *outboundWebhookJobStore) scanOutboundWebhookJob(job *types.OutboundWebhookJob, sc dbutil.Scanner) error {
 	return scanOutboundWebhookJob(s.key, job, sc)
 }
@@ -178,6 +226,8 @@ func scanOutboundWebhookJob(key encryption.Key, job *types.OutboundWebhookJob, s
 		pq.Array(&executionLogs),
 		&job.WorkerHostname,
 		&job.Cancel,
+		&job.OutboundWebhookID,
+		&job.RedeliveryOfJobID,
 	); err != nil {
 		return err
 	}
@@ -211,6 +261,8 @@ var OutboundWebhookJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("execution_logs"),
 	sqlf.Sprintf("worker_hostname"),
 	sqlf.Sprintf("cancel"),
+	sqlf.Sprintf("outbound_webhook_id"),
+	sqlf.Sprintf("redelivery_of_job_id"),
 }
 
 const outboundWebhookJobCreateQueryFmtstr = `
@@ -254,3 +306,64 @@ ORDER BY
 	id DESC
 LIMIT 1
 `
+
+const outboundWebhookJobRedeliverQueryFmtstr = `
+-- source: internal/database/outbound_webhook_jobs.go:Redeliver
+INSERT INTO
+	outbound_webhook_jobs (
+		event_type,
+		scope,
+		encryption_key_id,
+		payload,
+		outbound_webhook_id,
+		redelivery_of_job_id
+	)
+SELECT
+	event_type,
+	scope,
+	encryption_key_id,
+	payload,
+	%s,
+	-- Redelivering a redelivery refers back to the original job.
+	COALESCE(redelivery_of_job_id, id)
+FROM
+	outbound_webhook_jobs
+WHERE
+	id = %s
+RETURNING %s
+`
+
+const outboundWebhookJobReplayQueryFmtstr = `
+-- source: internal/database/outbound_webhook_jobs.go:Replay
+WITH inserted AS (
+	INSERT INTO
+		outbound_webhook_jobs (
+			event_type,
+			scope,
+			encryption_key_id,
+			payload,
+			outbound_webhook_id,
+			redelivery_of_job_id
+		)
+	SELECT
+		event_type,
+		scope,
+		encryption_key_id,
+		payload,
+		%s,
+		id
+	FROM
+		outbound_webhook_jobs
+	WHERE
+		event_type = %s AND
+		queued_at >= %s AND
+		queued_at < %s AND
+		-- Earlier redeliveries are excluded, since the original job is
+		-- replayed as well.
+		redelivery_of_job_id IS NULL
+	ORDER BY
+		id
+	RETURNING 1
+)
+SELECT COUNT(*) FROM inserted
+`
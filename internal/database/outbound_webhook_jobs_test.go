@@ -111,6 +111,81 @@ func TestOutboundWebhookJobs(t *testing.T) {
 	})
 }
 
+func TestOutboundWebhookJobsRedelivery(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	runBothEncryptionStates(t, func(t *testing.T, logger log.Logger, db DB, key encryption.Key) {
+		_, webhook := setupOutboundWebhookTest(t, ctx, db, key)
+		store := db.OutboundWebhookJobs(key)
+
+		payload := []byte(`"TEST"`)
+		original, err := store.Create(ctx, "foo", pointers.Ptr("scope"), payload)
+		require.NoError(t, err)
+		_, err = store.Create(ctx, "bar", nil, []byte(`"OTHER"`))
+		require.NoError(t, err)
+
+		t.Run("Redeliver", func(t *testing.T) {
+			t.Run("not found", func(t *testing.T) {
+				job, err := store.Redeliver(ctx, 0, webhook.ID)
+				assert.True(t, errcode.IsNotFound(err))
+				assert.Nil(t, job)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				job, err := store.Redeliver(ctx, original.ID, webhook.ID)
+				require.NoError(t, err)
+				assert.NotEqual(t, original.ID, job.ID)
+				assert.Equal(t, original.EventType, job.EventType)
+				assert.Equal(t, original.Scope, job.Scope)
+				assert.Equal(t, &webhook.ID, job.OutboundWebhookID)
+				assert.Equal(t, &original.ID, job.RedeliveryOfJobID)
+				assert.Equal(t, decryptedValue(t, ctx, original.Payload), decryptedValue(t, ctx, job.Payload))
+
+				// Redelivering a redelivery refers back to the original job.
+				again, err := store.Redeliver(ctx, job.ID, webhook.ID)
+				require.NoError(t, err)
+				assert.Equal(t, &original.ID, again.RedeliveryOfJobID)
+			})
+		})
+
+		t.Run("Replay", func(t *testing.T) {
+			opts := OutboundWebhookJobReplayOpts{
+				OutboundWebhookID: webhook.ID,
+				EventType:         "foo",
+				Since:             original.QueuedAt.Add(-time.Minute),
+				Until:             original.QueuedAt.Add(time.Minute),
+			}
+
+			t.Run("outside the window", func(t *testing.T) {
+				opts := opts
+				opts.Until = opts.Since
+				count, err := store.Replay(ctx, opts)
+				require.NoError(t, err)
+				assert.Zero(t, count)
+			})
+
+			t.Run("success", func(t *testing.T) {
+				before := listOutboundWebhookJobs(t, ctx, store)
+
+				// The redeliveries created above must not be replayed again,
+				// and neither must the job of the other event type.
+				count, err := store.Replay(ctx, opts)
+				require.NoError(t, err)
+				assert.Equal(t, 1, count)
+
+				after := listOutboundWebhookJobs(t, ctx, store)
+				require.Len(t, after, len(before)+1)
+
+				job := after[len(after)-1]
+				assert.Equal(t, "foo", job.EventType)
+				assert.Equal(t, &webhook.ID, job.OutboundWebhookID)
+				assert.Equal(t, &original.ID, job.RedeliveryOfJobID)
+			})
+		})
+	})
+}
+
 func assertEqualOutboundWebhookJobs(t *testing.T, ctx context.Context, want, have *types.OutboundWebhookJob) {
 	t.Helper()
 
@@ -134,6 +209,8 @@ func assertEqualOutboundWebhookJobs(t *testing.T, ctx context.Context, want, hav
 	assert.Equal(t, want.ExecutionLogs, have.ExecutionLogs)
 	assert.Equal(t, want.WorkerHostname, have.WorkerHostname)
 	assert.Equal(t, want.Cancel, have.Cancel)
+	assert.Equal(t, want.OutboundWebhookID, have.OutboundWebhookID)
+	assert.Equal(t, want.RedeliveryOfJobID, have.RedeliveryOfJobID)
 	assert.Equal(t, valueOf(want.Payload), valueOf(have.Payload))
 }
 
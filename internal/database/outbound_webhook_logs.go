@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"github.com/keegancsmith/sqlf"
 
@@ -22,6 +23,7 @@ type OutboundWebhookLogStore interface {
 
 	CountsForOutboundWebhook(ctx context.Context, outboundWebhookID int64) (total, errored int64, err error)
 	Create(context.Context, *types.OutboundWebhookLog) error
+	GetByID(ctx context.Context, id int64) (*types.OutboundWebhookLog, error)
 	ListForOutboundWebhook(ctx context.Context, opts OutboundWebhookLogListOpts) ([]*types.OutboundWebhookLog, error)
 }
 
@@ -46,6 +48,14 @@ func (opts *OutboundWebhookLogListOpts) where() *sqlf.Query {
 	return sqlf.Join(preds, "AND")
 }
 
+type OutboundWebhookLogNotFoundErr struct{ id int64 }
+
+func (err OutboundWebhookLogNotFoundErr) Error() string {
+	return fmt.Sprintf("outbound webhook log with id %v not found", err.id)
+}
+
+func (OutboundWebhookLogNotFoundErr) NotFound() bool { return true }
+
 type outboundWebhookLogStore struct {
 	*basestore.Store
 	key encryption.Key
@@ -109,6 +119,7 @@ func (s *outboundWebhookLogStore) Create(ctx context.Context, log *types.Outboun
 		[]byte(rawRequest),
 		[]byte(rawResponse),
 		[]byte(rawError),
+		log.Redelivery,
 		sqlf.Join(outboundWebhookLogColumns, ","),
 	)
 
@@ -120,6 +131,23 @@ func (s *outboundWebhookLogStore) Create(ctx context.Context, log *types.Outboun
 	return nil
 }
 
+func (s *outboundWebhookLogStore) GetByID(ctx context.Context, id int64) (*types.OutboundWebhookLog, error) {
+	q := sqlf.Sprintf(
+		outboundWebhookLogGetByIDQueryFmtstr,
+		sqlf.Join(outboundWebhookLogColumns, ","),
+		id,
+	)
+
+	var log types.OutboundWebhookLog
+	if err := s.scanOutboundWebhookLog(&log, s.QueryRow(ctx, q)); err == sql.ErrNoRows {
+		return nil, OutboundWebhookLogNotFoundErr{id: id}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return &log, nil
+}
+
 func (s *outboundWebhookLogStore) ListForOutboundWebhook(ctx context.Context, opts OutboundWebhookLogListOpts) ([]*types.OutboundWebhookLog, error) {
 	q := sqlf.Sprintf(
 		outboundWebhookLogListForOutboundWebhookQueryFmtstr,
@@ -164,6 +192,7 @@ func (s *outboundWebhookLogStore) scanOutboundWebhookLog(log *types.OutboundWebh
 		&rawRequest,
 		&rawResponse,
 		&rawError,
+		&log.Redelivery,
 	); err != nil {
 		return err
 	}
@@ -185,6 +214,7 @@ var outboundWebhookLogColumns = []*sqlf.Query{
 	sqlf.Sprintf("request"),
 	sqlf.Sprintf("response"),
 	sqlf.Sprintf("error"),
+	sqlf.Sprintf("redelivery"),
 }
 
 const outboundWebhookCountsForOutboundWebhookQueryFmtstr = `
@@ -208,12 +238,23 @@ INSERT INTO
 		encryption_key_id,
 		request,
 		response,
-		error
+		error,
+		redelivery
 	)
-VALUES (%s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
+const outboundWebhookLogGetByIDQueryFmtstr = `
+-- source: internal/database/outbound_webhook_logs.go:GetByID
+SELECT
+	%s
+FROM
+	outbound_webhook_logs
+WHERE
+	id = %s
+`
+
 const outboundWebhookLogListForOutboundWebhookQueryFmtstr = `
 -- source: internal/database/outbound_webhook_logs.go:ListForOutboundWebhook
 SELECT
@@ -12,6 +12,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	et "github.com/sourcegraph/sourcegraph/internal/encryption/testing"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -78,6 +79,20 @@ func TestOutboundWebhookLogs(t *testing.T) {
 			})
 		})
 
+		t.Run("GetByID", func(t *testing.T) {
+			t.Run("not found", func(t *testing.T) {
+				log, err := store.GetByID(ctx, 0)
+				assert.True(t, errcode.IsNotFound(err))
+				assert.Nil(t, log)
+			})
+
+			t.Run("found", func(t *testing.T) {
+				log, err := store.GetByID(ctx, serverErrorLog.ID)
+				assert.NoError(t, err)
+				assertEqualOutboundWebhookLogs(t, ctx, serverErrorLog, log)
+			})
+		})
+
 		t.Run("CountsForOutboundWebhook", func(t *testing.T) {
 			t.Run("missing ID", func(t *testing.T) {
 				total, errored, err := store.CountsForOutboundWebhook(ctx, 0)
@@ -169,6 +184,7 @@ func assertEqualOutboundWebhookLogs(t *testing.T, ctx context.Context, want, hav
 	assert.Equal(t, valueOf(want.Request.Encryptable), valueOf(have.Request.Encryptable))
 	assert.Equal(t, valueOf(want.Response.Encryptable), valueOf(have.Response.Encryptable))
 	assert.Equal(t, valueOf(want.Error), valueOf(have.Error))
+	assert.Equal(t, want.Redelivery, have.Redelivery)
 }
 
 func assertOutboundWebhookLogFieldsEncrypted(t *testing.T, ctx context.Context, store basestore.ShareableStore, log *types.OutboundWebhookLog) {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/keegancsmith/sqlf"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	rtypes "github.com/sourcegraph/sourcegraph/internal/rbac/types"
@@ -37,7 +38,8 @@ type PermissionStore interface {
 	// BulkDelete deletes a permission with the provided ID
 	BulkDelete(ctx context.Context, opts []DeletePermissionOpts) error
 	// GetPermissionForUser retrieves a permission for a user. If the user doesn't have the permission
-	// it returns an error.
+	// it returns an error. Permissions granted by scoped roles are only considered if the options
+	// target one of the scopes of the role.
 	GetPermissionForUser(ctx context.Context, opts GetPermissionForUserOpts) (*types.Permission, error)
 	// Count returns the number of permissions in the database matching the options provided.
 	Count(ctx context.Context, opts PermissionListOpts) (int, error)
@@ -56,6 +58,16 @@ type GetPermissionForUserOpts struct {
 
 	Namespace rtypes.PermissionNamespace
 	Action    rtypes.NamespaceAction
+
+	// OrgID and RepoID are the resource the permission is checked against. Scoped roles grant
+	// the permission if one of their scopes covers the resource. If both are zero, only roles
+	// without scopes grant the permission.
+	OrgID  int32
+	RepoID api.RepoID
+	// AnyScope considers roles regardless of their scopes. It is meant for operations that
+	// aren't tied to a single resource, which need to know if the user has the permission
+	// anywhere.
+	AnyScope bool
 }
 
 type CreatePermissionOpts struct {
@@ -111,10 +123,60 @@ const getPermissionForUserQuery = `
 SELECT %s FROM permissions
 INNER JOIN role_permissions ON role_permissions.permission_id = permissions.id
 INNER JOIN user_roles ON user_roles.role_id = role_permissions.role_id
-WHERE permissions.action = %s AND permissions.namespace = %s AND user_roles.user_id = %s
+WHERE permissions.action = %s AND permissions.namespace = %s AND user_roles.user_id = %s AND %s
 LIMIT 1
 `
 
+// roleWithoutScopesCondition matches the roles that are not scoped. Scoped roles are only
+// matched by their scopes, so that a scoped role whose scopes were all deleted grants no
+// permissions rather than becoming global.
+const roleWithoutScopesCondition = `
+NOT EXISTS (SELECT 1 FROM roles WHERE roles.id = user_roles.role_id AND roles.scoped)
+`
+
+const roleScopeMatchesCondition = `
+EXISTS (SELECT 1 FROM role_scopes WHERE role_scopes.role_id = user_roles.role_id AND (%s))
+`
+
+// searchContextScopeMatchesRepoCondition matches the search context scopes that contain a
+// repository. Only instance-level search contexts can be scopes, since the owners of a user or
+// organization search context could otherwise widen the permissions of a role.
+const searchContextScopeMatchesRepoCondition = `
+role_scopes.search_context_id IN (
+	SELECT scr.search_context_id
+	FROM search_context_repos scr
+	JOIN search_contexts sc ON sc.id = scr.search_context_id
+	WHERE scr.repo_id = %s AND sc.namespace_user_id IS NULL AND sc.namespace_org_id IS NULL
+)
+`
+
+// permissionScopeCondition returns the condition the roles granting a permission have to meet
+// for the resource targeted by the given options.
+func permissionScopeCondition(opts GetPermissionForUserOpts) *sqlf.Query {
+	if opts.AnyScope {
+		return sqlf.Sprintf("TRUE")
+	}
+
+	var targets []*sqlf.Query
+	if opts.OrgID != 0 {
+		targets = append(targets, sqlf.Sprintf("role_scopes.org_id = %s", opts.OrgID))
+	}
+	if opts.RepoID != 0 {
+		targets = append(targets,
+			sqlf.Sprintf("role_scopes.repo_id = %s", opts.RepoID),
+			sqlf.Sprintf(searchContextScopeMatchesRepoCondition, opts.RepoID),
+		)
+	}
+	if len(targets) == 0 {
+		return sqlf.Sprintf(roleWithoutScopesCondition)
+	}
+
+	return sqlf.Sprintf("(%s OR %s)",
+		sqlf.Sprintf(roleWithoutScopesCondition),
+		sqlf.Sprintf(roleScopeMatchesCondition, sqlf.Join(targets, " OR ")),
+	)
+}
+
 func (p *permissionStore) GetPermissionForUser(ctx context.Context, opts GetPermissionForUserOpts) (*types.Permission, error) {
 	if opts.UserID == 0 {
 		return nil, errors.New("missing user id")
@@ -134,6 +196,7 @@ func (p *permissionStore) GetPermissionForUser(ctx context.Context, opts GetPerm
 		opts.Action,
 		opts.Namespace,
 		opts.UserID,
+		permissionScopeCondition(opts),
 	)
 
 	permission, err := scanPermission(p.QueryRow(ctx, q))
@@ -0,0 +1,209 @@
+package database
+
+import (
+	"context"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var roleScopeInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("role_id"),
+	sqlf.Sprintf("org_id"),
+	sqlf.Sprintf("repo_id"),
+	sqlf.Sprintf("search_context_id"),
+}
+
+var roleScopeColumns = []*sqlf.Query{
+	sqlf.Sprintf("role_scopes.id"),
+	sqlf.Sprintf("role_scopes.role_id"),
+	sqlf.Sprintf("role_scopes.org_id"),
+	sqlf.Sprintf("role_scopes.repo_id"),
+	sqlf.Sprintf("role_scopes.search_context_id"),
+	sqlf.Sprintf("role_scopes.created_at"),
+}
+
+type GetRoleScopesOpts struct {
+	RoleID int32
+}
+
+type SetScopesForRoleOpts struct {
+	RoleID int32
+
+	OrgIDs           []int32
+	RepoIDs          []api.RepoID
+	SearchContextIDs []int64
+}
+
+// RoleScopeStore manages the scopes of roles. The permissions of a scoped role are only
+// granted on the organizations, repositories and search contexts it is scoped to, and not at
+// all if these were deleted. A role that is not scoped grants its permissions globally.
+type RoleScopeStore interface {
+	basestore.ShareableStore
+
+	// GetByRoleID returns all scopes of the role with the provided ID.
+	GetByRoleID(ctx context.Context, opts GetRoleScopesOpts) ([]*types.RoleScope, error)
+	// SetScopesForRole replaces all scopes of a role with those included in the options. The
+	// role is scoped if there are any, and global otherwise. System roles and search
+	// contexts defined by a query cannot be scoped.
+	SetScopesForRole(ctx context.Context, opts SetScopesForRoleOpts) error
+	// WithTransact creates a transaction for the RoleScopeStore.
+	WithTransact(context.Context, func(RoleScopeStore) error) error
+	// With is used to merge the store with another to pull data via other stores.
+	With(basestore.ShareableStore) RoleScopeStore
+}
+
+type roleScopeStore struct {
+	*basestore.Store
+}
+
+var _ RoleScopeStore = &roleScopeStore{}
+
+func RoleScopesWith(other basestore.ShareableStore) RoleScopeStore {
+	return &roleScopeStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (rs *roleScopeStore) With(other basestore.ShareableStore) RoleScopeStore {
+	return &roleScopeStore{Store: rs.Store.With(other)}
+}
+
+func (rs *roleScopeStore) WithTransact(ctx context.Context, f func(RoleScopeStore) error) error {
+	return rs.Store.WithTransact(ctx, func(tx *basestore.Store) error {
+		return f(&roleScopeStore{Store: tx})
+	})
+}
+
+func scanRoleScope(sc dbutil.Scanner) (*types.RoleScope, error) {
+	var s types.RoleScope
+	if err := sc.Scan(
+		&s.ID,
+		&s.RoleID,
+		&dbutil.NullInt32{N: &s.OrgID},
+		&dbutil.NullInt32{N: (*int32)(&s.RepoID)},
+		&dbutil.NullInt64{N: &s.SearchContextID},
+		&s.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &s, nil
+}
+
+const getRoleScopesQueryFmtStr = `
+SELECT
+	%s
+FROM role_scopes
+WHERE role_scopes.role_id = %s
+ORDER BY role_scopes.id ASC
+`
+
+func (rs *roleScopeStore) GetByRoleID(ctx context.Context, opts GetRoleScopesOpts) ([]*types.RoleScope, error) {
+	if opts.RoleID == 0 {
+		return nil, errors.New("missing role id")
+	}
+
+	q := sqlf.Sprintf(
+		getRoleScopesQueryFmtStr,
+		sqlf.Join(roleScopeColumns, ", "),
+		opts.RoleID,
+	)
+
+	var scanRoleScopes = basestore.NewSliceScanner(scanRoleScope)
+	return scanRoleScopes(rs.Query(ctx, q))
+}
+
+const deleteRoleScopesQueryFmtStr = `
+DELETE FROM role_scopes
+WHERE role_id = %s
+`
+
+const setRoleScopedQueryFmtStr = `
+UPDATE roles SET scoped = %s
+WHERE id = %s
+`
+
+const countInvalidScopeSearchContextsQueryFmtStr = `
+SELECT
+	COUNT(*) FILTER (WHERE query IS NOT NULL),
+	COUNT(*) FILTER (WHERE namespace_user_id IS NOT NULL OR namespace_org_id IS NOT NULL)
+FROM search_contexts
+WHERE id = ANY(%s)
+`
+
+const insertRoleScopesQueryFmtStr = `
+INSERT INTO
+	role_scopes (%s)
+VALUES %s
+`
+
+func (rs *roleScopeStore) SetScopesForRole(ctx context.Context, opts SetScopesForRoleOpts) error {
+	if opts.RoleID == 0 {
+		return errors.New("missing role id")
+	}
+
+	return rs.Store.WithTransact(ctx, func(tx *basestore.Store) error {
+		// System roles apply to every user of the instance, so they must stay global.
+		role, err := RolesWith(tx).Get(ctx, GetRoleOpts{ID: opts.RoleID})
+		if err != nil {
+			return err
+		}
+		if role.System {
+			return errors.New("cannot scope a system role")
+		}
+
+		// The repositories of search contexts defined by a query are only known when searching,
+		// so they can't be matched against a repository. Search contexts owned by a user or an
+		// organization can be edited by their owners, who could then widen the permissions of
+		// everyone holding the role, so only instance-level search contexts can be scoped.
+		if len(opts.SearchContextIDs) > 0 {
+			var withQuery, namespaced int
+			row := tx.QueryRow(ctx, sqlf.Sprintf(countInvalidScopeSearchContextsQueryFmtStr, pq.Array(opts.SearchContextIDs)))
+			if err := row.Scan(&withQuery, &namespaced); err != nil {
+				return errors.Wrap(err, "checking search contexts")
+			}
+			if withQuery > 0 {
+				return errors.New("cannot scope a role to a search context defined by a query")
+			}
+			if namespaced > 0 {
+				return errors.New("cannot scope a role to a search context owned by a user or an organization")
+			}
+		}
+
+		if err := tx.Exec(ctx, sqlf.Sprintf(deleteRoleScopesQueryFmtStr, opts.RoleID)); err != nil {
+			return errors.Wrap(err, "deleting role scopes")
+		}
+
+		var values []*sqlf.Query
+		for _, id := range opts.OrgIDs {
+			values = append(values, sqlf.Sprintf("(%s, %s, NULL, NULL)", opts.RoleID, id))
+		}
+		for _, id := range opts.RepoIDs {
+			values = append(values, sqlf.Sprintf("(%s, NULL, %s, NULL)", opts.RoleID, id))
+		}
+		for _, id := range opts.SearchContextIDs {
+			values = append(values, sqlf.Sprintf("(%s, NULL, NULL, %s)", opts.RoleID, id))
+		}
+		if err := tx.Exec(ctx, sqlf.Sprintf(setRoleScopedQueryFmtStr, len(values) > 0, opts.RoleID)); err != nil {
+			return errors.Wrap(err, "updating role")
+		}
+		if len(values) == 0 {
+			return nil
+		}
+
+		q := sqlf.Sprintf(
+			insertRoleScopesQueryFmtStr,
+			sqlf.Join(roleScopeInsertColumns, ", "),
+			sqlf.Join(values, ", "),
+		)
+		if err := tx.Exec(ctx, q); err != nil {
+			return errors.Wrap(err, "inserting role scopes")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,172 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	rtypes "github.com/sourcegraph/sourcegraph/internal/rbac/types"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRoleScopes(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	store := db.RoleScopes()
+
+	role, err := createTestRole(ctx, "SCOPED-ROLE", false, t, db.Roles())
+	require.NoError(t, err)
+
+	org, err := db.Orgs().Create(ctx, "the-org", nil)
+	require.NoError(t, err)
+
+	repo := &types.Repo{Name: "github.com/sourcegraph/sourcegraph"}
+	createRepo(ctx, t, db, repo)
+
+	searchContext, err := db.SearchContexts().CreateSearchContextWithRepositoryRevisions(ctx, &types.SearchContext{Name: "ctx"}, nil)
+	require.NoError(t, err)
+
+	t.Run("missing role id", func(t *testing.T) {
+		err := store.SetScopesForRole(ctx, SetScopesForRoleOpts{})
+		require.ErrorContains(t, err, "missing role id")
+	})
+
+	t.Run("system role", func(t *testing.T) {
+		userRole, err := db.Roles().Get(ctx, GetRoleOpts{Name: string(types.UserSystemRole)})
+		require.NoError(t, err)
+
+		err = store.SetScopesForRole(ctx, SetScopesForRoleOpts{RoleID: userRole.ID, OrgIDs: []int32{org.ID}})
+		require.ErrorContains(t, err, "cannot scope a system role")
+	})
+
+	t.Run("set and replace scopes", func(t *testing.T) {
+		err := store.SetScopesForRole(ctx, SetScopesForRoleOpts{
+			RoleID:           role.ID,
+			OrgIDs:           []int32{org.ID},
+			RepoIDs:          []api.RepoID{repo.ID},
+			SearchContextIDs: []int64{searchContext.ID},
+		})
+		require.NoError(t, err)
+
+		scopes, err := store.GetByRoleID(ctx, GetRoleScopesOpts{RoleID: role.ID})
+		require.NoError(t, err)
+		require.Len(t, scopes, 3)
+		require.Equal(t, org.ID, scopes[0].OrgID)
+		require.Equal(t, repo.ID, scopes[1].RepoID)
+		require.Equal(t, searchContext.ID, scopes[2].SearchContextID)
+
+		err = store.SetScopesForRole(ctx, SetScopesForRoleOpts{RoleID: role.ID, RepoIDs: []api.RepoID{repo.ID}})
+		require.NoError(t, err)
+
+		scopes, err = store.GetByRoleID(ctx, GetRoleScopesOpts{RoleID: role.ID})
+		require.NoError(t, err)
+		require.Len(t, scopes, 1)
+		require.Equal(t, repo.ID, scopes[0].RepoID)
+		require.Zero(t, scopes[0].OrgID)
+	})
+
+	t.Run("search context defined by a query", func(t *testing.T) {
+		queryContext, err := db.SearchContexts().CreateSearchContextWithRepositoryRevisions(ctx, &types.SearchContext{Name: "query-ctx", Query: "repo:^github\\.com/sourcegraph/"}, nil)
+		require.NoError(t, err)
+
+		err = store.SetScopesForRole(ctx, SetScopesForRoleOpts{RoleID: role.ID, SearchContextIDs: []int64{queryContext.ID}})
+		require.ErrorContains(t, err, "cannot scope a role to a search context defined by a query")
+	})
+
+	t.Run("search context owned by an organization", func(t *testing.T) {
+		orgContext, err := db.SearchContexts().CreateSearchContextWithRepositoryRevisions(ctx, &types.SearchContext{Name: "org-ctx", NamespaceOrgID: org.ID}, nil)
+		require.NoError(t, err)
+
+		err = store.SetScopesForRole(ctx, SetScopesForRoleOpts{RoleID: role.ID, SearchContextIDs: []int64{orgContext.ID}})
+		require.ErrorContains(t, err, "cannot scope a role to a search context owned by a user or an organization")
+	})
+
+	t.Run("clear scopes", func(t *testing.T) {
+		err := store.SetScopesForRole(ctx, SetScopesForRoleOpts{RoleID: role.ID})
+		require.NoError(t, err)
+
+		scopes, err := store.GetByRoleID(ctx, GetRoleScopesOpts{RoleID: role.ID})
+		require.NoError(t, err)
+		require.Empty(t, scopes)
+	})
+}
+
+func TestGetPermissionForUserWithScopedRole(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(t))
+	store := db.Permissions()
+
+	user := createTestUserWithoutRoles(t, db, "scoped-user", false)
+
+	role, err := createTestRole(ctx, "REPO-METADATA-EDITOR", false, t, db.Roles())
+	require.NoError(t, err)
+
+	p, err := store.Create(ctx, CreatePermissionOpts{
+		Namespace: rtypes.RepoMetadataNamespace,
+		Action:    rtypes.RepoMetadataWriteAction,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, db.RolePermissions().Assign(ctx, AssignRolePermissionOpts{RoleID: role.ID, PermissionID: p.ID}))
+	require.NoError(t, db.UserRoles().Assign(ctx, AssignUserRoleOpts{UserID: user.ID, RoleID: role.ID}))
+
+	org, err := db.Orgs().Create(ctx, "the-org", nil)
+	require.NoError(t, err)
+
+	inContext := &types.Repo{Name: "github.com/sourcegraph/in-context"}
+	createRepo(ctx, t, db, inContext)
+	outside := &types.Repo{Name: "github.com/sourcegraph/outside"}
+	createRepo(ctx, t, db, outside)
+
+	searchContext, err := db.SearchContexts().CreateSearchContextWithRepositoryRevisions(ctx, &types.SearchContext{Name: "ctx"}, []*types.SearchContextRepositoryRevisions{
+		{Repo: types.MinimalRepo{ID: inContext.ID, Name: inContext.Name}, Revisions: []string{"HEAD"}},
+	})
+	require.NoError(t, err)
+
+	hasPermission := func(opts GetPermissionForUserOpts) bool {
+		t.Helper()
+		opts.UserID = user.ID
+		opts.Namespace = rtypes.RepoMetadataNamespace
+		opts.Action = rtypes.RepoMetadataWriteAction
+		perm, err := store.GetPermissionForUser(ctx, opts)
+		if err != nil {
+			require.ErrorAs(t, err, new(*PermissionNotFoundErr))
+			return false
+		}
+		return perm != nil
+	}
+
+	// Without scopes, the role applies everywhere.
+	require.True(t, hasPermission(GetPermissionForUserOpts{}))
+	require.True(t, hasPermission(GetPermissionForUserOpts{RepoID: outside.ID}))
+
+	err = db.RoleScopes().SetScopesForRole(ctx, SetScopesForRoleOpts{
+		RoleID:           role.ID,
+		OrgIDs:           []int32{org.ID},
+		SearchContextIDs: []int64{searchContext.ID},
+	})
+	require.NoError(t, err)
+
+	require.False(t, hasPermission(GetPermissionForUserOpts{}))
+	require.True(t, hasPermission(GetPermissionForUserOpts{AnyScope: true}))
+	require.True(t, hasPermission(GetPermissionForUserOpts{RepoID: inContext.ID}))
+	require.False(t, hasPermission(GetPermissionForUserOpts{RepoID: outside.ID}))
+	require.True(t, hasPermission(GetPermissionForUserOpts{OrgID: org.ID}))
+	require.False(t, hasPermission(GetPermissionForUserOpts{OrgID: org.ID + 1}))
+
+	// Once the scopes are deleted along with their resources, the role grants nothing.
+	_, err = db.ExecContext(ctx, "DELETE FROM role_scopes WHERE role_id = $1", role.ID)
+	require.NoError(t, err)
+
+	require.False(t, hasPermission(GetPermissionForUserOpts{}))
+	require.False(t, hasPermission(GetPermissionForUserOpts{RepoID: inContext.ID}))
+	require.False(t, hasPermission(GetPermissionForUserOpts{OrgID: org.ID}))
+}
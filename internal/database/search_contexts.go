@@ -178,6 +178,8 @@ type ListSearchContextsPageOptions struct {
 // It produces a union of all search contexts that match NamespaceUserIDs, or NamespaceOrgIDs, or NoNamespace. If none of those
 // are specified, it produces all available search contexts.
 type ListSearchContextsOptions struct {
+	// IDs matches search contexts by ID.
+	IDs []int64
 	// Name is used for partial matching of search contexts by name (case-insensitvely).
 	Name string
 	// NamespaceName is used for partial matching of search context namespaces (user or org) by name (case-insensitvely).
@@ -252,6 +254,14 @@ func getSearchContextsQueryConditions(opts ListSearchContextsOptions) []*sqlf.Qu
 		conds = append(conds, sqlf.Sprintf("(%s)", sqlf.Join(namespaceConds, " OR ")))
 	}
 
+	if len(opts.IDs) > 0 {
+		ids := make([]*sqlf.Query, 0, len(opts.IDs))
+		for _, id := range opts.IDs {
+			ids = append(ids, sqlf.Sprintf("%s", id))
+		}
+		conds = append(conds, sqlf.Sprintf("id IN (%s)", sqlf.Join(ids, ",")))
+	}
+
 	if opts.Name != "" {
 		// name column has type citext which automatically performs case-insensitive comparison
 		conds = append(conds, sqlf.Sprintf("context_name LIKE %s", "%"+opts.Name+"%"))
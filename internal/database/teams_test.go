@@ -120,6 +120,33 @@ func TestTeams_CreateUpdateDelete(t *testing.T) {
 		require.Equal(t, "", team.DisplayName)
 	})
 
+	t.Run("scim", func(t *testing.T) {
+		scimTeam := &types.Team{Name: "scim-group", SCIMControlled: true, SCIMExternalID: "external-id"}
+		if _, err := store.CreateTeam(ctx, scimTeam); err != nil {
+			t.Fatal(err)
+		}
+		have, err := store.GetTeamByID(ctx, scimTeam.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		require.True(t, have.SCIMControlled)
+		require.Equal(t, "external-id", have.SCIMExternalID)
+
+		have.SCIMExternalID = ""
+		if err := store.UpdateTeam(ctx, have); err != nil {
+			t.Fatal(err)
+		}
+		require.True(t, have.SCIMControlled)
+		require.Equal(t, "", have.SCIMExternalID)
+
+		scimTeams, _, err := store.ListTeams(ctx, ListTeamsOpts{OnlySCIMControlled: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		require.Len(t, scimTeams, 1)
+		require.Equal(t, scimTeam.ID, scimTeams[0].ID)
+	})
+
 	t.Run("delete", func(t *testing.T) {
 		if err := store.DeleteTeam(ctx, team.ID); err != nil {
 			t.Fatal(err)
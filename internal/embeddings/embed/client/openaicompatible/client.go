@@ -0,0 +1,155 @@
+package openaicompatible
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/modeltransformations"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewClient returns a client for the embeddings API of a self-hosted model server exposing the
+// OpenAI API, such as vLLM, the llama.cpp server or Ollama. The configured endpoint is the base
+// URL of the API, for example "http://localhost:8000/v1".
+func NewClient(httpClient *http.Client, config *conftypes.EmbeddingsConfig) *openaiCompatibleEmbeddingsClient {
+	return &openaiCompatibleEmbeddingsClient{
+		httpClient:  httpClient,
+		dimensions:  config.Dimensions,
+		accessToken: config.AccessToken,
+		model:       config.Model,
+		endpoint:    strings.TrimSuffix(config.Endpoint, "/") + "/embeddings",
+	}
+}
+
+type openaiCompatibleEmbeddingsClient struct {
+	httpClient  *http.Client
+	model       string
+	dimensions  int
+	endpoint    string
+	accessToken string
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetDimensions() (int, error) {
+	if c.dimensions <= 0 {
+		return 0, errors.New("invalid config for embeddings.dimensions, must be > 0")
+	}
+	return c.dimensions, nil
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetModelIdentifier() string {
+	return fmt.Sprintf("%s/%s", conftypes.EmbeddingsProviderNameOpenAICompatible, c.model)
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetQueryEmbedding(ctx context.Context, query string) (*client.EmbeddingsResults, error) {
+	return c.getEmbeddings(ctx, []string{modeltransformations.ApplyToQuery(query, c.GetModelIdentifier())})
+}
+
+func (c *openaiCompatibleEmbeddingsClient) GetDocumentEmbeddings(ctx context.Context, documents []string) (*client.EmbeddingsResults, error) {
+	return c.getEmbeddings(ctx, modeltransformations.ApplyToDocuments(documents, c.GetModelIdentifier()))
+}
+
+func (c *openaiCompatibleEmbeddingsClient) getEmbeddings(ctx context.Context, texts []string) (*client.EmbeddingsResults, error) {
+	for _, text := range texts {
+		if text == "" {
+			// Many model servers return an error if any of the strings in texts is an empty
+			// string, so fail fast to avoid making tons of retryable requests.
+			return nil, errors.New("cannot generate embeddings for an empty string")
+		}
+	}
+
+	dimensions, err := c.GetDimensions()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := c.do(ctx, embeddingAPIRequest{Model: c.model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensure embedding responses are sorted in the original order.
+	sort.Slice(response.Data, func(i, j int) bool {
+		return response.Data[i].Index < response.Data[j].Index
+	})
+
+	// Texts without an embedding in the response get a zero value embedding and are reported
+	// as failed.
+	embeddings := make([]float32, len(texts)*dimensions)
+	found := make([]bool, len(texts))
+	for _, embedding := range response.Data {
+		if embedding.Index < 0 || embedding.Index >= len(texts) || len(embedding.Embedding) == 0 {
+			continue
+		}
+		if len(embedding.Embedding) != dimensions {
+			return nil, errors.Newf("embeddings: model %q returned embeddings with %d dimensions, but embeddings.dimensions is %d", c.model, len(embedding.Embedding), dimensions)
+		}
+
+		copy(embeddings[embedding.Index*dimensions:], embedding.Embedding)
+		found[embedding.Index] = true
+	}
+
+	failed := make([]int, 0)
+	for i, ok := range found {
+		if !ok {
+			failed = append(failed, i)
+		}
+	}
+
+	return &client.EmbeddingsResults{Embeddings: embeddings, Failed: failed, Dimensions: dimensions}, nil
+}
+
+func (c *openaiCompatibleEmbeddingsClient) do(ctx context.Context, request embeddingAPIRequest) (*embeddingAPIResponse, error) {
+	bodyBytes, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// Self-hosted model servers usually don't require authentication.
+	if c.accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("embeddings: %s %q: failed with status %d: %s", req.Method, req.URL.String(), resp.StatusCode, string(respBody))
+	}
+
+	var response embeddingAPIResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+type embeddingAPIRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingAPIResponse struct {
+	Data []embeddingAPIResponseData `json:"data"`
+}
+
+type embeddingAPIResponseData struct {
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
@@ -0,0 +1,74 @@
+package openaicompatible
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+)
+
+func TestOpenAICompatible(t *testing.T) {
+	t.Run("errors on empty embedding string", func(t *testing.T) {
+		client := NewClient(http.DefaultClient, &conftypes.EmbeddingsConfig{Dimensions: 3})
+		invalidTexts := []string{"a", ""} // empty string is invalid
+		_, err := client.GetDocumentEmbeddings(context.Background(), invalidTexts)
+		require.ErrorContains(t, err, "empty string")
+	})
+
+	t.Run("model identifier", func(t *testing.T) {
+		client := NewClient(http.DefaultClient, &conftypes.EmbeddingsConfig{Model: "BAAI/bge-base-en-v1.5"})
+		require.Equal(t, "openai-compatible/BAAI/bge-base-en-v1.5", client.GetModelIdentifier())
+	})
+
+	t.Run("orders embeddings and reports missing ones as failed", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, "/v1/embeddings", r.URL.Path)
+			require.Empty(t, r.Header.Get("Authorization"))
+
+			var req embeddingAPIRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			require.Equal(t, embeddingAPIRequest{Model: "nomic-embed-text", Input: []string{"a", "b", "c"}}, req)
+
+			json.NewEncoder(w).Encode(embeddingAPIResponse{
+				Data: []embeddingAPIResponseData{
+					{Index: 2, Embedding: []float32{3, 3, 3}},
+					{Index: 0, Embedding: []float32{1, 1, 1}},
+				},
+			})
+		}))
+		defer s.Close()
+
+		client := NewClient(s.Client(), &conftypes.EmbeddingsConfig{
+			Model:      "nomic-embed-text",
+			Endpoint:   s.URL + "/v1/",
+			Dimensions: 3,
+		})
+		resp, err := client.GetDocumentEmbeddings(context.Background(), []string{"a", "b", "c"})
+		require.NoError(t, err)
+		require.Equal(t, []float32{1, 1, 1, 0, 0, 0, 3, 3, 3}, resp.Embeddings)
+		require.Equal(t, []int{1}, resp.Failed)
+		require.Equal(t, 3, resp.Dimensions)
+	})
+
+	t.Run("errors on dimensions mismatch", func(t *testing.T) {
+		s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			json.NewEncoder(w).Encode(embeddingAPIResponse{
+				Data: []embeddingAPIResponseData{{Index: 0, Embedding: []float32{1, 1}}},
+			})
+		}))
+		defer s.Close()
+
+		client := NewClient(s.Client(), &conftypes.EmbeddingsConfig{
+			Model:      "nomic-embed-text",
+			Endpoint:   s.URL,
+			Dimensions: 3,
+		})
+		_, err := client.GetQueryEmbedding(context.Background(), "a")
+		require.ErrorContains(t, err, "2 dimensions")
+	})
+}
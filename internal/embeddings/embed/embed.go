@@ -15,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/azureopenai"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/openai"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/openaicompatible"
 	"github.com/sourcegraph/sourcegraph/internal/embeddings/embed/client/sourcegraph"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/paths"
@@ -30,6 +31,8 @@ func NewEmbeddingsClient(config *conftypes.EmbeddingsConfig) (client.EmbeddingsC
 		return openai.NewClient(httpcli.ExternalClient, config), nil
 	case conftypes.EmbeddingsProviderNameAzureOpenAI:
 		return azureopenai.NewClient(httpcli.ExternalClient, config), nil
+	case conftypes.EmbeddingsProviderNameOpenAICompatible:
+		return openaicompatible.NewClient(httpcli.ExternalClient, config), nil
 	default:
 		return nil, errors.Newf("invalid provider %q", config.Provider)
 	}
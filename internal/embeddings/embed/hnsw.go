@@ -0,0 +1,22 @@
+package embed
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/embeddings"
+)
+
+// BuildHNSWIndex builds the HNSW graphs used to search the code and text indexes of the given
+// repo embedding index approximately. The graphs are only valid for the given index and must be
+// rebuilt whenever the index changes.
+func BuildHNSWIndex(index *embeddings.RepoEmbeddingIndex, config conftypes.EmbeddingsApproximateSearchConfig) *embeddings.RepoEmbeddingHNSWIndex {
+	params := embeddings.HNSWParameters{
+		M:              config.M,
+		EfConstruction: config.EfConstruction,
+	}
+
+	return &embeddings.RepoEmbeddingHNSWIndex{
+		Revision:  index.Revision,
+		CodeIndex: embeddings.BuildHNSWIndex(&index.CodeIndex, params),
+		TextIndex: embeddings.BuildHNSWIndex(&index.TextIndex, params),
+	}
+}
@@ -0,0 +1,308 @@
+package embeddings
+
+import (
+	"container/heap"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// HNSWParameters controls the construction of an HNSWIndex. Larger values produce a graph
+// with better recall at the cost of a longer build and a larger index.
+type HNSWParameters struct {
+	// M is the maximum number of neighbors of a row in each layer above the bottom layer.
+	// Rows in the bottom layer may have up to 2*M neighbors.
+	M int
+	// EfConstruction is the number of candidates considered when choosing the neighbors
+	// of a newly inserted row.
+	EfConstruction int
+}
+
+// HNSWIndex is a hierarchical navigable small world graph (https://arxiv.org/abs/1603.09320)
+// over the rows of an EmbeddingIndex. It stores row numbers only and must be searched along
+// with the index it was built from.
+type HNSWIndex struct {
+	// NumRows is the number of rows of the EmbeddingIndex the graph was built from. A graph
+	// is stale and must not be used when this differs from the number of rows of the index.
+	NumRows    int
+	EntryPoint int32
+	MaxLayer   int
+	// Neighbors holds the neighbors of each row, per layer: Neighbors[row][layer]. A row is
+	// present in layers 0 through len(Neighbors[row])-1.
+	Neighbors [][][]int32
+}
+
+// EstimateSize returns the approximate in-memory size of the graph in bytes.
+func (h *HNSWIndex) EstimateSize() uint64 {
+	if h == nil {
+		return 0
+	}
+
+	size := uint64(len(h.Neighbors)) * 24
+	for _, layers := range h.Neighbors {
+		size += uint64(len(layers)) * 24
+		for _, neighbors := range layers {
+			size += uint64(len(neighbors)) * 4
+		}
+	}
+	return size
+}
+
+// BuildHNSWIndex constructs an HNSW graph over the rows of the given index. The construction
+// is deterministic for a given index and set of parameters.
+func BuildHNSWIndex(index *EmbeddingIndex, params HNSWParameters) *HNSWIndex {
+	numRows := len(index.RowMetadata)
+	m := max(2, params.M)
+
+	b := &hnswBuilder{
+		index: index,
+		graph: &HNSWIndex{
+			NumRows:    numRows,
+			EntryPoint: -1,
+			Neighbors:  make([][][]int32, numRows),
+		},
+		m:              m,
+		efConstruction: max(m, params.EfConstruction),
+		levelMult:      1 / math.Log(float64(m)),
+		rng:            rand.New(rand.NewSource(int64(numRows))),
+	}
+	for i := 0; i < numRows; i++ {
+		b.insert(int32(i))
+	}
+
+	return b.graph
+}
+
+type hnswBuilder struct {
+	index          *EmbeddingIndex
+	graph          *HNSWIndex
+	m              int
+	efConstruction int
+	levelMult      float64
+	rng            *rand.Rand
+}
+
+func (b *hnswBuilder) insert(row int32) {
+	// Draw the top layer of the row from an exponentially decaying distribution.
+	layer := int(math.Floor(-math.Log(1-b.rng.Float64()) * b.levelMult))
+	b.graph.Neighbors[row] = make([][]int32, layer+1)
+
+	if b.graph.EntryPoint < 0 {
+		b.graph.EntryPoint = row
+		b.graph.MaxLayer = layer
+		return
+	}
+
+	query := b.index.Row(int(row))
+	entryPoint := b.graph.EntryPoint
+	for l := b.graph.MaxLayer; l > layer; l-- {
+		entryPoint = b.graph.greedyClosest(b.index, query, entryPoint, l)
+	}
+
+	for l := min(layer, b.graph.MaxLayer); l >= 0; l-- {
+		candidates := b.graph.searchLayer(b.index, query, []int32{entryPoint}, b.efConstruction, l)
+		neighbors := b.selectNeighbors(candidates, b.m)
+		b.graph.Neighbors[row][l] = neighbors
+
+		maxNeighbors := b.maxNeighbors(l)
+		for _, neighbor := range neighbors {
+			b.graph.Neighbors[neighbor][l] = append(b.graph.Neighbors[neighbor][l], row)
+			if len(b.graph.Neighbors[neighbor][l]) > maxNeighbors {
+				b.graph.Neighbors[neighbor][l] = b.shrink(neighbor, b.graph.Neighbors[neighbor][l], maxNeighbors)
+			}
+		}
+
+		entryPoint = candidates[0].row
+	}
+
+	if layer > b.graph.MaxLayer {
+		b.graph.EntryPoint = row
+		b.graph.MaxLayer = layer
+	}
+}
+
+func (b *hnswBuilder) maxNeighbors(layer int) int {
+	if layer == 0 {
+		return 2 * b.m
+	}
+	return b.m
+}
+
+// selectNeighbors picks up to m neighbors from the given candidates, ordered by descending
+// similarity. A candidate is preferred when it is more similar to the inserted row than to any
+// neighbor selected so far, which keeps edges towards distinct clusters. Remaining slots are
+// filled with the most similar candidates that were passed over.
+func (b *hnswBuilder) selectNeighbors(candidates []hnswCandidate, m int) []int32 {
+	if len(candidates) <= m {
+		neighbors := make([]int32, 0, len(candidates))
+		for _, candidate := range candidates {
+			neighbors = append(neighbors, candidate.row)
+		}
+		return neighbors
+	}
+
+	selected := make([]int32, 0, m)
+	var skipped []int32
+	for _, candidate := range candidates {
+		if len(selected) == m {
+			break
+		}
+
+		diverse := true
+		for _, neighbor := range selected {
+			if Dot(b.index.Row(int(candidate.row)), b.index.Row(int(neighbor))) > candidate.similarity {
+				diverse = false
+				break
+			}
+		}
+
+		if diverse {
+			selected = append(selected, candidate.row)
+		} else {
+			skipped = append(skipped, candidate.row)
+		}
+	}
+
+	for i := 0; len(selected) < m && i < len(skipped); i++ {
+		selected = append(selected, skipped[i])
+	}
+	return selected
+}
+
+// shrink reduces the neighbors of the given row to at most m.
+func (b *hnswBuilder) shrink(row int32, neighbors []int32, m int) []int32 {
+	query := b.index.Row(int(row))
+	candidates := make([]hnswCandidate, 0, len(neighbors))
+	for _, neighbor := range neighbors {
+		candidates = append(candidates, hnswCandidate{row: neighbor, similarity: Dot(query, b.index.Row(int(neighbor)))})
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].similarity > candidates[j].similarity })
+
+	return b.selectNeighbors(candidates, m)
+}
+
+// Search returns the rows of the given index most similar to the query, ordered by descending
+// similarity. At most max(numResults, ef) rows are returned; ef controls the size of the
+// candidate list and trades latency for recall.
+func (h *HNSWIndex) Search(index *EmbeddingIndex, query []int8, numResults, ef int) []int32 {
+	if h.EntryPoint < 0 || numResults <= 0 {
+		return nil
+	}
+
+	entryPoint := h.EntryPoint
+	for l := h.MaxLayer; l > 0; l-- {
+		entryPoint = h.greedyClosest(index, query, entryPoint, l)
+	}
+
+	candidates := h.searchLayer(index, query, []int32{entryPoint}, max(numResults, ef), 0)
+	rows := make([]int32, 0, len(candidates))
+	for _, candidate := range candidates {
+		rows = append(rows, candidate.row)
+	}
+	return rows
+}
+
+// greedyClosest walks the given layer from the entry point towards the row most similar
+// to the query and returns it.
+func (h *HNSWIndex) greedyClosest(index *EmbeddingIndex, query []int8, entryPoint int32, layer int) int32 {
+	closest := entryPoint
+	closestSimilarity := Dot(query, index.Row(int(closest)))
+
+	for changed := true; changed; {
+		changed = false
+		for _, neighbor := range h.Neighbors[closest][layer] {
+			if similarity := Dot(query, index.Row(int(neighbor))); similarity > closestSimilarity {
+				closest, closestSimilarity = neighbor, similarity
+				changed = true
+			}
+		}
+	}
+
+	return closest
+}
+
+// searchLayer performs a best-first search of the given layer and returns up to ef of the
+// visited rows most similar to the query, ordered by descending similarity.
+func (h *HNSWIndex) searchLayer(index *EmbeddingIndex, query []int8, entryPoints []int32, ef int, layer int) []hnswCandidate {
+	visited := make(map[int32]struct{}, ef*4)
+	candidates := &hnswCandidateHeap{}       // most similar first
+	results := &hnswCandidateHeap{min: true} // least similar first
+
+	for _, entryPoint := range entryPoints {
+		visited[entryPoint] = struct{}{}
+		candidate := hnswCandidate{row: entryPoint, similarity: Dot(query, index.Row(int(entryPoint)))}
+		heap.Push(candidates, candidate)
+		heap.Push(results, candidate)
+	}
+
+	for candidates.Len() > 0 {
+		current := heap.Pop(candidates).(hnswCandidate)
+		if results.Len() >= ef && current.similarity < results.Peek().similarity {
+			// Every remaining candidate is less similar than the worst result
+			break
+		}
+
+		for _, neighbor := range h.Neighbors[current.row][layer] {
+			if _, ok := visited[neighbor]; ok {
+				continue
+			}
+			visited[neighbor] = struct{}{}
+
+			similarity := Dot(query, index.Row(int(neighbor)))
+			if results.Len() < ef || similarity > results.Peek().similarity {
+				candidate := hnswCandidate{row: neighbor, similarity: similarity}
+				heap.Push(candidates, candidate)
+				heap.Push(results, candidate)
+				if results.Len() > ef {
+					heap.Pop(results)
+				}
+			}
+		}
+	}
+
+	sorted := make([]hnswCandidate, results.Len())
+	for i := len(sorted) - 1; i >= 0; i-- {
+		sorted[i] = heap.Pop(results).(hnswCandidate)
+	}
+	return sorted
+}
+
+type hnswCandidate struct {
+	row        int32
+	similarity int32
+}
+
+// hnswCandidateHeap is a max-heap of candidates by similarity, or a min-heap if min is set.
+type hnswCandidateHeap struct {
+	candidates []hnswCandidate
+	min        bool
+}
+
+func (h *hnswCandidateHeap) Len() int { return len(h.candidates) }
+
+func (h *hnswCandidateHeap) Less(i, j int) bool {
+	if h.min {
+		return h.candidates[i].similarity < h.candidates[j].similarity
+	}
+	return h.candidates[i].similarity > h.candidates[j].similarity
+}
+
+func (h *hnswCandidateHeap) Swap(i, j int) {
+	h.candidates[i], h.candidates[j] = h.candidates[j], h.candidates[i]
+}
+
+func (h *hnswCandidateHeap) Push(x any) {
+	h.candidates = append(h.candidates, x.(hnswCandidate))
+}
+
+func (h *hnswCandidateHeap) Pop() any {
+	old := h.candidates
+	n := len(old)
+	x := old[n-1]
+	h.candidates = old[0 : n-1]
+	return x
+}
+
+func (h *hnswCandidateHeap) Peek() hnswCandidate {
+	return h.candidates[0]
+}
@@ -0,0 +1,106 @@
+package embeddings
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func getRandomNormalizedEmbeddings(prng *rand.Rand, numRows, columnDimension int) []int8 {
+	embeddings := make([]int8, 0, numRows*columnDimension)
+	row := make([]float32, columnDimension)
+	for i := 0; i < numRows; i++ {
+		norm := 0.0
+		for j := range row {
+			row[j] = float32(prng.NormFloat64())
+			norm += float64(row[j] * row[j])
+		}
+		for j := range row {
+			row[j] /= float32(math.Sqrt(norm))
+		}
+		embeddings = append(embeddings, Quantize(row, nil)...)
+	}
+	return embeddings
+}
+
+func TestHNSWIndexRecall(t *testing.T) {
+	prng := rand.New(rand.NewSource(0))
+
+	numRows, numQueries, columnDimension, numResults := 2000, 20, 64, 10
+	index := &EmbeddingIndex{
+		Embeddings:      getRandomNormalizedEmbeddings(prng, numRows, columnDimension),
+		ColumnDimension: columnDimension,
+		RowMetadata:     make([]RepoEmbeddingRowMetadata, numRows),
+	}
+	queries := getRandomNormalizedEmbeddings(prng, numQueries, columnDimension)
+
+	graph := BuildHNSWIndex(index, HNSWParameters{M: 16, EfConstruction: 100})
+	require.Equal(t, numRows, graph.NumRows)
+
+	found := 0
+	for q := 0; q < numQueries; q++ {
+		query := queries[q*columnDimension : (q+1)*columnDimension]
+
+		expected := map[int]struct{}{}
+		for _, neighbor := range index.exactSimilaritySearch(query, numResults, WorkerOptions{NumWorkers: 1}, SearchOptions{})[:numResults] {
+			expected[neighbor.index] = struct{}{}
+		}
+
+		rows := graph.Search(index, query, numResults, 64)
+		require.Len(t, rows, 64)
+		for _, row := range rows[:numResults] {
+			if _, ok := expected[int(row)]; ok {
+				found++
+			}
+		}
+	}
+
+	if recall := float64(found) / float64(numQueries*numResults); recall < 0.9 {
+		t.Errorf("unexpected recall. want>=0.9 have=%.2f", recall)
+	}
+}
+
+func TestSimilaritySearchHNSW(t *testing.T) {
+	numRows, columnDimension := 16, 3
+	index := EmbeddingIndex{
+		Embeddings:      embeddings,
+		ColumnDimension: columnDimension,
+	}
+	for i := 0; i < numRows; i++ {
+		index.RowMetadata = append(index.RowMetadata, RepoEmbeddingRowMetadata{FileName: strconv.Itoa(i)})
+	}
+	index.HNSW = BuildHNSWIndex(&index, HNSWParameters{M: 4, EfConstruction: 16})
+
+	for q := 0; q < len(queries)/columnDimension; q++ {
+		query := queries[q*columnDimension : (q+1)*columnDimension]
+		require.True(t, index.canSearchApproximately(3, SearchOptions{EfSearch: 8}))
+
+		// The graph over such a small index is exact
+		results := index.SimilaritySearch(query, 3, WorkerOptions{}, SearchOptions{EfSearch: 8}, "", "")
+		resultRowNums := make([]int, len(results))
+		for i, r := range results {
+			resultRowNums[i], _ = strconv.Atoi(r.FileName)
+		}
+		require.Equal(t, ranks[q][:3], resultRowNums)
+	}
+
+	// Searches fall back to an exact scan when the graph is stale
+	stale := index
+	stale.HNSW = &HNSWIndex{NumRows: numRows - 1}
+	require.False(t, stale.canSearchApproximately(3, SearchOptions{EfSearch: 8}))
+	require.False(t, index.canSearchApproximately(3, SearchOptions{}))
+	require.False(t, index.canSearchApproximately(3, SearchOptions{EfSearch: numRows}))
+
+	// Searches return only the neighbors found when the graph finds fewer than requested
+	disconnected := index
+	disconnected.HNSW = &HNSWIndex{NumRows: numRows, Neighbors: make([][][]int32, numRows)}
+	for i := range disconnected.HNSW.Neighbors {
+		disconnected.HNSW.Neighbors[i] = [][]int32{nil}
+	}
+	results := disconnected.SimilaritySearch(queries[:columnDimension], 3, WorkerOptions{}, SearchOptions{EfSearch: 8}, "", "")
+	require.Len(t, results, 1)
+	require.Equal(t, "0", results[0].FileName)
+}
@@ -24,3 +24,9 @@ func GetRepoEmbeddingIndexNameDeprecated(repoName api.RepoName) RepoEmbeddingInd
 func GetRepoEmbeddingIndexName(repoID api.RepoID) RepoEmbeddingIndexName {
 	return RepoEmbeddingIndexName(fmt.Sprintf(`%d.embeddingindex`, repoID))
 }
+
+// GetRepoEmbeddingHNSWIndexName returns the name of the HNSW graphs stored next to the
+// embedding index of the given repository.
+func GetRepoEmbeddingHNSWIndexName(repoID api.RepoID) RepoEmbeddingIndexName {
+	return RepoEmbeddingIndexName(fmt.Sprintf(`%d.embeddingindex.hnsw`, repoID))
+}
@@ -111,6 +111,29 @@ func UpdateRepoEmbeddingIndex(
 	return UploadRepoEmbeddingIndex(ctx, uploadStore, key, previous)
 }
 
+// RepoEmbeddingHNSWIndex holds the HNSW graphs built over the code and text indexes of a
+// RepoEmbeddingIndex.
+type RepoEmbeddingHNSWIndex struct {
+	Revision  api.CommitID
+	CodeIndex *HNSWIndex
+	TextIndex *HNSWIndex
+}
+
+// Attach sets the graphs on the given index if they were built from the same revision and
+// number of rows. It returns false if the graphs are stale, in which case the index is
+// searched exhaustively.
+func (h *RepoEmbeddingHNSWIndex) Attach(index *RepoEmbeddingIndex) bool {
+	if h.Revision != index.Revision ||
+		h.CodeIndex == nil || h.CodeIndex.NumRows != len(index.CodeIndex.RowMetadata) ||
+		h.TextIndex == nil || h.TextIndex.NumRows != len(index.TextIndex.RowMetadata) {
+		return false
+	}
+
+	index.CodeIndex.HNSW = h.CodeIndex
+	index.TextIndex.HNSW = h.TextIndex
+	return true
+}
+
 // DownloadRepoEmbeddingIndex wraps downloadRepoEmbeddingIndex to support
 // embeddings named based on either repo ID or repo Name.
 //
@@ -97,6 +97,63 @@ func (index *EmbeddingIndex) SimilaritySearch(
 	numRows := len(index.RowMetadata)
 	// Cannot request more results than there are rows.
 	numResults = min(numRows, numResults)
+
+	var neighbors []nearestNeighbor
+	if index.canSearchApproximately(numResults, opts) {
+		neighbors = index.approximateSimilaritySearch(query, numResults, opts)
+	} else {
+		neighbors = index.exactSimilaritySearch(query, numResults, workerOptions, opts)
+	}
+
+	// Take top neighbors and return them as results. The approximate search may find fewer
+	// neighbors than requested, so the results are bounded by the neighbors found.
+	results := make([]EmbeddingSearchResult, min(numResults, len(neighbors)))
+
+	for idx := range results {
+		metadata := index.RowMetadata[neighbors[idx].index]
+		results[idx] = EmbeddingSearchResult{
+			RepoName:     repoName,
+			Revision:     revision,
+			FileName:     metadata.FileName,
+			StartLine:    metadata.StartLine,
+			EndLine:      metadata.EndLine,
+			ScoreDetails: neighbors[idx].scoreDetails,
+		}
+	}
+
+	return results
+}
+
+// canSearchApproximately returns true if the index has an up-to-date HNSW graph and the
+// approximate search is enabled and expected to be cheaper than an exact scan.
+func (index *EmbeddingIndex) canSearchApproximately(numResults int, opts SearchOptions) bool {
+	if index.HNSW == nil || opts.EfSearch <= 0 {
+		return false
+	}
+
+	numRows := len(index.RowMetadata)
+	return index.HNSW.NumRows == numRows && max(numResults, opts.EfSearch) < numRows
+}
+
+// approximateSimilaritySearch finds candidate rows using the HNSW graph of the index and
+// returns them ordered by descending score. Candidates are scored the same way as in the
+// exact search, so document ranks can still reorder the candidates.
+func (index *EmbeddingIndex) approximateSimilaritySearch(query []int8, numResults int, opts SearchOptions) []nearestNeighbor {
+	rows := index.HNSW.Search(index, query, numResults, opts.EfSearch)
+
+	neighbors := make([]nearestNeighbor, 0, len(rows))
+	for _, row := range rows {
+		neighbors = append(neighbors, nearestNeighbor{index: int(row), scoreDetails: index.score(query, int(row), opts)})
+	}
+	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].scoreDetails.Score > neighbors[j].scoreDetails.Score })
+
+	return neighbors
+}
+
+// exactSimilaritySearch scores every row of the index and returns the top rows ordered by
+// descending score.
+func (index *EmbeddingIndex) exactSimilaritySearch(query []int8, numResults int, workerOptions WorkerOptions, opts SearchOptions) []nearestNeighbor {
+	numRows := len(index.RowMetadata)
 	// We need at least 1 worker.
 	numWorkers := max(1, workerOptions.NumWorkers)
 
@@ -129,22 +186,7 @@ func (index *EmbeddingIndex) SimilaritySearch(
 	// And re-sort it according to the score (descending).
 	sort.Slice(neighbors, func(i, j int) bool { return neighbors[i].scoreDetails.Score > neighbors[j].scoreDetails.Score })
 
-	// Take top neighbors and return them as results.
-	results := make([]EmbeddingSearchResult, numResults)
-
-	for idx := 0; idx < min(numResults, len(neighbors)); idx++ {
-		metadata := index.RowMetadata[neighbors[idx].index]
-		results[idx] = EmbeddingSearchResult{
-			RepoName:     repoName,
-			Revision:     revision,
-			FileName:     metadata.FileName,
-			StartLine:    metadata.StartLine,
-			EndLine:      metadata.EndLine,
-			ScoreDetails: neighbors[idx].scoreDetails,
-		}
-	}
-
-	return results
+	return neighbors
 }
 
 func (index *EmbeddingIndex) partialSimilaritySearch(query []int8, numResults int, partialRows partialRows, opts SearchOptions) *nearestNeighborsHeap {
@@ -218,4 +260,8 @@ func max(a, b int) int {
 
 type SearchOptions struct {
 	UseDocumentRanks bool
+	// EfSearch is the number of candidates considered when searching an index with an HNSW
+	// graph. Larger values improve recall at the cost of latency. If zero, or if the index has
+	// no up-to-date graph, every row of the index is scanned.
+	EfSearch int
 }
@@ -14,6 +14,10 @@ type EmbeddingIndex struct {
 	ColumnDimension int
 	RowMetadata     []RepoEmbeddingRowMetadata
 	Ranks           []float32
+
+	// HNSW is an optional graph used to search the index approximately. It is stored
+	// separately from the index (see RepoEmbeddingHNSWIndex) and is not encoded with it.
+	HNSW *HNSWIndex
 }
 
 // Row returns the embeddings for the nth row in the index
@@ -22,7 +26,7 @@ func (index *EmbeddingIndex) Row(n int) []int8 {
 }
 
 func (index *EmbeddingIndex) EstimateSize() uint64 {
-	return uint64(len(index.Embeddings) + len(index.RowMetadata)*(16+8+8) + len(index.Ranks)*4)
+	return uint64(len(index.Embeddings)+len(index.RowMetadata)*(16+8+8)+len(index.Ranks)*4) + index.HNSW.EstimateSize()
 }
 
 // Validate will return a non-nil error if the fields on index break an
@@ -41,6 +45,8 @@ func (index *EmbeddingIndex) filter(set map[string]struct{}, ranks types.RepoPat
 	// We can reset Ranks here because we are anyway going to update them based on
 	// "ranks".
 	index.Ranks = make([]float32, 0, len(index.RowMetadata))
+	// Rows are moved, so the graph no longer matches the index.
+	index.HNSW = nil
 
 	cursor := 0
 	for i, s := range index.RowMetadata {
@@ -64,6 +70,7 @@ func (index *EmbeddingIndex) filter(set map[string]struct{}, ranks types.RepoPat
 }
 
 func (index *EmbeddingIndex) append(other EmbeddingIndex) {
+	index.HNSW = nil
 	index.RowMetadata = append(index.RowMetadata, other.RowMetadata...)
 	index.Ranks = append(index.Ranks, other.Ranks...)
 	index.Embeddings = append(index.Embeddings, other.Embeddings...)
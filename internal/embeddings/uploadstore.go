@@ -29,15 +29,21 @@ type EmbeddingsUploadStoreConfig struct {
 	GCSProjectID               string
 	GCSCredentialsFile         string
 	GCSCredentialsFileContents string
+
+	FilesystemPath string
+
+	AzureAccountName string
+	AzureAccountKey  string
+	AzureEndpoint    string
 }
 
 func (c *EmbeddingsUploadStoreConfig) Load() {
-	c.Backend = strings.ToLower(c.Get("EMBEDDINGS_UPLOAD_BACKEND", "blobstore", "The target file service for embeddings. S3, GCS, and Blobstore are supported."))
+	c.Backend = strings.ToLower(c.Get("EMBEDDINGS_UPLOAD_BACKEND", "blobstore", "The target file service for embeddings. S3, GCS, Azure, Filesystem, and Blobstore are supported."))
 	c.ManageBucket = c.GetBool("EMBEDDINGS_UPLOAD_MANAGE_BUCKET", "false", "Whether or not the client should manage the target bucket configuration.")
 	c.Bucket = c.Get("EMBEDDINGS_UPLOAD_BUCKET", "embeddings", "The name of the bucket to store embeddings in.")
 
-	if c.Backend != "blobstore" && c.Backend != "s3" && c.Backend != "gcs" {
-		c.AddError(errors.Errorf("invalid backend %q for EMBEDDINGS_UPLOAD_BACKEND: must be S3, GCS, or Blobstore", c.Backend))
+	if c.Backend != "blobstore" && c.Backend != "s3" && c.Backend != "gcs" && c.Backend != "azure" && c.Backend != "filesystem" {
+		c.AddError(errors.Errorf("invalid backend %q for EMBEDDINGS_UPLOAD_BACKEND: must be S3, GCS, Azure, Filesystem, or Blobstore", c.Backend))
 	}
 
 	if c.Backend == "blobstore" || c.Backend == "s3" {
@@ -55,6 +61,12 @@ func (c *EmbeddingsUploadStoreConfig) Load() {
 		c.GCSProjectID = c.Get("EMBEDDINGS_UPLOAD_GCP_PROJECT_ID", "", "The project containing the GCS bucket.")
 		c.GCSCredentialsFile = c.GetOptional("EMBEDDINGS_UPLOAD_GOOGLE_APPLICATION_CREDENTIALS_FILE", "The path to a service account key file with access to GCS.")
 		c.GCSCredentialsFileContents = c.GetOptional("EMBEDDINGS_UPLOAD_GOOGLE_APPLICATION_CREDENTIALS_FILE_CONTENT", "The contents of a service account key file with access to GCS.")
+	} else if c.Backend == "azure" {
+		c.AzureAccountName = c.Get("EMBEDDINGS_UPLOAD_AZURE_ACCOUNT_NAME", "", "The name of the Azure storage account.")
+		c.AzureAccountKey = c.Get("EMBEDDINGS_UPLOAD_AZURE_ACCOUNT_KEY", "", "The shared key of the Azure storage account.")
+		c.AzureEndpoint = c.GetOptional("EMBEDDINGS_UPLOAD_AZURE_ENDPOINT", "The Azure Blob Storage endpoint, e.g. for the Azurite emulator. Defaults to https://<account name>.blob.core.windows.net.")
+	} else if c.Backend == "filesystem" {
+		c.FilesystemPath = c.Get("EMBEDDINGS_UPLOAD_FILESYSTEM_PATH", "", "The directory in which the bucket is stored. It must be shared by all services accessing embeddings.")
 	}
 }
 
@@ -78,6 +90,14 @@ func NewEmbeddingsUploadStore(ctx context.Context, observationCtx *observation.C
 			CredentialsFile:         conf.GCSCredentialsFile,
 			CredentialsFileContents: conf.GCSCredentialsFileContents,
 		},
+		Filesystem: uploadstore.FilesystemConfig{
+			Path: conf.FilesystemPath,
+		},
+		Azure: uploadstore.AzureConfig{
+			AccountName: conf.AzureAccountName,
+			AccountKey:  conf.AzureAccountKey,
+			Endpoint:    conf.AzureEndpoint,
+		},
 	}
 	return uploadstore.CreateLazy(ctx, c, uploadstore.NewOperations(observationCtx, "embeddings", "uploadstore"))
 }
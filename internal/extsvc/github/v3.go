@@ -880,6 +880,46 @@ func (c *V3Client) UpdateRef(ctx context.Context, owner, repo, ref, commit strin
 	return &updatedRef, nil
 }
 
+// Issue is a GitHub issue, as returned by the REST API.
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+	State   string `json:"state"`
+}
+
+// CreateIssue opens a new issue in the given repository.
+//
+// API docs: https://docs.github.com/en/rest/issues/issues#create-an-issue
+func (c *V3Client) CreateIssue(ctx context.Context, owner, repo, title, body string) (*Issue, error) {
+	payload := struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+	}{Title: title, Body: body}
+
+	var issue Issue
+	if _, err := c.post(ctx, "repos/"+owner+"/"+repo+"/issues", payload, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// CreateIssueComment adds a comment to the given issue. GitHub treats pull
+// requests as issues for the purposes of commenting, so number may also refer
+// to a pull request.
+//
+// API docs: https://docs.github.com/en/rest/issues/comments#create-an-issue-comment
+func (c *V3Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) error {
+	payload := struct {
+		Body string `json:"body"`
+	}{Body: body}
+
+	if _, err := c.post(ctx, fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, repo, number), payload, &struct{}{}); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetAppInstallation gets information of a GitHub App installation.
 //
 // API docs: https://docs.github.com/en/rest/reference/apps#get-an-installation-for-the-authenticated-app
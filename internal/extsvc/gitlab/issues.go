@@ -0,0 +1,85 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// Issue is a GitLab issue.
+type Issue struct {
+	ID     ID     `json:"id"`
+	IID    ID     `json:"iid"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	WebURL string `json:"web_url"`
+}
+
+// CreateIssue opens a new issue in the project with the given full path, such
+// as "namespace1/namespace2/name".
+func (c *Client) CreateIssue(ctx context.Context, pathWithNamespace, title, description string) (*Issue, error) {
+	if MockCreateIssue != nil {
+		return MockCreateIssue(c, ctx, pathWithNamespace, title, description)
+	}
+
+	payload := struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}{
+		Title:       title,
+		Description: description,
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshalling payload")
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%s/issues", url.PathEscape(pathWithNamespace)), bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "creating request to create an issue")
+	}
+
+	var issue Issue
+	if _, _, err := c.do(ctx, req, &issue); err != nil {
+		return nil, errors.Wrap(err, "sending request to create an issue")
+	}
+
+	return &issue, nil
+}
+
+// CreateIssueNote adds a comment to the issue with the given project-scoped ID
+// in the project with the given full path.
+func (c *Client) CreateIssueNote(ctx context.Context, pathWithNamespace string, iid ID, body string) error {
+	if MockCreateIssueNote != nil {
+		return MockCreateIssueNote(c, ctx, pathWithNamespace, iid, body)
+	}
+
+	var payload = struct {
+		Body string `json:"body"`
+	}{
+		Body: body,
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "marshalling payload")
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("projects/%s/issues/%d/notes", url.PathEscape(pathWithNamespace), iid), bytes.NewBuffer(data))
+	if err != nil {
+		return errors.Wrap(err, "creating request to comment on an issue")
+	}
+
+	var resp struct {
+		ID int32 `json:"id"`
+	}
+	if _, _, err := c.do(ctx, req, &resp); err != nil {
+		return errors.Wrap(err, "sending request to comment on an issue")
+	}
+
+	return nil
+}
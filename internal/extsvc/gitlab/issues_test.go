@@ -0,0 +1,67 @@
+package gitlab
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCreateIssue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"id": 10, "iid": 3, "title": "Code monitor triggered", "state": "opened", "web_url": "https://example.com/a/b/-/issues/3"}`,
+		}
+
+		issue, err := client.CreateIssue(ctx, "a/b", "Code monitor triggered", "")
+		if err != nil {
+			t.Fatalf("unexpected non-nil error: %+v", err)
+		}
+
+		want := &Issue{ID: 10, IID: 3, Title: "Code monitor triggered", State: "opened", WebURL: "https://example.com/a/b/-/issues/3"}
+		if diff := cmp.Diff(want, issue); diff != "" {
+			t.Errorf("unexpected issue: %s", diff)
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusForbidden}
+
+		issue, err := client.CreateIssue(ctx, "a/b", "Code monitor triggered", "")
+		if issue != nil {
+			t.Errorf("unexpected non-nil issue: %+v", issue)
+		}
+		if err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+}
+
+func TestCreateIssueNote(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPResponseBody{
+			responseBody: `{"id": 42}`,
+		}
+
+		if err := client.CreateIssueNote(ctx, "a/b", 3, "Triggered again"); err != nil {
+			t.Errorf("unexpected non-nil error: %+v", err)
+		}
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		client := newTestClient(t)
+		client.httpClient = &mockHTTPEmptyResponse{http.StatusNotFound}
+
+		if err := client.CreateIssueNote(ctx, "a/b", 3, "Triggered again"); err == nil {
+			t.Error("unexpected nil error")
+		}
+	})
+}
@@ -55,3 +55,10 @@ var MockCreateMergeRequestNote func(c *Client, ctx context.Context, project *Pro
 
 // MockGetVersion, if non-nil, will be called instead of Client.GetVersion
 var MockGetVersion func(ctx context.Context) (string, error)
+
+// MockCreateIssue, if non-nil, will be called instead of Client.CreateIssue
+var MockCreateIssue func(c *Client, ctx context.Context, pathWithNamespace, title, description string) (*Issue, error)
+
+// MockCreateIssueNote, if non-nil, will be called instead of
+// Client.CreateIssueNote
+var MockCreateIssueNote func(c *Client, ctx context.Context, pathWithNamespace string, iid ID, body string) error
@@ -12,6 +12,9 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
 
 	"github.com/sourcegraph/log"
 	"github.com/sourcegraph/log/logtest"
@@ -30,6 +33,11 @@ var (
 		Name: "src_gitserver_addr_for_repo_invoked",
 		Help: "Number of times gitserver.AddrForRepo was invoked",
 	}, []string{"user_agent"})
+
+	readFailovers = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_gitserver_read_failovers_total",
+		Help: "Number of reads sent to a replica because the primary gitserver of the repository was unavailable",
+	})
 )
 
 // NewGitserverAddresses fetches the current set of gitserver addresses
@@ -40,6 +48,7 @@ func NewGitserverAddresses(cfg *conf.Unified) GitserverAddresses {
 	}
 	if cfg.ExperimentalFeatures != nil {
 		addrs.PinnedServers = cfg.ExperimentalFeatures.GitServerPinnedRepos
+		addrs.ReplicationFactor = cfg.ExperimentalFeatures.GitServerReplicationFactor
 	}
 	return addrs
 }
@@ -108,6 +117,11 @@ func (c *testGitserverConns) AddrForRepo(ctx context.Context, userAgent string,
 	return c.conns.AddrForRepo(ctx, userAgent, repo)
 }
 
+// AddrsForRepo returns the addresses of the gitservers the given repo is cloned on.
+func (c *testGitserverConns) AddrsForRepo(ctx context.Context, userAgent string, repo api.RepoName) []string {
+	return c.conns.AddrsForRepo(ctx, userAgent, repo)
+}
+
 // Addresses returns the current list of gitserver addresses.
 func (c *testGitserverConns) Addresses() []AddressWithClient {
 	return c.testAddresses
@@ -132,6 +146,17 @@ func (c *testGitserverConns) ClientForRepo(ctx context.Context, userAgent string
 	return c.clientFunc(conn), nil
 }
 
+// ReadClientForRepo returns a client for the given repo name. Test clients don't
+// fail over to replicas.
+func (c *testGitserverConns) ReadClientForRepo(ctx context.Context, userAgent string, repo api.RepoName) (proto.GitserverServiceClient, error) {
+	conn, err := c.conns.ConnForRepo(ctx, userAgent, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.clientFunc(conn), nil
+}
+
 type testConnAndErr struct {
 	address    string
 	conn       *grpc.ClientConn
@@ -160,9 +185,14 @@ type GitserverAddresses struct {
 	// ensures that, even if the number of gitservers changes, these repos will
 	// not be moved.
 	PinnedServers map[string]string
+
+	// The number of gitserver instances each repo is cloned on. Values below 1
+	// mean that repos are only cloned on their primary instance.
+	ReplicationFactor int
 }
 
 // AddrForRepo returns the gitserver address to use for the given repo name.
+// This is the primary instance of the repo, which all writes go to.
 func (g *GitserverAddresses) AddrForRepo(ctx context.Context, userAgent string, repoName api.RepoName) string {
 	addrForRepoInvoked.WithLabelValues(userAgent).Inc()
 
@@ -175,6 +205,32 @@ func (g *GitserverAddresses) AddrForRepo(ctx context.Context, userAgent string,
 	return addrForKey(name, g.Addresses)
 }
 
+// AddrsForRepo returns the addresses of the gitservers the given repo is
+// cloned on. The first address is the primary returned by AddrForRepo, the
+// replicas are the instances following it in the list of addresses.
+func (g *GitserverAddresses) AddrsForRepo(ctx context.Context, userAgent string, repoName api.RepoName) []string {
+	return replicaAddrs(g.AddrForRepo(ctx, userAgent, repoName), g.Addresses, g.ReplicationFactor)
+}
+
+// replicaAddrs returns primary followed by the n-1 addresses that follow it in
+// addrs, wrapping around at the end.
+func replicaAddrs(primary string, addrs []string, n int) []string {
+	if n > len(addrs) {
+		n = len(addrs)
+	}
+
+	result := []string{primary}
+	start := slices.Index(addrs, primary)
+	if start < 0 {
+		// The repo is pinned to an instance that isn't in the list.
+		return result
+	}
+	for i := 1; i < n; i++ {
+		result = append(result, addrs[(start+i)%len(addrs)])
+	}
+	return result
+}
+
 // addrForKey returns the gitserver address to use for the given string key,
 // which is hashed for sharding purposes.
 func addrForKey(key string, addrs []string) string {
@@ -190,6 +246,7 @@ type GitserverConns struct {
 	grpcConns map[string]connAndErr
 }
 
+// ConnForRepo returns the connection to the primary gitserver of the repo.
 func (g *GitserverConns) ConnForRepo(ctx context.Context, userAgent string, repo api.RepoName) (*grpc.ClientConn, error) {
 	addr := g.AddrForRepo(ctx, userAgent, repo)
 	ce, ok := g.grpcConns[addr]
@@ -199,6 +256,73 @@ func (g *GitserverConns) ConnForRepo(ctx context.Context, userAgent string, repo
 	return ce.conn, ce.err
 }
 
+// ReadConnForRepo returns a connection to the healthy gitservers the repo is
+// cloned on, so that reads fail over to a replica while the primary is
+// unavailable. Requests go to the first healthy instance, and are retried
+// against the next one if it turns out to be unavailable. If no instance is
+// healthy, requests only go to the primary.
+func (g *GitserverConns) ReadConnForRepo(ctx context.Context, userAgent string, repo api.RepoName) (grpc.ClientConnInterface, error) {
+	var conns failoverConn
+	for i, addr := range g.AddrsForRepo(ctx, userAgent, repo) {
+		ce, ok := g.grpcConns[addr]
+		if !ok || ce.err != nil || !connHealthy(ce.conn) {
+			continue
+		}
+		if i > 0 && len(conns) == 0 {
+			readFailovers.Inc()
+		}
+		conns = append(conns, ce.conn)
+	}
+	if len(conns) == 0 {
+		return g.ConnForRepo(ctx, userAgent, repo)
+	}
+	return conns, nil
+}
+
+// failoverConn sends requests to the first of its connections, and retries
+// them against the next one while they fail with codes.Unavailable. Streams are
+// only retried if they can't be started.
+type failoverConn []*grpc.ClientConn
+
+var _ grpc.ClientConnInterface = failoverConn{}
+
+func (f failoverConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) (err error) {
+	for i, conn := range f {
+		if i > 0 {
+			readFailovers.Inc()
+		}
+		err = conn.Invoke(ctx, method, args, reply, opts...)
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+	}
+	return err
+}
+
+func (f failoverConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (stream grpc.ClientStream, err error) {
+	for i, conn := range f {
+		if i > 0 {
+			readFailovers.Inc()
+		}
+		stream, err = conn.NewStream(ctx, desc, method, opts...)
+		if status.Code(err) != codes.Unavailable {
+			return stream, err
+		}
+	}
+	return stream, err
+}
+
+// connHealthy returns false if the connection is failing or shut down. Idle
+// connections are considered healthy, since they connect on first use.
+func connHealthy(conn *grpc.ClientConn) bool {
+	switch conn.GetState() {
+	case connectivity.TransientFailure, connectivity.Shutdown:
+		return false
+	default:
+		return true
+	}
+}
+
 // AddressWithClient is a gitserver address with a client.
 type AddressWithClient interface {
 	Address() string                                   // returns the address of the endpoint that this GRPC client is targeting
@@ -228,6 +352,10 @@ func (a *atomicGitServerConns) AddrForRepo(ctx context.Context, userAgent string
 	return a.get().AddrForRepo(ctx, userAgent, repo)
 }
 
+func (a *atomicGitServerConns) AddrsForRepo(ctx context.Context, userAgent string, repo api.RepoName) []string {
+	return a.get().AddrsForRepo(ctx, userAgent, repo)
+}
+
 func (a *atomicGitServerConns) ClientForRepo(ctx context.Context, userAgent string, repo api.RepoName) (proto.GitserverServiceClient, error) {
 	conn, err := a.get().ConnForRepo(ctx, userAgent, repo)
 	if err != nil {
@@ -236,6 +364,14 @@ func (a *atomicGitServerConns) ClientForRepo(ctx context.Context, userAgent stri
 	return proto.NewGitserverServiceClient(conn), nil
 }
 
+func (a *atomicGitServerConns) ReadClientForRepo(ctx context.Context, userAgent string, repo api.RepoName) (proto.GitserverServiceClient, error) {
+	conn, err := a.get().ReadConnForRepo(ctx, userAgent, repo)
+	if err != nil {
+		return nil, err
+	}
+	return proto.NewGitserverServiceClient(conn), nil
+}
+
 func (a *atomicGitServerConns) Addresses() []AddressWithClient {
 	conns := a.get()
 	addrs := make([]AddressWithClient, 0, len(conns.Addresses))
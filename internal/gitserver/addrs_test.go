@@ -4,6 +4,8 @@ import (
 	"context"
 	"testing"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/sourcegraph/sourcegraph/internal/api"
 )
 
@@ -54,3 +56,63 @@ func TestAddrForRepo(t *testing.T) {
 		}
 	})
 }
+
+func TestAddrsForRepo(t *testing.T) {
+	ga := GitserverAddresses{
+		Addresses: []string{"gitserver-1", "gitserver-2", "gitserver-3"},
+		PinnedServers: map[string]string{
+			"repo2": "gitserver-1",
+			"repo3": "gitserver-unknown",
+		},
+		ReplicationFactor: 2,
+	}
+	ctx := context.Background()
+
+	testCases := []struct {
+		name              string
+		repo              api.RepoName
+		replicationFactor int
+		want              []string
+	}{
+		{
+			name:              "no replication",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 0,
+			want:              []string{"gitserver-3"},
+		},
+		{
+			name:              "replicas wrap around",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 2,
+			want:              []string{"gitserver-3", "gitserver-1"},
+		},
+		{
+			name:              "capped at number of gitservers",
+			repo:              api.RepoName("repo1"),
+			replicationFactor: 5,
+			want:              []string{"gitserver-3", "gitserver-1", "gitserver-2"},
+		},
+		{
+			name:              "pinned repo",
+			repo:              api.RepoName("repo2"),
+			replicationFactor: 2,
+			want:              []string{"gitserver-1", "gitserver-2"},
+		},
+		{
+			name:              "pinned to unknown gitserver",
+			repo:              api.RepoName("repo3"),
+			replicationFactor: 2,
+			want:              []string{"gitserver-unknown"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ga.ReplicationFactor = tc.replicationFactor
+			got := ga.AddrsForRepo(ctx, "gitserver", tc.repo)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("Want %q, got %q", tc.want, got)
+			}
+		})
+	}
+}
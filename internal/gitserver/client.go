@@ -53,8 +53,15 @@ var (
 	defaultDoer, _ = clientFactory.Doer()
 	// defaultLimiter limits concurrent HTTP requests per running process to gitserver.
 	defaultLimiter = limiter.New(500)
+	// replicaLimiter limits concurrent background requests per running process to
+	// gitserver replicas.
+	replicaLimiter = semaphore.NewWeighted(64)
 )
 
+// replicaRequestTimeout bounds how long a background request to a gitserver
+// replica may run.
+const replicaRequestTimeout = 2 * time.Minute
+
 var ClientMocks, emptyClientMocks struct {
 	GetObject               func(repo api.RepoName, objectName string) (*gitdomain.GitObject, error)
 	Archive                 func(ctx context.Context, repo api.RepoName, opt ArchiveOptions) (_ io.ReadCloser, err error)
@@ -76,10 +83,16 @@ var _ Client = &clientImplementor{}
 // ClientSource is a source of gitserver.Client instances.
 // It allows for mocking out the client source in tests.
 type ClientSource interface {
-	// ClientForRepo returns a Client for the given repo.
+	// ClientForRepo returns a Client for the primary gitserver of the given repo.
 	ClientForRepo(ctx context.Context, userAgent string, repo api.RepoName) (proto.GitserverServiceClient, error)
-	// AddrForRepo returns the address of the gitserver for the given repo.
+	// ReadClientForRepo returns a Client for the first healthy gitserver the given
+	// repo is cloned on. It must only be used for requests that don't modify the repo.
+	ReadClientForRepo(ctx context.Context, userAgent string, repo api.RepoName) (proto.GitserverServiceClient, error)
+	// AddrForRepo returns the address of the primary gitserver for the given repo.
 	AddrForRepo(ctx context.Context, userAgent string, repo api.RepoName) string
+	// AddrsForRepo returns the addresses of all gitservers the given repo is cloned
+	// on, starting with its primary.
+	AddrsForRepo(ctx context.Context, userAgent string, repo api.RepoName) []string
 	// Address the current list of gitserver addresses.
 	Addresses() []AddressWithClient
 	// GetAddressWithClient returns the address and client for a gitserver instance.
@@ -383,6 +396,12 @@ type Client interface {
 	// file is read. (If you just need to check a file's existence, use Stat, not ReadFile.)
 	ReadFile(ctx context.Context, repo api.RepoName, commit api.CommitID, name string) ([]byte, error)
 
+	// BatchReadFile reads the named files at commit and calls fn with the
+	// contents of each of them, in the order they were given. If a file does not
+	// exist, fn is called with an error for which os.IsNotExist is true. If fn
+	// returns an error, BatchReadFile stops and returns that error.
+	BatchReadFile(ctx context.Context, repo api.RepoName, commit api.CommitID, names []string, fn func(name string, contents []byte, err error) error) error
+
 	// BranchesContaining returns a map from branch names to branch tip hashes for
 	// each branch containing the given commit.
 	BranchesContaining(ctx context.Context, repo api.RepoName, commit api.CommitID) ([]string, error)
@@ -590,6 +609,12 @@ func (c *clientImplementor) ClientForRepo(ctx context.Context, repo api.RepoName
 	return c.clientSource.ClientForRepo(ctx, c.userAgent, repo)
 }
 
+// readClientForRepo returns a client for requests that only read from the repo,
+// which fail over to a replica while the primary gitserver is unavailable.
+func (c *clientImplementor) readClientForRepo(ctx context.Context, repo api.RepoName) (proto.GitserverServiceClient, error) {
+	return c.clientSource.ReadClientForRepo(ctx, c.userAgent, repo)
+}
+
 // ArchiveOptions contains options for the Archive func.
 type ArchiveOptions struct {
 	Treeish   string               // the tree or commit to produce an archive for
@@ -724,7 +749,7 @@ func (c *RemoteGitCommand) sendExec(ctx context.Context) (_ io.ReadCloser, err e
 	}
 
 	if conf.IsGRPCEnabled(ctx) {
-		client, err := c.execer.ClientForRepo(ctx, repoName)
+		client, err := c.execer.readClientForRepo(ctx, repoName)
 		if err != nil {
 			return nil, err
 		}
@@ -839,6 +864,9 @@ func convertGRPCErrorToGitDomainError(err error) error {
 				CloneInProgress: payload.CloneInProgress,
 				CloneProgress:   payload.CloneProgress,
 			}
+
+		case *proto.FileNotFoundPayload:
+			return &os.PathError{Op: "open", Path: payload.Path, Err: os.ErrNotExist}
 		}
 	}
 
@@ -883,7 +911,7 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 	repoName := protocol.NormalizeRepo(args.Repo)
 
 	if conf.IsGRPCEnabled(ctx) {
-		client, err := c.ClientForRepo(ctx, repoName)
+		client, err := c.readClientForRepo(ctx, repoName)
 		if err != nil {
 			return false, err
 		}
@@ -1133,7 +1161,7 @@ func (c *clientImplementor) BatchLog(ctx context.Context, opts BatchLogOptions,
 			return err
 		}
 
-		client, err := c.ClientForRepo(ctx, repoCommits[0].Repo)
+		client, err := c.readClientForRepo(ctx, repoCommits[0].Repo)
 		if err != nil {
 			err = errors.Wrapf(err, "getting gRPC client for repository %q", repoCommits[0].Repo)
 		}
@@ -1199,6 +1227,14 @@ func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.Repo
 			return nil, err
 		}
 
+		c.forEachReplica(ctx, repo, "RepoUpdate", func(ctx context.Context, client proto.GitserverServiceClient) error {
+			resp, err := client.RepoUpdate(ctx, req.ToProto())
+			if err == nil && resp.GetError() != "" {
+				err = errors.New(resp.GetError())
+			}
+			return err
+		})
+
 		var info protocol.RepoUpdateResponse
 		info.FromProto(resp)
 
@@ -1486,13 +1522,64 @@ func (c *clientImplementor) Remove(ctx context.Context, repo api.RepoName) error
 		_, err = client.RepoDelete(ctx, &proto.RepoDeleteRequest{
 			Repo: string(repo),
 		})
-		return err
+		if err != nil {
+			return err
+		}
+
+		c.forEachReplica(ctx, undeletedName, "RepoDelete", func(ctx context.Context, client proto.GitserverServiceClient) error {
+			_, err := client.RepoDelete(ctx, &proto.RepoDeleteRequest{
+				Repo: string(repo),
+			})
+			return err
+		})
+		return nil
 	}
 
 	addr := c.AddrForRepo(ctx, undeletedName)
 	return c.removeFrom(ctx, undeletedName, addr)
 }
 
+// forEachReplica calls f in the background with a client for each replica of the
+// repo, excluding its primary, so that callers only wait for the primary. Requests
+// to replicas are best effort: they outlive ctx, but are bounded by
+// replicaRequestTimeout and replicaLimiter. Requests over the limit are dropped, and
+// errors are logged instead of returned, since the replica reconciler of gitserver
+// heals replicas that missed a request.
+func (c *clientImplementor) forEachReplica(ctx context.Context, repo api.RepoName, op string, f func(context.Context, proto.GitserverServiceClient) error) {
+	addrs := c.clientSource.AddrsForRepo(ctx, c.userAgent, repo)
+	for _, addr := range addrs[1:] {
+		logFields := []sglog.Field{
+			sglog.String("op", op),
+			sglog.String("repo", string(repo)),
+			sglog.String("addr", addr),
+		}
+
+		ac := c.clientSource.GetAddressWithClient(addr)
+		if ac == nil {
+			continue
+		}
+		if !replicaLimiter.TryAcquire(1) {
+			c.logger.Warn("too many concurrent requests to gitserver replicas, skipping replica", logFields...)
+			continue
+		}
+
+		go func() {
+			defer replicaLimiter.Release(1)
+
+			ctx, cancel := context.WithTimeout(context.Background(), replicaRequestTimeout)
+			defer cancel()
+
+			client, err := ac.GRPCClient()
+			if err == nil {
+				err = f(ctx, client)
+			}
+			if err != nil {
+				c.logger.Warn("sending request to gitserver replica", append(logFields, sglog.Error(err))...)
+			}
+		}()
+	}
+}
+
 func (c *clientImplementor) removeFrom(ctx context.Context, repo api.RepoName, from string) error {
 	b, err := json.Marshal(&protocol.RepoDeleteRequest{
 		Repo: repo,
@@ -2145,7 +2232,7 @@ func (c *clientImplementor) GetObject(ctx context.Context, repo api.RepoName, ob
 		ObjectName: objectName,
 	}
 	if conf.IsGRPCEnabled(ctx) {
-		client, err := c.ClientForRepo(ctx, req.Repo)
+		client, err := c.readClientForRepo(ctx, req.Repo)
 		if err != nil {
 			return nil, err
 		}
@@ -25,6 +25,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/sourcegraph/go-diff/diff"
 
@@ -36,6 +37,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/fileutil"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/streamio"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
@@ -504,31 +506,43 @@ func (c *clientImplementor) lStat(ctx context.Context, repo api.RepoName, commit
 
 	path = filepath.Clean(rel(path))
 
-	if path == "." {
-		// Special case root, which is not returned by `git ls-tree`.
-		obj, err := c.GetObject(ctx, repo, string(commit)+"^{tree}")
+	var fi fs.FileInfo
+	if conf.IsGRPCEnabled(ctx) {
+		fi, err = c.statGRPC(ctx, repo, commit, path)
 		if err != nil {
 			return nil, err
 		}
-		return &fileutil.FileInfo{Mode_: os.ModeDir, Sys_: objectInfo(obj.ID)}, nil
-	}
+		if path == "." {
+			return fi, nil
+		}
+	} else {
+		if path == "." {
+			// Special case root, which is not returned by `git ls-tree`.
+			obj, err := c.GetObject(ctx, repo, string(commit)+"^{tree}")
+			if err != nil {
+				return nil, err
+			}
+			return &fileutil.FileInfo{Mode_: os.ModeDir, Sys_: objectInfo(obj.ID)}, nil
+		}
 
-	fis, err := c.lsTree(ctx, repo, commit, path, false)
-	if err != nil {
-		return nil, err
-	}
-	if len(fis) == 0 {
-		return nil, &os.PathError{Op: "ls-tree", Path: path, Err: os.ErrNotExist}
+		fis, err := c.lsTree(ctx, repo, commit, path, false)
+		if err != nil {
+			return nil, err
+		}
+		if len(fis) == 0 {
+			return nil, &os.PathError{Op: "ls-tree", Path: path, Err: os.ErrNotExist}
+		}
+		fi = fis[0]
 	}
 
 	if !authz.SubRepoEnabled(c.subRepoPermsChecker) {
-		return fis[0], nil
+		return fi, nil
 	}
 	// Applying sub-repo permissions
 	a := actor.FromContext(ctx)
-	include, filteringErr := authz.FilterActorFileInfo(ctx, c.subRepoPermsChecker, a, repo, fis[0])
+	include, filteringErr := authz.FilterActorFileInfo(ctx, c.subRepoPermsChecker, a, repo, fi)
 	if include && filteringErr == nil {
-		return fis[0], nil
+		return fi, nil
 	} else {
 		if filteringErr != nil {
 			err = errors.Wrap(filteringErr, "filtering paths")
@@ -564,6 +578,10 @@ func (c *clientImplementor) lsTreeUncached(ctx context.Context, repo api.RepoNam
 		return nil, err
 	}
 
+	if conf.IsGRPCEnabled(ctx) {
+		return c.readDirGRPC(ctx, repo, commit, path, recurse)
+	}
+
 	args := []string{
 		"ls-tree",
 		"--long", // show size
@@ -693,6 +711,93 @@ func (c *clientImplementor) lsTreeUncached(ctx context.Context, repo api.RepoNam
 	return fis, nil
 }
 
+// statGRPC returns a FileInfo describing path at commit using the Stat RPC.
+func (c *clientImplementor) statGRPC(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) (fs.FileInfo, error) {
+	client, err := c.readClientForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Stat(ctx, &proto.StatRequest{
+		Repo:   string(repo),
+		Commit: string(commit),
+		Path:   filepath.ToSlash(path),
+	})
+	if err != nil {
+		return nil, convertGRPCErrorToGitDomainError(err)
+	}
+
+	return fileInfoFromProto(resp.GetFileInfo())
+}
+
+// readDirGRPC lists the contents of the directory at path using the ReadDir
+// RPC.
+func (c *clientImplementor) readDirGRPC(ctx context.Context, repo api.RepoName, commit api.CommitID, path string, recurse bool) ([]fs.FileInfo, error) {
+	client, err := c.readClientForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.ReadDir(ctx, &proto.ReadDirRequest{
+		Repo:      string(repo),
+		Commit:    string(commit),
+		Path:      filepath.ToSlash(path),
+		Recursive: recurse,
+	})
+	if err != nil {
+		return nil, convertGRPCErrorToGitDomainError(err)
+	}
+
+	fis := []fs.FileInfo{}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, convertGRPCErrorToGitDomainError(err)
+		}
+		for _, pfi := range resp.GetFileInfo() {
+			fi, err := fileInfoFromProto(pfi)
+			if err != nil {
+				return nil, err
+			}
+			fis = append(fis, fi)
+		}
+	}
+	fileutil.SortFileInfosByName(fis)
+
+	return fis, nil
+}
+
+// fileInfoFromProto converts a FileInfo returned by gitserver into the same
+// fs.FileInfo that is returned by lsTree.
+func fileInfoFromProto(p *proto.FileInfo) (fs.FileInfo, error) {
+	oid, err := decodeOID(p.GetBlobOid())
+	if err != nil {
+		return nil, err
+	}
+
+	var sys any = objectInfo(oid)
+	if sm := p.GetSubmodule(); sm != nil {
+		sys = gitdomain.Submodule{
+			URL:      sm.GetUrl(),
+			Path:     sm.GetPath(),
+			CommitID: api.CommitID(sm.GetCommitSha()),
+		}
+	}
+
+	return &fileutil.FileInfo{
+		Name_: p.GetName(),
+		Mode_: fs.FileMode(p.GetMode()),
+		Size_: p.GetSize(),
+		Sys_:  sys,
+	}, nil
+}
+
 func decodeOID(sha string) (gitdomain.OID, error) {
 	oidBytes, err := hex.DecodeString(sha)
 	if err != nil {
@@ -749,6 +854,40 @@ type Hunk struct {
 	Filename string
 }
 
+func (h *Hunk) ToProto() *proto.BlameHunk {
+	return &proto.BlameHunk{
+		StartLine: uint32(h.StartLine),
+		EndLine:   uint32(h.EndLine),
+		StartByte: uint32(h.StartByte),
+		EndByte:   uint32(h.EndByte),
+		Commit:    string(h.CommitID),
+		Author: &proto.BlameAuthor{
+			Name:  h.Author.Name,
+			Email: h.Author.Email,
+			Date:  timestamppb.New(h.Author.Date),
+		},
+		Message:  h.Message,
+		Filename: h.Filename,
+	}
+}
+
+func (h *Hunk) FromProto(p *proto.BlameHunk) {
+	*h = Hunk{
+		StartLine: int(p.GetStartLine()),
+		EndLine:   int(p.GetEndLine()),
+		StartByte: int(p.GetStartByte()),
+		EndByte:   int(p.GetEndByte()),
+		CommitID:  api.CommitID(p.GetCommit()),
+		Author: gitdomain.Signature{
+			Name:  p.GetAuthor().GetName(),
+			Email: p.GetAuthor().GetEmail(),
+			Date:  p.GetAuthor().GetDate().AsTime(),
+		},
+		Message:  p.GetMessage(),
+		Filename: p.GetFilename(),
+	}
+}
+
 // StreamBlameFile returns Git blame information about a file.
 func (c *clientImplementor) StreamBlameFile(ctx context.Context, repo api.RepoName, path string, opt *BlameOptions) (_ HunkReader, err error) {
 	ctx, _, endObservation := c.operations.streamBlameFile.With(ctx, &err, observation.Args{
@@ -759,9 +898,59 @@ func (c *clientImplementor) StreamBlameFile(ctx context.Context, repo api.RepoNa
 	})
 	defer endObservation(1, observation.Args{})
 
+	if conf.IsGRPCEnabled(ctx) {
+		a := actor.FromContext(ctx)
+		hasAccess, err := authz.FilterActorPath(ctx, c.subRepoPermsChecker, a, repo, path)
+		if err != nil {
+			return nil, err
+		}
+		if !hasAccess {
+			return nil, errUnauthorizedStreamBlame{Repo: repo}
+		}
+		return c.blameGRPC(ctx, repo, path, opt, true)
+	}
+
 	return streamBlameFileCmd(ctx, c.subRepoPermsChecker, repo, path, opt, c.gitserverGitCommandFunc(repo))
 }
 
+// blameGRPC returns a HunkReader streaming the hunks returned by the Blame RPC.
+// The caller is responsible for checking sub-repo permissions.
+func (c *clientImplementor) blameGRPC(ctx context.Context, repo api.RepoName, path string, opt *BlameOptions, incremental bool) (HunkReader, error) {
+	if opt == nil {
+		opt = &BlameOptions{}
+	}
+	if err := checkSpecArgSafety(string(opt.NewestCommit)); err != nil {
+		return nil, err
+	}
+
+	client, err := c.readClientForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &proto.BlameRequest{
+		Repo:        string(repo),
+		Commit:      string(opt.NewestCommit),
+		Path:        filepath.ToSlash(path),
+		Incremental: incremental,
+	}
+	if opt.StartLine != 0 || opt.EndLine != 0 {
+		req.Range = &proto.BlameRange{
+			StartLine: uint32(opt.StartLine),
+			EndLine:   uint32(opt.EndLine),
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := client.Blame(ctx, req)
+	if err != nil {
+		cancel()
+		return nil, convertGRPCErrorToGitDomainError(err)
+	}
+
+	return &grpcBlameHunkReader{stream: stream, cancel: cancel}, nil
+}
+
 type errUnauthorizedStreamBlame struct {
 	Repo api.RepoName
 }
@@ -801,7 +990,7 @@ func streamBlameFileCmd(ctx context.Context, checker authz.SubRepoPermissionChec
 		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed", args))
 	}
 
-	return newBlameHunkReader(rc), nil
+	return NewBlameHunkReader(rc), nil
 }
 
 // BlameFile returns Git blame information about a file.
@@ -814,6 +1003,31 @@ func (c *clientImplementor) BlameFile(ctx context.Context, repo api.RepoName, pa
 	})
 	defer endObservation(1, observation.Args{})
 
+	if conf.IsGRPCEnabled(ctx) {
+		a := actor.FromContext(ctx)
+		if hasAccess, err := authz.FilterActorPath(ctx, c.subRepoPermsChecker, a, repo, path); err != nil || !hasAccess {
+			return nil, err
+		}
+
+		hr, err := c.blameGRPC(ctx, repo, path, opt, false)
+		if err != nil {
+			return nil, err
+		}
+		defer hr.Close()
+
+		var hunks []*Hunk
+		for {
+			h, err := hr.Read()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return hunks, nil
+				}
+				return nil, err
+			}
+			hunks = append(hunks, h)
+		}
+	}
+
 	return blameFileCmd(ctx, c.subRepoPermsChecker, c.gitserverGitCommandFunc(repo), path, opt, repo)
 }
 
@@ -843,11 +1057,11 @@ func blameFileCmd(ctx context.Context, checker authz.SubRepoPermissionChecker, c
 		return nil, nil
 	}
 
-	return parseGitBlameOutput(string(out))
+	return ParseGitBlameOutput(string(out))
 }
 
-// parseGitBlameOutput parses the output of `git blame -w --porcelain`
-func parseGitBlameOutput(out string) ([]*Hunk, error) {
+// ParseGitBlameOutput parses the output of `git blame -w --porcelain`
+func ParseGitBlameOutput(out string) ([]*Hunk, error) {
 	commits := make(map[string]gitdomain.Commit)
 	filenames := make(map[string]string)
 	hunks := make([]*Hunk, 0)
@@ -1382,6 +1596,10 @@ func (c *clientImplementor) NewFileReader(ctx context.Context, repo api.RepoName
 	}
 
 	name = rel(name)
+	if conf.IsGRPCEnabled(ctx) {
+		return c.newGRPCFileReader(ctx, repo, commit, name)
+	}
+
 	br, err := c.newBlobReader(ctx, repo, commit, name)
 	if err != nil {
 		return nil, errors.Wrapf(err, "getting blobReader for %q", name)
@@ -1389,6 +1607,198 @@ func (c *clientImplementor) NewFileReader(ctx context.Context, repo api.RepoName
 	return br, nil
 }
 
+// newGRPCFileReader returns an io.ReadCloser reading from the named file at
+// commit using the ReadFile RPC.
+func (c *clientImplementor) newGRPCFileReader(ctx context.Context, repo api.RepoName, commit api.CommitID, name string) (io.ReadCloser, error) {
+	if err := gitdomain.EnsureAbsoluteCommit(commit); err != nil {
+		return nil, err
+	}
+
+	client, err := c.readClientForRepo(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	stream, err := client.ReadFile(ctx, &proto.ReadFileRequest{
+		Repo:   string(repo),
+		Commit: string(commit),
+		Path:   name,
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// Read the first message so that errors such as the repository not
+	// existing are returned right away, like they are by newBlobReader. To
+	// match blobReader, a missing file is only reported once the caller starts
+	// reading.
+	firstMessage, firstErr := stream.Recv()
+	if firstErr != nil && !errors.Is(firstErr, io.EOF) {
+		firstErr = convertGRPCErrorToGitDomainError(firstErr)
+		if !os.IsNotExist(firstErr) {
+			cancel()
+			return nil, firstErr
+		}
+	}
+
+	firstMessageRead := false
+	r := streamio.NewReader(func() ([]byte, error) {
+		if !firstMessageRead {
+			firstMessageRead = true
+			if firstErr != nil {
+				return nil, firstErr
+			}
+			return firstMessage.GetData(), nil
+		}
+
+		msg, err := stream.Recv()
+		if err != nil {
+			return nil, convertGRPCErrorToGitDomainError(err)
+		}
+		return msg.GetData(), nil
+	})
+
+	return &readCloseWrapper{r: r, closeFn: cancel}, nil
+}
+
+// BatchReadFile reads the named files at commit and calls fn with the contents
+// of each of them. When gRPC is enabled, all files are read by a single git
+// process on gitserver.
+func (c *clientImplementor) BatchReadFile(ctx context.Context, repo api.RepoName, commit api.CommitID, names []string, fn func(name string, contents []byte, err error) error) (err error) {
+	ctx, _, endObservation := c.operations.batchReadFile.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		repo.Attr(),
+		commit.Attr(),
+		attribute.Int("names", len(names)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if !conf.IsGRPCEnabled(ctx) {
+		for _, name := range names {
+			contents, err := c.ReadFile(ctx, repo, commit, name)
+			if err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			if err := fn(name, contents, err); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := gitdomain.EnsureAbsoluteCommit(commit); err != nil {
+		return err
+	}
+
+	type batchFile struct {
+		name      string
+		path      string
+		hasAccess bool
+	}
+
+	// 🚨 SECURITY: Files the actor doesn't have access to are reported as not
+	// existing, like they are by NewFileReader.
+	a := actor.FromContext(ctx)
+	files := make([]batchFile, 0, len(names))
+	paths := make([]string, 0, len(names))
+	seen := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		path := rel(name)
+		if _, ok := seen[path]; ok {
+			continue
+		}
+		seen[path] = struct{}{}
+
+		hasAccess, err := authz.FilterActorPath(ctx, c.subRepoPermsChecker, a, repo, name)
+		if err != nil {
+			return err
+		}
+		files = append(files, batchFile{name: name, path: path, hasAccess: hasAccess})
+		if hasAccess {
+			paths = append(paths, path)
+		}
+	}
+
+	// gitserver returns the files in the order they were requested, so we
+	// can call fn as soon as a file is complete.
+	next := 0
+	deliver := func(path string, contents []byte, found bool) error {
+		for ; next < len(files); next++ {
+			f := files[next]
+			if f.hasAccess && f.path == path {
+				break
+			}
+			if err := fn(f.name, nil, &os.PathError{Op: "open", Path: f.name, Err: os.ErrNotExist}); err != nil {
+				return err
+			}
+		}
+		if next == len(files) {
+			return nil
+		}
+		f := files[next]
+		next++
+		if !found {
+			return fn(f.name, nil, &os.PathError{Op: "open", Path: f.name, Err: os.ErrNotExist})
+		}
+		if contents == nil {
+			contents = []byte{}
+		}
+		return fn(f.name, contents, nil)
+	}
+
+	if len(paths) > 0 {
+		client, err := c.readClientForRepo(ctx, repo)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		stream, err := client.BatchReadFile(ctx, &proto.BatchReadFileRequest{
+			Repo:   string(repo),
+			Commit: string(commit),
+			Paths:  paths,
+		})
+		if err != nil {
+			return convertGRPCErrorToGitDomainError(err)
+		}
+
+		var (
+			current  *proto.BatchReadFileResponse
+			contents []byte
+		)
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return convertGRPCErrorToGitDomainError(err)
+			}
+			if current != nil && current.GetPath() != resp.GetPath() {
+				if err := deliver(current.GetPath(), contents, !current.GetNotFound()); err != nil {
+					return err
+				}
+				contents = nil
+			}
+			current = resp
+			contents = append(contents, resp.GetData()...)
+		}
+		if current != nil {
+			if err := deliver(current.GetPath(), contents, !current.GetNotFound()); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Report any remaining files, which the actor doesn't have access to, as
+	// not existing.
+	return deliver("", nil, false)
+}
+
 // blobReader, which should be created using newBlobReader, is a struct that allows
 // us to get a ReadCloser to a specific named file at a specific commit
 type blobReader struct {
@@ -2507,7 +2917,7 @@ func (c *clientImplementor) ArchiveReader(
 	}
 
 	if conf.IsGRPCEnabled(ctx) {
-		client, err := c.clientSource.ClientForRepo(ctx, c.userAgent, repo)
+		client, err := c.readClientForRepo(ctx, repo)
 		if err != nil {
 			return nil, err
 		}
@@ -3151,9 +3151,9 @@ var testGitBlameOutputHunks = []*Hunk{
 }
 
 func TestParseGitBlameOutput(t *testing.T) {
-	hunks, err := parseGitBlameOutput(testGitBlameOutput)
+	hunks, err := ParseGitBlameOutput(testGitBlameOutput)
 	if err != nil {
-		t.Fatalf("parseGitBlameOutput failed: %s", err)
+		t.Fatalf("ParseGitBlameOutput failed: %s", err)
 	}
 
 	if d := cmp.Diff(testGitBlameOutputHunks, hunks); d != "" {
@@ -3190,7 +3190,7 @@ func TestStreamBlameFile(t *testing.T) {
 func TestBlameHunkReader(t *testing.T) {
 	t.Run("OK matching hunks", func(t *testing.T) {
 		rc := io.NopCloser(strings.NewReader(testGitBlameOutputIncremental))
-		reader := newBlameHunkReader(rc)
+		reader := NewBlameHunkReader(rc)
 		defer reader.Close()
 
 		hunks := []*Hunk{}
@@ -3229,7 +3229,7 @@ func TestBlameHunkReader(t *testing.T) {
 
 	t.Run("OK parsing hunks", func(t *testing.T) {
 		rc := io.NopCloser(strings.NewReader(testGitBlameOutputIncremental2))
-		reader := newBlameHunkReader(rc)
+		reader := NewBlameHunkReader(rc)
 		defer reader.Close()
 
 		for {
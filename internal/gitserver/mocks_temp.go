@@ -36,6 +36,9 @@ type MockClient struct {
 	// BatchLogFunc is an instance of a mock function object controlling the
 	// behavior of the method BatchLog.
 	BatchLogFunc *ClientBatchLogFunc
+	// BatchReadFileFunc is an instance of a mock function object controlling
+	// the behavior of the method BatchReadFile.
+	BatchReadFileFunc *ClientBatchReadFileFunc
 	// BlameFileFunc is an instance of a mock function object controlling
 	// the behavior of the method BlameFile.
 	BlameFileFunc *ClientBlameFileFunc
@@ -224,6 +227,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		BatchReadFileFunc: &ClientBatchReadFileFunc{
+			defaultHook: func(context.Context, api.RepoName, api.CommitID, []string, func(name string, contents []byte, err error) error) (r0 error) {
+				return
+			},
+		},
 		BlameFileFunc: &ClientBlameFileFunc{
 			defaultHook: func(context.Context, api.RepoName, string, *BlameOptions) (r0 []*Hunk, r1 error) {
 				return
@@ -521,6 +529,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.BatchLog")
 			},
 		},
+		BatchReadFileFunc: &ClientBatchReadFileFunc{
+			defaultHook: func(context.Context, api.RepoName, api.CommitID, []string, func(name string, contents []byte, err error) error) error {
+				panic("unexpected invocation of MockClient.BatchReadFile")
+			},
+		},
 		BlameFileFunc: &ClientBlameFileFunc{
 			defaultHook: func(context.Context, api.RepoName, string, *BlameOptions) ([]*Hunk, error) {
 				panic("unexpected invocation of MockClient.BlameFile")
@@ -810,6 +823,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		BatchLogFunc: &ClientBatchLogFunc{
 			defaultHook: i.BatchLog,
 		},
+		BatchReadFileFunc: &ClientBatchReadFileFunc{
+			defaultHook: i.BatchReadFile,
+		},
 		BlameFileFunc: &ClientBlameFileFunc{
 			defaultHook: i.BlameFile,
 		},
@@ -1394,6 +1410,119 @@ func (c ClientBatchLogFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ClientBatchReadFileFunc describes the behavior when the BatchReadFile
+// method of the parent MockClient instance is invoked.
+type ClientBatchReadFileFunc struct {
+	defaultHook func(context.Context, api.RepoName, api.CommitID, []string, func(name string, contents []byte, err error) error) error
+	hooks       []func(context.Context, api.RepoName, api.CommitID, []string, func(name string, contents []byte, err error) error) error
+	history     []ClientBatchReadFileFuncCall
+	mutex       sync.Mutex
+}
+
+// BatchReadFile delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockClient) BatchReadFile(v0 context.Context, v1 api.RepoName, v2 api.CommitID, v3 []string, v4 func(name string, contents []byte, err error) error) error {
+	r0 := m.BatchReadFileFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.BatchReadFileFunc.appendCall(ClientBatchReadFileFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the BatchReadFile method
+// of the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientBatchReadFileFunc) SetDefaultHook(hook func(context.Context, api.RepoName, api.CommitID, []string, func(name string, contents []byte, err error) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// BatchReadFile method of the parent MockClient instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ClientBatchReadFileFunc) PushHook(hook func(context.Context, api.RepoName, api.CommitID, []string, func(name string, contents []byte, err error) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientBatchReadFileFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, api.CommitID, []string, func(name string, contents []byte, err error) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientBatchReadFileFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, api.CommitID, []string, func(name string, contents []byte, err error) error) error {
+		return r0
+	})
+}
+
+func (f *ClientBatchReadFileFunc) nextHook() func(context.Context, api.RepoName, api.CommitID, []string, func(name string, contents []byte, err error) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientBatchReadFileFunc) appendCall(r0 ClientBatchReadFileFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientBatchReadFileFuncCall objects
+// describing the invocations of this function.
+func (f *ClientBatchReadFileFunc) History() []ClientBatchReadFileFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientBatchReadFileFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientBatchReadFileFuncCall is an object that describes an invocation of
+// method BatchReadFile on an instance of MockClient.
+type ClientBatchReadFileFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.CommitID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 func(name string, contents []byte, err error) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientBatchReadFileFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientBatchReadFileFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ClientBlameFileFunc describes the behavior when the BlameFile method of
 // the parent MockClient instance is invoked.
 type ClientBlameFileFunc struct {
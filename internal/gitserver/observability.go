@@ -16,6 +16,7 @@ type operations struct {
 	archiveReader    *observation.Operation
 	batchLog         *observation.Operation
 	batchLogSingle   *observation.Operation
+	batchReadFile    *observation.Operation
 	blameFile        *observation.Operation
 	commits          *observation.Operation
 	contributorCount *observation.Operation
@@ -90,6 +91,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		archiveReader:    op("ArchiveReader"),
 		batchLog:         op("BatchLog"),
 		batchLogSingle:   subOp("batchLogSingle"),
+		batchReadFile:    op("BatchReadFile"),
 		blameFile:        op("BlameFile"),
 		commits:          op("Commits"),
 		contributorCount: op("ContributorCount"),
@@ -2,12 +2,14 @@ package gitserver
 
 import (
 	"bufio"
+	"context"
 	"io"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -25,7 +27,9 @@ type blameHunkReader struct {
 	commits map[api.CommitID]*Hunk
 }
 
-func newBlameHunkReader(rc io.ReadCloser) HunkReader {
+// NewBlameHunkReader returns a HunkReader that parses the output of
+// `git blame --porcelain --incremental` read from rc.
+func NewBlameHunkReader(rc io.ReadCloser) HunkReader {
 	return &blameHunkReader{
 		rc:      rc,
 		sc:      bufio.NewScanner(rc),
@@ -173,6 +177,31 @@ func splitLine(line string) (annotation string, content string) {
 	return line, ""
 }
 
+// grpcBlameHunkReader reads hunks from a Blame RPC stream.
+type grpcBlameHunkReader struct {
+	stream proto.GitserverService_BlameClient
+	cancel context.CancelFunc
+}
+
+func (r *grpcBlameHunkReader) Read() (*Hunk, error) {
+	resp, err := r.stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.EOF
+		}
+		return nil, convertGRPCErrorToGitDomainError(err)
+	}
+
+	var h Hunk
+	h.FromProto(resp.GetHunk())
+	return &h, nil
+}
+
+func (r *grpcBlameHunkReader) Close() error {
+	r.cancel()
+	return nil
+}
+
 type mockHunkReader struct {
 	hunks []*Hunk
 	err   error
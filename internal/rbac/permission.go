@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/auth"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -24,8 +25,34 @@ func (e *ErrNotAuthorized) Unauthorized() bool {
 	return true
 }
 
+// Scope describes the resource a permission is checked against. Roles that are scoped to
+// organizations, repositories or search contexts only grant their permissions on matching
+// resources. The zero value checks the permission globally, which is only granted by roles
+// without scopes.
+type Scope struct {
+	// OrgID is the organization the action is performed on behalf of.
+	OrgID int32
+	// RepoID is the repository the action is performed on. A repository also matches
+	// roles scoped to a search context that contains it.
+	RepoID api.RepoID
+	// Any grants the permission if any of the user's roles has it, regardless of its
+	// scopes. It is meant for checks that gate access to a feature before the target
+	// resource is known.
+	Any bool
+}
+
+// AnyScope is the Scope used to check whether a user has a permission on at least some
+// resources.
+var AnyScope = Scope{Any: true}
+
 // CheckCurrentUserHasPermission returns an error if the current user doesn't have a permission assigned to them.
 func CheckCurrentUserHasPermission(ctx context.Context, db database.DB, permission string) error {
+	return CheckCurrentUserHasPermissionInScope(ctx, db, permission, Scope{})
+}
+
+// CheckCurrentUserHasPermissionInScope returns an error if the current user doesn't have a
+// permission assigned to them that applies to the given scope.
+func CheckCurrentUserHasPermissionInScope(ctx context.Context, db database.DB, permission string, scope Scope) error {
 	if actor.FromContext(ctx).IsInternal() {
 		return nil
 	}
@@ -37,15 +64,21 @@ func CheckCurrentUserHasPermission(ctx context.Context, db database.DB, permissi
 	if user == nil {
 		return auth.ErrNotAuthenticated
 	}
-	return checkUserHasPermission(ctx, db, user, permission)
+	return checkUserHasPermission(ctx, db, user, permission, scope)
 }
 
 // CheckGivenUserHasPermission returns an error if the given user doesn't have a permission assigned to them.
 func CheckGivenUserHasPermission(ctx context.Context, db database.DB, user *types.User, permission string) error {
-	return checkUserHasPermission(ctx, db, user, permission)
+	return checkUserHasPermission(ctx, db, user, permission, Scope{})
+}
+
+// CheckGivenUserHasPermissionInScope returns an error if the given user doesn't have a
+// permission assigned to them that applies to the given scope.
+func CheckGivenUserHasPermissionInScope(ctx context.Context, db database.DB, user *types.User, permission string, scope Scope) error {
+	return checkUserHasPermission(ctx, db, user, permission, scope)
 }
 
-func checkUserHasPermission(ctx context.Context, db database.DB, user *types.User, permission string) error {
+func checkUserHasPermission(ctx context.Context, db database.DB, user *types.User, permission string, scope Scope) error {
 	namespace, action, err := ParsePermissionDisplayName(permission)
 	if err != nil {
 		return err
@@ -55,6 +88,9 @@ func checkUserHasPermission(ctx context.Context, db database.DB, user *types.Use
 		UserID:    user.ID,
 		Namespace: namespace,
 		Action:    action,
+		OrgID:     scope.OrgID,
+		RepoID:    scope.RepoID,
+		AnyScope:  scope.Any,
 	})
 	if err != nil {
 		if errors.Is(err, &database.PermissionNotFoundErr{
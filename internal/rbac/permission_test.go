@@ -148,3 +148,58 @@ func setup(t *testing.T, ctx context.Context) (database.DB, *types.User, *types.
 	require.NoError(t, err)
 	return db, u1, u2, p
 }
+
+func TestCheckGivenUserHasPermissionInScope(t *testing.T) {
+	ctx := context.Background()
+	db, _, u2, p := setup(t, ctx)
+
+	org, err := db.Orgs().Create(ctx, "the-org", nil)
+	require.NoError(t, err)
+
+	role, err := db.Roles().Get(ctx, database.GetRoleOpts{Name: "TEST-ROLE"})
+	require.NoError(t, err)
+	err = db.RoleScopes().SetScopesForRole(ctx, database.SetScopesForRoleOpts{
+		RoleID: role.ID,
+		OrgIDs: []int32{org.ID},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		scope Scope
+
+		expectedErr error
+	}{
+		{
+			name:        "global",
+			scope:       Scope{},
+			expectedErr: &ErrNotAuthorized{Permission: p.DisplayName()},
+		},
+		{
+			name:        "any scope",
+			scope:       AnyScope,
+			expectedErr: nil,
+		},
+		{
+			name:        "matching org",
+			scope:       Scope{OrgID: org.ID},
+			expectedErr: nil,
+		},
+		{
+			name:        "other org",
+			scope:       Scope{OrgID: org.ID + 1},
+			expectedErr: &ErrNotAuthorized{Permission: p.DisplayName()},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CheckGivenUserHasPermissionInScope(ctx, db, u2, p.DisplayName(), tc.scope)
+			if tc.expectedErr != nil {
+				require.ErrorContains(t, err, tc.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -22,6 +22,10 @@ var (
 		Name: "src_repoupdater_sched_manual_fetch",
 		Help: "Incremented each time the scheduler updates a repository due to user traffic.",
 	})
+	schedClaimConflicts = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_repoupdater_sched_claim_conflicts",
+		Help: "Incremented each time an update is skipped because another replica is updating or has already updated the repository.",
+	})
 	schedKnownRepos = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "src_repoupdater_sched_known_repos",
 		Help: "The number of repositories that are managed by the scheduler.",
@@ -54,7 +54,9 @@ func (s *schedule) upsert(repo configuredRepo) (updated bool) {
 	return false
 }
 
-func (s *schedule) prioritiseUncloned(uncloned []types.MinimalRepo) {
+// prioritiseUncloned makes the uncloned repos due for cloning and returns
+// their due time.
+func (s *schedule) prioritiseUncloned(uncloned []types.MinimalRepo) time.Time {
 	// All non-cloned repos will be due for cloning as if they are newly added
 	// repos.
 	notClonedDue := timeNow().Add(minDelay)
@@ -85,6 +87,8 @@ func (s *schedule) prioritiseUncloned(uncloned []types.MinimalRepo) {
 	if rescheduleTimer {
 		s.rescheduleTimer()
 	}
+
+	return notClonedDue
 }
 
 // insertNew will insert repos only if they are not known to the scheduler
@@ -166,6 +170,76 @@ func (s *schedule) getCurrentInterval(repo configuredRepo) (time.Duration, bool)
 	return update.Interval, true
 }
 
+// get returns a copy of the scheduled update of the supplied repo and a bool
+// indicating whether it was found.
+func (s *schedule) get(repo configuredRepo) (scheduledRepoUpdate, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update, ok := s.index[repo.ID]
+	if !ok || update == nil {
+		return scheduledRepoUpdate{}, false
+	}
+	return *update, true
+}
+
+// load merges a persisted schedule into the schedule. Repos that became due
+// while no scheduler was running are spread over their interval instead of
+// all being due at once.
+func (s *schedule) load(updates []persistedUpdate) {
+	if len(updates) == 0 {
+		return
+	}
+
+	now := timeNow()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, u := range updates {
+		interval := u.Interval
+		if interval < minDelay {
+			interval = minDelay
+		}
+		due := u.Due
+		if due.Before(now) {
+			due = now.Add(time.Duration(s.randGenerator.Int63n(int64(interval))))
+		}
+
+		if update := s.index[u.Repo.ID]; update != nil {
+			update.Interval = interval
+			update.Due = due
+			heap.Fix(s, update.Index)
+			continue
+		}
+		heap.Push(s, &scheduledRepoUpdate{
+			Repo:     u.Repo,
+			Interval: interval,
+			Due:      due,
+		})
+	}
+
+	s.rescheduleTimer()
+}
+
+// sync sets the interval and due time of a repo to those in the shared
+// schedule. It does nothing if the repo is not in the schedule.
+func (s *schedule) sync(u persistedUpdate) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	update := s.index[u.Repo.ID]
+	if update == nil {
+		return
+	}
+	if u.Interval > 0 {
+		update.Interval = u.Interval
+	}
+	update.Due = u.Due
+	heap.Fix(s, update.Index)
+	s.rescheduleTimer()
+}
+
 // remove removes a repo from the schedule.
 func (s *schedule) remove(repo configuredRepo) (removed bool) {
 	if repo.ID == 0 {
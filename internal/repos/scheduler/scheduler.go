@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/internal/limiter"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
@@ -48,11 +49,19 @@ const (
 //
 // A worker continuously dequeues repos and sends updates to gitserver, but its concurrency
 // is limited by the gitMaxConcurrentClones site configuration.
+//
+// The schedule is persisted in the database, so that the learned intervals survive
+// restarts and can be shared by multiple repo-updater replicas. Before updating a repo,
+// a replica claims a lease on it in the shared schedule. A repo that another replica is
+// updating, or has updated since it became due, is skipped and its local schedule is
+// synced with the shared one.
 type UpdateScheduler struct {
 	db              database.DB
 	gitserverClient gitserver.Client
 	updateQueue     *updateQueue
 	schedule        *schedule
+	store           scheduleStore
+	owner           string // the name of this replica in the shared schedule
 	logger          log.Logger
 	cancelCtx       context.CancelFunc
 }
@@ -87,6 +96,8 @@ func NewUpdateScheduler(logger log.Logger, db database.DB, gitserverClient gitse
 			randGenerator: rand.New(rand.NewSource(time.Now().UnixNano())),
 			logger:        updateSchedLogger.Scoped("Schedule", ""),
 		},
+		store:  newScheduleStore(db),
+		owner:  hostname.Get(),
 		logger: updateSchedLogger,
 	}
 }
@@ -109,6 +120,8 @@ func (s *UpdateScheduler) Stop() {
 
 // runScheduleLoop starts the loop that schedules updates by enqueuing them into the updateQueue.
 func (s *UpdateScheduler) runScheduleLoop(ctx context.Context) {
+	s.loadSchedule(ctx)
+
 	for {
 		select {
 		case <-s.schedule.wakeup:
@@ -126,6 +139,18 @@ func (s *UpdateScheduler) runScheduleLoop(ctx context.Context) {
 	}
 }
 
+// loadSchedule restores the persisted schedule, so that the intervals learned before a
+// restart are kept instead of every repo becoming due at once.
+func (s *UpdateScheduler) loadSchedule(ctx context.Context) {
+	updates, err := s.store.List(ctx)
+	if err != nil {
+		schedError.WithLabelValues("loadSchedule").Inc()
+		s.logger.Warn("error loading persisted update schedule", log.Error(err))
+		return
+	}
+	s.schedule.load(updates)
+}
+
 func (s *UpdateScheduler) runSchedule() {
 	s.schedule.mu.Lock()
 	defer s.schedule.mu.Unlock()
@@ -163,7 +188,7 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 				return
 			}
 
-			repo, ok := s.updateQueue.acquireNext()
+			update, ok := s.updateQueue.acquireNextUpdate()
 			if !ok {
 				cancel()
 				break
@@ -171,9 +196,26 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 
 			subLogger := s.logger.Scoped("RunUpdateLoop", "")
 
-			go func(ctx context.Context, repo configuredRepo, cancel context.CancelFunc) {
+			go func(ctx context.Context, repo configuredRepo, force bool, cancel context.CancelFunc) {
 				defer cancel()
-				defer s.updateQueue.remove(repo, true)
+
+				var forcePending bool
+				defer func() {
+					s.updateQueue.remove(repo, true)
+					// A forced update of the repo was requested from another replica
+					// while we were updating it, so we update it again. This can only
+					// be enqueued once the repo is no longer being updated.
+					if forcePending {
+						s.updateQueue.enqueue(repo, priorityHigh)
+					}
+				}()
+
+				if !s.claim(ctx, repo, force) {
+					return
+				}
+				// The update request may outlive ctx, but the schedule must still be
+				// persisted.
+				defer func() { forcePending = s.complete(context.Background(), repo) }()
 
 				// This is a blocking call since the repo will be cloned synchronously by gitserver
 				// if it doesn't exist or update it if it does. The timeout of this request depends
@@ -209,9 +251,61 @@ func (s *UpdateScheduler) runUpdateLoop(ctx context.Context) {
 					interval := resp.LastFetched.Sub(*resp.LastChanged) / 2
 					s.schedule.updateInterval(repo, interval)
 				}
-			}(ctx, repo, cancel)
+			}(ctx, update.Repo, update.Priority == priorityHigh, cancel)
+		}
+	}
+}
+
+// claim acquires the lease on the repo in the shared schedule. Scheduled updates are
+// only claimed once the repo is due in the shared schedule, whereas forced updates are
+// claimed as soon as no other replica is updating the repo. If the repo can't be
+// claimed, the local schedule is synced with the shared one.
+func (s *UpdateScheduler) claim(ctx context.Context, repo configuredRepo, force bool) bool {
+	claimed, current, err := s.store.Claim(ctx, repo.ID, s.owner, leaseDuration(), force)
+	if err != nil {
+		// The shared schedule only prevents duplicate fetches, so we still update
+		// the repo if it is unavailable.
+		schedError.WithLabelValues("claim").Inc()
+		s.logger.Warn("error claiming repo update", log.Error(err), log.String("uri", string(repo.Name)))
+		return true
+	}
+
+	if !claimed {
+		schedClaimConflicts.Inc()
+		if current != nil {
+			s.schedule.sync(*current)
 		}
 	}
+	return claimed
+}
+
+// complete persists the next scheduled update of the repo and releases its lease. It
+// returns true if a forced update of the repo was requested while it held the lease.
+func (s *UpdateScheduler) complete(ctx context.Context, repo configuredRepo) bool {
+	var interval time.Duration
+	var due time.Time
+	if update, ok := s.schedule.get(repo); ok {
+		interval, due = update.Interval, update.Due
+	}
+
+	forcePending, err := s.store.Complete(ctx, repo.ID, s.owner, interval, due)
+	if err != nil {
+		schedError.WithLabelValues("complete").Inc()
+		s.logger.Warn("error persisting repo update schedule", log.Error(err), log.String("uri", string(repo.Name)))
+	}
+	return forcePending
+}
+
+// markDue makes the repos due in the shared schedule no later than due, so that
+// updates enqueued outside of the schedule can be claimed.
+func (s *UpdateScheduler) markDue(ids []api.RepoID, due time.Time) {
+	if len(ids) == 0 {
+		return
+	}
+	if err := s.store.MarkDue(context.Background(), ids, due); err != nil {
+		schedError.WithLabelValues("markDue").Inc()
+		s.logger.Warn("error persisting due repos", log.Error(err))
+	}
 }
 
 func getCustomInterval(logger log.Logger, c *conf.Unified, repoName string) time.Duration {
@@ -231,6 +325,12 @@ func getCustomInterval(logger log.Logger, c *conf.Unified, repoName string) time
 	return 0
 }
 
+// leaseDuration returns how long a replica may hold a claim on a repo. It
+// outlasts the longest update request sent to gitserver.
+var leaseDuration = func() time.Duration {
+	return conf.GitLongCommandTimeout() + minDelay
+}
+
 // configuredLimiter returns a mutable limiter that is
 // configured with the maximum number of concurrent update
 // requests that repo-updater should send to gitserver.
@@ -264,12 +364,16 @@ func (s *UpdateScheduler) UpdateFromDiff(diff types.RepoSyncDiff) {
 		s.remove(r)
 	}
 
+	var enqueued []api.RepoID
 	for _, r := range diff.Added {
 		s.upsert(r, true)
+		enqueued = append(enqueued, r.ID)
 	}
 	for _, r := range diff.Modified.Repos() {
 		s.upsert(r, true)
+		enqueued = append(enqueued, r.ID)
 	}
+	s.markDue(enqueued, timeNow())
 
 	known := len(diff.Added) + len(diff.Modified)
 	for _, r := range diff.Unmodified {
@@ -289,7 +393,13 @@ func (s *UpdateScheduler) UpdateFromDiff(diff types.RepoSyncDiff) {
 // This method should be called periodically with the list of all repositories
 // managed by the scheduler that are not cloned on gitserver.
 func (s *UpdateScheduler) PrioritiseUncloned(repos []types.MinimalRepo) {
-	s.schedule.prioritiseUncloned(repos)
+	due := s.schedule.prioritiseUncloned(repos)
+
+	ids := make([]api.RepoID, 0, len(repos))
+	for _, r := range repos {
+		ids = append(ids, r.ID)
+	}
+	s.markDue(ids, due)
 }
 
 // EnsureScheduled ensures that all repos in repos exist in the scheduler.
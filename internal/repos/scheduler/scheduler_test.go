@@ -4,6 +4,7 @@ import (
 	"container/heap"
 	"context"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	gitserverprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
@@ -29,6 +31,55 @@ func init() {
 	timeNow = nil
 	notify = nil
 	timeAfterFunc = nil
+	newScheduleStore = func(database.DB) scheduleStore {
+		return &fakeScheduleStore{}
+	}
+}
+
+// fakeScheduleStore is an in-memory scheduleStore. Claims are granted unless
+// the repo is in conflicts, which holds the shared schedule of repos claimed
+// by another replica.
+type fakeScheduleStore struct {
+	mu           sync.Mutex
+	persisted    []persistedUpdate
+	conflicts    map[api.RepoID]persistedUpdate
+	forcePending map[api.RepoID]bool
+	completed    []persistedUpdate
+	markedDue    []api.RepoID
+}
+
+func (f *fakeScheduleStore) List(context.Context) ([]persistedUpdate, error) {
+	return f.persisted, nil
+}
+
+func (f *fakeScheduleStore) Claim(_ context.Context, id api.RepoID, _ string, _ time.Duration, force bool) (bool, *persistedUpdate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if current, ok := f.conflicts[id]; ok {
+		if force {
+			if f.forcePending == nil {
+				f.forcePending = make(map[api.RepoID]bool)
+			}
+			f.forcePending[id] = true
+		}
+		return false, &current, nil
+	}
+	delete(f.forcePending, id)
+	return true, nil, nil
+}
+
+func (f *fakeScheduleStore) Complete(_ context.Context, id api.RepoID, _ string, interval time.Duration, due time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.completed = append(f.completed, persistedUpdate{Repo: configuredRepo{ID: id}, Interval: interval, Due: due})
+	return f.forcePending[id], nil
+}
+
+func (f *fakeScheduleStore) MarkDue(_ context.Context, ids []api.RepoID, _ time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.markedDue = append(f.markedDue, ids...)
+	return nil
 }
 
 func mockTime(t time.Time) {
@@ -1483,6 +1534,91 @@ func TestUpdateScheduler_runUpdateLoop(t *testing.T) {
 	}
 }
 
+func TestUpdateScheduler_claim(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+
+	_, stop := startRecording()
+	defer stop()
+
+	ctx := context.Background()
+	store := &fakeScheduleStore{
+		conflicts: map[api.RepoID]persistedUpdate{
+			b.ID: {Repo: b, Interval: 2 * time.Hour, Due: defaultTime.Add(2 * time.Hour)},
+		},
+	}
+	s := NewUpdateScheduler(logtest.Scoped(t), dbmocks.NewMockDB(), gitserver.NewMockClient())
+	s.store = store
+	setupInitialSchedule(s, []*scheduledRepoUpdate{
+		{Repo: a, Interval: time.Hour, Due: defaultTime},
+		{Repo: b, Interval: time.Hour, Due: defaultTime},
+	})
+
+	if !s.claim(ctx, a, false) {
+		t.Fatal("expected a to be claimed")
+	}
+	if s.complete(ctx, a) {
+		t.Fatal("expected no forced update of a to be pending")
+	}
+	if diff := cmp.Diff([]persistedUpdate{{Repo: configuredRepo{ID: a.ID}, Interval: time.Hour, Due: defaultTime}}, store.completed); diff != "" {
+		t.Fatalf("unexpected completed updates (-want +got):\n%s", diff)
+	}
+
+	// b is claimed by another replica, so the local schedule follows the shared one.
+	if s.claim(ctx, b, true) {
+		t.Fatal("expected b not to be claimed")
+	}
+	verifySchedule(t, s, []*scheduledRepoUpdate{
+		{Repo: a, Interval: time.Hour, Due: defaultTime},
+		{Repo: b, Interval: 2 * time.Hour, Due: defaultTime.Add(2 * time.Hour)},
+	})
+
+	// Completing b stands in for the replica holding the lease, which learns
+	// about the forced update it has to make, and the next claim satisfies it.
+	delete(store.conflicts, b.ID)
+	if !s.complete(ctx, b) {
+		t.Fatal("expected a forced update of b to be pending")
+	}
+	if !s.claim(ctx, b, true) {
+		t.Fatal("expected b to be claimed")
+	}
+	if s.complete(ctx, b) {
+		t.Fatal("expected no forced update of b to be pending")
+	}
+}
+
+func TestSchedule_load(t *testing.T) {
+	a := configuredRepo{ID: 1, Name: "a"}
+	b := configuredRepo{ID: 2, Name: "b"}
+	c := configuredRepo{ID: 3, Name: "c"}
+
+	r, stop := startRecording()
+	defer stop()
+
+	s := NewUpdateScheduler(logtest.Scoped(t), dbmocks.NewMockDB(), gitserver.NewMockClient())
+	s.schedule.randGenerator = &mockRandomGenerator{}
+	s.store = &fakeScheduleStore{
+		persisted: []persistedUpdate{
+			{Repo: a, Interval: 2 * time.Hour, Due: defaultTime.Add(30 * time.Minute)},
+			// Overdue repos are spread over their interval.
+			{Repo: b, Interval: 2 * time.Hour, Due: defaultTime.Add(-time.Hour)},
+			{Repo: c, Interval: 0, Due: defaultTime.Add(10 * time.Minute)},
+		},
+	}
+	setupInitialSchedule(s, []*scheduledRepoUpdate{
+		{Repo: a, Interval: time.Hour, Due: defaultTime.Add(time.Hour)},
+	})
+
+	s.loadSchedule(context.Background())
+
+	verifyScheduleRecording(t, s, []time.Duration{10 * time.Minute}, 1, r)
+	verifySchedule(t, s, []*scheduledRepoUpdate{
+		{Repo: c, Interval: minDelay, Due: defaultTime.Add(10 * time.Minute)},
+		{Repo: a, Interval: 2 * time.Hour, Due: defaultTime.Add(30 * time.Minute)},
+		{Repo: b, Interval: 2 * time.Hour, Due: defaultTime.Add(time.Hour)},
+	})
+}
+
 func verifyRecording(t *testing.T, s *UpdateScheduler, timeAfterFuncDelays []time.Duration, expectedNotifications func(s *UpdateScheduler) []chan struct{}, r *recording) {
 	if !reflect.DeepEqual(timeAfterFuncDelays, r.timeAfterFuncDelays) {
 		t.Fatalf("\nexpected timeAfterFuncDelays\n%s\ngot\n%s", spew.Sdump(timeAfterFuncDelays), spew.Sdump(r.timeAfterFuncDelays))
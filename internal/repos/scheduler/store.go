@@ -0,0 +1,187 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// persistedUpdate is the state of a repo in the shared update schedule.
+type persistedUpdate struct {
+	Repo     configuredRepo
+	Interval time.Duration
+	Due      time.Time
+}
+
+// scheduleStore persists the update schedule so that it survives restarts and
+// can be shared by multiple repo-updater replicas. Replicas claim a lease on a
+// repo before updating it, so that a repo is only fetched by one replica at a
+// time, and only once per scheduled due time.
+type scheduleStore interface {
+	// List returns the persisted schedule of all repos that are not deleted.
+	List(ctx context.Context) ([]persistedUpdate, error)
+
+	// Claim attempts to acquire a lease on the repo for owner. Unless force is
+	// true, the repo is only claimed if it is due for an update. If the repo
+	// couldn't be claimed, its persisted schedule is returned instead, and a
+	// forced claim is left pending for the replica holding the lease.
+	Claim(ctx context.Context, id api.RepoID, owner string, lease time.Duration, force bool) (claimed bool, current *persistedUpdate, err error)
+
+	// Complete records the next scheduled update of a repo claimed by owner
+	// and releases the lease. A zero interval leaves the schedule unchanged.
+	// It returns true if a forced claim failed while owner held the lease, in
+	// which case the repo must be updated again.
+	Complete(ctx context.Context, id api.RepoID, owner string, interval time.Duration, due time.Time) (forcePending bool, err error)
+
+	// MarkDue moves the persisted due time of the repos to due if it is later.
+	MarkDue(ctx context.Context, ids []api.RepoID, due time.Time) error
+}
+
+// claimClockSkew is how early a repo may be claimed before its due time, to
+// allow for clock differences between replicas and the database.
+const claimClockSkew = 10 * time.Second
+
+type dbScheduleStore struct {
+	*basestore.Store
+}
+
+// newScheduleStore returns the store of the shared schedule. It is a variable
+// so that tests can replace it.
+var newScheduleStore = func(db database.DB) scheduleStore {
+	return &dbScheduleStore{Store: basestore.NewWithHandle(db.Handle())}
+}
+
+const listScheduleQueryFmtStr = `
+SELECT
+	s.repo_id,
+	r.name,
+	s.interval_seconds,
+	s.due_at
+FROM repo_update_schedule s
+JOIN repo r ON r.id = s.repo_id
+WHERE r.deleted_at IS NULL
+`
+
+func (s *dbScheduleStore) List(ctx context.Context) ([]persistedUpdate, error) {
+	return scanPersistedUpdates(s.Query(ctx, sqlf.Sprintf(listScheduleQueryFmtStr)))
+}
+
+var scanPersistedUpdates = basestore.NewSliceScanner(func(sc dbutil.Scanner) (persistedUpdate, error) {
+	var (
+		u               persistedUpdate
+		intervalSeconds int
+	)
+	if err := sc.Scan(&u.Repo.ID, &u.Repo.Name, &intervalSeconds, &u.Due); err != nil {
+		return u, err
+	}
+	u.Interval = time.Duration(intervalSeconds) * time.Second
+	return u, nil
+})
+
+// claimScheduleQueryFmtStr inserts the repo into the schedule with the lease
+// held by the owner if it isn't scheduled yet. Otherwise, the lease is only
+// taken if no other replica holds it and, unless forced, the repo is due.
+// Taking the lease satisfies any pending forced claim.
+const claimScheduleQueryFmtStr = `
+INSERT INTO repo_update_schedule (repo_id, interval_seconds, due_at, claimed_by, lease_expires_at)
+VALUES (%s, %s, NOW(), %s, NOW() + %s * '1 second'::interval)
+ON CONFLICT (repo_id) DO UPDATE
+SET
+	claimed_by = EXCLUDED.claimed_by,
+	lease_expires_at = EXCLUDED.lease_expires_at,
+	force_pending = false
+WHERE
+	(
+		repo_update_schedule.lease_expires_at IS NULL
+		OR repo_update_schedule.lease_expires_at < NOW()
+		OR repo_update_schedule.claimed_by = EXCLUDED.claimed_by
+	)
+	AND (%s OR repo_update_schedule.due_at <= NOW() + %s * '1 second'::interval)
+RETURNING repo_id
+`
+
+const markForcePendingQueryFmtStr = `
+UPDATE repo_update_schedule
+SET force_pending = true
+WHERE repo_id = %s
+`
+
+const getScheduleQueryFmtStr = `
+SELECT
+	s.repo_id,
+	r.name,
+	s.interval_seconds,
+	s.due_at
+FROM repo_update_schedule s
+JOIN repo r ON r.id = s.repo_id
+WHERE s.repo_id = %s
+`
+
+func (s *dbScheduleStore) Claim(ctx context.Context, id api.RepoID, owner string, lease time.Duration, force bool) (bool, *persistedUpdate, error) {
+	q := sqlf.Sprintf(
+		claimScheduleQueryFmtStr,
+		id,
+		int(minDelay/time.Second),
+		owner,
+		int(lease/time.Second),
+		force,
+		int(claimClockSkew/time.Second),
+	)
+	_, claimed, err := basestore.ScanFirstInt(s.Query(ctx, q))
+	if err != nil || claimed {
+		return claimed, nil, err
+	}
+
+	// A forced claim only fails while another replica holds the lease. That
+	// replica may have fetched the repo before the update was requested, so it
+	// has to fetch it again once it is done.
+	if force {
+		if err := s.Exec(ctx, sqlf.Sprintf(markForcePendingQueryFmtStr, id)); err != nil {
+			return false, nil, err
+		}
+	}
+
+	updates, err := scanPersistedUpdates(s.Query(ctx, sqlf.Sprintf(getScheduleQueryFmtStr, id)))
+	if err != nil || len(updates) == 0 {
+		return false, nil, err
+	}
+	return false, &updates[0], nil
+}
+
+const completeScheduleQueryFmtStr = `
+UPDATE repo_update_schedule
+SET
+	interval_seconds = CASE WHEN %s > 0 THEN %s ELSE interval_seconds END,
+	due_at = CASE WHEN %s > 0 THEN %s ELSE due_at END,
+	last_fetched_at = NOW(),
+	claimed_by = NULL,
+	lease_expires_at = NULL
+WHERE repo_id = %s AND claimed_by = %s
+RETURNING force_pending
+`
+
+func (s *dbScheduleStore) Complete(ctx context.Context, id api.RepoID, owner string, interval time.Duration, due time.Time) (bool, error) {
+	seconds := int(interval / time.Second)
+	forcePending, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(completeScheduleQueryFmtStr, seconds, seconds, seconds, due, id, owner)))
+	return forcePending, err
+}
+
+const markDueScheduleQueryFmtStr = `
+UPDATE repo_update_schedule
+SET due_at = %s
+WHERE repo_id = ANY(%s) AND due_at > %s
+`
+
+func (s *dbScheduleStore) MarkDue(ctx context.Context, ids []api.RepoID, due time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(markDueScheduleQueryFmtStr, due, pq.Array(ids), due))
+}
@@ -0,0 +1,87 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDBScheduleStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+	ctx := context.Background()
+
+	require.NoError(t, db.Repos().Create(ctx, &types.Repo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}))
+	repo := configuredRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}
+
+	store := &dbScheduleStore{Store: basestore.NewWithHandle(db.Handle())}
+
+	// Unknown repos are added to the schedule when first claimed.
+	claimed, _, err := store.Claim(ctx, repo.ID, "replica-0", time.Minute, false)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// The lease is held by replica-0, even for forced updates.
+	claimed, current, err := store.Claim(ctx, repo.ID, "replica-1", time.Minute, true)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	require.NotNil(t, current)
+	assert.Equal(t, repo, current.Repo)
+	assert.Equal(t, minDelay, current.Interval)
+
+	// The failed forced claim is left pending for replica-0.
+	due := time.Now().Add(time.Hour).Truncate(time.Second)
+	forcePending, err := store.Complete(ctx, repo.ID, "replica-0", 30*time.Minute, due)
+	require.NoError(t, err)
+	assert.True(t, forcePending)
+
+	// The repo was just updated and isn't due yet.
+	claimed, current, err = store.Claim(ctx, repo.ID, "replica-1", time.Minute, false)
+	require.NoError(t, err)
+	assert.False(t, claimed)
+	require.NotNil(t, current)
+	assert.Equal(t, 30*time.Minute, current.Interval)
+	assert.True(t, due.Equal(current.Due))
+
+	updates, err := store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, repo, updates[0].Repo)
+
+	// Forced updates don't need to wait for the repo to be due, and claiming
+	// the repo satisfies the pending forced claim.
+	claimed, _, err = store.Claim(ctx, repo.ID, "replica-1", time.Minute, true)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	// Completing without an interval releases the lease and keeps the schedule.
+	forcePending, err = store.Complete(ctx, repo.ID, "replica-1", 0, time.Time{})
+	require.NoError(t, err)
+	assert.False(t, forcePending)
+	require.NoError(t, store.MarkDue(ctx, []api.RepoID{repo.ID}, time.Now().Add(-time.Minute)))
+
+	claimed, _, err = store.Claim(ctx, repo.ID, "replica-0", time.Minute, false)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	updates, err = store.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, updates, 1)
+	assert.Equal(t, 30*time.Minute, updates[0].Interval)
+}
@@ -109,19 +109,26 @@ func (q *updateQueue) remove(repo configuredRepo, updating bool) (removed bool)
 // The acquired repo must be removed from the queue
 // when the update finishes (independent of success or failure).
 func (q *updateQueue) acquireNext() (configuredRepo, bool) {
+	update, ok := q.acquireNextUpdate()
+	return update.Repo, ok
+}
+
+// acquireNextUpdate is like acquireNext, but returns a copy of the whole
+// update, including its priority.
+func (q *updateQueue) acquireNextUpdate() (repoUpdate, bool) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 	if len(q.heap) == 0 {
-		return configuredRepo{}, false
+		return repoUpdate{}, false
 	}
 	update := q.heap[0]
 	if update.Updating {
 		// Everything in the queue is already updating.
-		return configuredRepo{}, false
+		return repoUpdate{}, false
 	}
 	update.Updating = true
 	heap.Fix(q, update.Index)
-	return update.Repo, true
+	return *update, true
 }
 
 // The following methods implement heap.Interface based on the priority queue example:
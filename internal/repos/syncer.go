@@ -25,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -383,6 +384,7 @@ func (s *Syncer) notifyDeleted(ctx context.Context, deleted ...api.RepoID) {
 		d.Deleted = append(d.Deleted, &types.Repo{ID: id})
 	}
 	observeDiff(d)
+	s.enqueueDeletedWebhooks(ctx, deleted)
 
 	if s.Synced != nil && d.Len() > 0 {
 		select {
@@ -392,6 +394,32 @@ func (s *Syncer) notifyDeleted(ctx context.Context, deleted ...api.RepoID) {
 	}
 }
 
+// enqueueDeletedWebhooks sends a repo:removed outbound webhook for each of the
+// given repos that was deleted. Repos that only lost their association to one
+// external service are still owned by another and are skipped.
+func (s *Syncer) enqueueDeletedWebhooks(ctx context.Context, deleted []api.RepoID) {
+	if len(deleted) == 0 {
+		return
+	}
+
+	rs, err := s.Store.RepoStore().List(ctx, database.ReposListOptions{
+		IDs:            deleted,
+		IncludeDeleted: true,
+		IncludeBlocked: true,
+	})
+	if err != nil {
+		s.ObsvCtx.Logger.Warn("failed to list deleted repos for outbound webhooks", log.Error(err))
+		return
+	}
+
+	db := database.NewDBWith(s.ObsvCtx.Logger, s.Store)
+	for _, r := range rs {
+		if !r.DeletedAt.IsZero() {
+			outbound.EnqueueRepo(ctx, s.ObsvCtx.Logger, db, outbound.RepoRemoved, r)
+		}
+	}
+}
+
 // ErrCloudDefaultSync is returned by SyncExternalService if an attempt to
 // sync a cloud default external service is done. We can't sync these external services
 // because their repos are added via the lazy-syncing mechanism on sourcegraph.com
@@ -657,6 +685,9 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		return types.RepoSyncDiff{}, errors.Wrap(err, "syncer: opening transaction")
 	}
 
+	// previousName is set if the sourced repo was renamed on the code host.
+	var previousName api.RepoName
+
 	defer func() {
 		observeDiff(d)
 		// We must commit the transaction before publishing to s.Synced
@@ -669,6 +700,8 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 			return
 		}
 
+		s.enqueueSyncWebhooks(ctx, d, previousName)
+
 		if s.Synced != nil && d.Len() > 0 {
 			select {
 			case <-ctx.Done():
@@ -728,6 +761,7 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 		if err := UpdateRepoLicenseHook(ctx, tx, stored[0], sourced); err != nil {
 			return types.RepoSyncDiff{}, LicenseError{errors.Wrapf(err, "syncer: failed to update repo %s", sourced.Name)}
 		}
+		name := stored[0].Name
 		modified := stored[0].Update(sourced)
 		if modified == types.RepoUnmodified {
 			d.Unmodified = append(d.Unmodified, stored[0])
@@ -738,6 +772,10 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 			return types.RepoSyncDiff{}, errors.Wrap(err, "syncer: failed to update external service repo")
 		}
 
+		if modified&types.RepoModifiedName == types.RepoModifiedName {
+			previousName = name
+		}
+
 		*sourced = *stored[0]
 		d.Modified = append(d.Modified, types.RepoModified{Repo: stored[0], Modified: modified})
 		s.ObsvCtx.Logger.Debug("appended to modified repos")
@@ -762,6 +800,22 @@ func (s *Syncer) sync(ctx context.Context, svc *types.ExternalService, sourced *
 	return d, nil
 }
 
+// enqueueSyncWebhooks sends outbound webhooks for a repo that was added or
+// renamed by sync. It must only be called once the sync transaction has been
+// committed, so that receivers can look up the repo right away.
+func (s *Syncer) enqueueSyncWebhooks(ctx context.Context, d types.RepoSyncDiff, previousName api.RepoName) {
+	db := database.NewDBWith(s.ObsvCtx.Logger, s.Store)
+	for _, r := range d.Added {
+		outbound.EnqueueRepo(ctx, s.ObsvCtx.Logger, db, outbound.RepoAdded, r)
+	}
+	if previousName == "" {
+		return
+	}
+	for _, r := range d.Modified.Repos() {
+		outbound.EnqueueRepoRenamed(ctx, s.ObsvCtx.Logger, db, r, previousName)
+	}
+}
+
 // CreateRepoLicenseHook checks if there is still room for private repositories
 // available in the applied license before creating a new private repository.
 func CreateRepoLicenseHook(ctx context.Context, s Store, repo *types.Repo) error {
@@ -254,6 +254,12 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 		return nil, err
 	}
 
+	// Kinds aren't stored in rockskip_symbols, so they're filtered after parsing.
+	includeKinds := goset.NewSet[string]()
+	for _, kind := range args.IncludeKinds {
+		includeKinds.Add(strings.ToLower(kind))
+	}
+
 	paths := goset.NewSet[string]()
 	for rows.Next() {
 		var path string
@@ -287,6 +293,9 @@ func (s *Service) querySymbols(ctx context.Context, args search.SymbolsParameter
 		lines := strings.Split(string(contents), "\n")
 
 		for _, symbol := range allSymbols {
+			if includeKinds.Len() > 0 && !includeKinds.Contains(strings.ToLower(symbol.Kind)) {
+				continue
+			}
 			if isMatch(symbol.Name) {
 				if symbol.Line < 1 || symbol.Line > len(lines) {
 					log15.Warn("ctags returned an invalid line number", "path", path, "line", symbol.Line, "len(lines)", len(lines), "symbol", symbol.Name)
@@ -0,0 +1,165 @@
+package scim
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	AttrMembers       = "members"
+	AttrMemberValue   = "value"
+	AttrMemberDisplay = "display"
+	AttrMemberType    = "type"
+	AttrMemberRef     = "$ref"
+
+	memberTypeUser  = "User"
+	memberTypeGroup = "Group"
+)
+
+// Group is a SCIM group, backed by a team. Users in the group are the members of the team,
+// and groups nested in the group are its child teams.
+type Group struct {
+	Team        types.Team
+	MemberUsers []*types.User
+	ChildTeams  []*types.Team
+}
+
+func (g *Group) ToResource() scim.Resource {
+	members := make([]interface{}, 0, len(g.MemberUsers)+len(g.ChildTeams))
+	for _, user := range g.MemberUsers {
+		members = append(members, map[string]interface{}{
+			AttrMemberValue:   strconv.FormatInt(int64(user.ID), 10),
+			AttrMemberDisplay: user.Username,
+			AttrMemberType:    memberTypeUser,
+		})
+	}
+	for _, team := range g.ChildTeams {
+		members = append(members, map[string]interface{}{
+			AttrMemberValue:   strconv.FormatInt(int64(team.ID), 10),
+			AttrMemberDisplay: teamDisplayName(team),
+			AttrMemberType:    memberTypeGroup,
+		})
+	}
+
+	attributes := scim.ResourceAttributes{
+		AttrDisplayName: teamDisplayName(&g.Team),
+		AttrMembers:     members,
+	}
+	if g.Team.SCIMExternalID != "" {
+		attributes[AttrExternalId] = g.Team.SCIMExternalID
+	}
+
+	return scim.Resource{
+		ID:         strconv.FormatInt(int64(g.Team.ID), 10),
+		ExternalID: getOptionalExternalID(attributes),
+		Attributes: attributes,
+		Meta: scim.Meta{
+			Created:      &g.Team.CreatedAt,
+			LastModified: &g.Team.UpdatedAt,
+		},
+	}
+}
+
+// teamDisplayName returns the display name of the given team, falling back to its name.
+func teamDisplayName(team *types.Team) string {
+	if team.DisplayName != "" {
+		return team.DisplayName
+	}
+	return team.Name
+}
+
+// groupMembers are the IDs of the users and nested groups of a SCIM group.
+type groupMembers struct {
+	userIDs  map[int32]struct{}
+	groupIDs map[int32]struct{}
+}
+
+// extractGroupMembers extracts the members of a group from the given attributes. User and team
+// IDs overlap, so every member must say whether it is a user or a group, either through its type
+// or its reference. Members which don't are rejected rather than guessed.
+func extractGroupMembers(attributes scim.ResourceAttributes) (groupMembers, error) {
+	result := groupMembers{
+		userIDs:  map[int32]struct{}{},
+		groupIDs: map[int32]struct{}{},
+	}
+
+	members, _ := attributes[AttrMembers].([]interface{})
+	for _, rawMember := range members {
+		member, ok := rawMember.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		value := strings.TrimSpace(fmt.Sprint(member[AttrMemberValue]))
+		id, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return groupMembers{}, scimerrors.ScimErrorBadParams([]string{fmt.Sprintf("invalid member %q", value)})
+		}
+
+		kind, err := groupMemberType(member)
+		if err != nil {
+			return groupMembers{}, scimerrors.ScimErrorBadParams([]string{fmt.Sprintf("member %q: %s", value, err)})
+		}
+		if kind == memberTypeGroup {
+			result.groupIDs[int32(id)] = struct{}{}
+		} else {
+			result.userIDs[int32(id)] = struct{}{}
+		}
+	}
+
+	return result, nil
+}
+
+// groupMemberType returns whether the given member is a user or a group. The type attribute wins
+// if set; otherwise the kind of resource the reference points to is used.
+func groupMemberType(member map[string]interface{}) (string, error) {
+	var refType string
+	if ref, _ := member[AttrMemberRef].(string); ref != "" {
+		switch {
+		case strings.Contains(ref, "/Users/"):
+			refType = memberTypeUser
+		case strings.Contains(ref, "/Groups/"):
+			refType = memberTypeGroup
+		}
+	}
+
+	rawType, _ := member[AttrMemberType].(string)
+	var memberType string
+	switch {
+	case rawType == "":
+		if refType == "" {
+			return "", errors.New("type must be User or Group")
+		}
+		return refType, nil
+	case strings.EqualFold(rawType, memberTypeUser):
+		memberType = memberTypeUser
+	case strings.EqualFold(rawType, memberTypeGroup):
+		memberType = memberTypeGroup
+	default:
+		return "", errors.Newf("unknown type %q", rawType)
+	}
+	if refType != "" && refType != memberType {
+		return "", errors.Newf("type %q does not match reference", rawType)
+	}
+	return memberType, nil
+}
+
+// difference returns the sorted IDs in a which are not in b.
+func difference(a, b map[int32]struct{}) []int32 {
+	result := []int32{}
+	for id := range a {
+		if _, ok := b[id]; !ok {
+			result = append(result, id)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
@@ -0,0 +1,51 @@
+package scim
+
+import (
+	"github.com/elimity-com/scim"
+	"github.com/elimity-com/scim/optional"
+	"github.com/elimity-com/scim/schema"
+)
+
+// Schema creates a SCIM core schema for groups.
+func (g *GroupSCIMService) Schema() schema.Schema {
+	return schema.Schema{
+		ID:          "urn:ietf:params:scim:schemas:core:2.0:Group",
+		Name:        optional.NewString("Group"),
+		Description: optional.NewString("Group"),
+		Attributes: []schema.CoreAttribute{
+			schema.SimpleCoreAttribute(schema.SimpleStringParams(schema.StringParams{
+				Description: optional.NewString("A human-readable name for the Group. REQUIRED."),
+				Name:        "displayName",
+				Required:    true,
+			})),
+			schema.ComplexCoreAttribute(schema.ComplexParams{
+				Description: optional.NewString("A list of members of the Group."),
+				MultiValued: true,
+				Name:        "members",
+				SubAttributes: []schema.SimpleParams{
+					schema.SimpleStringParams(schema.StringParams{
+						Description: optional.NewString("Identifier of the member of this Group."),
+						Name:        "value",
+					}),
+					schema.SimpleStringParams(schema.StringParams{
+						Description: optional.NewString("A human-readable name, primarily used for display purposes. READ-ONLY."),
+						Name:        "display",
+					}),
+					schema.SimpleStringParams(schema.StringParams{
+						CanonicalValues: []string{"User", "Group"},
+						Description:     optional.NewString("A label indicating the type of resource, e.g., 'User' or 'Group'."),
+						Name:            "type",
+					}),
+					schema.SimpleStringParams(schema.StringParams{
+						Description: optional.NewString("The URI corresponding to a SCIM resource that is a member of this Group."),
+						Name:        "$ref",
+					}),
+				},
+			}),
+		},
+	}
+}
+
+func (g *GroupSCIMService) SchemaExtensions() []scim.SchemaExtension {
+	return []scim.SchemaExtension{}
+}
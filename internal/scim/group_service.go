@@ -0,0 +1,399 @@
+package scim
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// NewGroupResourceHandler returns a new ResourceHandler for groups, which are mapped onto
+// teams.
+func NewGroupResourceHandler(ctx context.Context, observationCtx *observation.Context, db database.DB) *ResourceHandler {
+	groupSCIMService := &GroupSCIMService{
+		db: db,
+	}
+	return &ResourceHandler{
+		ctx:              ctx,
+		observationCtx:   observationCtx,
+		coreSchema:       groupSCIMService.Schema(),
+		schemaExtensions: groupSCIMService.SchemaExtensions(),
+		service:          groupSCIMService,
+	}
+}
+
+// GroupSCIMService maps SCIM groups onto teams. Users in a group are the members of its team,
+// and groups nested in a group are the child teams of its team. As a team has at most one
+// parent, a group can only be nested in a single other group.
+//
+// Only teams created through SCIM are exposed as groups. They are read-only, as their members
+// are managed by the identity provider.
+type GroupSCIMService struct {
+	db database.DB
+}
+
+func (g *GroupSCIMService) Get(ctx context.Context, id string) (scim.Resource, error) {
+	group, err := getGroupFromDB(ctx, g.db, id)
+	if err != nil {
+		return scim.Resource{}, err
+	}
+	return group.ToResource(), nil
+}
+
+func (g *GroupSCIMService) GetAll(ctx context.Context, start int, count *int) (totalCount int, entities []scim.Resource, err error) {
+	// Calculate offset
+	var offset int
+	if start > 0 {
+		offset = start - 1
+	}
+
+	opts := database.ListTeamsOpts{OnlySCIMControlled: true}
+	total, err := g.db.Teams().CountTeams(ctx, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if count != nil {
+		if offset >= int(total) || *count == 0 {
+			return int(total), []scim.Resource{}, nil
+		}
+		opts.LimitOffset = &database.LimitOffset{Limit: *count, Offset: offset}
+	}
+	teams, _, err := g.db.Teams().ListTeams(ctx, opts)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	entities = make([]scim.Resource, 0, len(teams))
+	for _, team := range teams {
+		group, err := loadGroup(ctx, g.db, team)
+		if err != nil {
+			return 0, nil, err
+		}
+		entities = append(entities, group.ToResource())
+	}
+
+	return int(total), entities, nil
+}
+
+func (g *GroupSCIMService) Update(ctx context.Context, id string, applySCIMUpdates func(getResource func() scim.Resource) (updated scim.Resource, _ error)) (finalResource scim.Resource, _ error) {
+	var resourceAfterUpdate scim.Resource
+	err := g.db.WithTransact(ctx, func(tx database.DB) error {
+		group, err := getGroupFromDB(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// Capture a copy of the resource before applying updates so it can be compared to determine which
+		// database updates are necessary
+		resourceBeforeUpdate := group.ToResource()
+		resourceAfterUpdate, err = applySCIMUpdates(group.ToResource)
+		if err != nil {
+			return err
+		}
+
+		return updateGroup(ctx, tx, group.Team, resourceBeforeUpdate.Attributes, resourceAfterUpdate.Attributes)
+	})
+	if err != nil {
+		multiErr, ok := err.(errors.MultiError)
+		if !ok || len(multiErr.Errors()) == 0 {
+			return scim.Resource{}, err
+		}
+		return scim.Resource{}, multiErr.Errors()[len(multiErr.Errors())-1]
+	}
+	return g.Get(ctx, id)
+}
+
+func (g *GroupSCIMService) Create(ctx context.Context, attributes scim.ResourceAttributes) (scim.Resource, error) {
+	displayName := extractStringAttribute(attributes, AttrDisplayName)
+	if displayName == "" {
+		return scim.Resource{}, scimerrors.ScimErrorBadParams([]string{"displayName missing"})
+	}
+
+	var teamID int32
+	err := g.db.WithTransact(ctx, func(tx database.DB) error {
+		name, err := auth.NormalizeUsername(displayName)
+		if err != nil {
+			// Empty name after normalization. Generate a random one, it's the best we can do.
+			name, err = auth.AddRandomSuffix("team")
+			if err != nil {
+				return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+			}
+		}
+
+		// Teams that are not SCIM-controlled are never taken over, as their members are managed
+		// in Sourcegraph.
+		existing, err := tx.Teams().GetTeamByName(ctx, name)
+		if err != nil && !errcode.IsNotFound(err) {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+		}
+		if existing != nil {
+			return scimerrors.ScimError{Status: http.StatusConflict, Detail: "Group already exists based on display name"}
+		}
+
+		team, err := createTeam(ctx, tx.Teams(), &types.Team{
+			Name:           name,
+			DisplayName:    displayName,
+			ReadOnly:       true,
+			SCIMControlled: true,
+			SCIMExternalID: getOptionalExternalID(attributes).Value(),
+		})
+		if err != nil {
+			return err
+		}
+		teamID = team.ID
+
+		return updateGroup(ctx, tx, *team, scim.ResourceAttributes{AttrDisplayName: displayName}, attributes)
+	})
+	if err != nil {
+		multiErr, ok := err.(errors.MultiError)
+		if !ok || len(multiErr.Errors()) == 0 {
+			return scim.Resource{}, err
+		}
+		return scim.Resource{}, multiErr.Errors()[len(multiErr.Errors())-1]
+	}
+
+	return g.Get(ctx, strconv.Itoa(int(teamID)))
+}
+
+func (g *GroupSCIMService) Delete(ctx context.Context, id string) error {
+	return g.db.WithTransact(ctx, func(tx database.DB) error {
+		group, err := getGroupFromDB(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+
+		// Child teams are deleted along with their parent, but nested groups and teams created
+		// in Sourcegraph outlive the groups they are nested in, so they become root teams
+		// instead.
+		childTeams, _, err := tx.Teams().ListTeams(ctx, database.ListTeamsOpts{WithParentID: group.Team.ID})
+		if err != nil {
+			return errors.Wrap(err, "list child teams")
+		}
+		for _, child := range childTeams {
+			c := *child
+			c.ParentTeamID = 0
+			if err := tx.Teams().UpdateTeam(ctx, &c); err != nil {
+				return errors.Wrap(err, "detach nested team")
+			}
+		}
+
+		return tx.Teams().DeleteTeam(ctx, group.Team.ID)
+	})
+}
+
+// Helper functions used for Groups
+
+// getGroupFromDB returns the group with the given ID. Teams that are not SCIM-controlled are
+// not found.
+// When it fails, it returns an error that's safe to return to the client as a SCIM error.
+func getGroupFromDB(ctx context.Context, db database.DB, idStr string) (*Group, error) {
+	id, err := strconv.ParseInt(idStr, 10, 32)
+	if err != nil {
+		return nil, scimerrors.ScimErrorResourceNotFound(idStr)
+	}
+
+	team, err := db.Teams().GetTeamByID(ctx, int32(id))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, scimerrors.ScimErrorResourceNotFound(idStr)
+		}
+		return nil, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+	if !team.SCIMControlled {
+		return nil, scimerrors.ScimErrorResourceNotFound(idStr)
+	}
+
+	group, err := loadGroup(ctx, db, team)
+	if err != nil {
+		return nil, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+	return group, nil
+}
+
+// loadGroup loads the members and the SCIM-controlled child teams of the given team.
+func loadGroup(ctx context.Context, db database.DB, team *types.Team) (*Group, error) {
+	members, _, err := db.Teams().ListTeamMembers(ctx, database.ListTeamMembersOpts{TeamID: team.ID})
+	if err != nil {
+		return nil, errors.Wrap(err, "list team members")
+	}
+
+	var users []*types.User
+	if len(members) > 0 {
+		userIDs := make([]int32, 0, len(members))
+		for _, member := range members {
+			userIDs = append(userIDs, member.UserID)
+		}
+		users, err = db.Users().List(ctx, &database.UsersListOptions{UserIDs: userIDs})
+		if err != nil {
+			return nil, errors.Wrap(err, "list team member users")
+		}
+	}
+
+	childTeams, _, err := db.Teams().ListTeams(ctx, database.ListTeamsOpts{WithParentID: team.ID, OnlySCIMControlled: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "list child teams")
+	}
+
+	return &Group{
+		Team:        *team,
+		MemberUsers: users,
+		ChildTeams:  childTeams,
+	}, nil
+}
+
+// createTeam creates the given team, adding a random suffix to its name if it is already
+// taken by a user or an organization.
+func createTeam(ctx context.Context, store database.TeamStore, team *types.Team) (*types.Team, error) {
+	created, err := store.CreateTeam(ctx, team)
+	if errors.Is(err, database.ErrTeamNameAlreadyExists) {
+		team.Name, err = auth.AddRandomSuffix(team.Name)
+		if err != nil {
+			return nil, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "could not generate team name").Error()}
+		}
+		created, err = store.CreateTeam(ctx, team)
+	}
+	if err != nil {
+		return nil, scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+	return created, nil
+}
+
+// updateGroup applies the changes between the given attributes of a group to its team: its
+// display name and external ID, its members and its child teams.
+func updateGroup(ctx context.Context, tx database.DB, team types.Team, before, after scim.ResourceAttributes) error {
+	displayName := extractStringAttribute(after, AttrDisplayName)
+	if displayName == "" {
+		return scimerrors.ScimErrorBadParams([]string{"displayName missing"})
+	}
+
+	beforeMembers, err := extractGroupMembers(before)
+	if err != nil {
+		return err
+	}
+	afterMembers, err := extractGroupMembers(after)
+	if err != nil {
+		return err
+	}
+
+	// Update the team itself
+	externalID := getOptionalExternalID(after).Value()
+	if team.DisplayName != displayName || team.SCIMExternalID != externalID {
+		team.DisplayName = displayName
+		team.SCIMExternalID = externalID
+		if err := tx.Teams().UpdateTeam(ctx, &team); err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "update team").Error()}
+		}
+	}
+
+	// Update user members
+	if usersToAdd := difference(afterMembers.userIDs, beforeMembers.userIDs); len(usersToAdd) > 0 {
+		users, err := tx.Users().List(ctx, &database.UsersListOptions{UserIDs: usersToAdd})
+		if err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+		}
+		if len(users) != len(usersToAdd) {
+			found := make(map[int32]struct{}, len(users))
+			for _, user := range users {
+				found[user.ID] = struct{}{}
+			}
+			var invalid []string
+			for _, id := range usersToAdd {
+				if _, ok := found[id]; !ok {
+					invalid = append(invalid, fmt.Sprintf("unknown user member %d", id))
+				}
+			}
+			return scimerrors.ScimErrorBadParams(invalid)
+		}
+
+		members := make([]*types.TeamMember, 0, len(usersToAdd))
+		for _, id := range usersToAdd {
+			members = append(members, &types.TeamMember{TeamID: team.ID, UserID: id})
+		}
+		if err := tx.Teams().CreateTeamMember(ctx, members...); err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "add team members").Error()}
+		}
+	}
+	if usersToRemove := difference(beforeMembers.userIDs, afterMembers.userIDs); len(usersToRemove) > 0 {
+		members := make([]*types.TeamMember, 0, len(usersToRemove))
+		for _, id := range usersToRemove {
+			members = append(members, &types.TeamMember{TeamID: team.ID, UserID: id})
+		}
+		if err := tx.Teams().DeleteTeamMember(ctx, members...); err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "remove team members").Error()}
+		}
+	}
+
+	// Update nested groups
+	for _, id := range difference(afterMembers.groupIDs, beforeMembers.groupIDs) {
+		if err := nestTeam(ctx, tx.Teams(), team, id); err != nil {
+			return err
+		}
+	}
+	for _, id := range difference(beforeMembers.groupIDs, afterMembers.groupIDs) {
+		child, err := tx.Teams().GetTeamByID(ctx, id)
+		if err != nil {
+			if errcode.IsNotFound(err) {
+				continue
+			}
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+		}
+		if child.ParentTeamID != team.ID || !child.SCIMControlled {
+			continue
+		}
+
+		c := *child
+		c.ParentTeamID = 0
+		if err := tx.Teams().UpdateTeam(ctx, &c); err != nil {
+			return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "remove nested team").Error()}
+		}
+	}
+
+	return nil
+}
+
+// nestTeam makes the team with the given ID a child team of the given parent team.
+func nestTeam(ctx context.Context, store database.TeamStore, parent types.Team, childID int32) error {
+	child, err := store.GetTeamByID(ctx, childID)
+	if err != nil && !errcode.IsNotFound(err) {
+		return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+	if child == nil || !child.SCIMControlled {
+		return scimerrors.ScimErrorBadParams([]string{fmt.Sprintf("unknown group member %d", childID)})
+	}
+	if child.ParentTeamID == parent.ID {
+		return nil
+	}
+	if child.ParentTeamID != 0 {
+		return scimerrors.ScimError{
+			Status: http.StatusConflict,
+			Detail: fmt.Sprintf("group %d is already a member of group %d, and a group can only be a member of a single group", childID, child.ParentTeamID),
+		}
+	}
+
+	// The parent must not be the child itself, or one of its descendants
+	parentOutsideOfDescendants, err := store.ContainsTeam(ctx, parent.ID, database.ListTeamsOpts{ExceptAncestorID: childID})
+	if err != nil {
+		return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: err.Error()}
+	}
+	if !parentOutsideOfDescendants {
+		return scimerrors.ScimErrorBadParams([]string{fmt.Sprintf("group %d cannot be a member of its own member group %d", childID, parent.ID)})
+	}
+
+	c := *child
+	c.ParentTeamID = parent.ID
+	if err := store.UpdateTeam(ctx, &c); err != nil {
+		return scimerrors.ScimError{Status: http.StatusInternalServerError, Detail: errors.Wrap(err, "add nested team").Error()}
+	}
+	return nil
+}
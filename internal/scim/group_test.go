@@ -0,0 +1,251 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/elimity-com/scim"
+	scimerrors "github.com/elimity-com/scim/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/database/fakedb"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func newGroupTestHandler() (fakedb.Fakes, *ResourceHandler) {
+	fs := fakedb.New()
+	db := dbmocks.NewMockDB()
+	fs.Wire(db)
+	return fs, NewGroupResourceHandler(context.Background(), &observation.TestContext, db)
+}
+
+func userMember(id int32) map[string]interface{} {
+	return map[string]interface{}{AttrMemberValue: strconv.Itoa(int(id)), AttrMemberType: memberTypeUser}
+}
+
+func groupMember(id int32) map[string]interface{} {
+	return map[string]interface{}{AttrMemberValue: strconv.Itoa(int(id)), AttrMemberType: memberTypeGroup}
+}
+
+func teamMemberIDs(t *testing.T, fs fakedb.Fakes, teamID int32) []int32 {
+	t.Helper()
+	members, _, err := fs.TeamStore.ListTeamMembers(context.Background(), database.ListTeamMembersOpts{TeamID: teamID})
+	require.NoError(t, err)
+	ids := []int32{}
+	for _, m := range members {
+		ids = append(ids, m.UserID)
+	}
+	return ids
+}
+
+func getTeam(t *testing.T, fs fakedb.Fakes, id int32) types.Team {
+	t.Helper()
+	team, err := fs.TeamStore.GetTeamByID(context.Background(), id)
+	require.NoError(t, err)
+	return *team
+}
+
+func Test_GroupResourceHandler_Create(t *testing.T) {
+	t.Run("new team", func(t *testing.T) {
+		fs, handler := newGroupTestHandler()
+		alice := fs.AddUser(types.User{Username: "alice"})
+		bob := fs.AddUser(types.User{Username: "bob"})
+
+		res, err := handler.Create(createDummyRequest(), scim.ResourceAttributes{
+			AttrDisplayName: "Platform Engineering",
+			AttrExternalId:  "ext-1",
+			AttrMembers:     toInterfaceSlice(userMember(alice), userMember(bob)),
+		})
+		require.NoError(t, err)
+
+		id, err := strconv.Atoi(res.ID)
+		require.NoError(t, err)
+		team := getTeam(t, fs, int32(id))
+		assert.Equal(t, "Platform-Engineering", team.Name)
+		assert.Equal(t, "Platform Engineering", team.DisplayName)
+		assert.Equal(t, "ext-1", team.SCIMExternalID)
+		assert.True(t, team.SCIMControlled)
+		assert.True(t, team.ReadOnly)
+		assert.ElementsMatch(t, []int32{alice, bob}, teamMemberIDs(t, fs, team.ID))
+		assert.Len(t, res.Attributes[AttrMembers], 2)
+	})
+
+	t.Run("conflict with team not managed through SCIM", func(t *testing.T) {
+		fs, handler := newGroupTestHandler()
+		alice := fs.AddUser(types.User{Username: "alice"})
+		bob := fs.AddUser(types.User{Username: "bob"})
+		teamID := fs.AddTeam(&types.Team{Name: "backend"})
+		fs.AddTeamMember(&types.TeamMember{TeamID: teamID, UserID: alice})
+
+		_, err := handler.Create(createDummyRequest(), scim.ResourceAttributes{
+			AttrDisplayName: "backend",
+			AttrMembers:     toInterfaceSlice(userMember(bob)),
+		})
+		var scimErr scimerrors.ScimError
+		require.ErrorAs(t, err, &scimErr)
+		assert.Equal(t, http.StatusConflict, scimErr.Status)
+
+		// The team is left alone
+		team := getTeam(t, fs, teamID)
+		assert.False(t, team.SCIMControlled)
+		assert.False(t, team.ReadOnly)
+		assert.Equal(t, []int32{alice}, teamMemberIDs(t, fs, teamID))
+	})
+
+	t.Run("conflict with SCIM-controlled team", func(t *testing.T) {
+		fs, handler := newGroupTestHandler()
+		fs.AddTeam(&types.Team{Name: "backend", SCIMControlled: true})
+
+		_, err := handler.Create(createDummyRequest(), scim.ResourceAttributes{AttrDisplayName: "backend"})
+		var scimErr scimerrors.ScimError
+		require.ErrorAs(t, err, &scimErr)
+		assert.Equal(t, http.StatusConflict, scimErr.Status)
+	})
+
+	t.Run("unknown user member", func(t *testing.T) {
+		_, handler := newGroupTestHandler()
+
+		_, err := handler.Create(createDummyRequest(), scim.ResourceAttributes{
+			AttrDisplayName: "backend",
+			AttrMembers:     toInterfaceSlice(userMember(42)),
+		})
+		var scimErr scimerrors.ScimError
+		require.ErrorAs(t, err, &scimErr)
+		assert.Equal(t, http.StatusBadRequest, scimErr.Status)
+	})
+}
+
+func Test_GroupResourceHandler_Get(t *testing.T) {
+	fs, handler := newGroupTestHandler()
+	scimID := fs.AddTeam(&types.Team{Name: "engineering", SCIMControlled: true})
+	fs.AddTeam(&types.Team{Name: "backend", ParentTeamID: scimID})
+	manualID := fs.AddTeam(&types.Team{Name: "manual"})
+
+	// Teams not managed through SCIM are not groups
+	res, err := handler.GetAll(createDummyRequest(), scim.ListRequestParams{Count: 10, StartIndex: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, res.TotalResults)
+	require.Len(t, res.Resources, 1)
+	assert.Equal(t, strconv.Itoa(int(scimID)), res.Resources[0].ID)
+	assert.Empty(t, res.Resources[0].Attributes[AttrMembers])
+
+	_, err = handler.Get(createDummyRequest(), strconv.Itoa(int(manualID)))
+	var scimErr scimerrors.ScimError
+	require.ErrorAs(t, err, &scimErr)
+	assert.Equal(t, http.StatusNotFound, scimErr.Status)
+}
+
+func Test_GroupResourceHandler_Patch(t *testing.T) {
+	fs, handler := newGroupTestHandler()
+	alice := fs.AddUser(types.User{Username: "alice"})
+	bob := fs.AddUser(types.User{Username: "bob"})
+	parentID := fs.AddTeam(&types.Team{Name: "engineering", SCIMControlled: true, ReadOnly: true})
+	childID := fs.AddTeam(&types.Team{Name: "backend", SCIMControlled: true, ReadOnly: true})
+	fs.AddTeamMember(&types.TeamMember{TeamID: parentID, UserID: alice})
+	parent := strconv.Itoa(int(parentID))
+
+	// Add a user and a nested group
+	res, err := handler.Patch(createDummyRequest(), parent, []scim.PatchOperation{
+		{Op: "add", Path: createPath(AttrMembers, nil), Value: toInterfaceSlice(userMember(bob), groupMember(childID))},
+	})
+	require.NoError(t, err)
+	assert.Len(t, res.Attributes[AttrMembers], 3)
+	assert.ElementsMatch(t, []int32{alice, bob}, teamMemberIDs(t, fs, parentID))
+	assert.Equal(t, parentID, getTeam(t, fs, childID).ParentTeamID)
+
+	// Nesting the parent in its own child is rejected
+	_, err = handler.Patch(createDummyRequest(), strconv.Itoa(int(childID)), []scim.PatchOperation{
+		{Op: "add", Path: createPath(AttrMembers, nil), Value: toInterfaceSlice(groupMember(parentID))},
+	})
+	var scimErr scimerrors.ScimError
+	require.ErrorAs(t, err, &scimErr)
+	assert.Equal(t, http.StatusBadRequest, scimErr.Status)
+
+	// Remove members by value, the way Azure AD does
+	_, err = handler.Patch(createDummyRequest(), parent, []scim.PatchOperation{
+		{Op: "remove", Path: createPath(AttrMembers, nil), Value: toInterfaceSlice(userMember(alice), groupMember(childID))},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{bob}, teamMemberIDs(t, fs, parentID))
+	assert.Equal(t, int32(0), getTeam(t, fs, childID).ParentTeamID)
+
+	// Rename
+	res, err = handler.Patch(createDummyRequest(), parent, []scim.PatchOperation{
+		{Op: "replace", Path: createPath(AttrDisplayName, nil), Value: "Engineering"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Engineering", res.Attributes[AttrDisplayName])
+	assert.Equal(t, "Engineering", getTeam(t, fs, parentID).DisplayName)
+}
+
+func Test_GroupResourceHandler_Delete(t *testing.T) {
+	fs, handler := newGroupTestHandler()
+	parentID := fs.AddTeam(&types.Team{Name: "engineering", SCIMControlled: true})
+	childID := fs.AddTeam(&types.Team{Name: "backend", ParentTeamID: parentID})
+	manualID := fs.AddTeam(&types.Team{Name: "manual"})
+
+	require.NoError(t, handler.Delete(createDummyRequest(), strconv.Itoa(int(parentID))))
+
+	teams := fs.ListAllTeams()
+	require.Len(t, teams, 2)
+	assert.Equal(t, int32(0), getTeam(t, fs, childID).ParentTeamID)
+
+	// Teams not managed through SCIM are left alone
+	assert.Error(t, handler.Delete(createDummyRequest(), strconv.Itoa(int(manualID))))
+	assert.Len(t, fs.ListAllTeams(), 2)
+}
+
+func TestGroupToResource(t *testing.T) {
+	group := Group{
+		Team:        types.Team{ID: 1, Name: "engineering", DisplayName: "Engineering", SCIMExternalID: "ext-1"},
+		MemberUsers: []*types.User{{ID: 2, Username: "alice"}},
+		ChildTeams:  []*types.Team{{ID: 3, Name: "backend"}},
+	}
+
+	res := group.ToResource()
+	assert.Equal(t, "1", res.ID)
+	assert.Equal(t, "ext-1", res.ExternalID.Value())
+	assert.Equal(t, "Engineering", res.Attributes[AttrDisplayName])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{AttrMemberValue: "2", AttrMemberDisplay: "alice", AttrMemberType: memberTypeUser},
+		map[string]interface{}{AttrMemberValue: "3", AttrMemberDisplay: "backend", AttrMemberType: memberTypeGroup},
+	}, res.Attributes[AttrMembers])
+
+	members, err := extractGroupMembers(res.Attributes)
+	require.NoError(t, err)
+	assert.Equal(t, []int32{2}, difference(members.userIDs, nil))
+	assert.Equal(t, []int32{3}, difference(members.groupIDs, nil))
+}
+
+func TestExtractGroupMembers(t *testing.T) {
+	members, err := extractGroupMembers(scim.ResourceAttributes{
+		AttrMembers: toInterfaceSlice(
+			map[string]interface{}{AttrMemberValue: "1", AttrMemberType: "user"},
+			map[string]interface{}{AttrMemberValue: "2", AttrMemberRef: "https://example.com/.api/scim/v2/Groups/2"},
+			map[string]interface{}{AttrMemberValue: "3", AttrMemberRef: "https://example.com/.api/scim/v2/Users/3"},
+		),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int32{1, 3}, difference(members.userIDs, nil))
+	assert.Equal(t, []int32{2}, difference(members.groupIDs, nil))
+
+	for name, member := range map[string]map[string]interface{}{
+		"no type":          {AttrMemberValue: "1"},
+		"unknown type":     {AttrMemberValue: "1", AttrMemberType: "Device"},
+		"mismatched ref":   {AttrMemberValue: "1", AttrMemberType: memberTypeUser, AttrMemberRef: "https://example.com/.api/scim/v2/Groups/1"},
+		"unrecognized ref": {AttrMemberValue: "1", AttrMemberRef: "https://example.com/1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := extractGroupMembers(scim.ResourceAttributes{AttrMembers: toInterfaceSlice(member)})
+			var scimErr scimerrors.ScimError
+			require.ErrorAs(t, err, &scimErr)
+			assert.Equal(t, http.StatusBadRequest, scimErr.Status)
+		})
+	}
+}
@@ -218,6 +218,7 @@ func getMockDB(users []*types.UserForSCIM, usersEmails map[int32][]*database.Use
 	db.UserExternalAccountsFunc.SetDefaultReturn(userExternalAccountsStore)
 	db.UserEmailsFunc.SetDefaultReturn(userEmailsStore)
 	db.AuthzFunc.SetDefaultReturn(authzStore)
+	db.OutboundWebhooksFunc.SetDefaultReturn(dbmocks.NewMockOutboundWebhookStore())
 	return db
 }
 
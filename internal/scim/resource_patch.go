@@ -3,6 +3,7 @@ package scim
 import (
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/elimity-com/scim"
@@ -89,7 +90,14 @@ func (h *ResourceHandler) applyOperation(op scim.PatchOperation, resource *scim.
 
 		switch v := currentValue.(type) {
 		case []interface{}: // this value has multiple items
-			if valueExpr == nil { // this applies to whole attribute remove it
+			if valueExpr == nil {
+				if toRemove, ok := op.Value.([]interface{}); ok && len(toRemove) > 0 && attrName == AttrMembers {
+					// Only the listed group members should be removed. Identity providers like
+					// Azure AD remove members this way instead of using a filter.
+					applyAttributeChange(resource.Attributes, attrName, removeItemsByValue(v, toRemove), "replace")
+					return
+				}
+				// this applies to whole attribute remove it
 				applyAttributeChange(resource.Attributes, attrName, nil, op.Op)
 				return
 			}
@@ -303,6 +311,34 @@ func arrayItemMatchesFilter(attrName string, item interface{}, validator sgfilte
 	return validator.PassesFilter(tmp) == nil
 }
 
+// removeItemsByValue returns the items that don't match one of the items to remove. Items match
+// when they have the same "value" and, if the item to remove has one, the same "type".
+func removeItemsByValue(items []interface{}, toRemove []interface{}) []interface{} {
+	matches := func(item map[string]interface{}) bool {
+		for _, rawRemove := range toRemove {
+			remove, ok := rawRemove.(map[string]interface{})
+			if !ok || fmt.Sprint(remove["value"]) != fmt.Sprint(item["value"]) {
+				continue
+			}
+			removeType, _ := remove["type"].(string)
+			itemType, _ := item["type"].(string)
+			if removeType == "" || strings.EqualFold(removeType, itemType) {
+				return true
+			}
+		}
+		return false
+	}
+
+	remainingItems := []interface{}{}
+	for _, rawItem := range items {
+		if item, ok := rawItem.(map[string]interface{}); ok && matches(item) {
+			continue
+		}
+		remainingItems = append(remainingItems, rawItem)
+	}
+	return remainingItems
+}
+
 // buildFilterString converts filter.Expression (originally built from a string) back to a string.
 // It uses the attribute name so that the expression will work with a Validator.
 func buildFilterString(valueExpression filter.Expression, attrName string) string {
@@ -150,6 +150,26 @@ func Test_UserResourceHandler_PatchRemoveWithFilter(t *testing.T) {
 	assert.True(t, containsEmail(dbEmails, "primary@work.com", true, true))
 }
 
+func Test_UserResourceHandler_PatchRemoveWithValue(t *testing.T) {
+	// Only group members are removed one by one when listed in the value of a remove operation.
+	// Other multi-valued attributes are removed as a whole.
+	userResourceHandler := NewUserResourceHandler(context.Background(), &observation.TestContext, createMockDB())
+	resource := scim.Resource{Attributes: scim.ResourceAttributes{
+		AttrEmails: toInterfaceSlice(
+			map[string]interface{}{"value": "primary@work.com", "primary": true, "type": "work"},
+			map[string]interface{}{"value": "secondary@work.com", "primary": false, "type": "work"},
+		),
+	}}
+
+	err := userResourceHandler.applyOperation(scim.PatchOperation{
+		Op:    "remove",
+		Path:  parseStringPath("emails"),
+		Value: toInterfaceSlice(map[string]interface{}{"value": "secondary@work.com"}),
+	}, &resource)
+	assert.NoError(t, err)
+	assert.NotContains(t, resource.Attributes, AttrEmails)
+}
+
 func Test_UserResourceHandler_PatchReplaceWholeArrayField(t *testing.T) {
 	db := createMockDB()
 	userResourceHandler := NewUserResourceHandler(context.Background(), &observation.TestContext, db)
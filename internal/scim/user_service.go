@@ -25,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/txemail"
 	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -248,6 +249,8 @@ func (u *UserSCIMService) Create(ctx context.Context, attributes scim.ResourceAt
 		return scim.Resource{}, multiErr.Errors()[len(multiErr.Errors())-1]
 	}
 
+	outbound.EnqueueUserCreated(ctx, u.getLogger(), u.db, user)
+
 	// If there were additional emails provided, now that the user has been created
 	// we can try to add and verify them each in a separate trx so that if it fails we can ignore
 	// the error because they are not required.
@@ -317,6 +320,8 @@ func (u *UserSCIMService) Delete(ctx context.Context, id string) error {
 		return errors.Wrap(err, "delete user")
 	}
 
+	outbound.EnqueueUserDeleted(ctx, u.getLogger(), u.db, &user.User, true)
+
 	return nil
 }
 
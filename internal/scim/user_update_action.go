@@ -8,7 +8,10 @@ import (
 	scimerrors "github.com/elimity-com/scim/errors"
 	"k8s.io/utils/strings/slices"
 
+	"github.com/sourcegraph/log"
+
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/webhooks/outbound"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -190,6 +193,7 @@ func (u *hardDeleteInactiveUser) Update(ctx context.Context, before, after *scim
 	if err := u.tx.Users().HardDelete(ctx, u.user.ID); err != nil {
 		return err
 	}
+	outbound.EnqueueUserDeleted(ctx, log.Scoped("scim.user", "scim service for user"), u.tx, &u.user.User, true)
 
 	// NOTE: Practically, we don't reuse the ID for any new users, and the situation of left-over pending permissions
 	// is possible but highly unlikely. Therefore, there is no need to roll back user deletion even if this step failed.
@@ -217,6 +221,7 @@ func (u *softDeleteUser) Update(ctx context.Context, before, after *scim.Resourc
 	if err := u.tx.Users().Delete(ctx, u.user.ID); err != nil {
 		return err
 	}
+	outbound.EnqueueUserDeleted(ctx, log.Scoped("scim.user", "scim service for user"), u.tx, &u.user.User, false)
 
 	return nil
 }
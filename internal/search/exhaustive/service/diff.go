@@ -0,0 +1,234 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const (
+	diffAdded   = "added"
+	diffRemoved = "removed"
+)
+
+// diffLine is a line of the diff between the results of a search job and its
+// previous run.
+type diffLine struct {
+	Diff  string          `json:"diff"`
+	Match json.RawMessage `json:"match"`
+}
+
+type repoRevisionKey struct {
+	repoID   api.RepoID
+	revision string
+}
+
+// writeSearchJobDiff writes the matches added and removed between the
+// previous run previousID and the run id of a search job as JSON Lines.
+//
+// Repository revisions are compared by their JSON Lines results. Revisions
+// which resolved to the same commit in both runs are skipped, since their
+// results were copied over. Revisions which didn't complete in either run, or
+// whose results were only stored as CSV by an older job, are skipped as well,
+// since we can't tell which of their matches changed.
+func writeSearchJobDiff(ctx context.Context, uploadStore uploadstore.Store, previousID int64, previousRevs []types.RepoRevisionCommit, id int64, revs []types.RepoRevisionCommit, w io.Writer) (int64, error) {
+	previousByKey := make(map[repoRevisionKey]types.RepoRevisionCommit, len(previousRevs))
+	for _, r := range previousRevs {
+		previousByKey[repoRevisionKey{repoID: r.RepoID, revision: r.Revision}] = r
+	}
+
+	// readLines returns false if the results of r can't be compared.
+	readLines := func(jobID int64, r types.RepoRevisionCommit) ([][]byte, bool, error) {
+		if onlyCSV, err := hasOnlyCSVResults(ctx, uploadStore, jobID, r.ID); err != nil || onlyCSV {
+			return nil, false, err
+		}
+		lines, err := readRepoRevisionJSONLines(ctx, uploadStore, RepoRevisionJSONLinesPrefix(jobID, r.ID))
+		return lines, err == nil, err
+	}
+
+	wc := &writeCounter{w: w}
+	enc := json.NewEncoder(wc)
+	write := func(diff string, lines [][]byte) error {
+		for _, line := range lines {
+			if err := enc.Encode(diffLine{Diff: diff, Match: line}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, r := range revs {
+		key := repoRevisionKey{repoID: r.RepoID, revision: r.Revision}
+		previous, ok := previousByKey[key]
+		delete(previousByKey, key)
+
+		if r.State != types.JobStateCompleted {
+			continue
+		}
+
+		if ok && previous.State != types.JobStateCompleted {
+			continue
+		}
+
+		if !ok {
+			// The revision wasn't searched by the previous run, e.g. a new
+			// repository or branch.
+			added, ok, err := readLines(id, r)
+			if err != nil {
+				return wc.n, err
+			}
+			if !ok {
+				continue
+			}
+			if err := write(diffAdded, added); err != nil {
+				return wc.n, err
+			}
+			continue
+		}
+
+		if r.Commit != "" && r.Commit == previous.Commit {
+			continue
+		}
+
+		previousLines, ok, err := readLines(previousID, previous)
+		if err != nil {
+			return wc.n, err
+		}
+		if !ok {
+			continue
+		}
+		lines, ok, err := readLines(id, r)
+		if err != nil {
+			return wc.n, err
+		}
+		if !ok {
+			continue
+		}
+		removed, added, err := diffJSONLines(previousLines, lines)
+		if err != nil {
+			return wc.n, errors.Wrapf(err, "comparing results of repo revision job %d", r.ID)
+		}
+		if err := write(diffRemoved, removed); err != nil {
+			return wc.n, err
+		}
+		if err := write(diffAdded, added); err != nil {
+			return wc.n, err
+		}
+	}
+
+	// Revisions which are no longer searched, e.g. a deleted branch.
+	// Iterate previousRevs rather than the map to keep the output stable.
+	for _, previous := range previousRevs {
+		key := repoRevisionKey{repoID: previous.RepoID, revision: previous.Revision}
+		if _, ok := previousByKey[key]; !ok || previous.State != types.JobStateCompleted {
+			continue
+		}
+		removed, ok, err := readLines(previousID, previous)
+		if err != nil {
+			return wc.n, err
+		}
+		if !ok {
+			continue
+		}
+		if err := write(diffRemoved, removed); err != nil {
+			return wc.n, err
+		}
+	}
+
+	return wc.n, nil
+}
+
+// readRepoRevisionJSONLines returns every line written by a
+// BlobstoreJSONLinesWriter for prefix.
+func readRepoRevisionJSONLines(ctx context.Context, uploadStore uploadstore.Store, prefix string) ([][]byte, error) {
+	keys, err := listRepoRevisionKeys(ctx, uploadStore, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var lines [][]byte
+	for _, key := range keys {
+		rc, err := uploadStore.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		// We don't use a bufio.Scanner since lines contain the content of
+		// every chunk match, so they can exceed any reasonable limit.
+		br := bufio.NewReader(rc)
+		for {
+			line, err := br.ReadBytes('\n')
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				lines = append(lines, line)
+			}
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				rc.Close()
+				return nil, errors.Wrapf(err, "reading key %q", key)
+			}
+		}
+		rc.Close()
+	}
+
+	return lines, nil
+}
+
+// diffJSONLines returns the lines of previous missing from current and the
+// lines of current missing from previous. Lines are compared ignoring the
+// commit they were found in, so a match which didn't change between two
+// commits is not reported.
+func diffJSONLines(previous, current [][]byte) (removed, added [][]byte, err error) {
+	counts := make(map[string]int, len(previous))
+	for _, line := range previous {
+		key, err := diffKey(line)
+		if err != nil {
+			return nil, nil, err
+		}
+		counts[key]++
+	}
+
+	for _, line := range current {
+		key, err := diffKey(line)
+		if err != nil {
+			return nil, nil, err
+		}
+		if counts[key] > 0 {
+			counts[key]--
+			continue
+		}
+		added = append(added, line)
+	}
+
+	for _, line := range previous {
+		key, err := diffKey(line)
+		if err != nil {
+			return nil, nil, err
+		}
+		if counts[key] > 0 {
+			counts[key]--
+			removed = append(removed, line)
+		}
+	}
+
+	return removed, added, nil
+}
+
+// diffKey returns line without its commit. json.Marshal sorts the keys of a
+// map, so equal matches have equal keys.
+func diffKey(line []byte) (string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return "", err
+	}
+	delete(fields, "commit")
+	b, err := json.Marshal(fields)
+	return string(b), err
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/types"
+)
+
+func Test_writeSearchJobDiff(t *testing.T) {
+	ctx := context.Background()
+	blobstore := setupMockStore(t)
+
+	upload := func(key string, lines ...string) {
+		var b strings.Builder
+		for _, l := range lines {
+			b.WriteString(l + "\n")
+		}
+		_, err := blobstore.Upload(ctx, key, strings.NewReader(b.String()))
+		require.NoError(t, err)
+	}
+
+	// Job 1 is the previous run of job 2.
+	previousRevs := []types.RepoRevisionCommit{
+		// unchanged commit
+		{ID: 1, RepoID: 1, Revision: "main", Commit: "a", State: types.JobStateCompleted},
+		// changed commit
+		{ID: 2, RepoID: 2, Revision: "main", Commit: "b", State: types.JobStateCompleted},
+		// no longer searched
+		{ID: 3, RepoID: 3, Revision: "main", Commit: "c", State: types.JobStateCompleted},
+		// failed in the previous run
+		{ID: 4, RepoID: 4, Revision: "main", State: types.JobStateFailed},
+		// changed commit, but the previous run only stored CSV
+		{ID: 6, RepoID: 6, Revision: "main", Commit: "h", State: types.JobStateCompleted},
+	}
+	upload("jsonl-1-1", `{"path":"a.go","commit":"a"}`)
+	upload("jsonl-1-2", `{"path":"b.go","commit":"b"}`, `{"path":"c.go","commit":"b"}`, `{"path":"c.go","commit":"b"}`)
+	upload("jsonl-1-3", `{"path":"d.go","commit":"c"}`)
+	upload("1-6", "repository,revision,file_path,match_count,first_match_url", "r6,h,e.go,1,/r6@h/-/blob/e.go?L1")
+
+	revs := []types.RepoRevisionCommit{
+		{ID: 11, RepoID: 1, Revision: "main", Commit: "a", State: types.JobStateCompleted},
+		{ID: 12, RepoID: 2, Revision: "main", Commit: "e", State: types.JobStateCompleted},
+		{ID: 14, RepoID: 4, Revision: "main", Commit: "f", State: types.JobStateCompleted},
+		// new revision
+		{ID: 15, RepoID: 5, Revision: "main", Commit: "g", State: types.JobStateCompleted},
+		{ID: 16, RepoID: 6, Revision: "main", Commit: "i", State: types.JobStateCompleted},
+	}
+	upload("jsonl-2-11", `{"path":"a.go","commit":"a"}`)
+	upload("jsonl-2-12", `{"path":"c.go","commit":"e"}`, `{"path":"x.go","commit":"e"}`)
+	upload("jsonl-2-14", `{"path":"y.go","commit":"f"}`)
+	upload("jsonl-2-15", `{"path":"z.go","commit":"g"}`)
+	upload("jsonl-2-16", `{"path":"e.go","commit":"i"}`)
+	// Would be picked up if we only listed by prefix.
+	upload("jsonl-2-150", `{"path":"not-a-match.go","commit":"g"}`)
+
+	var buf bytes.Buffer
+	n, err := writeSearchJobDiff(ctx, blobstore, 1, previousRevs, 2, revs, &buf)
+	require.NoError(t, err)
+	require.Equal(t, int64(buf.Len()), n)
+
+	want := `{"diff":"removed","match":{"path":"b.go","commit":"b"}}
+{"diff":"removed","match":{"path":"c.go","commit":"b"}}
+{"diff":"added","match":{"path":"x.go","commit":"e"}}
+{"diff":"added","match":{"path":"z.go","commit":"g"}}
+{"diff":"removed","match":{"path":"d.go","commit":"c"}}
+`
+	require.Equal(t, want, buf.String())
+}
+
+func Test_diffJSONLines(t *testing.T) {
+	lines := func(ls ...string) [][]byte {
+		var res [][]byte
+		for _, l := range ls {
+			res = append(res, []byte(l))
+		}
+		return res
+	}
+
+	removed, added, err := diffJSONLines(
+		lines(`{"path":"a.go","commit":"1"}`, `{"path":"b.go","commit":"1"}`),
+		// Key order and commit don't matter.
+		lines(`{"commit":"2","path":"a.go"}`, `{"path":"c.go","commit":"2"}`),
+	)
+	require.NoError(t, err)
+	require.Equal(t, lines(`{"path":"b.go","commit":"1"}`), removed)
+	require.Equal(t, lines(`{"path":"c.go","commit":"2"}`), added)
+
+	_, _, err = diffJSONLines(lines(`not json`), nil)
+	require.Error(t, err)
+}
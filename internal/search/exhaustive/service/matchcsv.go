@@ -1,15 +1,23 @@
 package service
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
+	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
+// matchCSVWriter writes one CSV row per file or commit. We only store the
+// JSON Lines written by matchJSONWriter, so the rows are computed from those
+// lines when the results are downloaded as CSV.
 type matchCSVWriter struct {
 	w         CSVWriter
 	headerTyp string
@@ -25,20 +33,51 @@ func newMatchCSVWriter(w CSVWriter) (*matchCSVWriter, error) {
 	return &matchCSVWriter{w: w, host: u}, nil
 }
 
-func (w *matchCSVWriter) Write(match result.Match) error {
+// WriteLine writes the row for a line written by matchJSONWriter.
+func (w *matchCSVWriter) WriteLine(line []byte) error {
+	var typ struct {
+		Type streamhttp.MatchType `json:"type"`
+	}
+	if err := json.Unmarshal(line, &typ); err != nil {
+		return err
+	}
+
+	var event streamhttp.EventMatch
+	switch typ.Type {
+	case streamhttp.ContentMatchType:
+		event = &streamhttp.EventContentMatch{}
+	case streamhttp.PathMatchType:
+		event = &streamhttp.EventPathMatch{}
+	case streamhttp.CommitMatchType:
+		event = &streamhttp.EventCommitMatch{}
+	default:
+		return errors.Errorf("match type %v not yet supported", typ.Type)
+	}
+	if err := json.Unmarshal(line, event); err != nil {
+		return err
+	}
+
+	return w.Write(event)
+}
+
+func (w *matchCSVWriter) Write(event streamhttp.EventMatch) error {
 	// TODO compare to logic used by the webapp to convert
 	// results into csv. See
 	// client/web/src/search/results/export/searchResultsExport.ts
 
-	switch m := match.(type) {
-	case *result.FileMatch:
-		return w.writeFileMatch(m)
+	switch m := event.(type) {
+	case *streamhttp.EventContentMatch:
+		return w.writeFileMatch(m.Repository, m.Commit, m.Path, m.ChunkMatches)
+	case *streamhttp.EventPathMatch:
+		return w.writeFileMatch(m.Repository, m.Commit, m.Path, nil)
+	case *streamhttp.EventCommitMatch:
+		return w.writeCommitMatch(m)
 	default:
-		return errors.Errorf("match type %T not yet supported", match)
+		return errors.Errorf("match type %T not yet supported", event)
 	}
 }
 
-func (w *matchCSVWriter) writeFileMatch(fm *result.FileMatch) error {
+func (w *matchCSVWriter) writeFileMatch(repo, commit, path string, chunkMatches []streamhttp.ChunkMatch) error {
 	// Differences to "Export CSV" in webapp. We have removed columns since it
 	// is easier to add columns than to remove them.
 	//
@@ -84,36 +123,93 @@ func (w *matchCSVWriter) writeFileMatch(fm *result.FileMatch) error {
 		}
 	}
 
+	file := result.File{
+		Repo:     sgtypes.MinimalRepo{Name: api.RepoName(repo)},
+		CommitID: api.CommitID(commit),
+		Path:     path,
+	}
+
 	firstMatchURL := *w.host
-	firstMatchURL.Path = fm.File.URLAtCommit().Path
+	firstMatchURL.Path = file.URLAtCommit().Path
 
-	if queryParam, ok := firstMatchRawQuery(fm.ChunkMatches); ok {
+	if queryParam, ok := firstMatchRawQuery(chunkMatches); ok {
 		firstMatchURL.RawQuery = queryParam
 	}
 
+	matchCount := 0
+	for _, cm := range chunkMatches {
+		matchCount += len(cm.Ranges)
+	}
+
 	return w.w.WriteRow(
 		// repository
-		string(fm.Repo.Name),
+		repo,
 
 		// revision
-		string(fm.CommitID),
+		commit,
 
 		// file_path
-		fm.Path,
+		path,
 
 		// match_count
-		strconv.Itoa(fm.ChunkMatches.MatchCount()),
+		strconv.Itoa(matchCount),
 
 		// first_match_url
 		firstMatchURL.String(),
 	)
 }
 
+func (w *matchCSVWriter) writeCommitMatch(cm *streamhttp.EventCommitMatch) error {
+	// Commit and diff matches share the same columns. The matched content
+	// is only available in the JSON Lines export.
+	if ok, err := w.writeHeader("commit"); err != nil {
+		return err
+	} else if ok {
+		if err := w.w.WriteHeader(
+			"repository",
+			"commit",
+			"author_name",
+			"author_date",
+			"match_count",
+			"commit_url",
+		); err != nil {
+			return err
+		}
+	}
+
+	// Like CommitMatch.ResultCount we count commits without highlights as
+	// one match.
+	matchCount := len(cm.Ranges)
+	if matchCount == 0 {
+		matchCount = 1
+	}
+
+	return w.w.WriteRow(
+		// repository
+		cm.Repository,
+
+		// commit
+		cm.OID,
+
+		// author_name
+		cm.AuthorName,
+
+		// author_date
+		cm.AuthorDate.Format(time.RFC3339),
+
+		// match_count
+		strconv.Itoa(matchCount),
+
+		// commit_url (matchJSONWriter already made it absolute)
+		cm.URL,
+	)
+}
+
 // firstMatchRawQuery returns the raw query parameter for the location of the
 // first match. This is what is appended to the sourcegraph URL when clicking
 // on a search result. eg if the match is on line 11 it is "L11". If it is
 // multiline to line 13 it will be L11-13.
-func firstMatchRawQuery(cms result.ChunkMatches) (string, bool) {
+func firstMatchRawQuery(cms []streamhttp.ChunkMatch) (string, bool) {
 	cm, ok := minChunkMatch(cms)
 	if !ok {
 		return "", false
@@ -133,9 +229,9 @@ func firstMatchRawQuery(cms result.ChunkMatches) (string, bool) {
 	return fmt.Sprintf("L%d", r.Start.Line+1), true
 }
 
-func minChunkMatch(cms result.ChunkMatches) (result.ChunkMatch, bool) {
+func minChunkMatch(cms []streamhttp.ChunkMatch) (streamhttp.ChunkMatch, bool) {
 	if len(cms) == 0 {
-		return result.ChunkMatch{}, false
+		return streamhttp.ChunkMatch{}, false
 	}
 	min := cms[0]
 	for _, cm := range cms[1:] {
@@ -146,9 +242,9 @@ func minChunkMatch(cms result.ChunkMatches) (result.ChunkMatch, bool) {
 	return min, true
 }
 
-func minRange(ranges result.Ranges) (result.Range, bool) {
+func minRange(ranges []streamhttp.Range) (streamhttp.Range, bool) {
 	if len(ranges) == 0 {
-		return result.Range{}, false
+		return streamhttp.Range{}, false
 	}
 	min := ranges[0]
 	for _, r := range ranges[1:] {
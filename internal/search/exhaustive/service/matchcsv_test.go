@@ -0,0 +1,27 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchCSVWriter(t *testing.T) {
+	var buf csvBuffer
+	w, err := newMatchCSVWriter(&buf)
+	require.NoError(t, err)
+
+	// Lines as written by matchJSONWriter.
+	require.NoError(t, w.WriteLine([]byte(`{"type":"commit","label":"","url":"/foo/-/commit/deadbeef","detail":"","repositoryID":1,"repository":"foo","oid":"deadbeef","message":"fix bug","authorName":"alice","authorDate":"2023-10-01T12:00:00Z","committerName":"","committerDate":"0001-01-01T00:00:00Z","content":"","ranges":[[1,4,3]]}`)))
+	require.NoError(t, w.WriteLine([]byte(`{"type":"commit","label":"","url":"/foo/-/commit/c0ffee","detail":"","repositoryID":1,"repository":"foo","oid":"c0ffee","message":"chore","authorName":"bob","authorDate":"2023-10-02T12:00:00Z","committerName":"","committerDate":"0001-01-01T00:00:00Z","content":"","ranges":null}`)))
+
+	autogold.Expect(`repository,commit,author_name,author_date,match_count,commit_url
+foo,deadbeef,alice,2023-10-01T12:00:00Z,1,/foo/-/commit/deadbeef
+foo,c0ffee,bob,2023-10-02T12:00:00Z,1,/foo/-/commit/c0ffee
+`).Equal(t, buf.buf.String())
+
+	// A CSV file has a single header, so we can't mix result types.
+	err = w.WriteLine([]byte(`{"type":"path","path":"README.md","repositoryID":1,"repository":"foo","commit":"c0ffee"}`))
+	require.Error(t, err)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"net/url"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// matchJSONWriter writes one JSON line per match. Unlike matchCSVWriter we
+// include every chunk match with its ranges, so this is the format to use for
+// per-match processing.
+//
+// We reuse the event types of the streaming search API so that consumers can
+// share the same decoding logic for both. The only difference is we always
+// include chunk matches, never line matches.
+type matchJSONWriter struct {
+	w    JSONLinesWriter
+	host *url.URL
+}
+
+func newMatchJSONWriter(w JSONLinesWriter) (*matchJSONWriter, error) {
+	externalURL := conf.Get().ExternalURL
+	u, err := url.Parse(externalURL)
+	if err != nil {
+		return nil, err
+	}
+	return &matchJSONWriter{w: w, host: u}, nil
+}
+
+func (w *matchJSONWriter) Write(match result.Match) error {
+	switch m := match.(type) {
+	case *result.FileMatch:
+		return w.w.WriteLine(w.fromFileMatch(m))
+	case *result.CommitMatch:
+		// Covers both commit and diff matches. For diff matches the content
+		// is the diff preview and ranges point into it.
+		return w.w.WriteLine(w.fromCommitMatch(m))
+	default:
+		return errors.Errorf("match type %T not yet supported", match)
+	}
+}
+
+func (w *matchJSONWriter) fromFileMatch(fm *result.FileMatch) streamhttp.EventMatch {
+	var branches []string
+	if fm.InputRev != nil {
+		branches = []string{*fm.InputRev}
+	}
+
+	if len(fm.ChunkMatches) == 0 {
+		return &streamhttp.EventPathMatch{
+			Type:         streamhttp.PathMatchType,
+			Path:         fm.Path,
+			PathMatches:  fromRanges(fm.PathMatches),
+			RepositoryID: int32(fm.Repo.ID),
+			Repository:   string(fm.Repo.Name),
+			Branches:     branches,
+			Commit:       string(fm.CommitID),
+		}
+	}
+
+	chunkMatches := make([]streamhttp.ChunkMatch, 0, len(fm.ChunkMatches))
+	for _, cm := range fm.ChunkMatches {
+		chunkMatches = append(chunkMatches, streamhttp.ChunkMatch{
+			Content:      cm.Content,
+			ContentStart: fromLocation(cm.ContentStart),
+			Ranges:       fromRanges(cm.Ranges),
+		})
+	}
+
+	return &streamhttp.EventContentMatch{
+		Type:         streamhttp.ContentMatchType,
+		Path:         fm.Path,
+		PathMatches:  fromRanges(fm.PathMatches),
+		RepositoryID: int32(fm.Repo.ID),
+		Repository:   string(fm.Repo.Name),
+		Branches:     branches,
+		Commit:       string(fm.CommitID),
+		ChunkMatches: chunkMatches,
+	}
+}
+
+func (w *matchJSONWriter) fromCommitMatch(cm *result.CommitMatch) *streamhttp.EventCommitMatch {
+	hls := cm.Body().ToHighlightedString()
+	ranges := make([][3]int32, len(hls.Highlights))
+	for i, h := range hls.Highlights {
+		ranges[i] = [3]int32{h.Line, h.Character, h.Length}
+	}
+
+	commitURL := *w.host
+	commitURL.Path = cm.URL().Path
+
+	// Note: we leave out Detail since it contains a relative date which is
+	// meaningless once the results are downloaded.
+	event := &streamhttp.EventCommitMatch{
+		Type:         streamhttp.CommitMatchType,
+		Label:        cm.Label(),
+		URL:          commitURL.String(),
+		RepositoryID: int32(cm.Repo.ID),
+		Repository:   string(cm.Repo.Name),
+		OID:          string(cm.Commit.ID),
+		Message:      string(cm.Commit.Message),
+		AuthorName:   cm.Commit.Author.Name,
+		AuthorDate:   cm.Commit.Author.Date,
+		Content:      hls.Value,
+		Ranges:       ranges,
+	}
+
+	if committer := cm.Commit.Committer; committer != nil {
+		event.CommitterName = committer.Name
+		event.CommitterDate = committer.Date
+	}
+
+	return event
+}
+
+func fromLocation(l result.Location) streamhttp.Location {
+	return streamhttp.Location{
+		Offset: l.Offset,
+		Line:   l.Line,
+		Column: l.Column,
+	}
+}
+
+func fromRanges(rs result.Ranges) []streamhttp.Range {
+	res := make([]streamhttp.Range, 0, len(rs))
+	for _, r := range rs {
+		res = append(res, streamhttp.Range{
+			Start: fromLocation(r.Start),
+			End:   fromLocation(r.End),
+		})
+	}
+	return res
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestMatchJSONWriter(t *testing.T) {
+	repo := types.MinimalRepo{ID: 1, Name: "foo"}
+
+	matches := []result.Match{
+		&result.FileMatch{
+			File: result.File{Repo: repo, CommitID: "c0ffee", Path: "main.go"},
+			ChunkMatches: result.ChunkMatches{{
+				Content:      "func main() {\n\tfmt.Println(\"hello\")",
+				ContentStart: result.Location{Offset: 20, Line: 2, Column: 0},
+				Ranges: result.Ranges{{
+					Start: result.Location{Offset: 35, Line: 3, Column: 1},
+					End:   result.Location{Offset: 46, Line: 3, Column: 12},
+				}},
+			}},
+		},
+		&result.FileMatch{
+			File: result.File{Repo: repo, CommitID: "c0ffee", Path: "README.md"},
+			PathMatches: []result.Range{{
+				Start: result.Location{Offset: 0, Line: 0, Column: 0},
+				End:   result.Location{Offset: 6, Line: 0, Column: 6},
+			}},
+		},
+		&result.CommitMatch{
+			Repo: repo,
+			Commit: gitdomain.Commit{
+				ID:      "deadbeef",
+				Message: "fix bug",
+				Author: gitdomain.Signature{
+					Name: "alice",
+					Date: time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC),
+				},
+			},
+			MessagePreview: &result.MatchedString{
+				Content: "fix bug",
+				MatchedRanges: result.Ranges{{
+					Start: result.Location{Offset: 4, Line: 0, Column: 4},
+					End:   result.Location{Offset: 7, Line: 0, Column: 7},
+				}},
+			},
+		},
+	}
+
+	var buf jsonLinesBuffer
+	w, err := newMatchJSONWriter(&buf)
+	require.NoError(t, err)
+	for _, m := range matches {
+		require.NoError(t, w.Write(m))
+	}
+
+	autogold.Expect(`{"type":"content","path":"main.go","repositoryID":1,"repository":"foo","commit":"c0ffee","hunks":null,"chunkMatches":[{"content":"func main() {\n\tfmt.Println(\"hello\")","contentStart":{"offset":20,"line":2,"column":0},"ranges":[{"start":{"offset":35,"line":3,"column":1},"end":{"offset":46,"line":3,"column":12}}]}]}
+{"type":"path","path":"README.md","pathMatches":[{"start":{"offset":0,"line":0,"column":0},"end":{"offset":6,"line":0,"column":6}}],"repositoryID":1,"repository":"foo","commit":"c0ffee"}
+{"type":"commit","label":"[foo](/foo) › [alice](/foo/-/commit/deadbeef): [fix bug](/foo/-/commit/deadbeef)","url":"/foo/-/commit/deadbeef","detail":"","repositoryID":1,"repository":"foo","oid":"deadbeef","message":"fix bug","authorName":"alice","authorDate":"2023-10-01T12:00:00Z","committerName":"","committerDate":"0001-01-01T00:00:00Z","content":"`+"```"+`COMMIT_EDITMSG\nfix bug\n`+"```"+`","ranges":[[1,4,3]]}
+`).Equal(t, buf.buf.String())
+}
@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search/exhaustive/types"
+	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/lib/iterator"
@@ -72,7 +75,15 @@ type SearchQuery interface {
 
 	ResolveRepositoryRevSpec(context.Context, types.RepositoryRevSpecs) ([]types.RepositoryRevision, error)
 
-	Search(context.Context, types.RepositoryRevision, CSVWriter) error
+	// ResolveCommit resolves the revision of a RepositoryRevision to the
+	// commit Search would search. When re-running a search job we use this to
+	// skip repository revisions which didn't change since the previous run.
+	ResolveCommit(context.Context, types.RepositoryRevision) (api.CommitID, error)
+
+	// Search writes the results for a repository revision to JSONLinesWriter.
+	// We only store JSON Lines since they contain every match. The CSV
+	// download is computed from them.
+	Search(context.Context, types.RepositoryRevision, JSONLinesWriter) error
 }
 
 // CSVWriter makes it so we can avoid caring about search types and leave it
@@ -90,6 +101,14 @@ type CSVWriter interface {
 	WriteRow(...string) error
 }
 
+// JSONLinesWriter is the JSON Lines sibling of CSVWriter. Unlike CSVWriter
+// every line is self-describing, so it can contain more detail than fits in
+// a CSV row (eg every chunk match and its ranges).
+type JSONLinesWriter interface {
+	// WriteLine marshals v as JSON and writes it as a single line.
+	WriteLine(v any) error
+}
+
 // NewBlobstoreCSVWriter creates a new BlobstoreCSVWriter which writes a CSV to
 // the store. BlobstoreCSVWriter takes care of chunking the CSV into blobs of
 // 100MiB, each with the same header row. Blobs are named {prefix}-{shard}
@@ -229,6 +248,77 @@ func (c *BlobstoreCSVWriter) Close() error {
 	return c.close()
 }
 
+// NewBlobstoreJSONLinesWriter creates a new BlobstoreJSONLinesWriter which
+// writes JSON Lines to the store. Like BlobstoreCSVWriter it chunks the output
+// into blobs of 100MiB named {prefix}-{shard} except for the first blob, which
+// is named {prefix}. A line is never split across blobs.
+//
+// The caller is expected to call Close() once and only once after the last call
+// to WriteLine.
+func NewBlobstoreJSONLinesWriter(ctx context.Context, store uploadstore.Store, prefix string) *BlobstoreJSONLinesWriter {
+	return &BlobstoreJSONLinesWriter{
+		maxBlobSizeBytes: 100 * 1024 * 1024,
+		ctx:              ctx,
+		prefix:           prefix,
+		store:            store,
+		key:              prefix,
+		// Start with "1" because we increment it before creating a new file. The second
+		// shard will be called {prefix}-2.
+		shard: 1,
+	}
+}
+
+type BlobstoreJSONLinesWriter struct {
+	// ctx is the context we use for uploading blobs.
+	ctx context.Context
+
+	maxBlobSizeBytes int64
+
+	prefix string
+
+	store uploadstore.Store
+
+	// key is the key of the blob we are currently buffering.
+	key string
+
+	// local buffer for the current blob.
+	buf bytes.Buffer
+
+	// shard is incremented before we create a new shard.
+	shard int
+}
+
+func (c *BlobstoreJSONLinesWriter) WriteLine(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	// Create new file if we've exceeded the max blob size.
+	if int64(c.buf.Len()) >= c.maxBlobSizeBytes {
+		if err := c.Close(); err != nil {
+			return errors.Wrapf(err, "error closing upload")
+		}
+
+		c.shard++
+		c.key = fmt.Sprintf("%s-%d", c.prefix, c.shard)
+		c.buf = bytes.Buffer{}
+	}
+
+	c.buf.Write(b)
+	c.buf.WriteByte('\n')
+	return nil
+}
+
+func (c *BlobstoreJSONLinesWriter) Close() error {
+	// Don't upload empty files.
+	if c.buf.Len() == 0 {
+		return nil
+	}
+	_, err := c.store.Upload(c.ctx, c.key, &c.buf)
+	return err
+}
+
 // NewSearcherFake is a convenient working implementation of SearchQuery which
 // always will write results generated from the repoRevs. It expects a query
 // string which looks like
@@ -239,7 +329,8 @@ func (c *BlobstoreCSVWriter) Close() error {
 //
 //	- RepositoryRevSpecs will return one RepositoryRevSpec per unique repository.
 //	- ResolveRepositoryRevSpec returns the repoRevs for that repository.
-//	- Search will write one result which is just the repo and revision.
+//	- ResolveCommit returns the revision as the commit.
+//	- Search will write one path match which is just the repo, revspec and revision.
 func NewSearcherFake() NewSearcher {
 	return newSearcherFunc(fakeNewSearch)
 }
@@ -312,15 +403,27 @@ func (s searcherFake) ResolveRepositoryRevSpec(ctx context.Context, repoRevSpec
 	return repoRevs, nil
 }
 
-func (s searcherFake) Search(ctx context.Context, r types.RepositoryRevision, w CSVWriter) error {
+func (s searcherFake) ResolveCommit(ctx context.Context, r types.RepositoryRevision) (api.CommitID, error) {
 	if err := isSameUser(ctx, s.userID); err != nil {
-		return err
+		return "", err
 	}
+	return api.CommitID(r.Revision), nil
+}
 
-	if err := w.WriteHeader("repo", "revspec", "revision"); err != nil {
+func (s searcherFake) Search(ctx context.Context, r types.RepositoryRevision, w JSONLinesWriter) error {
+	if err := isSameUser(ctx, s.userID); err != nil {
 		return err
 	}
-	return w.WriteRow(strconv.Itoa(int(r.Repository)), string(r.RevisionSpecifiers), string(r.Revision))
+
+	// A path match is the smallest event we can convert to CSV. We use the
+	// revspec as the path so it shows up in the output.
+	return w.WriteLine(&streamhttp.EventPathMatch{
+		Type:         streamhttp.PathMatchType,
+		Path:         string(r.RevisionSpecifiers),
+		RepositoryID: int32(r.Repository),
+		Repository:   strconv.Itoa(int(r.Repository)),
+		Commit:       r.Revision,
+	})
 }
 
 func isSameUser(ctx context.Context, userID int32) error {
@@ -3,6 +3,7 @@ package service
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -63,6 +64,20 @@ func (c *csvBuffer) WriteRow(row ...string) error {
 	return err
 }
 
+type jsonLinesBuffer struct {
+	buf bytes.Buffer
+}
+
+func (j *jsonLinesBuffer) WriteLine(v any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	j.buf.Write(b)
+	j.buf.WriteByte('\n')
+	return nil
+}
+
 func TestBlobstoreCSVWriter(t *testing.T) {
 	mockStore := setupMockStore(t)
 
@@ -129,6 +144,54 @@ func TestNoUploadIfNotData(t *testing.T) {
 	}
 }
 
+func TestBlobstoreJSONLinesWriter(t *testing.T) {
+	mockStore := setupMockStore(t)
+
+	jsonWriter := NewBlobstoreJSONLinesWriter(context.Background(), mockStore, "blob")
+	jsonWriter.maxBlobSizeBytes = 12
+
+	err := jsonWriter.WriteLine(map[string]string{"a": "a"}) // 9 bytes + 1 byte (newline) = 10 bytes
+	require.NoError(t, err)
+	err = jsonWriter.WriteLine(map[string]string{"b": "b"})
+	require.NoError(t, err)
+	// We expect a new file to be created here because we have reached the max blob size.
+	err = jsonWriter.WriteLine(map[string]string{"c": "c"})
+	require.NoError(t, err)
+
+	err = jsonWriter.Close()
+	require.NoError(t, err)
+
+	tc := []struct {
+		wantKey  string
+		wantBlob []byte
+	}{
+		{
+			wantKey:  "blob",
+			wantBlob: []byte("{\"a\":\"a\"}\n{\"b\":\"b\"}\n"),
+		},
+		{
+			wantKey:  "blob-2",
+			wantBlob: []byte("{\"c\":\"c\"}\n"),
+		},
+	}
+
+	iter, err := mockStore.List(context.Background(), "")
+	require.NoError(t, err)
+	keys, err := iterator.Collect(iter)
+	require.NoError(t, err)
+	require.Len(t, keys, len(tc))
+
+	for _, c := range tc {
+		blob, err := mockStore.Get(context.Background(), c.wantKey)
+		require.NoError(t, err)
+
+		blobBytes, err := io.ReadAll(blob)
+		require.NoError(t, err)
+
+		require.Equal(t, c.wantBlob, blobBytes)
+	}
+}
+
 func setupMockStore(t *testing.T) *mocks.MockStore {
 	t.Helper()
 
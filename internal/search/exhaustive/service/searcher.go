@@ -6,6 +6,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
@@ -153,7 +154,22 @@ func (s searchQuery) toRepoRevSpecs(ctx context.Context, repoRevSpec types.Repos
 	}, nil
 }
 
-func (s searchQuery) Search(ctx context.Context, repoRev types.RepositoryRevision, w CSVWriter) error {
+func (s searchQuery) ResolveCommit(ctx context.Context, repoRev types.RepositoryRevision) (api.CommitID, error) {
+	if err := isSameUser(ctx, s.userID); err != nil {
+		return "", err
+	}
+
+	repo, err := s.minimalRepo(ctx, repoRev.Repository)
+	if err != nil {
+		return "", err
+	}
+
+	return s.clients.Gitserver.ResolveRevision(ctx, repo.Name, repoRev.Revision, gitserver.ResolveRevisionOptions{
+		NoEnsureRevision: true,
+	})
+}
+
+func (s searchQuery) Search(ctx context.Context, repoRev types.RepositoryRevision, w JSONLinesWriter) error {
 	if err := isSameUser(ctx, s.userID); err != nil {
 		return err
 	}
@@ -173,7 +189,7 @@ func (s searchQuery) Search(ctx context.Context, repoRev types.RepositoryRevisio
 
 	var mu sync.Mutex     // serialize writes to w
 	var writeRowErr error // capture if w.Write fails
-	matchWriter, err := newMatchCSVWriter(w)
+	matchWriter, err := newMatchJSONWriter(w)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"strings"
 	"testing"
@@ -38,10 +39,15 @@ func TestBackendFake(t *testing.T) {
 		Query:        "1@rev1 1@rev2 2@rev3",
 		WantRefSpecs: "RepositoryRevSpec{1@spec} RepositoryRevSpec{2@spec}",
 		WantRepoRevs: "RepositoryRevision{1@rev1} RepositoryRevision{1@rev2} RepositoryRevision{2@rev3}",
-		WantCSV: autogold.Expect(`repo,revspec,revision
-1,spec,rev1
-1,spec,rev2
-2,spec,rev3
+		WantCommits:  "rev1 rev2 rev3",
+		WantCSV: autogold.Expect(`repository,revision,file_path,match_count,first_match_url
+1,rev1,spec,0,/1@rev1/-/blob/spec
+1,rev2,spec,0,/1@rev2/-/blob/spec
+2,rev3,spec,0,/2@rev3/-/blob/spec
+`),
+		WantJSONLines: autogold.Expect(`{"type":"path","path":"spec","repositoryID":1,"repository":"1","commit":"rev1"}
+{"type":"path","path":"spec","repositoryID":1,"repository":"1","commit":"rev2"}
+{"type":"path","path":"spec","repositoryID":2,"repository":"2","commit":"rev3"}
 `),
 	})
 }
@@ -50,7 +56,11 @@ type newSearcherTestCase struct {
 	Query        string
 	WantRefSpecs string
 	WantRepoRevs string
+	WantCommits  string
 	WantCSV      autogold.Value
+
+	// WantJSONLines is optional since the JSON Lines output is verbose.
+	WantJSONLines autogold.Value
 }
 
 func TestFromSearchClient(t *testing.T) {
@@ -122,6 +132,7 @@ foo1,commitfoo1,,1,/foo1@commitfoo1/-/blob/?L2
 		Query:        "repo:foo rev:*refs/heads/dev* content",
 		WantRefSpecs: "RepositoryRevSpec{1@*refs/heads/dev*}",
 		WantRepoRevs: "RepositoryRevision{1@dev1} RepositoryRevision{1@dev2}",
+		WantCommits:  "commitfoo1 commitfoo2",
 		WantCSV: autogold.Expect(`repository,revision,file_path,match_count,first_match_url
 foo1,commitfoo1,,1,/foo1@commitfoo1/-/blob/?L2
 foo1,commitfoo2,,1,/foo1@commitfoo2/-/blob/?L2
@@ -353,12 +364,36 @@ func testNewSearcher(t *testing.T, ctx context.Context, newSearcher NewSearcher,
 	}
 	assert.Equal(tc.WantRepoRevs, joinStringer(repoRevs))
 
+	// Test ResolveCommit
+	if tc.WantCommits != "" {
+		var commits []string
+		for _, repoRev := range repoRevs {
+			commit, err := searcher.ResolveCommit(ctx, repoRev)
+			assert.NoError(err)
+			commits = append(commits, string(commit))
+		}
+		assert.Equal(tc.WantCommits, strings.Join(commits, " "))
+	}
+
 	// Test Search
-	var csv csvBuffer
+	var jsonLines jsonLinesBuffer
 	for _, repoRev := range repoRevs {
-		err := searcher.Search(ctx, repoRev, &csv)
+		err := searcher.Search(ctx, repoRev, &jsonLines)
 		assert.NoError(err)
 	}
+	if tc.WantJSONLines != nil {
+		tc.WantJSONLines.Equal(t, jsonLines.buf.String())
+	}
+
+	// The CSV download is computed from the JSON Lines.
+	var csv csvBuffer
+	matchWriter, err := newMatchCSVWriter(&csv)
+	assert.NoError(err)
+	for _, line := range bytes.Split(jsonLines.buf.Bytes(), []byte("\n")) {
+		if len(line) > 0 {
+			assert.NoError(matchWriter.WriteLine(line))
+		}
+	}
 	if tc.WantCSV != nil {
 		tc.WantCSV.Equal(t, csv.buf.String())
 	}
@@ -2,10 +2,12 @@ package service
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"encoding/csv"
 	"fmt"
 	"io"
+	"strings"
 	"sync"
 	"time"
 
@@ -57,14 +59,17 @@ func opAttrs(attrs ...attribute.KeyValue) observation.Args {
 
 type operations struct {
 	createSearchJob          *observation.Operation
+	rerunSearchJob           *observation.Operation
 	getSearchJob             *observation.Operation
 	deleteSearchJob          *observation.Operation
 	listSearchJobs           *observation.Operation
 	cancelSearchJob          *observation.Operation
 	getAggregateRepoRevState *observation.Operation
 
-	getSearchJobCSVWriterTo  operationWithWriterTo
-	getSearchJobLogsWriterTo operationWithWriterTo
+	getSearchJobCSVWriterTo       operationWithWriterTo
+	getSearchJobJSONLinesWriterTo operationWithWriterTo
+	getSearchJobLogsWriterTo      operationWithWriterTo
+	getSearchJobDiffWriterTo      operationWithWriterTo
 }
 
 // operationWithWriterTo encodes our pattern around our CSV WriterTo were we
@@ -103,6 +108,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 		singletonOperations = &operations{
 			createSearchJob:          op("CreateSearchJob"),
+			rerunSearchJob:           op("RerunSearchJob"),
 			getSearchJob:             op("GetSearchJob"),
 			deleteSearchJob:          op("DeleteSearchJob"),
 			listSearchJobs:           op("ListSearchJobs"),
@@ -113,10 +119,18 @@ func newOperations(observationCtx *observation.Context) *operations {
 				get:      op("GetSearchJobCSVWriterTo"),
 				writerTo: op("GetSearchJobCSVWriterTo.WriteTo"),
 			},
+			getSearchJobJSONLinesWriterTo: operationWithWriterTo{
+				get:      op("GetSearchJobJSONLinesWriterTo"),
+				writerTo: op("GetSearchJobJSONLinesWriterTo.WriteTo"),
+			},
 			getSearchJobLogsWriterTo: operationWithWriterTo{
 				get:      op("GetSearchJobLogsWriterTo"),
 				writerTo: op("GetSearchJobLogsWriterTo.WriteTo"),
 			},
+			getSearchJobDiffWriterTo: operationWithWriterTo{
+				get:      op("GetSearchJobDiffWriterTo"),
+				writerTo: op("GetSearchJobDiffWriterTo.WriteTo"),
+			},
 		}
 	})
 	return singletonOperations
@@ -163,6 +177,53 @@ func (s *Service) CreateSearchJob(ctx context.Context, query string) (_ *types.E
 	return tx.GetExhaustiveSearchJob(ctx, jobID)
 }
 
+// RerunSearchJob creates a new search job with the same query and initiator
+// as job id. When the new job runs, repository revisions which still resolve
+// to the same commit reuse the results of job id instead of being searched
+// again. Use GetSearchJobDiffWriterTo to compare the results of both runs.
+func (s *Service) RerunSearchJob(ctx context.Context, id int64) (_ *types.ExhaustiveSearchJob, err error) {
+	ctx, _, endObservation := s.operations.rerunSearchJob.With(ctx, &err, opAttrs(
+		attribute.Int64("id", id),
+	))
+	defer endObservation(1, observation.Args{})
+
+	if !isEnabled() {
+		return nil, errors.New("search jobs is an experimental feature, enable it by setting \"experimentalFeatures.searchJobs: true\" in site configuration")
+	}
+
+	// 🚨 SECURITY: only someone with access to the job may re-run it
+	previous, err := s.store.GetExhaustiveSearchJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch previous.AggState {
+	case types.JobStateQueued, types.JobStateProcessing:
+		return nil, errors.Newf("search job %d can only be re-run once it has finished", id)
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// We keep the initiator of the previous job. Otherwise the results of a
+	// site admin re-running the job of another user are not comparable, and
+	// could not be reused, since searches run with the initiator's
+	// permissions.
+	jobID, err := tx.CreateExhaustiveSearchJob(ctx, types.ExhaustiveSearchJob{
+		InitiatorID:   previous.InitiatorID,
+		Query:         previous.Query,
+		PreviousJobID: previous.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return tx.GetExhaustiveSearchJob(ctx, jobID)
+}
+
 func (s *Service) CancelSearchJob(ctx context.Context, id int64) (err error) {
 	ctx, _, endObservation := s.operations.cancelSearchJob.With(ctx, &err, opAttrs(
 		attribute.Int64("id", id),
@@ -271,10 +332,108 @@ func formatOrNULL(t time.Time) string {
 	return t.Format(time.RFC3339)
 }
 
+// getPrefix is the prefix of the CSV blobs of a job. Jobs only stored CSV
+// before we started storing JSON Lines, so we only read these blobs to
+// download the results of older jobs.
 func getPrefix(id int64) string {
 	return fmt.Sprintf("%d-", id)
 }
 
+// getJSONLinesPrefix is the prefix of the JSON Lines blobs of a job. It must
+// not overlap with getPrefix, so the CSV download of older jobs doesn't pick
+// them up.
+func getJSONLinesPrefix(id int64) string {
+	return fmt.Sprintf("jsonl-%d-", id)
+}
+
+// repoRevisionCSVPrefix is the blob prefix of the CSV results of the repo
+// revision job repoRevJobID of job id. Only jobs which ran before we stored
+// JSON Lines have these blobs.
+func repoRevisionCSVPrefix(id, repoRevJobID int64) string {
+	return fmt.Sprintf("%d-%d", id, repoRevJobID)
+}
+
+// RepoRevisionJSONLinesPrefix is the blob prefix of the JSON Lines results of
+// the repo revision job repoRevJobID of job id.
+func RepoRevisionJSONLinesPrefix(id, repoRevJobID int64) string {
+	return fmt.Sprintf("jsonl-%d-%d", id, repoRevJobID)
+}
+
+// listRepoRevisionKeys returns the keys of the blobs written for prefix, as
+// returned by RepoRevisionJSONLinesPrefix or repoRevisionCSVPrefix. We can't
+// just list by prefix, since "jsonl-1-1" is also a prefix of "jsonl-1-12".
+func listRepoRevisionKeys(ctx context.Context, uploadStore uploadstore.Store, prefix string) ([]string, error) {
+	iter, err := uploadStore.List(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for iter.Next() {
+		key := iter.Current()
+		if key == prefix || strings.HasPrefix(key, prefix+"-") {
+			keys = append(keys, key)
+		}
+	}
+	return keys, iter.Err()
+}
+
+// hasOnlyCSVResults returns true if the repo revision job repoRevJobID of
+// job id stored its results as CSV but not as JSON Lines. A repo revision job
+// without any results has neither.
+func hasOnlyCSVResults(ctx context.Context, uploadStore uploadstore.Store, id, repoRevJobID int64) (bool, error) {
+	keys, err := listRepoRevisionKeys(ctx, uploadStore, RepoRevisionJSONLinesPrefix(id, repoRevJobID))
+	if err != nil || len(keys) > 0 {
+		return false, err
+	}
+	keys, err = listRepoRevisionKeys(ctx, uploadStore, repoRevisionCSVPrefix(id, repoRevJobID))
+	if err != nil {
+		return false, err
+	}
+	return len(keys) > 0, nil
+}
+
+// CopyRepoRevisionResults copies the results of the repo revision job
+// fromRepoRevJobID of job fromID to the repo revision job toRepoRevJobID of
+// job toID. Re-runs use this to reuse the results of repository revisions
+// whose commit didn't change.
+//
+// It returns false without copying anything if the results were only stored
+// as CSV. Those can't be downloaded as JSON Lines or compared, so the caller
+// should search the repository revision again.
+func CopyRepoRevisionResults(ctx context.Context, uploadStore uploadstore.Store, fromID, fromRepoRevJobID, toID, toRepoRevJobID int64) (bool, error) {
+	if onlyCSV, err := hasOnlyCSVResults(ctx, uploadStore, fromID, fromRepoRevJobID); err != nil || onlyCSV {
+		return false, err
+	}
+
+	from := RepoRevisionJSONLinesPrefix(fromID, fromRepoRevJobID)
+	to := RepoRevisionJSONLinesPrefix(toID, toRepoRevJobID)
+
+	keys, err := listRepoRevisionKeys(ctx, uploadStore, from)
+	if err != nil {
+		return false, err
+	}
+	for _, key := range keys {
+		// Keep the suffix of additional blobs, e.g. "-2".
+		if err := copyBlob(ctx, uploadStore, key, to+strings.TrimPrefix(key, from)); err != nil {
+			return false, errors.Wrapf(err, "copying key %q", key)
+		}
+	}
+
+	return true, nil
+}
+
+func copyBlob(ctx context.Context, uploadStore uploadstore.Store, from, to string) error {
+	rc, err := uploadStore.Get(ctx, from)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	_, err = uploadStore.Upload(ctx, to, rc)
+	return err
+}
+
 func (s *Service) DeleteSearchJob(ctx context.Context, id int64) (err error) {
 	ctx, _, endObservation := s.operations.deleteSearchJob.With(ctx, &err, opAttrs(
 		attribute.Int64("id", id)))
@@ -287,33 +446,32 @@ func (s *Service) DeleteSearchJob(ctx context.Context, id int64) (err error) {
 		return err
 	}
 
-	iter, err := s.uploadStore.List(ctx, getPrefix(id))
-	if err != nil {
-		return err
-	}
-	for iter.Next() {
-		key := iter.Current()
-		err := s.uploadStore.Delete(ctx, key)
-		// If we continued, we might end up with data in the upload store without
-		// entries in the db to reference it.
+	for _, prefix := range []string{getPrefix(id), getJSONLinesPrefix(id)} {
+		iter, err := s.uploadStore.List(ctx, prefix)
 		if err != nil {
-			return errors.Wrapf(err, "deleting key %q", key)
+			return err
+		}
+		for iter.Next() {
+			key := iter.Current()
+			err := s.uploadStore.Delete(ctx, key)
+			// If we continued, we might end up with data in the upload store without
+			// entries in the db to reference it.
+			if err != nil {
+				return errors.Wrapf(err, "deleting key %q", key)
+			}
 		}
-	}
 
-	if err := iter.Err(); err != nil {
-		return err
+		if err := iter.Err(); err != nil {
+			return err
+		}
 	}
 
 	return s.store.DeleteExhaustiveSearchJob(ctx, id)
 }
 
-// WriteSearchJobCSV copies all CSVs associated with a search job to the given
-// writer. It returns the number of bytes written and any error encountered.
-
 // GetSearchJobCSVWriterTo returns a WriterTo which can be called once to
-// write all CSVs associated with a search job to the given writer for job id.
-// Note: ctx is used by WriterTo.
+// write the results of job id as CSV to the given writer. The rows are
+// computed from the stored JSON Lines. Note: ctx is used by WriterTo.
 //
 // io.WriterTo is a specialization of an io.Reader. We expect callers of this
 // function to want to write an http response, so we avoid an io.Pipe and
@@ -328,7 +486,7 @@ func (s *Service) GetSearchJobCSVWriterTo(parentCtx context.Context, id int64) (
 		return nil, err
 	}
 
-	iter, err := s.uploadStore.List(ctx, getPrefix(id))
+	iter, err := s.uploadStore.List(ctx, getJSONLinesPrefix(id))
 	if err != nil {
 		return nil, err
 	}
@@ -340,7 +498,90 @@ func (s *Service) GetSearchJobCSVWriterTo(parentCtx context.Context, id int64) (
 			endObservation(1, opAttrs(attribute.Int64("bytesWritten", n)))
 		}()
 
-		return writeSearchJobCSV(ctx, iter, s.uploadStore, w)
+		n, err = writeSearchJobJSONLinesAsCSV(ctx, iter, s.uploadStore, w)
+		if err != nil || n > 0 {
+			return n, err
+		}
+
+		// Older jobs only stored CSV.
+		csvIter, err := s.uploadStore.List(ctx, getPrefix(id))
+		if err != nil {
+			return 0, err
+		}
+		return writeSearchJobCSV(ctx, csvIter, s.uploadStore, w)
+	}), nil
+}
+
+// GetSearchJobJSONLinesWriterTo is the JSON Lines sibling of
+// GetSearchJobCSVWriterTo. It returns a WriterTo which writes the stored JSON
+// Lines, one line per match including every chunk match with its ranges.
+// Note: ctx is used by WriterTo.
+func (s *Service) GetSearchJobJSONLinesWriterTo(parentCtx context.Context, id int64) (_ io.WriterTo, err error) {
+	ctx, _, endObservation := s.operations.getSearchJobJSONLinesWriterTo.get.With(parentCtx, &err, opAttrs(
+		attribute.Int64("id", id)))
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: only someone with access to the job may copy the blobs
+	if err := s.store.UserHasAccess(ctx, id); err != nil {
+		return nil, err
+	}
+
+	iter, err := s.uploadStore.List(ctx, getJSONLinesPrefix(id))
+	if err != nil {
+		return nil, err
+	}
+
+	return writerToFunc(func(w io.Writer) (n int64, err error) {
+		ctx, _, endObservation := s.operations.getSearchJobJSONLinesWriterTo.writerTo.With(parentCtx, &err, opAttrs(
+			attribute.Int64("id", id)))
+		defer func() {
+			endObservation(1, opAttrs(attribute.Int64("bytesWritten", n)))
+		}()
+
+		return writeSearchJobJSONLines(ctx, iter, s.uploadStore, w)
+	}), nil
+}
+
+// ErrNoPreviousJob is returned by GetSearchJobDiffWriterTo for search jobs
+// which are not a re-run, or whose previous job has been deleted.
+var ErrNoPreviousJob = errors.New("search job is not a re-run of another search job")
+
+// GetSearchJobDiffWriterTo returns a WriterTo which writes the matches added
+// and removed since the previous run of job id as JSON Lines. Note: ctx is
+// used by WriterTo.
+func (s *Service) GetSearchJobDiffWriterTo(parentCtx context.Context, id int64) (_ io.WriterTo, err error) {
+	ctx, _, endObservation := s.operations.getSearchJobDiffWriterTo.get.With(parentCtx, &err, opAttrs(
+		attribute.Int64("id", id)))
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: only someone with access to the job may copy the blobs
+	job, err := s.store.GetExhaustiveSearchJob(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if job.PreviousJobID == 0 {
+		return nil, ErrNoPreviousJob
+	}
+
+	// 🚨 SECURITY: ListRepoRevisionCommits checks access to the job, this
+	// matters for the previous job.
+	revs, err := s.store.ListRepoRevisionCommits(ctx, job.ID)
+	if err != nil {
+		return nil, err
+	}
+	previousRevs, err := s.store.ListRepoRevisionCommits(ctx, job.PreviousJobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return writerToFunc(func(w io.Writer) (n int64, err error) {
+		ctx, _, endObservation := s.operations.getSearchJobDiffWriterTo.writerTo.With(parentCtx, &err, opAttrs(
+			attribute.Int64("id", id)))
+		defer func() {
+			endObservation(1, opAttrs(attribute.Int64("bytesWritten", n)))
+		}()
+
+		return writeSearchJobDiff(ctx, s.uploadStore, job.PreviousJobID, previousRevs, job.ID, revs, w)
 	}), nil
 }
 
@@ -392,6 +633,8 @@ func discardUntil(br *bufio.Reader, delim byte) error {
 	}
 }
 
+// writeSearchJobCSV concatenates the CSV blobs of jobs which ran before we
+// stored JSON Lines, keeping only the header of the first blob.
 func writeSearchJobCSV(ctx context.Context, iter *iterator.Iterator[string], uploadStore uploadstore.Store, w io.Writer) (int64, error) {
 	// keep a single bufio.Reader so we can reuse its buffer.
 	var br bufio.Reader
@@ -435,6 +678,85 @@ func writeSearchJobCSV(ctx context.Context, iter *iterator.Iterator[string], upl
 	return n, iter.Err()
 }
 
+// writeSearchJobJSONLines concatenates the blobs. Unlike CSV there is no
+// header to skip.
+func writeSearchJobJSONLines(ctx context.Context, iter *iterator.Iterator[string], uploadStore uploadstore.Store, w io.Writer) (int64, error) {
+	writeKey := func(key string) (int64, error) {
+		rc, err := uploadStore.Get(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+		defer rc.Close()
+
+		return io.Copy(w, rc)
+	}
+
+	var n int64
+	for iter.Next() {
+		key := iter.Current()
+		m, err := writeKey(key)
+		n += m
+		if err != nil {
+			return n, errors.Wrapf(err, "writing json lines for key %q", key)
+		}
+	}
+
+	return n, iter.Err()
+}
+
+// writeSearchJobJSONLinesAsCSV converts the JSON Lines blobs to a single CSV
+// with one row per file or commit.
+func writeSearchJobJSONLinesAsCSV(ctx context.Context, iter *iterator.Iterator[string], uploadStore uploadstore.Store, w io.Writer) (int64, error) {
+	// Like writeSearchJobLogs we wrap w to track the bytes written by the
+	// csv writer.
+	writeCounter := &writeCounter{w: w}
+	cw := csv.NewWriter(writeCounter)
+	matchWriter, err := newMatchCSVWriter(csvWriter{w: cw})
+	if err != nil {
+		return 0, err
+	}
+
+	writeKey := func(key string) error {
+		rc, err := uploadStore.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		defer rc.Close()
+
+		// We don't use a bufio.Scanner since lines contain the content of
+		// every chunk match, so they can exceed any reasonable limit.
+		br := bufio.NewReader(rc)
+		for {
+			line, err := br.ReadBytes('\n')
+			if line = bytes.TrimSpace(line); len(line) > 0 {
+				if err := matchWriter.WriteLine(line); err != nil {
+					return err
+				}
+			}
+			if err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+
+	for iter.Next() {
+		key := iter.Current()
+		if err := writeKey(key); err != nil {
+			return writeCounter.n, errors.Wrapf(err, "writing csv for key %q", key)
+		}
+	}
+
+	if err := iter.Err(); err != nil {
+		return writeCounter.n, err
+	}
+
+	// Flush data before checking for any final write errors.
+	cw.Flush()
+	return writeCounter.n, cw.Error()
+}
+
 func writeSearchJobLogs(iter *iterator.Iterator[types.SearchJobLog], w io.Writer) (int64, error) {
 	// For csv.NewWriter we have no way to track bytes written, so we wrap
 	// w to find out. The implementation of csv writer uses a
@@ -486,6 +808,19 @@ func (f writerToFunc) WriteTo(w io.Writer) (int64, error) {
 	return f(w)
 }
 
+// csvWriter adapts a csv.Writer to CSVWriter.
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (c csvWriter) WriteHeader(s ...string) error {
+	return c.w.Write(s)
+}
+
+func (c csvWriter) WriteRow(s ...string) error {
+	return c.w.Write(s)
+}
+
 // writeCounter wraps an io.Writer and keeps track of bytes written.
 type writeCounter struct {
 	w io.Writer
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -35,3 +36,86 @@ func Test_copyBlobs(t *testing.T) {
 	want := "h/h/h\na/a/a\nb/b/b\nc/c/c\n"
 	require.Equal(t, want, w.String())
 }
+
+func Test_writeSearchJobJSONLines(t *testing.T) {
+	keysIter := iterator.From([]string{"a", "b"})
+
+	blobs := map[string]io.Reader{
+		"a": bytes.NewReader([]byte("{\"a\":1}\n{\"a\":2}\n")),
+		"b": bytes.NewReader([]byte("{\"b\":1}\n")),
+	}
+
+	blobstore := mocks.NewMockStore()
+	blobstore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
+		return io.NopCloser(blobs[key]), nil
+	})
+
+	w := &bytes.Buffer{}
+
+	n, err := writeSearchJobJSONLines(context.Background(), keysIter, blobstore, w)
+	require.NoError(t, err)
+	require.Equal(t, int64(24), n)
+
+	want := "{\"a\":1}\n{\"a\":2}\n{\"b\":1}\n"
+	require.Equal(t, want, w.String())
+}
+
+func Test_writeSearchJobJSONLinesAsCSV(t *testing.T) {
+	keysIter := iterator.From([]string{"a", "b"})
+
+	blobs := map[string]io.Reader{
+		"a": bytes.NewReader([]byte(`{"type":"path","path":"a.go","repositoryID":1,"repository":"foo","commit":"c1"}` + "\n")),
+		"b": bytes.NewReader([]byte(`{"type":"content","path":"b.go","repositoryID":2,"repository":"bar","commit":"c2","chunkMatches":[{"content":"x","contentStart":{"offset":0,"line":4,"column":0},"ranges":[{"start":{"offset":0,"line":4,"column":0},"end":{"offset":1,"line":4,"column":1}}]}]}` + "\n")),
+	}
+
+	blobstore := mocks.NewMockStore()
+	blobstore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
+		return io.NopCloser(blobs[key]), nil
+	})
+
+	w := &bytes.Buffer{}
+
+	n, err := writeSearchJobJSONLinesAsCSV(context.Background(), keysIter, blobstore, w)
+	require.NoError(t, err)
+
+	want := "repository,revision,file_path,match_count,first_match_url\n" +
+		"foo,c1,a.go,0,/foo@c1/-/blob/a.go\n" +
+		"bar,c2,b.go,1,/bar@c2/-/blob/b.go?L5\n"
+	require.Equal(t, want, w.String())
+	require.Equal(t, int64(len(want)), n)
+}
+
+func TestCopyRepoRevisionResults(t *testing.T) {
+	ctx := context.Background()
+	blobstore := setupMockStore(t)
+
+	for _, key := range []string{"1-2", "jsonl-1-2", "jsonl-1-2-2", "jsonl-1-20"} {
+		_, err := blobstore.Upload(ctx, key, strings.NewReader(key))
+		require.NoError(t, err)
+	}
+
+	copied, err := CopyRepoRevisionResults(ctx, blobstore, 1, 2, 3, 4)
+	require.NoError(t, err)
+	require.True(t, copied)
+
+	for from, to := range map[string]string{"jsonl-1-2": "jsonl-3-4", "jsonl-1-2-2": "jsonl-3-4-2"} {
+		rc, err := blobstore.Get(ctx, to)
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.Equal(t, from, string(b))
+	}
+
+	// Only JSON Lines are copied, CSV blobs are from older jobs.
+	for _, key := range []string{"3-4", "jsonl-3-40"} {
+		_, err := blobstore.Get(ctx, key)
+		require.Error(t, err)
+	}
+
+	// Results an older job only stored as CSV are not reused.
+	_, err = blobstore.Upload(ctx, "1-5", strings.NewReader("1-5"))
+	require.NoError(t, err)
+	copied, err = CopyRepoRevisionResults(ctx, blobstore, 1, 5, 3, 6)
+	require.NoError(t, err)
+	require.False(t, copied)
+}
@@ -60,6 +60,7 @@ var exhaustiveSearchJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cancel"),
 	sqlf.Sprintf("created_at"),
 	sqlf.Sprintf("updated_at"),
+	sqlf.Sprintf("previous_job_id"),
 }
 
 func (s *Store) CreateExhaustiveSearchJob(ctx context.Context, job types.ExhaustiveSearchJob) (_ int64, err error) {
@@ -83,7 +84,7 @@ func (s *Store) CreateExhaustiveSearchJob(ctx context.Context, job types.Exhaust
 
 	return basestore.ScanAny[int64](s.Store.QueryRow(
 		ctx,
-		sqlf.Sprintf(createExhaustiveSearchJobQueryFmtr, job.Query, job.InitiatorID),
+		sqlf.Sprintf(createExhaustiveSearchJobQueryFmtr, job.Query, job.InitiatorID, dbutil.NewNullInt64(job.PreviousJobID)),
 	))
 }
 
@@ -94,8 +95,8 @@ var MissingQueryErr = errors.New("missing query")
 var MissingInitiatorIDErr = errors.New("missing initiator ID")
 
 const createExhaustiveSearchJobQueryFmtr = `
-INSERT INTO exhaustive_search_jobs (query, initiator_id)
-VALUES (%s, %s)
+INSERT INTO exhaustive_search_jobs (query, initiator_id, previous_job_id)
+VALUES (%s, %s, %s)
 RETURNING id
 `
 
@@ -546,6 +547,7 @@ func defaultScanTargets(job *types.ExhaustiveSearchJob) []any {
 		&job.Cancel,
 		&job.CreatedAt,
 		&job.UpdatedAt,
+		&dbutil.NullInt64{N: &job.PreviousJobID},
 	}
 }
 
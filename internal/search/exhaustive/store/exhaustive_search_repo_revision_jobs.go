@@ -2,9 +2,11 @@ package store
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -51,6 +53,7 @@ var revSearchJobColumns = []*sqlf.Query{
 	sqlf.Sprintf("cancel"),
 	sqlf.Sprintf("created_at"),
 	sqlf.Sprintf("updated_at"),
+	sqlf.Sprintf("commit"),
 }
 
 func (s *Store) CreateExhaustiveSearchRepoRevisionJob(ctx context.Context, job types.ExhaustiveSearchRepoRevisionJob) (int64, error) {
@@ -112,6 +115,98 @@ func (s *Store) GetQueryRepoRev(ctx context.Context, job *types.ExhaustiveSearch
 	return id, query, repoRev, initiatorID, nil
 }
 
+const setRepoRevisionJobCommitFmtStr = `
+UPDATE exhaustive_search_repo_revision_jobs
+SET commit = %s
+WHERE id = %s
+`
+
+// SetRepoRevisionJobCommit records the commit the revision of the repo
+// revision job id resolved to.
+func (s *Store) SetRepoRevisionJobCommit(ctx context.Context, id int64, commit string) (err error) {
+	ctx, _, endObservation := s.operations.setRepoRevisionJobCommit.With(ctx, &err, opAttrs(
+		attribute.Int64("ID", id),
+	))
+	defer endObservation(1, observation.Args{})
+
+	return s.Exec(ctx, sqlf.Sprintf(setRepoRevisionJobCommitFmtStr, commit, id))
+}
+
+// getPreviousRepoRevisionJobFmtStr finds the completed repo revision job of
+// the previous run of a search job which searched the same repository and
+// revision.
+const getPreviousRepoRevisionJobFmtStr = `
+SELECT sj.previous_job_id, prev_rrj.id, prev_rrj.commit
+FROM exhaustive_search_repo_revision_jobs rrj
+JOIN exhaustive_search_repo_jobs rj ON rrj.search_repo_job_id = rj.id
+JOIN exhaustive_search_jobs sj ON rj.search_job_id = sj.id
+JOIN exhaustive_search_repo_jobs prev_rj ON prev_rj.search_job_id = sj.previous_job_id AND prev_rj.repo_id = rj.repo_id
+JOIN exhaustive_search_repo_revision_jobs prev_rrj ON prev_rrj.search_repo_job_id = prev_rj.id AND prev_rrj.revision = rrj.revision
+WHERE
+	rrj.id = %s AND
+	prev_rrj.state = 'completed' AND
+	prev_rrj.commit IS NOT NULL
+ORDER BY prev_rrj.id DESC
+LIMIT 1
+`
+
+// GetPreviousRepoRevisionJob returns the search job ID, repo revision job ID
+// and commit of the completed repo revision job in the previous run of the
+// search job which searched the same repository and revision as job id. ok is
+// false if the search job isn't a re-run or the previous run has no such
+// job.
+func (s *Store) GetPreviousRepoRevisionJob(ctx context.Context, id int64) (searchJobID, repoRevJobID int64, commit string, ok bool, err error) {
+	ctx, _, endObservation := s.operations.getPreviousRepoRevisionJob.With(ctx, &err, opAttrs(
+		attribute.Int64("ID", id),
+	))
+	defer endObservation(1, observation.Args{})
+
+	row := s.QueryRow(ctx, sqlf.Sprintf(getPreviousRepoRevisionJobFmtStr, id))
+	if err := row.Scan(&searchJobID, &repoRevJobID, &commit); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, 0, "", false, nil
+		}
+		return 0, 0, "", false, err
+	}
+	return searchJobID, repoRevJobID, commit, true, nil
+}
+
+const listRepoRevisionCommitsFmtStr = `
+SELECT rrj.id, rj.repo_id, rrj.revision, rrj.commit, rrj.state
+FROM exhaustive_search_repo_revision_jobs rrj
+JOIN exhaustive_search_repo_jobs rj ON rrj.search_repo_job_id = rj.id
+WHERE rj.search_job_id = %s
+ORDER BY rrj.id ASC
+`
+
+// ListRepoRevisionCommits returns the repository revisions of search job id
+// together with the commits they resolved to.
+func (s *Store) ListRepoRevisionCommits(ctx context.Context, id int64) (_ []types.RepoRevisionCommit, err error) {
+	ctx, _, endObservation := s.operations.listRepoRevisionCommits.With(ctx, &err, opAttrs(
+		attribute.Int64("ID", id),
+	))
+	defer endObservation(1, observation.Args{})
+
+	// 🚨 SECURITY: only someone with access to the job may list its revisions
+	if err := s.UserHasAccess(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return scanRepoRevisionCommits(s.Store.Query(ctx, sqlf.Sprintf(listRepoRevisionCommitsFmtStr, id)))
+}
+
+var scanRepoRevisionCommits = basestore.NewSliceScanner(func(sc dbutil.Scanner) (types.RepoRevisionCommit, error) {
+	var rc types.RepoRevisionCommit
+	err := sc.Scan(
+		&rc.ID,
+		&rc.RepoID,
+		&rc.Revision,
+		&dbutil.NullString{S: &rc.Commit},
+		&rc.State,
+	)
+	return rc, err
+})
+
 func scanRevSearchJob(sc dbutil.Scanner) (*types.ExhaustiveSearchRepoRevisionJob, error) {
 	var job types.ExhaustiveSearchRepoRevisionJob
 	// required field for the sync worker, but
@@ -134,5 +229,6 @@ func scanRevSearchJob(sc dbutil.Scanner) (*types.ExhaustiveSearchRepoRevisionJob
 		&job.Cancel,
 		&job.CreatedAt,
 		&job.UpdatedAt,
+		&dbutil.NullString{S: &job.Commit},
 	)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/keegancsmith/sqlf"
 	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -93,3 +94,96 @@ func TestStore_CreateExhaustiveSearchRepoRevisionJob(t *testing.T) {
 		})
 	}
 }
+
+func TestStore_RepoRevisionCommits(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(t))
+
+	bs := basestore.NewWithHandle(db.Handle())
+
+	userID, err := createUser(bs, "alice")
+	require.NoError(t, err)
+	repoID, err := createRepo(db, "repo-test")
+	require.NoError(t, err)
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{
+		UID: userID,
+	})
+
+	s := store.New(db, &observation.TestContext)
+
+	// createRun creates a search job with one repo revision job for main.
+	createRun := func(previousJobID int64) (searchJobID, repoRevJobID int64) {
+		searchJobID, err := s.CreateExhaustiveSearchJob(
+			ctx,
+			types.ExhaustiveSearchJob{InitiatorID: userID, Query: "repo:repo-test foo", PreviousJobID: previousJobID},
+		)
+		require.NoError(t, err)
+		repoJobID, err := s.CreateExhaustiveSearchRepoJob(
+			ctx,
+			types.ExhaustiveSearchRepoJob{SearchJobID: searchJobID, RepoID: repoID, RefSpec: "main"},
+		)
+		require.NoError(t, err)
+		repoRevJobID, err = s.CreateExhaustiveSearchRepoRevisionJob(
+			ctx,
+			types.ExhaustiveSearchRepoRevisionJob{SearchRepoJobID: repoJobID, Revision: "main"},
+		)
+		require.NoError(t, err)
+		return searchJobID, repoRevJobID
+	}
+
+	previousJobID, previousRepoRevJobID := createRun(0)
+	require.NoError(t, s.SetRepoRevisionJobCommit(ctx, previousRepoRevJobID, "c0ffee"))
+
+	jobID, repoRevJobID := createRun(previousJobID)
+
+	// Only completed repo revision jobs of the previous run are returned.
+	_, _, _, ok, err := s.GetPreviousRepoRevisionJob(ctx, repoRevJobID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	err = bs.Exec(ctx, sqlf.Sprintf("UPDATE exhaustive_search_repo_revision_jobs SET state = 'completed' WHERE id = %s", previousRepoRevJobID))
+	require.NoError(t, err)
+
+	gotJobID, gotRepoRevJobID, gotCommit, ok, err := s.GetPreviousRepoRevisionJob(ctx, repoRevJobID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, previousJobID, gotJobID)
+	assert.Equal(t, previousRepoRevJobID, gotRepoRevJobID)
+	assert.Equal(t, "c0ffee", gotCommit)
+
+	// The first run has no previous run.
+	_, _, _, ok, err = s.GetPreviousRepoRevisionJob(ctx, previousRepoRevJobID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	commits, err := s.ListRepoRevisionCommits(ctx, jobID)
+	require.NoError(t, err)
+	assert.Equal(t, []types.RepoRevisionCommit{{
+		ID:       repoRevJobID,
+		RepoID:   repoID,
+		Revision: "main",
+		State:    types.JobStateQueued,
+	}}, commits)
+
+	commits, err = s.ListRepoRevisionCommits(ctx, previousJobID)
+	require.NoError(t, err)
+	assert.Equal(t, []types.RepoRevisionCommit{{
+		ID:       previousRepoRevJobID,
+		RepoID:   repoID,
+		Revision: "main",
+		Commit:   "c0ffee",
+		State:    types.JobStateCompleted,
+	}}, commits)
+
+	// Other users can't list the revisions of the job.
+	otherUserID, err := createUser(bs, "mallory")
+	require.NoError(t, err)
+	otherCtx := actor.WithActor(context.Background(), &actor.Actor{UID: otherUserID})
+	_, err = s.ListRepoRevisionCommits(otherCtx, jobID)
+	require.Error(t, err)
+}
@@ -18,6 +18,11 @@ type ExhaustiveSearchJob struct {
 
 	Query string
 
+	// PreviousJobID is the job this job is a re-run of, or 0 if it isn't a
+	// re-run. Repository revisions whose resolved commit didn't change since
+	// the previous job reuse its results.
+	PreviousJobID int64
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 
@@ -17,6 +17,10 @@ type ExhaustiveSearchRepoRevisionJob struct {
 	SearchRepoJobID int64
 	Revision        string
 
+	// Commit is the commit Revision resolved to when it was searched. It is
+	// empty until the revision has been searched.
+	Commit string
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -29,6 +33,19 @@ func (j *ExhaustiveSearchRepoRevisionJob) RecordUID() string {
 	return strconv.FormatInt(j.ID, 10)
 }
 
+// RepoRevisionCommit is the resolved commit of a repository revision searched
+// by a search job. It is used to compare the results of a search job with its
+// previous run.
+type RepoRevisionCommit struct {
+	// ID is the ID of the ExhaustiveSearchRepoRevisionJob.
+	ID int64
+
+	RepoID   api.RepoID
+	Revision string
+	Commit   string
+	State    JobState
+}
+
 type SearchJobLog struct {
 	ID       int64
 	RepoName api.RepoName
@@ -0,0 +1,234 @@
+package jobutil
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/search/symbol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// fileSymbolsLimit bounds the number of symbols we fetch for the files of a
+// repository revision. It should be large enough to include all matching
+// symbols from the files of a single event.
+const fileSymbolsLimit = 999999
+
+// fileHasSymbolsConcurrency bounds the number of concurrent symbols requests
+// made for a single event.
+const fileHasSymbolsConcurrency = 8
+
+// symbolsComputer looks up symbols in a repository at a commit. It is
+// implemented by *symbol.ZoektSymbolsClient.
+type symbolsComputer interface {
+	Compute(ctx context.Context, repo types.MinimalRepo, commitID api.CommitID, inputRev *string, query *string, first *int32, includePatterns *[]string, includeKinds []string) ([]*result.SymbolMatch, error)
+}
+
+// NewFileHasSymbolsJob creates a filter job to post-filter results for the file:has.symbol() predicate.
+//
+// All predicates are AND'ed together i.e. result will be filtered out and not returned in result page if any predicate
+// does not pass.
+func NewFileHasSymbolsJob(child job.Job, args []query.HasSymbolArgs, caseSensitive bool) job.Job {
+	return &fileHasSymbolsJob{
+		child:         child,
+		args:          args,
+		caseSensitive: caseSensitive,
+		symbols:       symbol.DefaultZoektSymbolsClient(),
+	}
+}
+
+type fileHasSymbolsJob struct {
+	child job.Job
+
+	args          []query.HasSymbolArgs
+	caseSensitive bool
+
+	symbols symbolsComputer
+}
+
+func (j *fileHasSymbolsJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer finish(alert, err)
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = j.filter(ctx, event.Results, &event.Stats)
+		if err != nil {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+// filter returns the file matches that pass all of the has.symbol() predicates
+// of the job. Symbols are requested once per repository revision and predicate.
+// Repositories whose files couldn't be checked before the deadline are marked
+// as timed out in stats, so that the partial results are reported.
+func (j *fileHasSymbolsJob) filter(ctx context.Context, matches result.Matches, stats *streaming.Stats) (result.Matches, error) {
+	type repoRev struct {
+		repo   api.RepoID
+		commit api.CommitID
+	}
+	var (
+		order  []repoRev
+		groups = map[repoRev][]*result.FileMatch{}
+	)
+	for _, m := range matches {
+		// Filter out any result that is not a file
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		key := repoRev{repo: fm.Repo.ID, commit: fm.CommitID}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], fm)
+	}
+
+	var (
+		mu   sync.Mutex
+		errs error
+		// passed counts the predicates each file passed.
+		passed = map[*result.FileMatch]int{}
+	)
+
+	p := pool.New().WithMaxGoroutines(fileHasSymbolsConcurrency)
+	for _, key := range order {
+		for _, arg := range j.args {
+			fms, arg := groups[key], arg
+			p.Go(func() {
+				paths, err := j.pathsWithSymbol(ctx, fms, arg)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+						stats.Status.Update(fms[0].Repo.ID, search.RepoStatusTimedout)
+						return
+					}
+					errs = errors.Append(errs, err)
+					return
+				}
+				for _, fm := range fms {
+					if _, hasSymbol := paths[fm.Path]; hasSymbol != arg.Negated {
+						passed[fm]++
+					}
+				}
+			})
+		}
+	}
+	p.Wait()
+
+	filtered := matches[:0]
+	for _, m := range matches {
+		if fm, ok := m.(*result.FileMatch); ok && passed[fm] == len(j.args) {
+			filtered = append(filtered, fm)
+		}
+	}
+	return filtered, errs
+}
+
+// pathsWithSymbol returns the paths of fms, which all belong to the same
+// repository revision, that define a symbol matching arg.
+func (j *fileHasSymbolsJob) pathsWithSymbol(ctx context.Context, fms []*result.FileMatch, arg query.HasSymbolArgs) (map[string]struct{}, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(fms))
+	for _, fm := range fms {
+		paths = append(paths, regexp.QuoteMeta(fm.Path))
+	}
+	var (
+		fm              = fms[0]
+		first           = int32(fileSymbolsLimit)
+		includePatterns = []string{"^(?:" + strings.Join(paths, "|") + ")$"}
+		kinds           []string
+	)
+	if arg.Kind != "" {
+		kinds = result.SymbolKindsForSelectKind(arg.Kind)
+	}
+	symbols, err := j.symbols.Compute(ctx, fm.Repo, fm.CommitID, fm.InputRev, &arg.Name, &first, &includePatterns, kinds)
+	if err != nil {
+		return nil, err
+	}
+
+	// The symbols backends match names case insensitively, so we check each
+	// file's symbols again.
+	byPath := map[string][]*result.SymbolMatch{}
+	for _, s := range symbols {
+		byPath[s.Symbol.Path] = append(byPath[s.Symbol.Path], s)
+	}
+	matched := map[string]struct{}{}
+	for path, symbols := range byPath {
+		hasSymbol, err := symbol.MatchesHasSymbol(symbols, arg, j.caseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		if hasSymbol {
+			matched[path] = struct{}{}
+		}
+	}
+	return matched, nil
+}
+
+func (j *fileHasSymbolsJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, fn)
+	return &cp
+}
+
+func (j *fileHasSymbolsJob) Name() string {
+	return "FileHasSymbolsFilterJob"
+}
+
+func (j *fileHasSymbolsJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHasSymbolsJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		var include, exclude []string
+		for _, arg := range j.args {
+			s := "name:" + arg.Name + " kind:" + arg.Kind
+			if arg.Negated {
+				exclude = append(exclude, s)
+			} else {
+				include = append(include, s)
+			}
+		}
+		res = append(res,
+			attribute.StringSlice("includeSymbols", include),
+			attribute.StringSlice("excludeSymbols", exclude),
+		)
+	}
+	return res
+}
@@ -0,0 +1,174 @@
+package jobutil
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type fakeSymbolsComputer []*result.SymbolMatch
+
+func (f fakeSymbolsComputer) Compute(context.Context, types.MinimalRepo, api.CommitID, *string, *string, *int32, *[]string, []string) ([]*result.SymbolMatch, error) {
+	return f, nil
+}
+
+func TestFileHasSymbolsJob(t *testing.T) {
+	r := func(ms ...result.Match) (res result.Matches) {
+		for _, m := range ms {
+			res = append(res, m)
+		}
+		return res
+	}
+
+	fm := func() *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Path:     "path",
+				CommitID: "commitID",
+			},
+		}
+	}
+
+	syms := fakeSymbolsComputer{
+		{Symbol: result.Symbol{Name: "HandleRequest", Kind: "function", Path: "path"}},
+		{Symbol: result.Symbol{Name: "Server", Kind: "struct", Path: "path"}},
+	}
+
+	tests := []struct {
+		name          string
+		caseSensitive bool
+		args          []query.HasSymbolArgs
+		matches       result.Match
+		outputEvent   streaming.SearchEvent
+	}{{
+		name:        "include matches name",
+		args:        []query.HasSymbolArgs{{Name: "^Handle"}},
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "include matches kind",
+		args:        []query.HasSymbolArgs{{Kind: "struct"}},
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "include matches name and kind",
+		args:        []query.HasSymbolArgs{{Name: "^Handle", Kind: "function"}},
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "include has no matches",
+		args:        []query.HasSymbolArgs{{Name: "^Handle", Kind: "struct"}},
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "exclude matches",
+		args:        []query.HasSymbolArgs{{Kind: "struct", Negated: true}},
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "exclude has no matches",
+		args:        []query.HasSymbolArgs{{Kind: "class", Negated: true}},
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: r(fm())},
+	}, {
+		name:        "not every include matches",
+		args:        []query.HasSymbolArgs{{Name: "Server"}, {Name: "Client"}},
+		matches:     fm(),
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:          "include case sensitive has no matches",
+		args:          []query.HasSymbolArgs{{Name: "server"}},
+		caseSensitive: true,
+		matches:       fm(),
+		outputEvent:   streaming.SearchEvent{Results: result.Matches{}},
+	}, {
+		name:        "not all matches are files",
+		args:        []query.HasSymbolArgs{{Name: "Server"}},
+		matches:     &result.CommitMatch{},
+		outputEvent: streaming.SearchEvent{Results: result.Matches{}},
+	}}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			childJob := mockjob.NewMockJob()
+			childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: r(tc.matches)})
+				return nil, nil
+			})
+
+			var resultEvent streaming.SearchEvent
+			streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+				resultEvent = ev
+			})
+
+			j := &fileHasSymbolsJob{
+				child:         childJob,
+				args:          tc.args,
+				caseSensitive: tc.caseSensitive,
+				symbols:       syms,
+			}
+			alert, err := j.Run(context.Background(), job.RuntimeClients{}, streamCollector)
+			require.Nil(t, alert)
+			require.NoError(t, err)
+			require.Equal(t, tc.outputEvent, resultEvent)
+		})
+	}
+}
+
+type countingSymbolsComputer struct {
+	fakeSymbolsComputer
+	calls atomic.Int32
+}
+
+func (c *countingSymbolsComputer) Compute(ctx context.Context, repo types.MinimalRepo, commitID api.CommitID, inputRev *string, query *string, first *int32, includePatterns *[]string, includeKinds []string) ([]*result.SymbolMatch, error) {
+	c.calls.Add(1)
+	return c.fakeSymbolsComputer.Compute(ctx, repo, commitID, inputRev, query, first, includePatterns, includeKinds)
+}
+
+func TestFileHasSymbolsJobBatchesPerRevision(t *testing.T) {
+	fm := func(path string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{ID: 1},
+				Path:     path,
+				CommitID: "commitID",
+			},
+		}
+	}
+
+	childJob := mockjob.NewMockJob()
+	childJob.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{fm("a.go"), fm("b.go"), fm("c.go")}})
+		return nil, nil
+	})
+
+	var resultEvent streaming.SearchEvent
+	streamCollector := streaming.StreamFunc(func(ev streaming.SearchEvent) {
+		resultEvent = ev
+	})
+
+	syms := &countingSymbolsComputer{fakeSymbolsComputer: fakeSymbolsComputer{
+		{Symbol: result.Symbol{Name: "Server", Kind: "struct", Path: "a.go"}},
+		{Symbol: result.Symbol{Name: "Server", Kind: "struct", Path: "c.go"}},
+	}}
+	j := &fileHasSymbolsJob{
+		child:   childJob,
+		args:    []query.HasSymbolArgs{{Name: "Server"}},
+		symbols: syms,
+	}
+	alert, err := j.Run(context.Background(), job.RuntimeClients{}, streamCollector)
+	require.Nil(t, alert)
+	require.NoError(t, err)
+	require.Equal(t, result.Matches{fm("a.go"), fm("c.go")}, resultEvent.Results)
+	require.Equal(t, int32(1), syms.calls.Load())
+}
@@ -210,6 +210,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic) (job.Job, error) {
 		}
 	}
 
+	{ // Apply file:has.symbol() post-search filter
+		if symbolArgs := b.FileHasSymbol(); len(symbolArgs) > 0 {
+			basicJob = NewFileHasSymbolsJob(basicJob, symbolArgs, b.IsCaseSensitive())
+		}
+	}
+
 	{ // Apply subrepo permissions checks
 		checker := authz.DefaultSubRepoPermsChecker
 		if authz.SubRepoEnabled(checker) {
@@ -769,6 +775,7 @@ func toRepoOptions(b query.Basic, userSettings *schema.Settings) search.RepoOpti
 		NoArchived:          archived == query.No,
 		Visibility:          visibility,
 		HasFileContent:      b.RepoHasFileContent(),
+		HasSymbol:           b.RepoHasSymbol(),
 		CommitAfter:         b.RepoContainsCommitAfter(),
 		UseIndex:            b.Index(),
 		HasKVPs:             b.RepoHasKVPs(),
@@ -1074,6 +1081,12 @@ func isGlobal(op search.RepoOptions) bool {
 		return false
 	}
 
+	// repo:has.symbol() is handled during the repo resolution step, since
+	// it needs symbol information per resolved revision.
+	if len(op.HasSymbol) > 0 {
+		return false
+	}
+
 	// There should be no cursors when calling this, but if there are that
 	// means we're already paginating. Cursors should probably not live on this
 	// struct since they are an implementation detail of pagination.
@@ -3,10 +3,12 @@ package query
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/grafana/regexp"
 	"github.com/grafana/regexp/syntax"
 
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -40,6 +42,7 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.key":               func() Predicate { return &RepoHasKeyPredicate{} },
 		"has.meta":              func() Predicate { return &RepoHasMetaPredicate{} },
 		"has.topic":             func() Predicate { return &RepoHasTopicPredicate{} },
+		"has.symbol":            func() Predicate { return &RepoHasSymbolPredicate{} },
 
 		// Deprecated predicates
 		"contains": func() Predicate { return &RepoContainsPredicate{} },
@@ -49,6 +52,10 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
 		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
 		"has.contributor":  func() Predicate { return &FileHasContributorPredicate{} },
+		"has.symbol":       func() Predicate { return &FileHasSymbolPredicate{} },
+	},
+	FieldRev: {
+		"at.time": func() Predicate { return &RevAtTimePredicate{} },
 	},
 }
 
@@ -483,6 +490,30 @@ func (p *RepoHasTopicPredicate) Unmarshal(params string, negated bool) (err erro
 func (p *RepoHasTopicPredicate) Field() string { return FieldRepo }
 func (p *RepoHasTopicPredicate) Name() string  { return "has.topic" }
 
+/* repo:has.symbol(kind:k name:n) */
+
+// RepoHasSymbolPredicate represents the `repo:has.symbol()` predicate, which
+// filters to repos that define a symbol with a matching name and/or kind.
+type RepoHasSymbolPredicate struct {
+	SymbolName string
+	Kind       string
+	Negated    bool
+}
+
+func (f *RepoHasSymbolPredicate) Unmarshal(params string, negated bool) error {
+	name, kind, err := parseHasSymbolParams(params, f.Field()+":"+f.Name())
+	if err != nil {
+		return err
+	}
+	f.SymbolName = name
+	f.Kind = kind
+	f.Negated = negated
+	return nil
+}
+
+func (f *RepoHasSymbolPredicate) Field() string { return FieldRepo }
+func (f *RepoHasSymbolPredicate) Name() string  { return "has.symbol" }
+
 // RepoContainsPredicate represents the `repo:contains(file:a content:b)` predicate.
 // DEPRECATED: this syntax is deprecated in favor of `repo:contains.file`.
 type RepoContainsPredicate struct {
@@ -614,3 +645,101 @@ func (f *FileHasContributorPredicate) Unmarshal(params string, negated bool) err
 
 func (f FileHasContributorPredicate) Field() string { return FieldFile }
 func (f FileHasContributorPredicate) Name() string  { return "has.contributor" }
+
+/* file:has.symbol(kind:k name:n) */
+
+// FileHasSymbolPredicate represents the `file:has.symbol()` predicate, which
+// filters to files that define a symbol with a matching name and/or kind.
+type FileHasSymbolPredicate struct {
+	SymbolName string
+	Kind       string
+	Negated    bool
+}
+
+func (f *FileHasSymbolPredicate) Unmarshal(params string, negated bool) error {
+	name, kind, err := parseHasSymbolParams(params, f.Field()+":"+f.Name())
+	if err != nil {
+		return err
+	}
+	f.SymbolName = name
+	f.Kind = kind
+	f.Negated = negated
+	return nil
+}
+
+func (f *FileHasSymbolPredicate) Field() string { return FieldFile }
+func (f *FileHasSymbolPredicate) Name() string  { return "has.symbol" }
+
+// parseHasSymbolParams parses the arguments shared by the has.symbol()
+// predicates. It accepts space-separated `name:` and `kind:` arguments, and a
+// single unnamed argument is interpreted as the symbol name. At least one of
+// name or kind must be set, and kind must be one of the symbol kinds accepted
+// by select:symbol.
+func parseHasSymbolParams(params, predicate string) (name, kind string, err error) {
+	for _, arg := range strings.Fields(params) {
+		field, value, ok := strings.Cut(arg, ":")
+		switch {
+		case ok && strings.EqualFold(field, "kind"):
+			if kind != "" {
+				return "", "", errors.New("cannot specify kind multiple times")
+			}
+			value = strings.ToLower(value)
+			if _, err := filter.SelectPathFromString(filter.Symbol + "." + value); err != nil {
+				return "", "", errors.Errorf("`%s` predicate has invalid `kind` argument %q", predicate, value)
+			}
+			kind = value
+		case ok && strings.EqualFold(field, "name"):
+			if name != "" {
+				return "", "", errors.New("cannot specify name multiple times")
+			}
+			if _, err := syntax.Parse(value, syntax.Perl); err != nil {
+				return "", "", errors.Errorf("`%s` predicate has invalid `name` argument: %w", predicate, err)
+			}
+			name = value
+		case ok && (strings.EqualFold(field, "-kind") || strings.EqualFold(field, "-name")):
+			return "", "", errors.New("predicates do not currently support negated values")
+		default:
+			if name != "" {
+				return "", "", errors.New("cannot specify name multiple times")
+			}
+			if _, err := syntax.Parse(arg, syntax.Perl); err != nil {
+				return "", "", errors.Errorf("`%s` predicate has invalid `name` argument: %w", predicate, err)
+			}
+			name = arg
+		}
+	}
+
+	if name == "" && kind == "" {
+		return "", "", errors.New("one of name or kind must be set")
+	}
+	return name, kind, nil
+}
+
+/* rev:at.time(time) */
+
+// RevAtTimePredicate represents the `rev:at.time()` predicate, which searches
+// each repository at the last commit on its default branch before the given
+// time. The time may be in any format accepted by ParseGitDate, for example
+// `2023-01-01` or `3 months ago`.
+type RevAtTimePredicate struct {
+	Time string
+}
+
+func (f *RevAtTimePredicate) Unmarshal(params string, negated bool) error {
+	if negated {
+		return &NegatedPredicateError{f.Field() + ":" + f.Name()}
+	}
+
+	params = strings.TrimSpace(params)
+	if params == "" {
+		return errors.New("rev:at.time() argument should not be empty")
+	}
+	if _, err := ParseGitDate(params, time.Now); err != nil {
+		return errors.Errorf("rev:at.time() argument: %w", err)
+	}
+	f.Time = params
+	return nil
+}
+
+func (f RevAtTimePredicate) Field() string { return FieldRev }
+func (f RevAtTimePredicate) Name() string  { return "at.time" }
@@ -366,3 +366,112 @@ func TestFileHasContributorPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestHasSymbolPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *FileHasSymbolPredicate
+		}
+
+		valid := []test{
+			{`name`, `name:^Handle`, &FileHasSymbolPredicate{SymbolName: "^Handle"}},
+			{`kind`, `kind:function`, &FileHasSymbolPredicate{Kind: "function"}},
+			{`kind is case insensitive`, `kind:Struct`, &FileHasSymbolPredicate{Kind: "struct"}},
+			{`name and kind`, `kind:function name:^Handle`, &FileHasSymbolPredicate{SymbolName: "^Handle", Kind: "function"}},
+			{`unnamed name`, `NewServer`, &FileHasSymbolPredicate{SymbolName: "NewServer"}},
+			{`unnamed name and kind`, `kind:class Server$`, &FileHasSymbolPredicate{SymbolName: "Server$", Kind: "class"}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasSymbolPredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+
+				r := &RepoHasSymbolPredicate{}
+				if err := r.Unmarshal(tc.params, true); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				require.Equal(t, &RepoHasSymbolPredicate{SymbolName: tc.expected.SymbolName, Kind: tc.expected.Kind, Negated: true}, r)
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, nil},
+			{`unknown kind`, `kind:banana`, nil},
+			{`invalid name regexp`, `name:([)`, nil},
+			{`name twice`, `name:a name:b`, nil},
+			{`negated name`, `-name:a`, nil},
+			{`two unnamed names`, `a b`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileHasSymbolPredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
+
+func TestRevAtTimePredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *RevAtTimePredicate
+		}
+
+		valid := []test{
+			{`date`, `2023-01-01`, &RevAtTimePredicate{Time: "2023-01-01"}},
+			{`RFC 3339`, `2023-01-01T12:00:00Z`, &RevAtTimePredicate{Time: "2023-01-01T12:00:00Z"}},
+			{`relative`, `3 months ago`, &RevAtTimePredicate{Time: "3 months ago"}},
+			{`surrounding whitespace`, ` 2023-01-01 `, &RevAtTimePredicate{Time: "2023-01-01"}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RevAtTimePredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, nil},
+			{`not a date`, `banana`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &RevAtTimePredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+
+		t.Run("negated", func(t *testing.T) {
+			p := &RevAtTimePredicate{}
+			require.Error(t, p.Unmarshal("2023-01-01", true))
+		})
+	})
+}
@@ -6,7 +6,7 @@ import (
 	"github.com/grafana/regexp"
 )
 
-// RevisionSpecifier represents either a revspec or a ref glob. At most one
+// RevisionSpecifier represents a revspec, a ref glob or a point in time. At most one
 // field is set. The default branch is represented by all fields being empty.
 type RevisionSpecifier struct {
 	// RevSpec is a revision range specifier suitable for passing to git. See
@@ -20,9 +20,17 @@ type RevisionSpecifier struct {
 	// ExcludeRefGlob is a glob for references to exclude. See the
 	// documentation for "--exclude" in git-log.
 	ExcludeRefGlob string
+
+	// AtTime is a date in any format accepted by ParseGitDate. It refers to
+	// the last commit on the default branch before that date. See the
+	// rev:at.time() predicate.
+	AtTime string
 }
 
 func (r1 RevisionSpecifier) String() string {
+	if r1.AtTime != "" {
+		return "at.time(" + r1.AtTime + ")"
+	}
 	if r1.ExcludeRefGlob != "" {
 		return "*!" + r1.ExcludeRefGlob
 	}
@@ -44,7 +52,10 @@ func (r1 RevisionSpecifier) Less(r2 RevisionSpecifier) bool {
 	if r1.RefGlob != r2.RefGlob {
 		return r1.RefGlob < r2.RefGlob
 	}
-	return r1.ExcludeRefGlob < r2.ExcludeRefGlob
+	if r1.ExcludeRefGlob != r2.ExcludeRefGlob {
+		return r1.ExcludeRefGlob < r2.ExcludeRefGlob
+	}
+	return r1.AtTime < r2.AtTime
 }
 
 func (r1 RevisionSpecifier) HasRefGlob() bool {
@@ -90,6 +101,9 @@ func (p ParsedRepoFilter) String() string {
 //   - 'foo@*bar' refers to the 'foo' repo and all refs matching the glob 'bar/*',
 //     because git interprets the ref glob 'bar' as being 'bar/*' (see `man git-log`
 //     section on the --glob flag)
+//   - 'foo@at.time(2023-01-01)' refers to the 'foo' repo at the last commit on
+//     the default branch before 2023-01-01. Colons inside the parentheses do
+//     not separate revspecs.
 func ParseRepositoryRevisions(repoAndOptionalRev string) (ParsedRepoFilter, error) {
 	var repo string
 	var revs []RevisionSpecifier
@@ -105,7 +119,7 @@ func ParseRepositoryRevisions(repoAndOptionalRev string) (ParsedRepoFilter, erro
 		revs = []RevisionSpecifier{}
 	} else {
 		repo = repoAndOptionalRev[:i]
-		for _, part := range strings.Split(repoAndOptionalRev[i+1:], ":") {
+		for _, part := range splitRevSpecs(repoAndOptionalRev[i+1:]) {
 			if part == "" {
 				continue
 			}
@@ -127,8 +141,37 @@ func ParseRepositoryRevisions(repoAndOptionalRev string) (ParsedRepoFilter, erro
 	return ParsedRepoFilter{Repo: repo, RepoRegex: repoRegex, Revs: revs}, nil
 }
 
+// splitRevSpecs splits a ':'-separated list of revspecs, ignoring any ':'
+// nested inside parentheses such as in 'at.time(2023-01-01T12:00:00Z)'.
+func splitRevSpecs(revs string) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i, r := range revs {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			if depth > 0 {
+				depth--
+			}
+		case ':':
+			if depth == 0 {
+				parts = append(parts, revs[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, revs[start:])
+}
+
 // ParseRevisionSpecifier is the inverse of RevisionSpecifier.String().
 func ParseRevisionSpecifier(spec string) RevisionSpecifier {
+	if strings.HasPrefix(spec, "at.time(") && strings.HasSuffix(spec, ")") {
+		return RevisionSpecifier{AtTime: spec[len("at.time(") : len(spec)-1]}
+	}
 	if strings.HasPrefix(spec, "*!") {
 		return RevisionSpecifier{ExcludeRefGlob: spec[2:]}
 	} else if strings.HasPrefix(spec, "*") {
@@ -35,6 +35,12 @@ func TestParseRepositoryRevisions(t *testing.T) {
 				{RefGlob: "glob3"},
 			},
 		},
+		"repo@at.time(2023-01-01)":   {repo: "repo", revs: []RevisionSpecifier{{AtTime: "2023-01-01"}}},
+		"repo@at.time(3 months ago)": {repo: "repo", revs: []RevisionSpecifier{{AtTime: "3 months ago"}}},
+		"repo@rev1:at.time(2023-01-01T12:00:00Z):rev2": {
+			repo: "repo",
+			revs: []RevisionSpecifier{{RevSpec: "rev1"}, {AtTime: "2023-01-01T12:00:00Z"}, {RevSpec: "rev2"}},
+		},
 		"@rev1":            {repo: "", revs: []RevisionSpecifier{{RevSpec: "rev1"}}},
 		"repo?*@rev1:rev2": {err: &syntax.Error{Code: "invalid nested repetition operator", Expr: "?*"}},
 	}
@@ -393,6 +393,10 @@ func TestConcatRevFilters(t *testing.T) {
 			input: "repo:foo rev:4.2.1 repo:has.file(content:fix)",
 			want:  `("repo:foo@4.2.1" "repo:has.file(content:fix)")`,
 		},
+		{
+			input: "repo:foo rev:at.time(3 months ago)",
+			want:  `("repo:foo@at.time(3 months ago)")`,
+		},
 	}
 	for _, c := range cases {
 		t.Run(c.input, func(t *testing.T) {
@@ -505,6 +505,37 @@ func (p Parameters) FileHasContributor() (include []string, exclude []string) {
 	return include, exclude
 }
 
+// HasSymbolArgs represents the args of the file:has.symbol() and
+// repo:has.symbol() predicates.
+type HasSymbolArgs struct {
+	// At least one of these strings should be non-empty
+	Name    string // optional, a regular expression matched against the symbol name
+	Kind    string // optional, a symbol kind as accepted by select:symbol.<kind>
+	Negated bool
+}
+
+func (p Parameters) FileHasSymbol() (res []HasSymbolArgs) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasSymbolPredicate) {
+		res = append(res, HasSymbolArgs{
+			Name:    pred.SymbolName,
+			Kind:    pred.Kind,
+			Negated: pred.Negated,
+		})
+	})
+	return res
+}
+
+func (p Parameters) RepoHasSymbol() (res []HasSymbolArgs) {
+	VisitTypedPredicate(toNodes(p), func(pred *RepoHasSymbolPredicate) {
+		res = append(res, HasSymbolArgs{
+			Name:    pred.SymbolName,
+			Kind:    pred.Kind,
+			Negated: pred.Negated,
+		})
+	})
+	return res
+}
+
 // Exists returns whether a parameter exists in the query (whether negated or not).
 func (p Parameters) Exists(field string) bool {
 	found := false
@@ -30,9 +30,11 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/limits"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
 	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/search/symbol"
 	searchzoekt "github.com/sourcegraph/sourcegraph/internal/search/zoekt"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
@@ -84,6 +86,23 @@ type Resolver struct {
 	gitserver gitserver.Client
 	zoekt     zoekt.Streamer
 	searcher  *endpoint.Map
+
+	// symbolsClient is used to evaluate repo:has.symbol() predicates. When
+	// nil, symbol.DefaultZoektSymbolsClient is used.
+	symbolsClient symbolsComputer
+}
+
+// symbolsComputer looks up symbols in a repository at a commit. It is
+// implemented by *symbol.ZoektSymbolsClient.
+type symbolsComputer interface {
+	Compute(ctx context.Context, repo types.MinimalRepo, commitID api.CommitID, inputRev *string, query *string, first *int32, includePatterns *[]string, includeKinds []string) ([]*result.SymbolMatch, error)
+}
+
+func (r *Resolver) symbols() symbolsComputer {
+	if r.symbolsClient != nil {
+		return r.symbolsClient
+	}
+	return symbol.DefaultZoektSymbolsClient()
 }
 
 // Iterator returns an iterator of Resolved for opts.
@@ -376,6 +395,14 @@ func (r *Resolver) doFilterDBResolved(ctx context.Context, tr trace.Trace, op se
 	}
 	tr.AddEvent("finished contains filtering")
 
+	tr.AddEvent("starting symbol filtering")
+	filteredRepoRevs, missingHasSymbolRevs, err := r.filterRepoHasSymbol(ctx, filteredRepoRevs, op)
+	missing = append(missing, missingHasSymbolRevs...)
+	if err != nil {
+		return Resolved{}, errors.Wrap(err, "filter has symbol")
+	}
+	tr.AddEvent("finished symbol filtering")
+
 	return Resolved{
 		RepoRevs:        filteredRepoRevs,
 		BackendsMissing: backendsMissing,
@@ -525,6 +552,21 @@ func (r *Resolver) normalizeRepoRefs(
 			globs = append(globs, gitdomain.RefGlob{Include: rev.RefGlob})
 		case rev.ExcludeRefGlob != "":
 			globs = append(globs, gitdomain.RefGlob{Exclude: rev.ExcludeRefGlob})
+		case rev.AtTime != "":
+			commitID, err := r.resolveRevAtTime(ctx, repo, rev.AtTime)
+			if err != nil {
+				if errors.Is(err, context.DeadlineExceeded) || errors.HasType(err, &gitdomain.BadCommitError{}) {
+					return nil, err
+				}
+				reportMissing(RepoRevSpecs{Repo: repo, Revs: []query.RevisionSpecifier{rev}})
+				continue
+			}
+			if commitID == "" {
+				// The repository has no commits before the requested time, so
+				// there is nothing to search.
+				continue
+			}
+			revs = append(revs, string(commitID))
 		case rev.RevSpec == "" || rev.RevSpec == "HEAD":
 			// NOTE: HEAD is the only case here that we don't resolve to a
 			// commit ID. We should consider building []gitdomain.Ref here
@@ -570,6 +612,35 @@ func (r *Resolver) normalizeRepoRefs(
 
 }
 
+// resolveRevAtTime returns the last commit on the default branch of repo
+// before the time described by at, which is in any format accepted by
+// query.ParseGitDate. It returns an empty commit ID if the repository has no
+// commits before that time.
+func (r *Resolver) resolveRevAtTime(ctx context.Context, repo types.MinimalRepo, at string) (api.CommitID, error) {
+	t, err := query.ParseGitDate(at, time.Now) // validated by the rev:at.time() predicate
+	if err != nil {
+		return "", err
+	}
+
+	commits, err := r.gitserver.Commits(ctx, repo.Name, gitserver.CommitsOptions{
+		Range:            "HEAD",
+		Before:           t.Format(time.RFC3339),
+		N:                1,
+		NoEnsureRevision: true,
+	})
+	if err != nil {
+		if e := (&gitdomain.RevisionNotFoundError{}); errors.As(err, &e) {
+			// An empty repository has no HEAD, and so no commits before t.
+			return "", nil
+		}
+		return "", err
+	}
+	if len(commits) == 0 {
+		return "", nil
+	}
+	return commits[0].ID, nil
+}
+
 // filterHasCommitAfter filters the revisions on each of a set of RepositoryRevisions to ensure that
 // any repo-level filters (e.g. `repo:contains.commit.after()`) apply to this repo/rev combo.
 func (r *Resolver) filterHasCommitAfter(
@@ -873,6 +944,134 @@ func (r *Resolver) repoHasFileContentAtCommit(ctx context.Context, repo types.Mi
 	return foundMatches, err
 }
 
+// hasSymbolSearchLimit is the maximum number of symbols we fetch per
+// repository revision when evaluating a repo:has.symbol() predicate. The
+// symbols backends filter by name and kind, but always match names case
+// insensitively, so we need some headroom for case sensitive searches.
+const hasSymbolSearchLimit = 1000
+
+// filterRepoHasSymbol filters a page of repos to only those that match the
+// given has.symbol predicates in RepoOptions.HasSymbol. Each revision is
+// checked against the symbols backend, which uses zoekt for indexed revisions
+// and the symbols service otherwise.
+func (r *Resolver) filterRepoHasSymbol(
+	ctx context.Context,
+	repoRevs []*search.RepositoryRevisions,
+	op search.RepoOptions,
+) (
+	_ []*search.RepositoryRevisions,
+	_ []RepoRevSpecs,
+	err error,
+) {
+	tr, ctx := trace.New(ctx, "Resolve.FilterHasSymbol")
+	tr.SetAttributes(attribute.Int("inputRevCount", len(repoRevs)))
+	defer func() {
+		tr.SetError(err)
+		tr.End()
+	}()
+
+	// Early return if there are no filters
+	if len(op.HasSymbol) == 0 {
+		return repoRevs, nil, nil
+	}
+
+	var (
+		mu         sync.Mutex
+		filtered   = map[api.RepoID]*search.RepositoryRevisions{}
+		addRepoRev = func(repo types.MinimalRepo, rev string) {
+			mu.Lock()
+			defer mu.Unlock()
+			repoRev := filtered[repo.ID]
+			if repoRev == nil {
+				repoRev = &search.RepositoryRevisions{
+					Repo: repo,
+				}
+			}
+			repoRev.Revs = append(repoRev.Revs, rev)
+			filtered[repo.ID] = repoRev
+		}
+	)
+
+	var (
+		missingMu  sync.Mutex
+		missing    []RepoRevSpecs
+		addMissing = func(rs RepoRevSpecs) {
+			missingMu.Lock()
+			missing = append(missing, rs)
+			missingMu.Unlock()
+		}
+	)
+
+	checkHasSymbol := func(ctx context.Context, arg query.HasSymbolArgs, repo types.MinimalRepo, rev string) (bool, error) {
+		commitID, err := r.gitserver.ResolveRevision(ctx, repo.Name, rev, gitserver.ResolveRevisionOptions{NoEnsureRevision: true})
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) || errors.HasType(err, &gitdomain.BadCommitError{}) {
+				return false, err
+			} else if e := (&gitdomain.RevisionNotFoundError{}); errors.As(err, &e) && (rev == "HEAD" || rev == "") {
+				// In the case that we can't find HEAD, that means there are no commits, which means
+				// we can safely say this repo does not define the symbol being requested.
+				return false, nil
+			}
+
+			// For any other error, add this repo/rev pair to the set of missing repos
+			addMissing(RepoRevSpecs{Repo: repo, Revs: []query.RevisionSpecifier{{RevSpec: rev}}})
+			return false, nil
+		}
+
+		var kinds []string
+		if arg.Kind != "" {
+			kinds = result.SymbolKindsForSelectKind(arg.Kind)
+		}
+		first := int32(hasSymbolSearchLimit)
+		symbols, err := r.symbols().Compute(ctx, repo, commitID, &rev, &arg.Name, &first, nil, kinds)
+		if err != nil {
+			return false, err
+		}
+		return symbol.MatchesHasSymbol(symbols, arg, op.CaseSensitiveRepoFilters)
+	}
+
+	p := pool.New().WithContext(ctx).WithMaxGoroutines(16)
+	for _, repoRevs := range repoRevs {
+		for _, rev := range repoRevs.Revs {
+			repo, rev := repoRevs.Repo, rev
+
+			p.Go(func(ctx context.Context) error {
+				for _, arg := range op.HasSymbol {
+					hasSymbol, err := checkHasSymbol(ctx, arg, repo, rev)
+					if err != nil {
+						return err
+					}
+
+					wantSymbol := !arg.Negated
+					if wantSymbol != hasSymbol {
+						// One of the conditions has failed, so we can return early
+						return nil
+					}
+				}
+
+				// If we made it here, we found a match for each of the has.symbol filters.
+				addRepoRev(repo, rev)
+				return nil
+			})
+		}
+	}
+
+	if err := p.Wait(); err != nil {
+		return nil, nil, err
+	}
+
+	// Filter the input revs to only those that matched all the has.symbol conditions
+	matchedRepoRevs := repoRevs[:0]
+	for _, repoRev := range repoRevs {
+		if matched, ok := filtered[repoRev.Repo.ID]; ok {
+			matchedRepoRevs = append(matchedRepoRevs, matched)
+		}
+	}
+
+	tr.SetAttributes(attribute.Int("filteredRevCount", len(matchedRepoRevs)))
+	return matchedRepoRevs, missing, nil
+}
+
 // computeExcludedRepos computes the ExcludedRepos that the given RepoOptions would not match. This is
 // used to show in the search UI what repos are excluded precisely.
 func computeExcludedRepos(ctx context.Context, db database.DB, op search.RepoOptions) (ex ExcludedRepos, err error) {
@@ -1112,6 +1311,8 @@ func (r *RepoRevSpecs) RevSpecs() []string {
 		switch {
 		case rev.RefGlob != "":
 		case rev.ExcludeRefGlob != "":
+		case rev.AtTime != "":
+			res = append(res, rev.String())
 		default:
 			res = append(res, rev.RevSpec)
 		}
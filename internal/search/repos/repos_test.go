@@ -28,6 +28,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -80,6 +81,18 @@ func TestRevisionValidation(t *testing.T) {
 		}}, nil
 	})
 
+	mockGitserver.CommitsFunc.SetDefaultHook(func(_ context.Context, _ api.RepoName, opt gitserver.CommitsOptions) ([]*gitdomain.Commit, error) {
+		before, err := time.Parse(time.RFC3339, opt.Before)
+		if err != nil {
+			return nil, err
+		}
+		// repoFoo has a single commit on its default branch, made in 2022.
+		if before.Year() < 2022 {
+			return nil, nil
+		}
+		return []*gitdomain.Commit{{ID: "deadbeef"}}, nil
+	})
+
 	tests := []struct {
 		repoFilters  []string
 		wantRepoRevs []*search.RepositoryRevisions
@@ -129,6 +142,20 @@ func TestRevisionValidation(t *testing.T) {
 			wantRepoRevs: nil,
 			wantErr:      context.DeadlineExceeded,
 		},
+		{
+			repoFilters: []string{"repoFoo@at.time(2023-01-01)"},
+			wantRepoRevs: []*search.RepositoryRevisions{{
+				Repo: types.MinimalRepo{Name: "repoFoo"},
+				Revs: []string{"deadbeef"},
+			}},
+		},
+		{
+			repoFilters: []string{"repoFoo@revBar:at.time(2021-01-01)"},
+			wantRepoRevs: []*search.RepositoryRevisions{{
+				Repo: types.MinimalRepo{Name: "repoFoo"},
+				Revs: []string{"revBar"},
+			}},
+		},
 		{
 			repoFilters: []string{"repoFoo"},
 			wantRepoRevs: []*search.RepositoryRevisions{{
@@ -772,6 +799,112 @@ func TestRepoHasFileContent(t *testing.T) {
 	}
 }
 
+type symbolsComputerFunc func(types.MinimalRepo) []*result.SymbolMatch
+
+func (f symbolsComputerFunc) Compute(_ context.Context, repo types.MinimalRepo, _ api.CommitID, _ *string, _ *string, _ *int32, _ *[]string, kinds []string) ([]*result.SymbolMatch, error) {
+	symbols := f(repo)
+	if len(kinds) == 0 {
+		return symbols, nil
+	}
+	filtered := []*result.SymbolMatch{}
+	for _, s := range symbols {
+		for _, kind := range kinds {
+			if s.Symbol.Kind == kind {
+				filtered = append(filtered, s)
+			}
+		}
+	}
+	return filtered, nil
+}
+
+func TestRepoHasSymbol(t *testing.T) {
+	repoA := types.MinimalRepo{ID: 1, Name: "example.com/1"}
+	repoB := types.MinimalRepo{ID: 2, Name: "example.com/2"}
+	repoC := types.MinimalRepo{ID: 3, Name: "example.com/3"}
+
+	mkHead := func(repo types.MinimalRepo) *search.RepositoryRevisions {
+		return &search.RepositoryRevisions{
+			Repo: repo,
+			Revs: []string{""},
+		}
+	}
+
+	repos := dbmocks.NewMockRepoStore()
+	repos.ListMinimalReposFunc.SetDefaultHook(func(context.Context, database.ReposListOptions) ([]types.MinimalRepo, error) {
+		return []types.MinimalRepo{repoA, repoB, repoC}, nil
+	})
+
+	db := dbmocks.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	mockGitserver := gitserver.NewMockClient()
+	mockGitserver.ResolveRevisionFunc.SetDefaultHook(func(_ context.Context, name api.RepoName, _ string, _ gitserver.ResolveRevisionOptions) (api.CommitID, error) {
+		if name == repoC.Name {
+			return "", &gitdomain.RevisionNotFoundError{}
+		}
+		return "", nil
+	})
+
+	symbols := symbolsComputerFunc(func(repo types.MinimalRepo) []*result.SymbolMatch {
+		switch repo.ID {
+		case repoA.ID:
+			return []*result.SymbolMatch{{Symbol: result.Symbol{Name: "HandleRequest", Kind: "function"}}}
+		case repoB.ID:
+			return []*result.SymbolMatch{{Symbol: result.Symbol{Name: "Handler", Kind: "struct"}}}
+		default:
+			return nil
+		}
+	})
+
+	cases := []struct {
+		name     string
+		filters  []query.HasSymbolArgs
+		expected []*search.RepositoryRevisions
+	}{{
+		name:    "no filters",
+		filters: nil,
+		expected: []*search.RepositoryRevisions{
+			mkHead(repoA),
+			mkHead(repoB),
+			mkHead(repoC),
+		},
+	}, {
+		name:    "name",
+		filters: []query.HasSymbolArgs{{Name: "^Handle"}},
+		expected: []*search.RepositoryRevisions{
+			mkHead(repoA),
+			mkHead(repoB),
+		},
+	}, {
+		name:    "name and kind",
+		filters: []query.HasSymbolArgs{{Name: "^Handle", Kind: "struct"}},
+		expected: []*search.RepositoryRevisions{
+			mkHead(repoB),
+		},
+	}, {
+		name:    "negated kind",
+		filters: []query.HasSymbolArgs{{Kind: "function", Negated: true}},
+		expected: []*search.RepositoryRevisions{
+			mkHead(repoB),
+			mkHead(repoC),
+		},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := NewResolver(logtest.Scoped(t), db, mockGitserver, endpoint.Static("test"), NewMockStreamer())
+			res.symbolsClient = symbols
+			resolved, _, err := res.resolve(context.Background(), search.RepoOptions{
+				RepoFilters: toParsedRepoFilters(".*"),
+				HasSymbol:   tc.filters,
+			})
+			require.NoError(t, err)
+
+			require.Equal(t, tc.expected, resolved.RepoRevs)
+		})
+	}
+}
+
 func TestRepoHasCommitAfter(t *testing.T) {
 	repoA := types.MinimalRepo{ID: 1, Name: "example.com/1"}
 	repoB := types.MinimalRepo{ID: 2, Name: "example.com/2"}
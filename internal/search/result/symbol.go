@@ -3,6 +3,7 @@ package result
 import (
 	"fmt"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -240,3 +241,17 @@ func SelectSymbolKind(symbols []*SymbolMatch, field string) []*SymbolMatch {
 		return field == toSelectKind[strings.ToLower(s.Symbol.Kind)]
 	})
 }
+
+// SymbolKindsForSelectKind returns the internal symbol kinds (cf. ctagsKind)
+// that map to the given symbol selector kind, so that symbol backends, which
+// only know about internal kinds, can filter by a selector kind.
+func SymbolKindsForSelectKind(field string) []string {
+	var kinds []string
+	for kind, selectKind := range toSelectKind {
+		if selectKind == field {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
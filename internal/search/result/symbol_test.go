@@ -53,3 +53,9 @@ func TestSymbolURL(t *testing.T) {
 		})
 	}
 }
+
+func TestSymbolKindsForSelectKind(t *testing.T) {
+	require.Equal(t, []string{"const", "constant"}, SymbolKindsForSelectKind("constant"))
+	require.Equal(t, []string{"struct"}, SymbolKindsForSelectKind("struct"))
+	require.Empty(t, SymbolKindsForSelectKind("unknown"))
+}
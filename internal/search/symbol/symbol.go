@@ -3,6 +3,7 @@ package symbol
 import (
 	"context"
 	"regexp/syntax" //nolint:depguard // zoekt requires this pkg
+	"strings"
 	"time"
 
 	"github.com/RoaringBitmap/roaring"
@@ -14,6 +15,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	zoektutil "github.com/sourcegraph/sourcegraph/internal/search/zoekt"
 	"github.com/sourcegraph/sourcegraph/internal/symbols"
@@ -42,11 +44,14 @@ type ZoektSymbolsClient struct {
 	symbols             *symbols.Client
 }
 
-func (s *ZoektSymbolsClient) Compute(ctx context.Context, repoName types.MinimalRepo, commitID api.CommitID, inputRev *string, query *string, first *int32, includePatterns *[]string) (res []*result.SymbolMatch, err error) {
+// Compute returns the symbols of a repository at a commit. If includeKinds is
+// not empty, only symbols of one of those kinds (cf.
+// result.SymbolKindsForSelectKind) are returned.
+func (s *ZoektSymbolsClient) Compute(ctx context.Context, repoName types.MinimalRepo, commitID api.CommitID, inputRev *string, query *string, first *int32, includePatterns *[]string, includeKinds []string) (res []*result.SymbolMatch, err error) {
 	// TODO(keegancsmith) we should be able to use indexedSearchRequest here
 	// and remove indexedSymbolsBranch.
 	if branch := indexedSymbolsBranch(ctx, s.zoektStreamer, &repoName, string(commitID)); branch != "" {
-		results, err := searchZoekt(ctx, s.zoektStreamer, repoName, commitID, inputRev, branch, query, first, includePatterns)
+		results, err := searchZoekt(ctx, s.zoektStreamer, repoName, commitID, inputRev, branch, query, first, includePatterns, includeKinds)
 		if err != nil {
 			return nil, errors.Wrap(err, "zoekt symbol search")
 		}
@@ -76,6 +81,7 @@ func (s *ZoektSymbolsClient) Compute(ctx context.Context, repoName types.Minimal
 		First:           limitOrDefault(first) + 1, // add 1 so we can determine PageInfo.hasNextPage
 		Repo:            repoName.Name,
 		IncludePatterns: includePatternsSlice,
+		IncludeKinds:    includeKinds,
 		Timeout:         serverTimeout,
 	}
 	if query != nil {
@@ -117,7 +123,7 @@ func (s *ZoektSymbolsClient) GetMatchAtLineCharacter(ctx context.Context, repo t
 	first := int32(999999)
 	emptyString := ""
 	includePatterns := []string{regexp.QuoteMeta(filePath)}
-	symbolMatches, err := s.Compute(ctx, repo, commitID, &emptyString, &emptyString, &first, &includePatterns)
+	symbolMatches, err := s.Compute(ctx, repo, commitID, &emptyString, &emptyString, &first, &includePatterns, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -188,6 +194,7 @@ func searchZoekt(
 	queryString *string,
 	first *int32,
 	includePatterns *[]string,
+	includeKinds []string,
 ) (res []*result.SymbolMatch, err error) {
 	var raw string
 	if queryString != nil {
@@ -231,6 +238,20 @@ func searchZoekt(
 		}
 	}
 
+	// Zoekt can't filter symbols by kind, so we drop symbols of other kinds
+	// as we collect the results.
+	kinds := make(map[string]struct{}, len(includeKinds))
+	for _, kind := range includeKinds {
+		kinds[strings.ToLower(kind)] = struct{}{}
+	}
+	hasKind := func(kind string) bool {
+		if len(kinds) == 0 {
+			return true
+		}
+		_, ok := kinds[strings.ToLower(kind)]
+		return ok
+	}
+
 	final := zoektquery.Simplify(zoektquery.NewAnd(ands...))
 	match := limitOrDefault(first) + 1
 	resp, err := z.Search(ctx, final, &zoekt.SearchOptions{
@@ -259,7 +280,7 @@ func searchZoekt(
 			}
 
 			for _, m := range l.LineFragments {
-				if m.SymbolInfo == nil {
+				if m.SymbolInfo == nil || !hasKind(m.SymbolInfo.Kind) {
 					continue
 				}
 
@@ -285,7 +306,7 @@ func searchZoekt(
 
 			for i, r := range cm.Ranges {
 				si := cm.SymbolInfo[i]
-				if si == nil {
+				if si == nil || !hasKind(si.Kind) {
 					continue
 				}
 
@@ -307,6 +328,32 @@ func searchZoekt(
 	return
 }
 
+// MatchesHasSymbol reports whether any of symbols satisfies the name and kind
+// constraints of a has.symbol() predicate. Negation is left to the caller.
+func MatchesHasSymbol(symbols []*result.SymbolMatch, arg query.HasSymbolArgs, caseSensitive bool) (bool, error) {
+	if arg.Kind != "" {
+		symbols = result.SelectSymbolKind(symbols, arg.Kind)
+	}
+	if arg.Name == "" {
+		return len(symbols) > 0, nil
+	}
+
+	pattern := arg.Name
+	if !caseSensitive {
+		pattern = "(?i:" + pattern + ")"
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return false, err
+	}
+	for _, s := range symbols {
+		if re.MatchString(s.Symbol.Name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func limitOrDefault(first *int32) int {
 	if first == nil {
 		return DefaultSymbolLimit
@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	srp "github.com/sourcegraph/sourcegraph/internal/authz/subrepoperms"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
@@ -23,7 +24,7 @@ func TestSearchZoektDoesntPanicWithNilQuery(t *testing.T) {
 	expectedErr := errors.New("short circuit")
 	mockStreamer.SearchFunc.SetDefaultReturn(nil, expectedErr)
 
-	_, err := searchZoekt(context.Background(), mockStreamer, types.MinimalRepo{ID: 1}, "commitID", nil, "branch", nil, nil, nil)
+	_, err := searchZoekt(context.Background(), mockStreamer, types.MinimalRepo{ID: 1}, "commitID", nil, "branch", nil, nil, nil, nil)
 	assert.ErrorIs(t, err, expectedErr)
 }
 
@@ -68,3 +69,34 @@ func TestFilterZoektResults(t *testing.T) {
 	r := filtered[0]
 	assert.Equal(t, r.File.Path, "foo.go")
 }
+
+func TestMatchesHasSymbol(t *testing.T) {
+	symbols := []*result.SymbolMatch{
+		{Symbol: result.Symbol{Name: "HandleRequest", Kind: "func"}},
+		{Symbol: result.Symbol{Name: "Server", Kind: "struct"}},
+	}
+
+	tests := []struct {
+		name          string
+		arg           query.HasSymbolArgs
+		caseSensitive bool
+		want          bool
+	}{
+		{name: "name", arg: query.HasSymbolArgs{Name: "^Handle"}, want: true},
+		{name: "name no match", arg: query.HasSymbolArgs{Name: "^Client"}},
+		{name: "kind", arg: query.HasSymbolArgs{Kind: "function"}, want: true},
+		{name: "kind no match", arg: query.HasSymbolArgs{Kind: "class"}},
+		{name: "name and kind", arg: query.HasSymbolArgs{Name: "Server", Kind: "struct"}, want: true},
+		{name: "name and kind on different symbols", arg: query.HasSymbolArgs{Name: "Server", Kind: "function"}},
+		{name: "case insensitive", arg: query.HasSymbolArgs{Name: "^handle"}, want: true},
+		{name: "case sensitive", arg: query.HasSymbolArgs{Name: "^handle"}, caseSensitive: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := MatchesHasSymbol(symbols, tc.arg, tc.caseSensitive)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -126,6 +126,11 @@ type SymbolsParameters struct {
 	// need to match to get included in the result
 	ExcludePattern string
 
+	// IncludeKinds is an optional list of lowercase symbol kinds (cf.
+	// result.SymbolKindsForSelectKind). If it is not empty, only symbols of
+	// one of these kinds are returned.
+	IncludeKinds []string
+
 	// First indicates that only the first n symbols should be returned.
 	First int
 
@@ -452,6 +457,7 @@ type RepoOptions struct {
 	// Whether we should depend on Zoekt for resolving repositories
 	UseIndex       query.YesNoOnly
 	HasFileContent []query.RepoHasFileContentArgs
+	HasSymbol      []query.HasSymbolArgs
 	HasKVPs        []query.RepoKVPFilter
 	HasTopics      []query.RepoHasTopicPredicate
 
@@ -522,6 +528,21 @@ func (op *RepoOptions) Attributes() []attribute.KeyValue {
 			add(trace.Scoped(fmt.Sprintf("hasFileContent[%d]", i), nondefault...)...)
 		}
 	}
+	if len(op.HasSymbol) > 0 {
+		for i, arg := range op.HasSymbol {
+			nondefault := []attribute.KeyValue{}
+			if arg.Name != "" {
+				nondefault = append(nondefault, attribute.String("name", arg.Name))
+			}
+			if arg.Kind != "" {
+				nondefault = append(nondefault, attribute.String("kind", arg.Kind))
+			}
+			if arg.Negated {
+				nondefault = append(nondefault, attribute.Bool("negated", arg.Negated))
+			}
+			add(trace.Scoped(fmt.Sprintf("hasSymbol[%d]", i), nondefault...)...)
+		}
+	}
 	if len(op.HasKVPs) > 0 {
 		for i, arg := range op.HasKVPs {
 			nondefault := []attribute.KeyValue{}
@@ -612,6 +633,19 @@ func (op *RepoOptions) String() string {
 			}
 		}
 	}
+	if len(op.HasSymbol) > 0 {
+		for i, arg := range op.HasSymbol {
+			if arg.Name != "" {
+				fmt.Fprintf(&b, "HasSymbol[%d].name: %s\n", i, arg.Name)
+			}
+			if arg.Kind != "" {
+				fmt.Fprintf(&b, "HasSymbol[%d].kind: %s\n", i, arg.Kind)
+			}
+			if arg.Negated {
+				fmt.Fprintf(&b, "HasSymbol[%d].negated: %t\n", i, arg.Negated)
+			}
+		}
+	}
 	if len(op.HasKVPs) > 0 {
 		for i, arg := range op.HasKVPs {
 			if arg.Key != "" {
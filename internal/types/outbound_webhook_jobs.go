@@ -15,6 +15,12 @@ type OutboundWebhookJob struct {
 	Scope     *string
 	Payload   *encryption.Encryptable
 
+	// OutboundWebhookID restricts the job to a single outbound webhook, rather
+	// than every webhook subscribed to EventType. It is set on redeliveries.
+	OutboundWebhookID *int64
+	// RedeliveryOfJobID is the ID of the job whose payload is being redelivered.
+	RedeliveryOfJobID *int64
+
 	State           string
 	FailureMessage  *string
 	QueuedAt        time.Time
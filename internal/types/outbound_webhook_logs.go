@@ -15,6 +15,7 @@ type OutboundWebhookLog struct {
 	Request           *EncryptableWebhookLogMessage
 	Response          *EncryptableWebhookLogMessage
 	Error             *encryption.Encryptable
+	Redelivery        bool
 }
 
 const OutboundWebhookLogUnsentStatusCode int = 0
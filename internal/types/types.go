@@ -912,6 +912,18 @@ type RolePermission struct {
 	CreatedAt    time.Time
 }
 
+// RoleScope restricts the permissions of a role to a single target: an organization, a
+// repository, or the repositories of a search context. Exactly one of OrgID, RepoID and
+// SearchContextID is set. Roles without any scope apply globally.
+type RoleScope struct {
+	ID              int32
+	RoleID          int32
+	OrgID           int32
+	RepoID          api.RepoID
+	SearchContextID int64
+	CreatedAt       time.Time
+}
+
 type UserRole struct {
 	RoleID    int32
 	UserID    int32
@@ -2049,6 +2061,10 @@ type Team struct {
 	CreatorID    int32
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
+	// SCIMControlled is true if the team is provisioned from a SCIM group.
+	SCIMControlled bool
+	// SCIMExternalID is the identifier of the SCIM group in the identity provider.
+	SCIMExternalID string
 }
 
 type TeamMember struct {
@@ -0,0 +1,133 @@
+package uploadstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+type azureAPI interface {
+	CreateContainer(ctx context.Context, container string) error
+	GetBlob(ctx context.Context, container, name string) (io.ReadCloser, error)
+	UploadBlob(ctx context.Context, container, name string, r io.Reader) (int64, error)
+	DeleteBlob(ctx context.Context, container, name string) error
+	ListBlobs(ctx context.Context, container, prefix, marker string) (*azureBlobList, error)
+}
+
+type azureBlobList struct {
+	Blobs      []azureBlob
+	NextMarker string
+}
+
+type azureBlob struct {
+	Name         string
+	LastModified time.Time
+}
+
+type azureAPIShim struct{ client *azblob.Client }
+
+var _ azureAPI = &azureAPIShim{}
+
+// newAzureAPIShim creates a client of the blob service of the configured storage account,
+// authenticating with the account's shared key. Requests are sent through the given
+// transport.
+func newAzureAPIShim(transport policy.Transporter, config AzureConfig) (*azureAPIShim, error) {
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.blob.core.windows.net/", config.AccountName)
+	}
+
+	credential, err := azblob.NewSharedKeyCredential(config.AccountName, config.AccountKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid Azure storage account key")
+	}
+
+	client, err := azblob.NewClientWithSharedKeyCredential(endpoint, credential, &azblob.ClientOptions{
+		ClientOptions: azcore.ClientOptions{Transport: transport},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &azureAPIShim{client: client}, nil
+}
+
+func (s *azureAPIShim) CreateContainer(ctx context.Context, container string) error {
+	if _, err := s.client.CreateContainer(ctx, container, nil); err != nil && !bloberror.HasCode(err, bloberror.ContainerAlreadyExists) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *azureAPIShim) GetBlob(ctx context.Context, container, name string) (io.ReadCloser, error) {
+	resp, err := s.client.DownloadStream(ctx, container, name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Body, nil
+}
+
+func (s *azureAPIShim) UploadBlob(ctx context.Context, container, name string, r io.Reader) (int64, error) {
+	cr := &countingReader{r: r}
+	if _, err := s.client.UploadStream(ctx, container, name, cr, &azblob.UploadStreamOptions{BlockSize: azureBlockSize}); err != nil {
+		return 0, err
+	}
+
+	return int64(cr.n), nil
+}
+
+func (s *azureAPIShim) DeleteBlob(ctx context.Context, container, name string) error {
+	_, err := s.client.DeleteBlob(ctx, container, name, nil)
+	return err
+}
+
+func (s *azureAPIShim) ListBlobs(ctx context.Context, container, prefix, marker string) (*azureBlobList, error) {
+	options := &azblob.ListBlobsFlatOptions{}
+	if prefix != "" {
+		options.Prefix = &prefix
+	}
+	if marker != "" {
+		options.Marker = &marker
+	}
+
+	page, err := s.client.NewListBlobsFlatPager(container, options).NextPage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &azureBlobList{}
+	if page.Segment != nil {
+		for _, item := range page.Segment.BlobItems {
+			if item.Name == nil {
+				continue
+			}
+
+			blob := azureBlob{Name: *item.Name}
+			if item.Properties != nil && item.Properties.LastModified != nil {
+				blob.LastModified = *item.Properties.LastModified
+			}
+			list.Blobs = append(list.Blobs, blob)
+		}
+	}
+	if page.NextMarker != nil {
+		list.NextMarker = *page.NextMarker
+	}
+
+	return list, nil
+}
+
+// isAzureNotFound returns true if the given error is a response of the blob service for a
+// missing blob.
+func isAzureNotFound(err error) bool {
+	return bloberror.HasCode(err, bloberror.BlobNotFound)
+}
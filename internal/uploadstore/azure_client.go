@@ -0,0 +1,261 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"time"
+
+	sglog "github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+)
+
+type azureStore struct {
+	container    string
+	manageBucket bool
+	client       azureAPI
+	operations   *Operations
+}
+
+var _ Store = &azureStore{}
+
+type AzureConfig struct {
+	// AccountName is the name of the storage account.
+	AccountName string
+	// AccountKey is the base64 encoded shared key of the storage account.
+	AccountKey string
+	// Endpoint overrides the blob service endpoint of the storage account, which defaults to
+	// https://<account name>.blob.core.windows.net. This is used to target the Azurite
+	// emulator, e.g. http://127.0.0.1:10000/devstoreaccount1.
+	Endpoint string
+}
+
+// azureBlockSize is the size of the blocks uploaded blobs are split into.
+const azureBlockSize = 4 * 1024 * 1024
+
+// newAzureFromConfig creates a new store backed by Azure Blob Storage. The configured bucket
+// is used as the container name.
+//
+// Azure lifecycle management policies are configured on the storage account through the
+// Azure Resource Manager API, which can't be reached with the account's shared key. When the
+// container is managed, Init instead starts a background routine expiring objects older than
+// the TTL.
+func newAzureFromConfig(_ context.Context, config Config, operations *Operations) (Store, error) {
+	if config.Azure.AccountName == "" || config.Azure.AccountKey == "" {
+		return nil, errors.New("no account name or key configured for the Azure upload store")
+	}
+
+	doer, err := httpcli.UncachedExternalClientFactory.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newAzureAPIShim(doer, config.Azure)
+	if err != nil {
+		return nil, err
+	}
+
+	return newAzureWithClient(client, config.Bucket, config.ManageBucket, operations), nil
+}
+
+func newAzureWithClient(client azureAPI, container string, manageBucket bool, operations *Operations) *azureStore {
+	return &azureStore{
+		container:    container,
+		manageBucket: manageBucket,
+		client:       client,
+		operations:   operations,
+	}
+}
+
+func (s *azureStore) Init(ctx context.Context) error {
+	if !s.manageBucket {
+		return nil
+	}
+
+	if err := s.client.CreateContainer(ctx, s.container); err != nil {
+		return errors.Wrap(err, "failed to create container")
+	}
+
+	return nil
+}
+
+func (s *azureStore) List(ctx context.Context, prefix string) (_ *iterator.Iterator[string], err error) {
+	ctx, _, endObservation := s.operations.List.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("prefix", prefix),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	marker := ""
+	done := false
+
+	next := func() ([]string, error) {
+		if done {
+			return nil, nil
+		}
+
+		list, err := s.client.ListBlobs(ctx, s.container, prefix, marker)
+		if err != nil {
+			s.operations.List.Logger.Error("Failed to list objects in Azure container", sglog.Error(err))
+			return nil, err
+		}
+
+		keys := make([]string, 0, len(list.Blobs))
+		for _, blob := range list.Blobs {
+			keys = append(keys, blob.Name)
+		}
+
+		marker = list.NextMarker
+		done = marker == ""
+		return keys, nil
+	}
+
+	return iterator.New[string](next), nil
+}
+
+func (s *azureStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	done := func() { endObservation(1, observation.Args{}) }
+
+	rc, err := s.client.GetBlob(ctx, s.container, key)
+	if err != nil {
+		done()
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	return NewExtraCloser(rc, done), nil
+}
+
+func (s *azureStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	n, err := s.client.UploadBlob(ctx, s.container, key, r)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+func (s *azureStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("destination", destination),
+		attribute.StringSlice("sources", sources),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	defer func() {
+		if err == nil {
+			// Delete sources on success
+			if err := s.deleteSources(ctx, destination, sources); err != nil {
+				s.operations.Compose.Logger.Error("Failed to delete source objects", sglog.Error(err))
+			}
+		}
+	}()
+
+	// Azure can only compose blocks of a single blob server-side, so we stream the
+	// sources through the client instead.
+	pr, pw := io.Pipe()
+	go func() {
+		for _, source := range sources {
+			rc, err := s.client.GetBlob(ctx, s.container, source)
+			if err != nil {
+				pw.CloseWithError(errors.Wrap(err, "failed to get source object"))
+				return
+			}
+
+			_, err = io.Copy(pw, rc)
+			rc.Close()
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+
+		pw.Close()
+	}()
+	defer pr.Close()
+
+	n, err := s.client.UploadBlob(ctx, s.container, destination, pr)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+
+	return n, nil
+}
+
+func (s *azureStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return errors.Wrap(s.delete(ctx, key), "failed to delete object")
+}
+
+func (s *azureStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) (err error) {
+	ctx, _, endObservation := s.operations.ExpireObjects.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("prefix", prefix),
+		attribute.Stringer("maxAge", maxAge),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	marker := ""
+	for {
+		list, err := s.client.ListBlobs(ctx, s.container, prefix, marker)
+		if err != nil {
+			s.operations.ExpireObjects.Logger.Error("Failed to list objects in Azure container", sglog.Error(err))
+			break // we'll try again later
+		}
+
+		for _, blob := range list.Blobs {
+			if time.Since(blob.LastModified) >= maxAge {
+				if err := s.delete(ctx, blob.Name); err != nil {
+					s.operations.ExpireObjects.Logger.Error("Failed to delete expired Azure object",
+						sglog.Error(err),
+						sglog.String("container", s.container),
+						sglog.String("object", blob.Name))
+					continue
+				}
+			}
+		}
+
+		if list.NextMarker == "" {
+			break
+		}
+		marker = list.NextMarker
+	}
+
+	return nil
+}
+
+// delete removes the given blob. Deleting a blob which does not exist is not an error.
+func (s *azureStore) delete(ctx context.Context, key string) error {
+	if err := s.client.DeleteBlob(ctx, s.container, key); err != nil && !isAzureNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *azureStore) deleteSources(ctx context.Context, destination string, sources []string) error {
+	return ForEachString(sources, func(index int, source string) error {
+		if source == destination {
+			return nil
+		}
+
+		if err := s.delete(ctx, source); err != nil {
+			return errors.Wrap(err, "failed to delete source object")
+		}
+
+		return nil
+	})
+}
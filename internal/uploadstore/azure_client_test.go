@@ -0,0 +1,363 @@
+package uploadstore
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// Well-known credentials of the Azurite emulator.
+const (
+	azuriteAccountName = "devstoreaccount1"
+	azuriteAccountKey  = "Eby8vdM02xNOcqFlqUwJPLlmEtlCDXJ1OUzFT50uSRZ6IFsuFq2UVErCz4I6tq/K1SZFPTOtr/KBHBeksoGMGw=="
+)
+
+func TestAzureInit(t *testing.T) {
+	server := newFakeAzureServer(t)
+	client := testAzureClient(t, server.URL, true)
+
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+	// Creating an existing container is not an error
+	if err := testAzureClient(t, server.URL, true).Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if !server.containers["test-bucket"] {
+		t.Errorf("expected container to be created")
+	}
+}
+
+func TestAzureUnmanagedInit(t *testing.T) {
+	server := newFakeAzureServer(t)
+	client := testAzureClient(t, server.URL, false)
+
+	if err := client.Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+
+	if server.containers["test-bucket"] {
+		t.Errorf("unexpected container creation")
+	}
+}
+
+func TestAzureUploadGet(t *testing.T) {
+	server := newFakeAzureServer(t)
+	client := testAzureClient(t, server.URL, true)
+
+	payload := strings.Repeat("x", azureBlockSize+10)
+	n, err := client.Upload(context.Background(), "uploads/1/upload.lsif.gz", strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if n != int64(len(payload)) {
+		t.Errorf("unexpected size. want=%d have=%d", len(payload), n)
+	}
+	if blocks := server.blockCount["uploads/1/upload.lsif.gz"]; blocks != 2 {
+		t.Errorf("unexpected number of blocks. want=%d have=%d", 2, blocks)
+	}
+
+	if contents := readObject(t, client, "uploads/1/upload.lsif.gz"); contents != payload {
+		t.Errorf("unexpected contents")
+	}
+
+	if _, err := client.Get(context.Background(), "uploads/2/upload.lsif.gz"); err == nil {
+		t.Errorf("expected error getting missing object")
+	}
+}
+
+func TestAzureCompose(t *testing.T) {
+	server := newFakeAzureServer(t)
+	client := testAzureClient(t, server.URL, true)
+
+	for _, key := range []string{"part-1", "part-2", "part-3"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader(key+";")); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	n, err := client.Compose(context.Background(), "composed", "part-1", "part-2", "part-3")
+	if err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	}
+	if n != 21 {
+		t.Errorf("unexpected size. want=%d have=%d", 21, n)
+	}
+
+	if contents := readObject(t, client, "composed"); contents != "part-1;part-2;part-3;" {
+		t.Errorf("unexpected contents. want=%s have=%s", "part-1;part-2;part-3;", contents)
+	}
+
+	if diff := cmp.Diff([]string{"composed"}, listObjects(t, client, "")); diff != "" {
+		t.Errorf("unexpected objects after compose (-want +got):\n%s", diff)
+	}
+}
+
+func TestAzureDelete(t *testing.T) {
+	server := newFakeAzureServer(t)
+	client := testAzureClient(t, server.URL, true)
+
+	if _, err := client.Upload(context.Background(), "key", strings.NewReader("payload")); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+
+	if err := client.Delete(context.Background(), "key"); err != nil {
+		t.Fatalf("unexpected error deleting object: %s", err)
+	}
+	if err := client.Delete(context.Background(), "key"); err != nil {
+		t.Fatalf("unexpected error deleting missing object: %s", err)
+	}
+}
+
+func TestAzureListPagination(t *testing.T) {
+	server := newFakeAzureServer(t)
+	server.pageSize = 2
+	client := testAzureClient(t, server.URL, true)
+
+	for _, key := range []string{"uploads/3", "uploads/1", "uploads/2", "other/1"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("")); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"uploads/1", "uploads/2", "uploads/3"}, listObjects(t, client, "uploads/")); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+}
+
+func TestAzureExpireObjects(t *testing.T) {
+	server := newFakeAzureServer(t)
+	server.pageSize = 1
+	client := testAzureClient(t, server.URL, true)
+
+	for _, key := range []string{"uploads/old", "uploads/new", "other/old"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("payload")); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+	server.lastModified["uploads/old"] = time.Now().Add(-2 * time.Hour)
+	server.lastModified["other/old"] = time.Now().Add(-2 * time.Hour)
+
+	if err := client.ExpireObjects(context.Background(), "uploads/", time.Hour); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"other/old", "uploads/new"}, listObjects(t, client, "")); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+}
+
+// TestAzurite runs the Azure store against an Azurite emulator, e.g. started with
+//
+//	docker run -p 10000:10000 mcr.microsoft.com/azure-storage/azurite azurite-blob --blobHost 0.0.0.0
+//
+// and AZURITE_BLOB_ENDPOINT=http://127.0.0.1:10000/devstoreaccount1.
+func TestAzurite(t *testing.T) {
+	endpoint := os.Getenv("AZURITE_BLOB_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("AZURITE_BLOB_ENDPOINT not set")
+	}
+
+	ctx := context.Background()
+	store, err := newAzureFromConfig(ctx, Config{
+		Bucket:       fmt.Sprintf("test-%d", time.Now().UnixNano()),
+		ManageBucket: true,
+		Azure: AzureConfig{
+			AccountName: azuriteAccountName,
+			AccountKey:  azuriteAccountKey,
+			Endpoint:    endpoint,
+		},
+	}, NewOperations(&observation.TestContext, "test", "brittlestore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := newLazyStore(store)
+
+	for _, key := range []string{"uploads/part-1", "uploads/part-2"} {
+		if _, err := client.Upload(ctx, key, strings.NewReader(key+";")); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+	if _, err := client.Compose(ctx, "uploads/composed", "uploads/part-1", "uploads/part-2"); err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	}
+	if contents := readObject(t, client, "uploads/composed"); contents != "uploads/part-1;uploads/part-2;" {
+		t.Errorf("unexpected contents. want=%s have=%s", "uploads/part-1;uploads/part-2;", contents)
+	}
+	if diff := cmp.Diff([]string{"uploads/composed"}, listObjects(t, client, "uploads/")); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+
+	if err := client.ExpireObjects(ctx, "uploads/", 0); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+	if keys := listObjects(t, client, "uploads/"); len(keys) != 0 {
+		t.Errorf("unexpected objects after expiry: %v", keys)
+	}
+}
+
+func testAzureClient(t *testing.T, endpoint string, manageBucket bool) Store {
+	client, err := newAzureAPIShim(http.DefaultClient, AzureConfig{
+		AccountName: azuriteAccountName,
+		AccountKey:  azuriteAccountKey,
+		Endpoint:    endpoint,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return newLazyStore(newAzureWithClient(client, "test-bucket", manageBucket, NewOperations(&observation.TestContext, "test", "brittlestore")))
+}
+
+// fakeAzureServer is an in-memory implementation of the subset of the Azure Blob Storage REST
+// API used by the azblob client.
+type fakeAzureServer struct {
+	*httptest.Server
+
+	mu           sync.Mutex
+	pageSize     int
+	containers   map[string]bool
+	blocks       map[string][]byte
+	blobs        map[string][]byte
+	blockCount   map[string]int
+	lastModified map[string]time.Time
+}
+
+func newFakeAzureServer(t *testing.T) *fakeAzureServer {
+	s := &fakeAzureServer{
+		pageSize:     1000,
+		containers:   map[string]bool{},
+		blocks:       map[string][]byte{},
+		blobs:        map[string][]byte{},
+		blockCount:   map[string]int{},
+		lastModified: map[string]time.Time{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *fakeAzureServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey "+azuriteAccountName+":") || r.Header.Get("x-ms-version") == "" {
+		writeFakeAzureError(w, http.StatusForbidden, "AuthenticationFailed")
+		return
+	}
+
+	container, name, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	query := r.URL.Query()
+	body, _ := io.ReadAll(r.Body)
+
+	switch {
+	case r.Method == http.MethodPut && query.Get("restype") == "container":
+		if s.containers[container] {
+			writeFakeAzureError(w, http.StatusConflict, "ContainerAlreadyExists")
+			return
+		}
+		s.containers[container] = true
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodGet && query.Get("comp") == "list":
+		var names []string
+		for name := range s.blobs {
+			if strings.HasPrefix(name, query.Get("prefix")) && name > query.Get("marker") {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+
+		var list struct {
+			XMLName    xml.Name        `xml:"EnumerationResults"`
+			Blobs      []fakeAzureBlob `xml:"Blobs>Blob"`
+			NextMarker string
+		}
+		if len(names) > s.pageSize {
+			names = names[:s.pageSize]
+			list.NextMarker = names[len(names)-1]
+		}
+		for _, name := range names {
+			blob := fakeAzureBlob{Name: name}
+			blob.Properties.LastModified = s.lastModified[name].UTC().Format(http.TimeFormat)
+			list.Blobs = append(list.Blobs, blob)
+		}
+		_ = xml.NewEncoder(w).Encode(list)
+
+	case r.Method == http.MethodPut && query.Get("comp") == "block":
+		s.blocks[name+"/"+query.Get("blockid")] = body
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut && query.Get("comp") == "blocklist":
+		var blockList struct {
+			Latest []string `xml:"Latest"`
+		}
+		if err := xml.Unmarshal(body, &blockList); err != nil {
+			writeFakeAzureError(w, http.StatusBadRequest, "InvalidXmlDocument")
+			return
+		}
+
+		var content []byte
+		for _, blockID := range blockList.Latest {
+			content = append(content, s.blocks[name+"/"+blockID]...)
+			delete(s.blocks, name+"/"+blockID)
+		}
+		s.blobs[name] = content
+		s.blockCount[name] = len(blockList.Latest)
+		s.lastModified[name] = time.Now()
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodPut:
+		s.blobs[name] = body
+		s.blockCount[name] = 1
+		s.lastModified[name] = time.Now()
+		w.WriteHeader(http.StatusCreated)
+
+	case r.Method == http.MethodGet:
+		content, ok := s.blobs[name]
+		if !ok {
+			writeFakeAzureError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		_, _ = w.Write(content)
+
+	case r.Method == http.MethodDelete:
+		if _, ok := s.blobs[name]; !ok {
+			writeFakeAzureError(w, http.StatusNotFound, "BlobNotFound")
+			return
+		}
+		delete(s.blobs, name)
+		w.WriteHeader(http.StatusAccepted)
+
+	default:
+		writeFakeAzureError(w, http.StatusBadRequest, "UnsupportedHttpVerb")
+	}
+}
+
+type fakeAzureBlob struct {
+	XMLName    xml.Name `xml:"Blob"`
+	Name       string
+	Properties struct {
+		LastModified string `xml:"Last-Modified"`
+	}
+}
+
+func writeFakeAzureError(w http.ResponseWriter, statusCode int, code string) {
+	w.Header().Set("x-ms-error-code", code)
+	w.WriteHeader(statusCode)
+	fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"utf-8\"?><Error><Code>%s</Code><Message>fake error</Message></Error>", code)
+}
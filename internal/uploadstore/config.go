@@ -14,6 +14,8 @@ type Config struct {
 	TTL          time.Duration
 	S3           S3Config
 	GCS          GCSConfig
+	Filesystem   FilesystemConfig
+	Azure        AzureConfig
 }
 
 func normalizeConfig(t Config) Config {
@@ -28,5 +30,10 @@ func normalizeConfig(t Config) Config {
 		// No subdomains on built-in blobstore.
 		o.S3.UsePathStyle = true
 	}
+
+	if o.Backend == "filesystem" {
+		// The bucket is a directory we own.
+		o.ManageBucket = true
+	}
 	return o
 }
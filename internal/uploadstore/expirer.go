@@ -30,3 +30,33 @@ func NewExpirer(ctx context.Context, store Store, prefix string, maxAge time.Dur
 func (e *expirer) Handle(ctx context.Context) error {
 	return e.store.ExpireObjects(ctx, e.prefix, e.maxAge)
 }
+
+// ttlExpirerInterval is the frequency at which the routine returned by NewTTLExpirer
+// expires objects.
+const ttlExpirerInterval = time.Hour
+
+// NewTTLExpirer returns a background routine periodically deleting the objects of the given
+// store which are older than the TTL of the given configuration. GCS and S3 stores expire
+// objects through the bucket lifecycle configuration applied by Init, so the routine only
+// expires objects of filesystem and Azure stores that manage their bucket. For other stores,
+// or if the TTL is not positive, the routine does nothing.
+func NewTTLExpirer(ctx context.Context, store Store, config Config) goroutine.BackgroundRoutine {
+	config = normalizeConfig(config)
+	if !config.ManageBucket || config.TTL <= 0 {
+		return goroutine.NoopRoutine()
+	}
+	if config.Backend != "filesystem" && config.Backend != "azure" {
+		return goroutine.NoopRoutine()
+	}
+
+	return goroutine.NewPeriodicGoroutine(
+		ctx,
+		&expirer{
+			store:  store,
+			maxAge: config.TTL,
+		},
+		goroutine.WithName("uploadstore.ttl-expirer"),
+		goroutine.WithDescription("expires entries older than the TTL in upload stores without a bucket lifecycle configuration"),
+		goroutine.WithInterval(ttlExpirerInterval),
+	)
+}
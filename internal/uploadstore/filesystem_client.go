@@ -0,0 +1,401 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+	"github.com/sourcegraph/sourcegraph/lib/iterator"
+)
+
+// filesystemStore stores objects as files in a directory on local disk. Keys map to paths
+// relative to that directory, with "/" separating directories. It is meant for single-node
+// deployments, or for deployments where all services share the same volume.
+type filesystemStore struct {
+	root         string
+	ttl          time.Duration
+	manageBucket bool
+	operations   *Operations
+}
+
+var _ Store = &filesystemStore{}
+
+type FilesystemConfig struct {
+	// Path is the directory under which buckets are stored as subdirectories.
+	Path string
+}
+
+// newFilesystemFromConfig creates a new store backed by the local filesystem.
+func newFilesystemFromConfig(_ context.Context, config Config, operations *Operations) (Store, error) {
+	if config.Filesystem.Path == "" {
+		return nil, errors.New("no path configured for the filesystem upload store")
+	}
+	if !isValidFilesystemKey(config.Bucket) {
+		return nil, errors.Errorf("invalid bucket name %q", config.Bucket)
+	}
+
+	return newFilesystemWithRoot(filepath.Join(config.Filesystem.Path, config.Bucket), config.TTL, config.ManageBucket, operations), nil
+}
+
+func newFilesystemWithRoot(root string, ttl time.Duration, manageBucket bool, operations *Operations) *filesystemStore {
+	return &filesystemStore{
+		root:         root,
+		ttl:          ttl,
+		manageBucket: manageBucket,
+		operations:   operations,
+	}
+}
+
+func (s *filesystemStore) Init(ctx context.Context) error {
+	if !s.manageBucket {
+		if _, err := os.Stat(s.root); err != nil {
+			return errors.Wrap(err, "failed to stat bucket directory")
+		}
+
+		return nil
+	}
+
+	if err := os.MkdirAll(s.root, 0o755); err != nil {
+		return errors.Wrap(err, "failed to create bucket directory")
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) List(ctx context.Context, prefix string) (_ *iterator.Iterator[string], err error) {
+	ctx, _, endObservation := s.operations.List.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("prefix", prefix),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var keys []string
+	if err := s.walk(ctx, prefix, func(key string, info fs.FileInfo) error {
+		if !s.expired(info) {
+			keys = append(keys, key)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// Return the whole listing as a single page.
+	return iterator.New[string](func() ([]string, error) {
+		page := keys
+		keys = nil
+		return page, nil
+	}), nil
+}
+
+func (s *filesystemStore) Get(ctx context.Context, key string) (_ io.ReadCloser, err error) {
+	ctx, _, endObservation := s.operations.Get.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	done := func() { endObservation(1, observation.Args{}) }
+
+	filename, err := s.path(key)
+	if err != nil {
+		done()
+		return nil, err
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		done()
+		return nil, errors.Wrap(err, "failed to get object")
+	}
+
+	if info, err := f.Stat(); err != nil {
+		f.Close()
+		done()
+		return nil, errors.Wrap(err, "failed to stat object")
+	} else if s.expired(info) {
+		// The expirer started by Init only runs periodically, so objects may outlive the
+		// TTL until its next run. Don't serve them in the meantime.
+		f.Close()
+		s.remove(filename)
+		done()
+		return nil, errors.Wrap(&fs.PathError{Op: "open", Path: filename, Err: fs.ErrNotExist}, "failed to get object")
+	}
+
+	return NewExtraCloser(f, done), nil
+}
+
+func (s *filesystemStore) Upload(ctx context.Context, key string, r io.Reader) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Upload.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	n, err := s.write(key, r)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to upload object")
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Compose(ctx context.Context, destination string, sources ...string) (_ int64, err error) {
+	ctx, _, endObservation := s.operations.Compose.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("destination", destination),
+		attribute.StringSlice("sources", sources),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	files := make([]*os.File, 0, len(sources))
+	closeAll := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+	defer closeAll()
+
+	readers := make([]io.Reader, 0, len(sources))
+	for _, source := range sources {
+		filename, err := s.path(source)
+		if err != nil {
+			return 0, err
+		}
+
+		f, err := os.Open(filename)
+		if err != nil {
+			return 0, errors.Wrap(err, "failed to open source object")
+		}
+
+		files = append(files, f)
+		readers = append(readers, f)
+	}
+
+	n, err := s.write(destination, io.MultiReader(readers...))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compose objects")
+	}
+	closeAll()
+	files = nil
+
+	// Delete sources on success
+	for _, source := range sources {
+		if source == destination {
+			continue
+		}
+		if err := s.delete(source); err != nil {
+			s.operations.Compose.Logger.Error("Failed to delete source object", log.Error(err), log.String("source", source))
+		}
+	}
+
+	return n, nil
+}
+
+func (s *filesystemStore) Delete(ctx context.Context, key string) (err error) {
+	ctx, _, endObservation := s.operations.Delete.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("key", key),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return errors.Wrap(s.delete(key), "failed to delete object")
+}
+
+func (s *filesystemStore) ExpireObjects(ctx context.Context, prefix string, maxAge time.Duration) (err error) {
+	ctx, _, endObservation := s.operations.ExpireObjects.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("prefix", prefix),
+		attribute.Stringer("maxAge", maxAge),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := s.walk(ctx, prefix, func(key string, info fs.FileInfo) error {
+		if time.Since(info.ModTime()) >= maxAge {
+			if err := s.delete(key); err != nil {
+				s.operations.ExpireObjects.Logger.Error("Failed to delete expired object",
+					log.Error(err),
+					log.String("root", s.root),
+					log.String("object", key))
+			}
+		}
+		return nil
+	}); err != nil {
+		s.operations.ExpireObjects.Logger.Error("Failed to walk bucket directory", log.Error(err))
+		// we'll try again later
+	}
+
+	return nil
+}
+
+// write atomically writes the content of the given reader to the object at the given key.
+// The content is written to a temporary file which is renamed once complete, so that readers
+// never observe partially written objects.
+func (s *filesystemStore) write(key string, r io.Reader) (_ int64, err error) {
+	filename, err := s.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	dir := filepath.Dir(filename)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return 0, err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".upload-*")
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	n, err := io.Copy(tmp, r)
+	if err != nil {
+		tmp.Close()
+		return 0, err
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, err
+	}
+
+	if err := os.Rename(tmp.Name(), filename); err != nil {
+		return 0, err
+	}
+
+	return n, nil
+}
+
+// delete removes the object at the given key along with any parent directories left empty.
+// Deleting an object which does not exist is not an error.
+func (s *filesystemStore) delete(key string) error {
+	filename, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	s.removeEmptyParents(filename)
+	return nil
+}
+
+// remove deletes the given file, ignoring errors.
+func (s *filesystemStore) remove(filename string) {
+	if err := os.Remove(filename); err == nil {
+		s.removeEmptyParents(filename)
+	}
+}
+
+func (s *filesystemStore) removeEmptyParents(filename string) {
+	for dir := filepath.Dir(filename); dir != s.root && strings.HasPrefix(dir, s.root); dir = filepath.Dir(dir) {
+		// Remove fails on non-empty directories
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+	}
+}
+
+// walk invokes the given function for each object with the given prefix, in lexicographical
+// order of keys.
+func (s *filesystemStore) walk(ctx context.Context, prefix string, fn func(key string, info fs.FileInfo) error) error {
+	var entries []struct {
+		key  string
+		info fs.FileInfo
+	}
+
+	// Only walk the directory containing the prefix, rather than the whole bucket.
+	dir := s.root
+	if i := strings.LastIndex(prefix, "/"); i >= 0 {
+		if !isValidFilesystemKey(prefix[:i]) {
+			return nil
+		}
+		dir = filepath.Join(s.root, filepath.FromSlash(prefix[:i]))
+	}
+
+	err := filepath.WalkDir(dir, func(filename string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".upload-") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.root, filename)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Deleted concurrently
+				return nil
+			}
+			return err
+		}
+
+		entries = append(entries, struct {
+			key  string
+			info fs.FileInfo
+		}{key, info})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+	for _, entry := range entries {
+		if err := fn(entry.key, entry.info); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *filesystemStore) expired(info fs.FileInfo) bool {
+	return s.ttl > 0 && time.Since(info.ModTime()) >= s.ttl
+}
+
+// path returns the path of the file storing the object at the given key.
+func (s *filesystemStore) path(key string) (string, error) {
+	if !isValidFilesystemKey(key) {
+		return "", errors.Errorf("invalid object key %q", key)
+	}
+
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// isValidFilesystemKey returns true if the given key is a relative, clean path that does not
+// escape the bucket directory.
+func isValidFilesystemKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key {
+		return false
+	}
+
+	for _, segment := range strings.Split(key, "/") {
+		if segment == ".." || segment == "." || strings.HasPrefix(segment, ".upload-") {
+			return false
+		}
+	}
+
+	return true
+}
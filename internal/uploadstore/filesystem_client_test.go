@@ -0,0 +1,260 @@
+package uploadstore
+
+import (
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestFilesystemInit(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "test-bucket")
+
+	if err := testFilesystemClient(root, 0, false).Init(context.Background()); err == nil {
+		t.Fatalf("expected error initializing unmanaged client without directory")
+	}
+
+	if err := testFilesystemClient(root, 0, true).Init(context.Background()); err != nil {
+		t.Fatalf("unexpected error initializing client: %s", err)
+	}
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		t.Fatalf("expected bucket directory to be created: %v", err)
+	}
+}
+
+func TestFilesystemUploadGet(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), 0, true)
+
+	n, err := client.Upload(context.Background(), "uploads/1/upload.lsif.gz", strings.NewReader("TEST PAYLOAD"))
+	if err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+	if n != 12 {
+		t.Errorf("unexpected size. want=%d have=%d", 12, n)
+	}
+
+	if contents := readObject(t, client, "uploads/1/upload.lsif.gz"); contents != "TEST PAYLOAD" {
+		t.Errorf("unexpected contents. want=%s have=%s", "TEST PAYLOAD", contents)
+	}
+
+	if _, err := client.Get(context.Background(), "uploads/2/upload.lsif.gz"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected not exist error getting missing object, got %v", err)
+	}
+}
+
+func TestFilesystemInvalidKeys(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), 0, true)
+
+	for _, key := range []string{"", "/abs", "../escape", "a/../../escape", "a//b", "a/./b", `a\b`, "a/.upload-123"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("")); err == nil {
+			t.Errorf("expected error uploading object with key %q", key)
+		}
+	}
+}
+
+func TestFilesystemCompose(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), 0, true)
+
+	for key, contents := range map[string]string{"part-1": "foo", "part-2": "bar", "part-3": "baz"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader(contents)); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	n, err := client.Compose(context.Background(), "composed", "part-1", "part-2", "part-3")
+	if err != nil {
+		t.Fatalf("unexpected error composing objects: %s", err)
+	}
+	if n != 9 {
+		t.Errorf("unexpected size. want=%d have=%d", 9, n)
+	}
+
+	if contents := readObject(t, client, "composed"); contents != "foobarbaz" {
+		t.Errorf("unexpected contents. want=%s have=%s", "foobarbaz", contents)
+	}
+
+	if diff := cmp.Diff([]string{"composed"}, listObjects(t, client, "")); diff != "" {
+		t.Errorf("unexpected objects after compose (-want +got):\n%s", diff)
+	}
+}
+
+func TestFilesystemDelete(t *testing.T) {
+	root := t.TempDir()
+	client := testFilesystemClient(root, 0, true)
+
+	if _, err := client.Upload(context.Background(), "a/b/c", strings.NewReader("payload")); err != nil {
+		t.Fatalf("unexpected error uploading object: %s", err)
+	}
+
+	if err := client.Delete(context.Background(), "a/b/c"); err != nil {
+		t.Fatalf("unexpected error deleting object: %s", err)
+	}
+	if err := client.Delete(context.Background(), "a/b/c"); err != nil {
+		t.Fatalf("unexpected error deleting missing object: %s", err)
+	}
+
+	// Empty directories are cleaned up, but not the bucket directory itself
+	if _, err := os.Stat(filepath.Join(root, "a")); !os.IsNotExist(err) {
+		t.Errorf("expected empty parent directories to be removed")
+	}
+	if _, err := os.Stat(root); err != nil {
+		t.Errorf("expected bucket directory to remain: %s", err)
+	}
+}
+
+func TestFilesystemList(t *testing.T) {
+	client := testFilesystemClient(t.TempDir(), 0, true)
+
+	for _, key := range []string{"uploads/2", "uploads/1", "uploads-other/1", "other/1"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("")); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+
+	if diff := cmp.Diff([]string{"uploads/1", "uploads/2"}, listObjects(t, client, "uploads/")); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"uploads-other/1", "uploads/1", "uploads/2"}, listObjects(t, client, "uploads")); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"other/1", "uploads-other/1", "uploads/1", "uploads/2"}, listObjects(t, client, "")); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+}
+
+func TestFilesystemTTL(t *testing.T) {
+	root := t.TempDir()
+	client := testFilesystemClient(root, time.Hour, true)
+
+	for _, key := range []string{"old", "new"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("payload")); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+	setAge(t, filepath.Join(root, "old"), 2*time.Hour)
+
+	if diff := cmp.Diff([]string{"new"}, listObjects(t, client, "")); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+
+	if _, err := client.Get(context.Background(), "old"); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("expected not exist error getting expired object, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "old")); !os.IsNotExist(err) {
+		t.Errorf("expected expired object to be removed")
+	}
+}
+
+func TestFilesystemTTLExpirer(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "old"), []byte("payload"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	setAge(t, filepath.Join(root, "old"), 2*time.Hour)
+
+	config := Config{Backend: "filesystem", TTL: time.Hour, Filesystem: FilesystemConfig{Path: root}}
+	routine := NewTTLExpirer(context.Background(), testFilesystemClient(root, time.Hour, true), config)
+
+	// The periodic goroutine handles its first tick immediately
+	go routine.Start()
+	defer routine.Stop()
+
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if _, err := os.Stat(filepath.Join(root, "old")); os.IsNotExist(err) {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected expired object to be removed by the expirer")
+		}
+	}
+
+	for _, config := range []Config{
+		{Backend: "filesystem", TTL: 0},
+		{Backend: "gcs", ManageBucket: true, TTL: time.Hour},
+		{Backend: "azure", ManageBucket: false, TTL: time.Hour},
+	} {
+		if routine := NewTTLExpirer(context.Background(), testFilesystemClient(root, 0, true), config); routine != goroutine.NoopRoutine() {
+			t.Errorf("expected no expirer for %s store with TTL %s", config.Backend, config.TTL)
+		}
+	}
+}
+
+func TestFilesystemExpireObjects(t *testing.T) {
+	root := t.TempDir()
+	client := testFilesystemClient(root, 0, true)
+
+	for _, key := range []string{"uploads/old", "uploads/new", "other/old"} {
+		if _, err := client.Upload(context.Background(), key, strings.NewReader("payload")); err != nil {
+			t.Fatalf("unexpected error uploading object: %s", err)
+		}
+	}
+	setAge(t, filepath.Join(root, "uploads", "old"), 2*time.Hour)
+	setAge(t, filepath.Join(root, "other", "old"), 2*time.Hour)
+
+	if err := client.ExpireObjects(context.Background(), "uploads/", time.Hour); err != nil {
+		t.Fatalf("unexpected error expiring objects: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"other/old", "uploads/new"}, listObjects(t, client, "")); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+}
+
+func testFilesystemClient(root string, ttl time.Duration, manageBucket bool) Store {
+	return newLazyStore(newFilesystemWithRoot(root, ttl, manageBucket, NewOperations(&observation.TestContext, "test", "brittlestore")))
+}
+
+func readObject(t *testing.T, client Store, key string) string {
+	t.Helper()
+
+	rc, err := client.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("unexpected error getting object: %s", err)
+	}
+	defer rc.Close()
+
+	contents, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("unexpected error reading object: %s", err)
+	}
+
+	return string(contents)
+}
+
+func listObjects(t *testing.T, client Store, prefix string) []string {
+	t.Helper()
+
+	iter, err := client.List(context.Background(), prefix)
+	if err != nil {
+		t.Fatalf("unexpected error listing objects: %s", err)
+	}
+
+	var keys []string
+	for iter.Next() {
+		keys = append(keys, iter.Current())
+	}
+	if err := iter.Err(); err != nil {
+		t.Fatalf("unexpected error iterating objects: %s", err)
+	}
+
+	return keys
+}
+
+func setAge(t *testing.T, filename string, age time.Duration) {
+	t.Helper()
+
+	modTime := time.Now().Add(-age)
+	if err := os.Chtimes(filename, modTime, modTime); err != nil {
+		t.Fatalf("unexpected error setting modification time: %s", err)
+	}
+}
@@ -0,0 +1,66 @@
+package outbound
+
+const (
+	CodeMonitorTriggered    = "code_monitor:triggered"
+	PermissionSyncCompleted = "permission_sync:completed"
+	PermissionSyncFailed    = "permission_sync:failed"
+	RepoAdded               = "repo:added"
+	RepoCloneFailed         = "repo:clone_failed"
+	RepoRemoved             = "repo:removed"
+	RepoRenamed             = "repo:renamed"
+	UserCreated             = "user:created"
+	UserDeleted             = "user:deleted"
+	UserSiteAdminGranted    = "user:site_admin_granted"
+)
+
+func init() {
+	RegisterEventType(EventType{
+		Key:         CodeMonitorTriggered,
+		Description: "sent when a code monitor finds new results and runs its actions",
+	})
+
+	RegisterEventType(EventType{
+		Key:         PermissionSyncCompleted,
+		Description: "sent when a user or repository permissions sync completes",
+	})
+
+	RegisterEventType(EventType{
+		Key:         PermissionSyncFailed,
+		Description: "sent when an attempt to sync user or repository permissions fails",
+	})
+
+	RegisterEventType(EventType{
+		Key:         RepoAdded,
+		Description: "sent when a repository is added from a code host connection",
+	})
+
+	RegisterEventType(EventType{
+		Key:         RepoCloneFailed,
+		Description: "sent when an attempt to clone a repository fails",
+	})
+
+	RegisterEventType(EventType{
+		Key:         RepoRemoved,
+		Description: "sent when a repository is removed because no code host connection syncs it anymore",
+	})
+
+	RegisterEventType(EventType{
+		Key:         RepoRenamed,
+		Description: "sent when a repository is renamed on the code host",
+	})
+
+	RegisterEventType(EventType{
+		Key:         UserCreated,
+		Description: "sent when a user account is created",
+	})
+
+	RegisterEventType(EventType{
+		Key:         UserDeleted,
+		Description: "sent when a user account is deleted",
+	})
+
+	RegisterEventType(EventType{
+		Key:         UserSiteAdminGranted,
+		Description: "sent when a user is promoted to site admin",
+	})
+}
@@ -0,0 +1,219 @@
+package outbound
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// EnqueueEvent creates an outbound webhook job that will dispatch a webhook of
+// the given type with the given payload marshalled to JSON.
+//
+// Jobs are only created if at least one outbound webhook is subscribed to the
+// event type, since some of the core event types (such as repo:added during the
+// initial sync of a code host connection) can be emitted in large numbers.
+//
+// Like the typed helpers below, EnqueueEvent is fire and forget: errors are
+// logged rather than returned, since failing to send a webhook must not fail
+// the operation that caused it.
+func EnqueueEvent(ctx context.Context, logger log.Logger, db database.DB, eventType string, payload any) {
+	logger = logger.With(log.String("event_type", eventType))
+
+	count, err := db.OutboundWebhooks(keyring.Default().OutboundWebhookKey).Count(ctx, database.OutboundWebhookCountOpts{
+		EventTypes: []database.FilterEventType{{EventType: eventType}},
+	})
+	if err != nil {
+		logger.Error("error counting outbound webhooks", log.Error(err))
+		return
+	}
+	if count == 0 {
+		return
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("error marshalling webhook payload", log.Error(err))
+		return
+	}
+
+	if err := newOutboundWebhookServiceFromDB(db).Enqueue(ctx, eventType, nil, data); err != nil {
+		logger.Error("error enqueuing webhook job", log.Error(err))
+		return
+	}
+}
+
+// repo represents a repository in a webhook payload.
+type repo struct {
+	ID           graphql.ID `json:"id"`
+	Name         string     `json:"name"`
+	PreviousName string     `json:"previous_name,omitempty"`
+	Private      bool       `json:"private"`
+	ServiceType  string     `json:"service_type,omitempty"`
+	ServiceID    string     `json:"service_id,omitempty"`
+	ExternalID   string     `json:"external_id,omitempty"`
+}
+
+func newRepo(r *types.Repo) repo {
+	return repo{
+		ID:          relay.MarshalID("Repository", r.ID),
+		Name:        string(api.UndeletedRepoName(r.Name)),
+		Private:     r.Private,
+		ServiceType: r.ExternalRepo.ServiceType,
+		ServiceID:   r.ExternalRepo.ServiceID,
+		ExternalID:  r.ExternalRepo.ID,
+	}
+}
+
+// EnqueueRepo enqueues a repo:added or repo:removed webhook for the given
+// repository.
+func EnqueueRepo(ctx context.Context, logger log.Logger, db database.DB, eventType string, r *types.Repo) {
+	EnqueueEvent(ctx, logger.With(log.Int32("repo_id", int32(r.ID))), db, eventType, newRepo(r))
+}
+
+// EnqueueRepoRenamed enqueues a repo:renamed webhook for the given repository,
+// which must already have its new name.
+func EnqueueRepoRenamed(ctx context.Context, logger log.Logger, db database.DB, r *types.Repo, previousName api.RepoName) {
+	payload := newRepo(r)
+	payload.PreviousName = string(previousName)
+	EnqueueEvent(ctx, logger.With(log.Int32("repo_id", int32(r.ID))), db, RepoRenamed, payload)
+}
+
+// repoCloneFailure represents a failed clone in a webhook payload.
+type repoCloneFailure struct {
+	Name  string `json:"name"`
+	Error string `json:"error"`
+}
+
+// EnqueueRepoCloneFailed enqueues a repo:clone_failed webhook. The caller is
+// responsible for redacting credentials from cloneErr.
+func EnqueueRepoCloneFailed(ctx context.Context, logger log.Logger, db database.DB, name api.RepoName, cloneErr error) {
+	EnqueueEvent(ctx, logger, db, RepoCloneFailed, repoCloneFailure{
+		Name:  string(name),
+		Error: cloneErr.Error(),
+	})
+}
+
+// codeMonitorTrigger represents a code monitor trigger event in a webhook
+// payload.
+type codeMonitorTrigger struct {
+	ID             graphql.ID `json:"id"`
+	Description    string     `json:"description"`
+	Owner          graphql.ID `json:"owner_user_id"`
+	Query          string     `json:"query"`
+	TriggerEventID graphql.ID `json:"trigger_event_id"`
+	ResultCount    int        `json:"result_count"`
+}
+
+// EnqueueCodeMonitorTriggered enqueues a code_monitor:triggered webhook for a
+// monitor whose query found resultCount new results in the given trigger job.
+func EnqueueCodeMonitorTriggered(ctx context.Context, logger log.Logger, db database.DB, m *database.Monitor, query string, triggerJobID int32, resultCount int) {
+	EnqueueEvent(ctx, logger.With(log.Int64("monitor_id", m.ID)), db, CodeMonitorTriggered, codeMonitorTrigger{
+		ID:             relay.MarshalID("CodeMonitor", m.ID),
+		Description:    m.Description,
+		Owner:          relay.MarshalID("User", m.UserID),
+		Query:          query,
+		TriggerEventID: relay.MarshalID("CodeMonitorTriggerEvent", triggerJobID),
+		ResultCount:    resultCount,
+	})
+}
+
+// user represents a user in a webhook payload.
+type user struct {
+	ID          graphql.ID  `json:"id"`
+	Username    string      `json:"username"`
+	DisplayName string      `json:"display_name,omitempty"`
+	SiteAdmin   bool        `json:"site_admin"`
+	CreatedAt   time.Time   `json:"created_at"`
+	HardDelete  *bool       `json:"hard_delete,omitempty"`
+	GrantedBy   *graphql.ID `json:"granted_by_user_id,omitempty"`
+}
+
+func newUser(u *types.User) user {
+	return user{
+		ID:          relay.MarshalID("User", u.ID),
+		Username:    u.Username,
+		DisplayName: u.DisplayName,
+		SiteAdmin:   u.SiteAdmin,
+		CreatedAt:   u.CreatedAt,
+	}
+}
+
+// EnqueueUserCreated enqueues a user:created webhook for the given user.
+func EnqueueUserCreated(ctx context.Context, logger log.Logger, db database.DB, u *types.User) {
+	EnqueueEvent(ctx, logger.With(log.Int32("user_id", u.ID)), db, UserCreated, newUser(u))
+}
+
+// EnqueueUserDeleted enqueues a user:deleted webhook for the given user. The
+// user must have been loaded before it was deleted.
+func EnqueueUserDeleted(ctx context.Context, logger log.Logger, db database.DB, u *types.User, hardDelete bool) {
+	payload := newUser(u)
+	payload.HardDelete = &hardDelete
+	EnqueueEvent(ctx, logger.With(log.Int32("user_id", u.ID)), db, UserDeleted, payload)
+}
+
+// EnqueueUserSiteAdminGranted enqueues a user:site_admin_granted webhook for
+// the given user. grantedBy is the ID of the user who made the change, or 0 if
+// it was made by Sourcegraph itself.
+func EnqueueUserSiteAdminGranted(ctx context.Context, logger log.Logger, db database.DB, u *types.User, grantedBy int32) {
+	payload := newUser(u)
+	payload.SiteAdmin = true
+	if grantedBy != 0 {
+		id := relay.MarshalID("User", grantedBy)
+		payload.GrantedBy = &id
+	}
+	EnqueueEvent(ctx, logger.With(log.Int32("user_id", u.ID)), db, UserSiteAdminGranted, payload)
+}
+
+// permissionSync represents the outcome of a permissions sync job in a webhook
+// payload.
+type permissionSync struct {
+	ID                 graphql.ID  `json:"id"`
+	User               *graphql.ID `json:"user_id,omitempty"`
+	Repository         *graphql.ID `json:"repository_id,omitempty"`
+	Reason             string      `json:"reason"`
+	PermissionsAdded   int         `json:"permissions_added"`
+	PermissionsRemoved int         `json:"permissions_removed"`
+	PermissionsFound   int         `json:"permissions_found"`
+	Error              *string     `json:"error,omitempty"`
+}
+
+// EnqueuePermissionSync enqueues a permission_sync:completed webhook for the
+// given permissions sync job, or a permission_sync:failed webhook if syncErr
+// is not nil.
+func EnqueuePermissionSync(ctx context.Context, logger log.Logger, db database.DB, job *database.PermissionSyncJob, result *database.SetPermissionsResult, syncErr error) {
+	payload := permissionSync{
+		ID:     relay.MarshalID("PermissionsSyncJob", job.ID),
+		Reason: string(job.Reason),
+	}
+	if job.UserID != 0 {
+		id := relay.MarshalID("User", int32(job.UserID))
+		payload.User = &id
+	}
+	if job.RepositoryID != 0 {
+		id := relay.MarshalID("Repository", int32(job.RepositoryID))
+		payload.Repository = &id
+	}
+	if result != nil {
+		payload.PermissionsAdded = result.Added
+		payload.PermissionsRemoved = result.Removed
+		payload.PermissionsFound = result.Found
+	}
+
+	eventType := PermissionSyncCompleted
+	if syncErr != nil {
+		eventType = PermissionSyncFailed
+		msg := syncErr.Error()
+		payload.Error = &msg
+	}
+
+	EnqueueEvent(ctx, logger.With(log.Int("job_id", job.ID)), db, eventType, payload)
+}
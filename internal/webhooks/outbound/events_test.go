@@ -0,0 +1,115 @@
+package outbound
+
+import (
+	"context"
+	"testing"
+
+	mockassert "github.com/derision-test/go-mockgen/testutil/assert"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbmocks"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestEnqueueEvent(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+
+	setup := func(count int64) (*dbmocks.MockDB, *dbmocks.MockOutboundWebhookStore, *dbmocks.MockOutboundWebhookJobStore) {
+		webhooks := dbmocks.NewMockOutboundWebhookStore()
+		webhooks.CountFunc.SetDefaultReturn(count, nil)
+		jobs := dbmocks.NewMockOutboundWebhookJobStore()
+		jobs.CreateFunc.SetDefaultReturn(&types.OutboundWebhookJob{}, nil)
+
+		db := dbmocks.NewMockDB()
+		db.OutboundWebhooksFunc.SetDefaultReturn(webhooks)
+		db.OutboundWebhookJobsFunc.SetDefaultReturn(jobs)
+		return db, webhooks, jobs
+	}
+
+	t.Run("no subscribed webhooks", func(t *testing.T) {
+		db, webhooks, jobs := setup(0)
+
+		EnqueueEvent(ctx, logger, db, UserCreated, map[string]string{"id": "VXNlcjox"})
+		mockassert.CalledOnce(t, webhooks.CountFunc)
+		mockassert.NotCalled(t, jobs.CreateFunc)
+
+		opts := webhooks.CountFunc.History()[0].Arg1
+		assert.Equal(t, []database.FilterEventType{{EventType: UserCreated}}, opts.EventTypes)
+	})
+
+	t.Run("count error", func(t *testing.T) {
+		db, webhooks, jobs := setup(0)
+		webhooks.CountFunc.SetDefaultReturn(0, errors.New("mock error"))
+
+		EnqueueEvent(ctx, logger, db, UserCreated, map[string]string{"id": "VXNlcjox"})
+		mockassert.NotCalled(t, jobs.CreateFunc)
+	})
+
+	t.Run("subscribed webhooks", func(t *testing.T) {
+		db, _, jobs := setup(1)
+
+		EnqueueEvent(ctx, logger, db, UserCreated, map[string]string{"id": "VXNlcjox"})
+		mockassert.CalledOnce(t, jobs.CreateFunc)
+
+		call := jobs.CreateFunc.History()[0]
+		assert.Equal(t, UserCreated, call.Arg1)
+		assert.Nil(t, call.Arg2)
+		assert.JSONEq(t, `{"id":"VXNlcjox"}`, string(call.Arg3))
+	})
+
+	t.Run("create error", func(t *testing.T) {
+		db, _, jobs := setup(1)
+		jobs.CreateFunc.SetDefaultReturn(nil, errors.New("mock error"))
+
+		// Errors are logged rather than returned.
+		EnqueueEvent(ctx, logger, db, UserCreated, map[string]string{"id": "VXNlcjox"})
+		mockassert.CalledOnce(t, jobs.CreateFunc)
+	})
+}
+
+func TestEnqueuePermissionSync(t *testing.T) {
+	ctx := context.Background()
+	logger := logtest.Scoped(t)
+
+	webhooks := dbmocks.NewMockOutboundWebhookStore()
+	webhooks.CountFunc.SetDefaultReturn(1, nil)
+	jobs := dbmocks.NewMockOutboundWebhookJobStore()
+	jobs.CreateFunc.SetDefaultReturn(&types.OutboundWebhookJob{}, nil)
+	db := dbmocks.NewMockDB()
+	db.OutboundWebhooksFunc.SetDefaultReturn(webhooks)
+	db.OutboundWebhookJobsFunc.SetDefaultReturn(jobs)
+
+	job := &database.PermissionSyncJob{ID: 3, UserID: 1, Reason: database.ReasonUserOutdatedPermissions}
+
+	EnqueuePermissionSync(ctx, logger, db, job, &database.SetPermissionsResult{Added: 1, Removed: 2, Found: 3}, nil)
+	EnqueuePermissionSync(ctx, logger, db, job, nil, errors.New("code host unavailable"))
+
+	history := jobs.CreateFunc.History()
+	require.Len(t, history, 2)
+
+	assert.Equal(t, PermissionSyncCompleted, history[0].Arg1)
+	assert.JSONEq(t, `{
+		"id": "UGVybWlzc2lvbnNTeW5jSm9iOjM=",
+		"user_id": "VXNlcjox",
+		"reason": "REASON_USER_OUTDATED_PERMS",
+		"permissions_added": 1,
+		"permissions_removed": 2,
+		"permissions_found": 3
+	}`, string(history[0].Arg3))
+
+	assert.Equal(t, PermissionSyncFailed, history[1].Arg1)
+	assert.JSONEq(t, `{
+		"id": "UGVybWlzc2lvbnNTeW5jSm9iOjM=",
+		"user_id": "VXNlcjox",
+		"reason": "REASON_USER_OUTDATED_PERMS",
+		"permissions_added": 0,
+		"permissions_removed": 0,
+		"permissions_found": 0,
+		"error": "code host unavailable"
+	}`, string(history[1].Arg3))
+}
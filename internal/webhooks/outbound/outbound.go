@@ -42,6 +42,15 @@ func NewOutboundWebhookService(db basestore.ShareableStore, key encryption.Key)
 	}
 }
 
+// newOutboundWebhookServiceFromDB instantiates a new outbound webhook service
+// backed by the outbound webhook job store of the given database, using the
+// outbound webhook key from the default keyring.
+func newOutboundWebhookServiceFromDB(db database.DB) OutboundWebhookService {
+	return &outboundWebhookService{
+		store: db.OutboundWebhookJobs(keyring.Default().OutboundWebhookKey),
+	}
+}
+
 func (s *outboundWebhookService) Enqueue(
 	ctx context.Context,
 	eventType string,
@@ -0,0 +1,28 @@
+package outbound
+
+import (
+	"time"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// This matches the documented value in the site configuration schema.
+const defaultRetention = 72 * time.Hour
+
+// Retention returns how long outbound webhook jobs and their logs are kept
+// before the janitor deletes them. Events can only be redelivered or replayed
+// within this window.
+func Retention(logger log.Logger, c *conf.Unified) time.Duration {
+	if cfg := c.WebhookLogging; cfg != nil {
+		retention, err := time.ParseDuration(cfg.Retention)
+		if err != nil {
+			logger.Warn("invalid webhook log retention period; ignoring", log.String("raw", cfg.Retention), log.Error(err))
+		} else {
+			return retention
+		}
+	}
+
+	return defaultRetention
+}
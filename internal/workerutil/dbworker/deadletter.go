@@ -0,0 +1,179 @@
+package dbworker
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/debugserver"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+const deadLetterPath = "/dbworker-dead-letters"
+
+// DeadLetterDebugEndpoint returns a debug endpoint for inspecting, requeueing and purging
+// the failed records of every dbworker store registered in this process via
+// store.RegisterDeadLetterStore. It serves the
+// following JSON API:
+//
+//	GET  /dbworker-dead-letters                 lists the stores
+//	GET  /dbworker-dead-letters/{store}/groups  lists failed records grouped by normalized failure message
+//	GET  /dbworker-dead-letters/{store}/records lists failed records
+//	POST /dbworker-dead-letters/{store}/requeue requeues failed records
+//	POST /dbworker-dead-letters/{store}/purge   deletes failed records
+//
+// Records are filtered by the query parameters `message` (a normalized failure message),
+// `id` (repeatable), `failed_before` and `failed_after` (RFC 3339 timestamps), and `limit`.
+// Purging requires at least one filter and is only supported by stores that define how their
+// records are deleted. Listed records include their execution logs only if
+// the `logs` query parameter is set to true.
+func DeadLetterDebugEndpoint() debugserver.Endpoint {
+	return debugserver.Endpoint{
+		Name:     "DB Worker Dead Letters",
+		Path:     deadLetterPath,
+		IsPrefix: true,
+		Handler:  newDeadLetterHandler(store.DeadLetterStores, store.DeadLetterStoreByName),
+	}
+}
+
+func newDeadLetterHandler(
+	listStores func() []store.DeadLetterStore,
+	storeByName func(name string) (store.DeadLetterStore, bool),
+) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Trim(strings.TrimPrefix(r.URL.Path, deadLetterPath), "/")
+		if path == "" {
+			var names []string
+			for _, s := range listStores() {
+				names = append(names, s.Name())
+			}
+			writeDeadLetterJSON(w, map[string]any{"stores": names})
+			return
+		}
+
+		name, action, ok := strings.Cut(path, "/")
+		if !ok {
+			http.Error(w, "expected a path of the form /{store}/{action}", http.StatusNotFound)
+			return
+		}
+		s, ok := storeByName(name)
+		if !ok {
+			http.Error(w, fmt.Sprintf("unknown store %q", name), http.StatusNotFound)
+			return
+		}
+
+		opts, err := parseDeadLetterOptions(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		switch action {
+		case "groups", "records":
+			if r.Method != http.MethodGet {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+
+			var result any
+			if action == "groups" {
+				result, err = s.FailedRecordGroups(r.Context(), opts)
+			} else {
+				result, err = s.FailedRecords(r.Context(), opts)
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeDeadLetterJSON(w, map[string]any{action: result})
+
+		case "requeue", "purge":
+			if r.Method != http.MethodPost {
+				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
+			}
+			if action == "purge" && opts.FailureMessage == "" && len(opts.IDs) == 0 && opts.FailedBefore.IsZero() && opts.FailedAfter.IsZero() {
+				http.Error(w, "refusing to purge all failed records: supply at least one filter", http.StatusBadRequest)
+				return
+			}
+
+			var count int
+			if action == "requeue" {
+				count, err = s.RequeueFailed(r.Context(), opts)
+			} else {
+				count, err = s.PurgeFailed(r.Context(), opts)
+			}
+			if errors.Is(err, store.ErrPurgeNotSupported) {
+				http.Error(w, err.Error(), http.StatusNotImplemented)
+				return
+			}
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeDeadLetterJSON(w, map[string]any{"count": count})
+
+		default:
+			http.Error(w, fmt.Sprintf("unknown action %q", action), http.StatusNotFound)
+		}
+	})
+}
+
+func parseDeadLetterOptions(r *http.Request) (opts store.DeadLetterOptions, err error) {
+	q := r.URL.Query()
+	opts.FailureMessage = q.Get("message")
+
+	for _, v := range q["id"] {
+		id, err := strconv.Atoi(v)
+		if err != nil {
+			return opts, errors.Newf("invalid id %q", v)
+		}
+		opts.IDs = append(opts.IDs, id)
+	}
+
+	parseTime := func(key string) (time.Time, error) {
+		v := q.Get(key)
+		if v == "" {
+			return time.Time{}, nil
+		}
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return time.Time{}, errors.Newf("invalid %s %q: expected an RFC 3339 timestamp", key, v)
+		}
+		return t, nil
+	}
+	if opts.FailedBefore, err = parseTime("failed_before"); err != nil {
+		return opts, err
+	}
+	if opts.FailedAfter, err = parseTime("failed_after"); err != nil {
+		return opts, err
+	}
+
+	if v := q.Get("limit"); v != "" {
+		if opts.Limit, err = strconv.Atoi(v); err != nil {
+			return opts, errors.Newf("invalid limit %q", v)
+		}
+	}
+
+	if v := q.Get("logs"); v != "" {
+		if opts.IncludeExecutionLogs, err = strconv.ParseBool(v); err != nil {
+			return opts, errors.Newf("invalid logs %q", v)
+		}
+	}
+
+	return opts, nil
+}
+
+func writeDeadLetterJSON(w http.ResponseWriter, v any) {
+	resp, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to marshal response: %q", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(resp)
+}
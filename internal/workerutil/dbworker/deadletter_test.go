@@ -0,0 +1,148 @@
+package dbworker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+type fakeDeadLetterStore struct {
+	name     string
+	opts     []store.DeadLetterOptions
+	requeued int
+	purged   int
+	purgeErr error
+}
+
+func (s *fakeDeadLetterStore) Name() string { return s.name }
+
+func (s *fakeDeadLetterStore) FailedRecordGroups(_ context.Context, opts store.DeadLetterOptions) ([]store.FailedRecordGroup, error) {
+	s.opts = append(s.opts, opts)
+	return []store.FailedRecordGroup{{FailureMessage: "repo N: rate limited", Count: 3}}, nil
+}
+
+func (s *fakeDeadLetterStore) FailedRecords(_ context.Context, opts store.DeadLetterOptions) ([]store.FailedRecord, error) {
+	s.opts = append(s.opts, opts)
+	return []store.FailedRecord{{ID: 1, FailureMessage: "repo 42: rate limited"}}, nil
+}
+
+func (s *fakeDeadLetterStore) RequeueFailed(_ context.Context, opts store.DeadLetterOptions) (int, error) {
+	s.opts = append(s.opts, opts)
+	s.requeued++
+	return 3, nil
+}
+
+func (s *fakeDeadLetterStore) PurgeFailed(_ context.Context, opts store.DeadLetterOptions) (int, error) {
+	s.opts = append(s.opts, opts)
+	if s.purgeErr != nil {
+		return 0, s.purgeErr
+	}
+	s.purged++
+	return 2, nil
+}
+
+func TestDeadLetterHandler(t *testing.T) {
+	fake := &fakeDeadLetterStore{name: "test_store"}
+	handler := newDeadLetterHandler(
+		func() []store.DeadLetterStore { return []store.DeadLetterStore{fake} },
+		func(name string) (store.DeadLetterStore, bool) { return fake, name == fake.name },
+	)
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(method, target, nil))
+		return w
+	}
+
+	t.Run("list stores", func(t *testing.T) {
+		w := serve(http.MethodGet, "/dbworker-dead-letters")
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected status. want=%d have=%d", http.StatusOK, w.Code)
+		}
+		if !strings.Contains(w.Body.String(), `"test_store"`) {
+			t.Errorf("expected store name in response, got %s", w.Body.String())
+		}
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		w := serve(http.MethodGet, "/dbworker-dead-letters/test_store/groups?message=repo+N%3A+rate+limited&limit=10")
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected status. want=%d have=%d", http.StatusOK, w.Code)
+		}
+		want := store.DeadLetterOptions{FailureMessage: "repo N: rate limited", Limit: 10}
+		if diff := cmp.Diff(want, fake.opts[len(fake.opts)-1]); diff != "" {
+			t.Errorf("unexpected options (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("records with logs", func(t *testing.T) {
+		w := serve(http.MethodGet, "/dbworker-dead-letters/test_store/records?id=1&logs=true")
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected status. want=%d have=%d", http.StatusOK, w.Code)
+		}
+		want := store.DeadLetterOptions{IDs: []int{1}, IncludeExecutionLogs: true}
+		if diff := cmp.Diff(want, fake.opts[len(fake.opts)-1]); diff != "" {
+			t.Errorf("unexpected options (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("requeue", func(t *testing.T) {
+		w := serve(http.MethodPost, "/dbworker-dead-letters/test_store/requeue?id=1&id=2")
+		if w.Code != http.StatusOK {
+			t.Fatalf("unexpected status. want=%d have=%d", http.StatusOK, w.Code)
+		}
+		if fake.requeued != 1 {
+			t.Errorf("expected records to be requeued")
+		}
+		if diff := cmp.Diff([]int{1, 2}, fake.opts[len(fake.opts)-1].IDs); diff != "" {
+			t.Errorf("unexpected ids (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("purge requires a filter", func(t *testing.T) {
+		w := serve(http.MethodPost, "/dbworker-dead-letters/test_store/purge")
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("unexpected status. want=%d have=%d", http.StatusBadRequest, w.Code)
+		}
+		if fake.purged != 0 {
+			t.Errorf("did not expect records to be purged")
+		}
+	})
+
+	t.Run("purge unsupported", func(t *testing.T) {
+		fake.purgeErr = store.ErrPurgeNotSupported
+		defer func() { fake.purgeErr = nil }()
+
+		w := serve(http.MethodPost, "/dbworker-dead-letters/test_store/purge?id=1")
+		if w.Code != http.StatusNotImplemented {
+			t.Fatalf("unexpected status. want=%d have=%d", http.StatusNotImplemented, w.Code)
+		}
+	})
+
+	t.Run("mutations require POST", func(t *testing.T) {
+		w := serve(http.MethodGet, "/dbworker-dead-letters/test_store/purge?id=1")
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("unexpected status. want=%d have=%d", http.StatusMethodNotAllowed, w.Code)
+		}
+	})
+
+	t.Run("unknown store", func(t *testing.T) {
+		w := serve(http.MethodGet, "/dbworker-dead-letters/missing/groups")
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("unexpected status. want=%d have=%d", http.StatusNotFound, w.Code)
+		}
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		w := serve(http.MethodGet, "/dbworker-dead-letters/test_store/records?failed_before=yesterday")
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("unexpected status. want=%d have=%d", http.StatusBadRequest, w.Code)
+		}
+	})
+}
@@ -0,0 +1,345 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/executor"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+// DeadLetterStore gives operators access to the records of a dbworker store that have
+// been moved into the failed state, either because they exhausted their retries or because
+// they failed with a non-retryable error.
+type DeadLetterStore interface {
+	// Name returns the name of the underlying store.
+	Name() string
+
+	// FailedRecordGroups returns the failed records matching the given options grouped by
+	// their normalized failure message, ordered by descending group size.
+	FailedRecordGroups(ctx context.Context, opts DeadLetterOptions) ([]FailedRecordGroup, error)
+
+	// FailedRecords returns the failed records matching the given options, most recently
+	// failed first. Execution logs are only returned if IncludeExecutionLogs is set.
+	FailedRecords(ctx context.Context, opts DeadLetterOptions) ([]FailedRecord, error)
+
+	// RequeueFailed moves the failed records matching the given options back into the queued
+	// state with their failure and reset counters cleared. It returns the number of records
+	// that were requeued.
+	RequeueFailed(ctx context.Context, opts DeadLetterOptions) (int, error)
+
+	// PurgeFailed deletes the failed records matching the given options. It returns the number
+	// of records that were deleted, or ErrPurgeNotSupported if the store does not define how
+	// its records are deleted.
+	PurgeFailed(ctx context.Context, opts DeadLetterOptions) (int, error)
+}
+
+// DeadLetterOptions filter the failed records targeted by a DeadLetterStore method. Unset
+// fields do not filter.
+type DeadLetterOptions struct {
+	// FailureMessage matches records whose normalized failure message is equal to this value.
+	// See FailedRecordGroup.
+	FailureMessage string
+
+	// IDs matches records with one of the given identifiers.
+	IDs []int
+
+	// FailedBefore and FailedAfter match records that failed within the given window.
+	FailedBefore time.Time
+	FailedAfter  time.Time
+
+	// Limit bounds the number of records or groups returned by FailedRecords and
+	// FailedRecordGroups. It is ignored by RequeueFailed and PurgeFailed.
+	Limit int
+
+	// IncludeExecutionLogs makes FailedRecords return the execution logs of each record.
+	// Execution logs are unbounded in size, so they are omitted unless requested.
+	IncludeExecutionLogs bool
+}
+
+// FailedRecordGroup is a set of failed records that share a normalized failure message.
+// Failure messages are normalized by replacing numbers and hexadecimal identifiers such as
+// commit hashes with placeholders, so that failures differing only by a repository identifier
+// or a revision are grouped together.
+type FailedRecordGroup struct {
+	FailureMessage string
+	Count          int
+	OldestFailure  time.Time
+	NewestFailure  time.Time
+
+	// ExampleFailureMessage is the unnormalized failure message of the most recently failed
+	// record in the group.
+	ExampleFailureMessage string
+}
+
+// FailedRecord is a record in the failed state, optionally along with its execution logs.
+type FailedRecord struct {
+	ID             int
+	FailureMessage string
+	NumFailures    int
+	NumResets      int
+	FinishedAt     *time.Time
+	ExecutionLogs  []executor.ExecutionLogEntry
+}
+
+var _ DeadLetterStore = &store[workerutil.Record]{}
+
+// Name returns the name of the underlying store.
+func (s *store[T]) Name() string {
+	return s.options.Name
+}
+
+// FailedRecordGroups returns the failed records matching the given options grouped by
+// their normalized failure message, ordered by descending group size.
+func (s *store[T]) FailedRecordGroups(ctx context.Context, opts DeadLetterOptions) (_ []FailedRecordGroup, err error) {
+	ctx, _, endObservation := s.operations.failedRecordGroups.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return scanFailedRecordGroups(s.Query(ctx, s.formatQuery(
+		failedRecordGroupsQuery,
+		s.normalizedFailureMessage(),
+		quote(s.options.TableName),
+		sqlf.Join(s.deadLetterConditions(opts), "AND"),
+		s.normalizedFailureMessage(),
+		limitClause(opts.Limit),
+	)))
+}
+
+const failedRecordGroupsQuery = `
+SELECT
+	%s AS normalized_failure_message,
+	COUNT(*),
+	MIN({finished_at}),
+	MAX({finished_at}),
+	(ARRAY_AGG({failure_message} ORDER BY {finished_at} DESC NULLS LAST))[1]
+FROM %s
+WHERE %s
+GROUP BY %s
+ORDER BY COUNT(*) DESC, normalized_failure_message
+%s
+`
+
+var scanFailedRecordGroups = basestore.NewSliceScanner(func(s dbutil.Scanner) (g FailedRecordGroup, err error) {
+	err = s.Scan(
+		&g.FailureMessage,
+		&g.Count,
+		&dbutil.NullTime{Time: &g.OldestFailure},
+		&dbutil.NullTime{Time: &g.NewestFailure},
+		&dbutil.NullString{S: &g.ExampleFailureMessage},
+	)
+	return g, err
+})
+
+// FailedRecords returns the failed records matching the given options, most recently
+// failed first. Execution logs are only returned if IncludeExecutionLogs is set.
+func (s *store[T]) FailedRecords(ctx context.Context, opts DeadLetterOptions) (_ []FailedRecord, err error) {
+	ctx, _, endObservation := s.operations.failedRecords.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	executionLogs := sqlf.Sprintf("NULL")
+	if opts.IncludeExecutionLogs {
+		executionLogs = s.formatQuery("{execution_logs}")
+	}
+
+	return scanFailedRecords(s.Query(ctx, s.formatQuery(
+		failedRecordsQuery,
+		executionLogs,
+		quote(s.options.TableName),
+		sqlf.Join(s.deadLetterConditions(opts), "AND"),
+		limitClause(opts.Limit),
+	)))
+}
+
+const failedRecordsQuery = `
+SELECT
+	{id},
+	{failure_message},
+	{num_failures},
+	{num_resets},
+	{finished_at},
+	%s
+FROM %s
+WHERE %s
+ORDER BY {finished_at} DESC NULLS LAST, {id}
+%s
+`
+
+var scanFailedRecords = basestore.NewSliceScanner(func(s dbutil.Scanner) (r FailedRecord, err error) {
+	err = s.Scan(
+		&r.ID,
+		&dbutil.NullString{S: &r.FailureMessage},
+		&r.NumFailures,
+		&r.NumResets,
+		&r.FinishedAt,
+		pq.Array(&r.ExecutionLogs),
+	)
+	return r, err
+})
+
+// RequeueFailed moves the failed records matching the given options back into the queued
+// state with their failure and reset counters cleared. It returns the number of records
+// that were requeued.
+func (s *store[T]) RequeueFailed(ctx context.Context, opts DeadLetterOptions) (_ int, err error) {
+	ctx, _, endObservation := s.operations.requeueFailed.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("failureMessage", opts.FailureMessage),
+		attribute.Int("numIDs", len(opts.IDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.execDeadLetterQuery(ctx, requeueFailedQuery, opts)
+}
+
+const requeueFailedQuery = `
+WITH updated AS (
+	UPDATE %s
+	SET
+		{state} = 'queued',
+		{started_at} = NULL,
+		{finished_at} = NULL,
+		{process_after} = NULL,
+		{failure_message} = NULL,
+		{num_failures} = 0,
+		{num_resets} = 0
+	WHERE %s
+	RETURNING {id}
+)
+SELECT COUNT(*) FROM updated
+`
+
+// PurgeFailed deletes the failed records matching the given options through the store's
+// PurgeRecords function. It returns the number of records that were deleted.
+func (s *store[T]) PurgeFailed(ctx context.Context, opts DeadLetterOptions) (_ int, err error) {
+	ctx, _, endObservation := s.operations.purgeFailed.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("failureMessage", opts.FailureMessage),
+		attribute.Int("numIDs", len(opts.IDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if s.options.PurgeRecords == nil {
+		return 0, ErrPurgeNotSupported
+	}
+
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	ids, err := basestore.ScanInts(tx.Query(ctx, s.formatQuery(
+		purgeableFailedRecordsQuery,
+		quote(s.options.TableName),
+		sqlf.Join(s.deadLetterConditions(opts), "AND"),
+	)))
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	if err := s.options.PurgeRecords(ctx, tx, ids); err != nil {
+		return 0, err
+	}
+	return len(ids), nil
+}
+
+// purgeableFailedRecordsQuery locks the failed records to purge so that they cannot be
+// requeued while the store's PurgeRecords function deletes them.
+const purgeableFailedRecordsQuery = `
+SELECT {id}
+FROM %s
+WHERE %s
+ORDER BY {id}
+FOR UPDATE SKIP LOCKED
+`
+
+func (s *store[T]) execDeadLetterQuery(ctx context.Context, query string, opts DeadLetterOptions) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(
+		query,
+		quote(s.options.TableName),
+		sqlf.Join(s.deadLetterConditions(opts), "AND"),
+	)))
+	return count, err
+}
+
+// deadLetterConditions returns the conditions matching the failed records described by
+// the given options.
+func (s *store[T]) deadLetterConditions(opts DeadLetterOptions) []*sqlf.Query {
+	conds := []*sqlf.Query{s.formatQuery("{state} = 'failed'")}
+	if opts.FailureMessage != "" {
+		conds = append(conds, sqlf.Sprintf("%s = %s", s.normalizedFailureMessage(), opts.FailureMessage))
+	}
+	if len(opts.IDs) > 0 {
+		conds = append(conds, s.formatQuery("{id} = ANY(%s)", pq.Array(opts.IDs)))
+	}
+	if !opts.FailedBefore.IsZero() {
+		conds = append(conds, s.formatQuery("{finished_at} < %s", opts.FailedBefore))
+	}
+	if !opts.FailedAfter.IsZero() {
+		conds = append(conds, s.formatQuery("{finished_at} > %s", opts.FailedAfter))
+	}
+	return conds
+}
+
+// normalizedFailureMessage returns an expression that replaces the volatile parts of a
+// failure message, such as hexadecimal identifiers and numbers, with placeholders.
+func (s *store[T]) normalizedFailureMessage() *sqlf.Query {
+	return s.formatQuery(`regexp_replace(regexp_replace(COALESCE({failure_message}, ''), '\m[0-9a-f]{7,64}\M', '<hex>', 'g'), '[0-9]+', 'N', 'g')`)
+}
+
+func limitClause(limit int) *sqlf.Query {
+	if limit <= 0 {
+		return sqlf.Sprintf("")
+	}
+	return sqlf.Sprintf("LIMIT %s", limit)
+}
+
+var (
+	deadLetterStoresMu sync.RWMutex
+	deadLetterStores   = map[string]DeadLetterStore{}
+)
+
+// RegisterDeadLetterStore makes the given store available via DeadLetterStores. Services
+// register the stores of the queues they own. Stores are keyed by name, so registering a
+// store more than once replaces its earlier instance. Stores that do not implement
+// DeadLetterStore, such as mocks, are ignored.
+func RegisterDeadLetterStore[T workerutil.Record](s Store[T]) {
+	d, ok := s.(DeadLetterStore)
+	if !ok {
+		return
+	}
+
+	deadLetterStoresMu.Lock()
+	defer deadLetterStoresMu.Unlock()
+
+	deadLetterStores[d.Name()] = d
+}
+
+// DeadLetterStores returns every dbworker store registered in this process, ordered by name.
+func DeadLetterStores() []DeadLetterStore {
+	deadLetterStoresMu.RLock()
+	defer deadLetterStoresMu.RUnlock()
+
+	stores := make([]DeadLetterStore, 0, len(deadLetterStores))
+	for _, s := range deadLetterStores {
+		stores = append(stores, s)
+	}
+	sort.Slice(stores, func(i, j int) bool { return stores[i].Name() < stores[j].Name() })
+	return stores
+}
+
+// DeadLetterStoreByName returns the dbworker store registered in this process with the
+// given name, if any.
+func DeadLetterStoreByName(name string) (DeadLetterStore, bool) {
+	deadLetterStoresMu.RLock()
+	defer deadLetterStoresMu.RUnlock()
+
+	s, ok := deadLetterStores[name]
+	return s, ok
+}
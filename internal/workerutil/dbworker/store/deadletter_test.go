@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestStoreFailedRecordGroups(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, finished_at, failure_message)
+		VALUES
+			(1, 'failed',  NOW() - '1 minute'::interval, 'repo 42: rate limited'),
+			(2, 'failed',  NOW() - '2 minute'::interval, 'repo 43: rate limited'),
+			(3, 'failed',  NOW() - '3 minute'::interval, 'commit deadbeef1 not found'),
+			(4, 'errored', NOW() - '4 minute'::interval, 'repo 44: rate limited'),
+			(5, 'failed',  NOW() - '5 minute'::interval, 'repo 45: rate limited')
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	groups, err := testStore(db, defaultTestStoreOptions(nil, testScanRecord)).FailedRecordGroups(context.Background(), DeadLetterOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error fetching failed record groups: %s", err)
+	}
+
+	type summary struct {
+		FailureMessage        string
+		Count                 int
+		ExampleFailureMessage string
+	}
+	var summaries []summary
+	for _, g := range groups {
+		summaries = append(summaries, summary{g.FailureMessage, g.Count, g.ExampleFailureMessage})
+	}
+	expected := []summary{
+		{"repo N: rate limited", 3, "repo 42: rate limited"},
+		{"commit <hex> not found", 1, "commit deadbeef1 not found"},
+	}
+	if diff := cmp.Diff(expected, summaries); diff != "" {
+		t.Errorf("unexpected groups (-want +got):\n%s", diff)
+	}
+}
+
+func TestStoreFailedRecords(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, finished_at, failure_message, num_failures)
+		VALUES
+			(1, 'failed',    NOW() - '3 minute'::interval, 'repo 42: rate limited', 3),
+			(2, 'failed',    NOW() - '1 minute'::interval, 'repo 43: rate limited', 3),
+			(3, 'failed',    NOW() - '2 minute'::interval, 'boom', 1),
+			(4, 'completed', NOW() - '2 minute'::interval, NULL, 0)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	records, err := testStore(db, defaultTestStoreOptions(nil, testScanRecord)).FailedRecords(context.Background(), DeadLetterOptions{
+		FailureMessage: "repo N: rate limited",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error fetching failed records: %s", err)
+	}
+
+	var ids []int
+	for _, r := range records {
+		ids = append(ids, r.ID)
+		if r.NumFailures != 3 {
+			t.Errorf("unexpected num failures for record %d. want=%d have=%d", r.ID, 3, r.NumFailures)
+		}
+	}
+	if diff := cmp.Diff([]int{2, 1}, ids); diff != "" {
+		t.Errorf("unexpected record ids (-want +got):\n%s", diff)
+	}
+}
+
+func TestStoreRequeueFailed(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, finished_at, failure_message, num_failures, num_resets)
+		VALUES
+			(1, 'failed',    NOW(), 'repo 42: rate limited', 3, 1),
+			(2, 'failed',    NOW(), 'repo 43: rate limited', 3, 0),
+			(3, 'failed',    NOW(), 'boom', 1, 0),
+			(4, 'completed', NOW(), NULL, 0, 0)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	count, err := testStore(db, defaultTestStoreOptions(nil, testScanRecord)).RequeueFailed(context.Background(), DeadLetterOptions{
+		FailureMessage: "repo N: rate limited",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error requeueing failed records: %s", err)
+	}
+	if count != 2 {
+		t.Errorf("unexpected count. want=%d have=%d", 2, count)
+	}
+
+	rows, err := db.QueryContext(context.Background(), `SELECT id, state, failure_message IS NULL, num_failures, num_resets FROM workerutil_test ORDER BY id`)
+	if err != nil {
+		t.Fatalf("unexpected error querying records: %s", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	type row struct {
+		ID                 int
+		State              string
+		FailureMessageNull bool
+		NumFailures        int
+		NumResets          int
+	}
+	var have []row
+	for rows.Next() {
+		var r row
+		if err := rows.Scan(&r.ID, &r.State, &r.FailureMessageNull, &r.NumFailures, &r.NumResets); err != nil {
+			t.Fatalf("unexpected error scanning record: %s", err)
+		}
+		have = append(have, r)
+	}
+	expected := []row{
+		{1, "queued", true, 0, 0},
+		{2, "queued", true, 0, 0},
+		{3, "failed", false, 1, 0},
+		{4, "completed", true, 0, 0},
+	}
+	if diff := cmp.Diff(expected, have); diff != "" {
+		t.Errorf("unexpected records (-want +got):\n%s", diff)
+	}
+}
+
+func TestStorePurgeFailed(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, finished_at, failure_message)
+		VALUES
+			(1, 'failed',    NOW(), 'boom'),
+			(2, 'failed',    NOW(), 'boom'),
+			(3, 'failed',    NOW(), 'boom'),
+			(4, 'completed', NOW(), NULL)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	if _, err := testStore(db, options).PurgeFailed(context.Background(), DeadLetterOptions{IDs: []int{1}}); !errors.Is(err, ErrPurgeNotSupported) {
+		t.Fatalf("unexpected error purging without PurgeRecords. want=%q have=%q", ErrPurgeNotSupported, err)
+	}
+
+	var purgedIDs []int
+	options.PurgeRecords = func(ctx context.Context, tx *basestore.Store, ids []int) error {
+		purgedIDs = append(purgedIDs, ids...)
+		return tx.Exec(ctx, sqlf.Sprintf("DELETE FROM workerutil_test WHERE id = ANY(%s)", pq.Array(ids)))
+	}
+
+	count, err := testStore(db, options).PurgeFailed(context.Background(), DeadLetterOptions{
+		IDs: []int{1, 3, 4},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error purging failed records: %s", err)
+	}
+	if count != 2 {
+		t.Errorf("unexpected count. want=%d have=%d", 2, count)
+	}
+	if diff := cmp.Diff([]int{1, 3}, purgedIDs); diff != "" {
+		t.Errorf("unexpected purged records (-want +got):\n%s", diff)
+	}
+
+	ids, err := basestore.ScanInts(db.QueryContext(context.Background(), `SELECT id FROM workerutil_test ORDER BY id`))
+	if err != nil {
+		t.Fatalf("unexpected error querying records: %s", err)
+	}
+	if diff := cmp.Diff([]int{2, 4}, ids); diff != "" {
+		t.Errorf("unexpected remaining records (-want +got):\n%s", diff)
+	}
+}
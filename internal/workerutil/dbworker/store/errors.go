@@ -10,3 +10,7 @@ var ErrDequeueRace = errors.New("dequeue race")
 
 // ErrNoRecord occurs when a record cannot be selected after it has been locked.
 var ErrNoRecord = errors.New("locked record not found")
+
+// ErrPurgeNotSupported occurs when purging the failed records of a store that does not
+// define how its records are deleted.
+var ErrPurgeNotSupported = errors.New("store does not support purging records")
@@ -21,6 +21,10 @@ type operations struct {
 	resetStalled            *observation.Operation
 	updateExecutionLogEntry *observation.Operation
 	canceledJobs            *observation.Operation
+	failedRecordGroups      *observation.Operation
+	failedRecords           *observation.Operation
+	requeueFailed           *observation.Operation
+	purgeFailed             *observation.Operation
 }
 
 // as newOperations changes based on the store name passed in, and a dbworker store
@@ -70,5 +74,9 @@ func newOperations(observationCtx *observation.Context, storeName string) *opera
 		resetStalled:            op("ResetStalled"),
 		updateExecutionLogEntry: op("UpdateExecutionLogEntry"),
 		canceledJobs:            op("CanceledJobs"),
+		failedRecordGroups:      op("FailedRecordGroups"),
+		failedRecords:           op("FailedRecords"),
+		requeueFailed:           op("RequeueFailed"),
+		purgeFailed:             op("PurgeFailed"),
 	}
 }
@@ -205,6 +205,27 @@ type Options[T workerutil.Record] struct {
 	// supplied.
 	OrderByExpression *sqlf.Query
 
+	// PriorityExpression is an optional SQL expression used to split candidate records into priority
+	// lanes. Records with a higher priority value are always selected before records with a lower
+	// priority value; records within the same lane are ordered by `OrderByExpression`. This expression
+	// may use the alias provided in `ViewName`, if one was supplied.
+	PriorityExpression *sqlf.Query
+
+	// ConcurrencyKeyExpression is an optional SQL expression that groups records sharing a limited
+	// resource, such as a repository or a code host. When both this expression and MaxConcurrencyPerKey
+	// are set, the store will not dequeue a record while MaxConcurrencyPerKey records with the same key
+	// are being processed. Records with a null key are not limited. This expression may use the alias
+	// provided in `ViewName`, if one was supplied.
+	//
+	// Dequeues of records sharing a key are serialized with a transaction-level advisory lock, so
+	// the limit also holds for concurrent dequeues. The key expression is cast to text to compute
+	// the lock key.
+	ConcurrencyKeyExpression *sqlf.Query
+
+	// MaxConcurrencyPerKey is the maximum number of records sharing a ConcurrencyKeyExpression value
+	// that may be processed at once. Setting this value to zero disables the limit.
+	MaxConcurrencyPerKey int
+
 	// ColumnExpressions are the target columns provided to the query when selecting a job record. These
 	// expressions may use the alias provided in `ViewName`, if one was supplied.
 	ColumnExpressions []*sqlf.Query
@@ -234,10 +255,21 @@ type Options[T workerutil.Record] struct {
 	//   - the finished_at timestamp was more than RetryAfter ago
 	RetryAfter time.Duration
 
+	// MaxRetryAfter enables exponential backoff of retries. If MaxRetryAfter is greater than RetryAfter,
+	// the delay before an errored record is retried starts at RetryAfter and doubles with each failed
+	// attempt, up to MaxRetryAfter. Otherwise, errored records are retried RetryAfter after each failure.
+	MaxRetryAfter time.Duration
+
 	// MaxNumRetries is the maximum number of times a record can be retried after an explicit failure.
 	// Setting this value to zero will disable retries entirely.
 	MaxNumRetries int
 
+	// PurgeRecords deletes the failed records with the given identifiers, along with any data
+	// that depends on them, using the given transaction. Records of stores that do not supply
+	// this function cannot be purged by their dead letter store, as only the owner of a table
+	// knows whether its rows can be deleted safely.
+	PurgeRecords func(ctx context.Context, tx *basestore.Store, ids []int) error
+
 	// clock is used to mock out the wall clock used for heartbeat updates.
 	clock glock.Clock
 }
@@ -386,6 +418,7 @@ func (s *store[T]) MaxDurationInQueue(ctx context.Context) (_ time.Duration, err
 
 	now := s.now()
 	retryAfter := int(s.options.RetryAfter / time.Second)
+	retryDelay := s.retryDelayExpression()
 
 	ageInSeconds, ok, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(
 		maxDurationInQueueQuery,
@@ -393,11 +426,11 @@ func (s *store[T]) MaxDurationInQueue(ctx context.Context) (_ time.Duration, err
 		quote(s.options.TableName),
 		now,
 		// oldest_retryable
-		retryAfter,
+		retryDelay,
 		quote(s.options.TableName),
 		retryAfter,
 		now,
-		retryAfter,
+		retryDelay,
 	)))
 	if err != nil {
 		return 0, err
@@ -423,12 +456,12 @@ oldest_queued AS (
 oldest_retryable AS (
 	SELECT
 		-- Select when the record was most recently dequeueable
-		{finished_at} + (%s * '1 second'::interval) AS last_queued_at
+		{finished_at} + %s AS last_queued_at
 	FROM %s
 	WHERE
 		%s > 0 AND
 		{state} = 'errored' AND
-		%s - {finished_at} > (%s * '1 second'::interval)
+		%s - {finished_at} > %s
 ),
 oldest_record AS (
 	(
@@ -472,6 +505,8 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 
 	now := s.now()
 	retryAfter := int(s.options.RetryAfter / time.Second)
+	orderBy := s.orderByExpression()
+	saturatedKeys, concurrencyCondition := s.concurrencyLimitExpressions()
 
 	var (
 		processingExpr     = sqlf.Sprintf("%s", "processing")
@@ -492,23 +527,32 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 		s.columnReplacer.Replace("{worker_hostname}"):   workerHostnameExpr,
 	}
 
-	records, err := s.options.Scan(s.Query(ctx, s.formatQuery(
+	query := s.formatQuery(
 		dequeueQuery,
-		s.options.OrderByExpression,
+		saturatedKeys,
+		orderBy,
 		quote(s.options.ViewName),
 		now,
 		retryAfter,
 		now,
-		retryAfter,
+		s.retryDelayExpression(),
+		concurrencyCondition,
 		makeConditionSuffix(conditions),
-		s.options.OrderByExpression,
+		orderBy,
 		quote(s.options.TableName),
 		quote(s.options.TableName),
 		quote(s.options.TableName),
 		sqlf.Join(s.makeDequeueUpdateStatements(updatedColumns), ", "),
 		sqlf.Join(s.makeDequeueSelectExpressions(updatedColumns), ", "),
 		quote(s.options.ViewName),
-	)))
+	)
+
+	var records []T
+	if s.concurrencyLimited() {
+		records, err = s.dequeueWithinConcurrencyLimit(ctx, query)
+	} else {
+		records, err = s.options.Scan(s.Query(ctx, query))
+	}
 	if err != nil {
 		return ret, false, err
 	}
@@ -524,7 +568,8 @@ func (s *store[T]) Dequeue(ctx context.Context, workerHostname string, condition
 }
 
 const dequeueQuery = `
-WITH potential_candidates AS (
+WITH %s
+potential_candidates AS (
 	SELECT
 		{id} AS candidate_id,
 		ROW_NUMBER() OVER (ORDER BY %s) AS order
@@ -537,10 +582,11 @@ WITH potential_candidates AS (
 			) OR (
 				%s > 0 AND
 				{state} = 'errored' AND
-				%s - {finished_at} > (%s * '1 second'::interval)
+				%s - {finished_at} > %s
 			)
 		)
 		%s
+		%s
 	ORDER BY %s
 	LIMIT 50
 ),
@@ -571,6 +617,148 @@ WHERE
 	{id} IN (SELECT {id} FROM candidate)
 `
 
+// orderByExpression returns the expression used to order candidate records in the dequeue query.
+// Records in higher priority lanes are ordered before all records in lower priority lanes.
+func (s *store[T]) orderByExpression() *sqlf.Query {
+	if s.options.PriorityExpression == nil {
+		return s.options.OrderByExpression
+	}
+
+	return sqlf.Sprintf("%s DESC, %s", s.options.PriorityExpression, s.options.OrderByExpression)
+}
+
+// concurrencyLimited returns true if a per-key concurrency limit is configured.
+func (s *store[T]) concurrencyLimited() bool {
+	return s.options.ConcurrencyKeyExpression != nil && s.options.MaxConcurrencyPerKey > 0
+}
+
+// errConcurrencyLimitExceeded rolls back a dequeue that lost a race with concurrent dequeues
+// of records sharing its concurrency key.
+var errConcurrencyLimitExceeded = errors.New("concurrency limit exceeded")
+
+// dequeueWithinConcurrencyLimit runs the given dequeue query in a transaction. Once the record
+// is marked as processing, the transaction takes an advisory lock on the record's concurrency
+// key and counts the processing records with that key. The lock is taken in its own statement
+// so that the count sees every record dequeued by transactions that held the lock before us.
+// If the limit is exceeded the transaction is rolled back and no record is returned.
+func (s *store[T]) dequeueWithinConcurrencyLimit(ctx context.Context, query *sqlf.Query) (records []T, err error) {
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		// Done appends the error of the rollback, so the sentinel is wrapped.
+		if err = tx.Done(err); errors.Is(err, errConcurrencyLimitExceeded) {
+			records, err = nil, nil
+		}
+	}()
+
+	records, err = s.options.Scan(tx.Query(ctx, query))
+	if err != nil || len(records) != 1 {
+		return records, err
+	}
+	id := records[0].RecordID()
+
+	if err := tx.Exec(ctx, s.formatQuery(
+		concurrencyKeyLockQuery,
+		s.options.TableName,
+		s.options.ConcurrencyKeyExpression,
+		quote(s.options.ViewName),
+		id,
+	)); err != nil {
+		return nil, err
+	}
+
+	count, _, err := basestore.ScanFirstInt(tx.Query(ctx, s.formatQuery(
+		concurrencyKeyCountQuery,
+		quote(s.options.ViewName),
+		s.options.ConcurrencyKeyExpression,
+		s.options.ConcurrencyKeyExpression,
+		quote(s.options.ViewName),
+		id,
+	)))
+	if err != nil {
+		return nil, err
+	}
+	if count > s.options.MaxConcurrencyPerKey {
+		return nil, errConcurrencyLimitExceeded
+	}
+
+	return records, nil
+}
+
+const concurrencyKeyLockQuery = `
+SELECT pg_advisory_xact_lock(hashtext(%s), hashtext((%s)::text))
+FROM %s
+WHERE {id} = %s
+`
+
+const concurrencyKeyCountQuery = `
+SELECT COUNT(*)
+FROM %s
+WHERE
+	{state} = 'processing' AND
+	%s = (SELECT %s FROM %s WHERE {id} = %s)
+`
+
+// concurrencyLimitExpressions returns a common table expression selecting the concurrency keys
+// that have reached MaxConcurrencyPerKey processing records, and a condition excluding candidate
+// records with one of those keys. Both expressions are empty if no concurrency limit is configured.
+func (s *store[T]) concurrencyLimitExpressions() (saturatedKeys, condition *sqlf.Query) {
+	if !s.concurrencyLimited() {
+		return sqlf.Sprintf(""), sqlf.Sprintf("")
+	}
+
+	saturatedKeys = s.formatQuery(
+		saturatedKeysQuery,
+		s.options.ConcurrencyKeyExpression,
+		quote(s.options.ViewName),
+		s.options.ConcurrencyKeyExpression,
+		s.options.MaxConcurrencyPerKey,
+	)
+	condition = sqlf.Sprintf(
+		"AND NOT EXISTS (SELECT 1 FROM saturated_keys sk WHERE sk.key = %s)",
+		s.options.ConcurrencyKeyExpression,
+	)
+	return saturatedKeys, condition
+}
+
+const saturatedKeysQuery = `
+saturated_keys AS (
+	SELECT %s AS key
+	FROM %s
+	WHERE
+		{state} = 'processing' AND
+		%s IS NOT NULL
+	GROUP BY 1
+	HAVING COUNT(*) >= %s
+),
+`
+
+// maxRetryBackoffExponent bounds the exponent used to compute retry delays so that the
+// computation cannot overflow for records that have failed many times.
+const maxRetryBackoffExponent = 30
+
+// retryDelayExpression returns an interval expression for the amount of time an errored
+// record must wait before it is retried. See the MaxRetryAfter option.
+func (s *store[T]) retryDelayExpression() *sqlf.Query {
+	retryAfter := int(s.options.RetryAfter / time.Second)
+	if s.options.MaxRetryAfter <= s.options.RetryAfter {
+		return sqlf.Sprintf("(%s * '1 second'::interval)", retryAfter)
+	}
+
+	return s.formatQuery(
+		retryBackoffQuery,
+		retryAfter,
+		maxRetryBackoffExponent,
+		int(s.options.MaxRetryAfter/time.Second),
+	)
+}
+
+const retryBackoffQuery = `
+(LEAST(%s * POWER(2, GREATEST(LEAST({num_failures} - 1, %s), 0)), %s) * '1 second'::interval)
+`
+
 // makeDequeueSelectExpressions constructs the ordered set of SQL expressions that are returned
 // from the dequeue query. This method returns a copy of the configured column expressions slice
 // where expressions referencing one of the column updated by dequeue are replaced by the updated
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -422,6 +423,196 @@ func TestStoreDequeueRetryAfterDisabled(t *testing.T) {
 	}
 }
 
+func TestStoreDequeueRetryBackoff(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, finished_at, failure_message, num_failures, created_at)
+		VALUES
+			(1, 'errored', NOW() -  '6 minute'::interval, 'error', 1, NOW() - '2 minutes'::interval),
+			(2, 'errored', NOW() - '15 minute'::interval, 'error', 3, NOW() - '3 minutes'::interval),
+			(3, 'errored', NOW() - '25 minute'::interval, 'error', 3, NOW() - '4 minutes'::interval),
+			(4, 'errored', NOW() - '45 minute'::interval, 'error', 9, NOW() - '5 minutes'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecordRetry)
+	options.MaxNumRetries = 10
+	options.RetryAfter = 5 * time.Minute
+	options.MaxRetryAfter = 40 * time.Minute
+	options.ColumnExpressions = []*sqlf.Query{
+		sqlf.Sprintf("workerutil_test.id"),
+		sqlf.Sprintf("workerutil_test.state"),
+		sqlf.Sprintf("workerutil_test.num_resets"),
+	}
+	store := testStore(db, options)
+
+	// Record 4 has waited longer than the maximum delay
+	record1, ok, err := store.Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordRetryResult(t, 4, record1, ok, err)
+
+	// Record 3 has waited longer than 4x the initial delay
+	record2, ok, err := store.Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordRetryResult(t, 3, record2, ok, err)
+
+	// Record 1 has waited longer than the initial delay
+	record3, ok, err := store.Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordRetryResult(t, 1, record3, ok, err)
+
+	// Record 2 is still backing off
+	if _, ok, _ := store.Dequeue(context.Background(), "test", nil); ok {
+		t.Fatalf("did not expect a fourth dequeueable record")
+	}
+}
+
+func TestStoreDequeuePriority(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at)
+		VALUES
+			(1, 'queued', NOW() - '5 minute'::interval),
+			(2, 'queued', NOW() - '4 minute'::interval),
+			(3, 'queued', NOW() - '3 minute'::interval),
+			(4, 'queued', NOW() - '2 minute'::interval),
+			(5, 'queued', NOW() - '1 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.PriorityExpression = sqlf.Sprintf("CASE WHEN workerutil_test.id IN (1, 3, 5) THEN 1 ELSE 0 END")
+	store := testStore(db, options)
+
+	// Odd records are in the higher priority lane
+	for _, expectedID := range []int{1, 3, 5, 2, 4} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+}
+
+func TestStoreDequeueConcurrencyLimit(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at)
+		VALUES
+			(11, 'processing', NOW() - '6 minute'::interval),
+			(12, 'queued',     NOW() - '5 minute'::interval),
+			(13, 'queued',     NOW() - '4 minute'::interval),
+			(21, 'queued',     NOW() - '3 minute'::interval),
+			(22, 'queued',     NOW() - '2 minute'::interval),
+			(23, 'queued',     NOW() - '1 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.ConcurrencyKeyExpression = sqlf.Sprintf("workerutil_test.id / 10")
+	options.MaxConcurrencyPerKey = 2
+	store := testStore(db, options)
+
+	// Key 1 has one processing record and may take one more; key 2 may take two
+	for _, expectedID := range []int{12, 21, 22} {
+		record, ok, err := store.Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+
+	// Both keys are saturated
+	if _, ok, _ := store.Dequeue(context.Background(), "test", nil); ok {
+		t.Fatalf("did not expect a fourth dequeueable record")
+	}
+
+	// Completing a record frees a slot for its key
+	if _, err := store.MarkComplete(context.Background(), 11, MarkFinalOptions{}); err != nil {
+		t.Fatalf("unexpected error marking record as complete: %s", err)
+	}
+	record, ok, err := store.Dequeue(context.Background(), "test", nil)
+	assertDequeueRecordResult(t, 13, record, ok, err)
+}
+
+func TestStoreDequeueConcurrencyLimitRace(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, created_at)
+		VALUES
+			(11, 'queued', NOW() - '2 minute'::interval),
+			(12, 'queued', NOW() - '1 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	options := defaultTestStoreOptions(nil, testScanRecord)
+	options.ConcurrencyKeyExpression = sqlf.Sprintf("workerutil_test.id / 10")
+	options.MaxConcurrencyPerKey = 1
+	store := testStore(db, options)
+
+	// Hold the lock of the concurrency key, so that both dequeues select a record
+	// before either of them counts the processing records of the key.
+	tx, err := db.BeginTx(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error starting transaction: %s", err)
+	}
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock(hashtext('workerutil_test'), hashtext('1'))`); err != nil {
+		t.Fatalf("unexpected error taking lock: %s", err)
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		dequeued []bool
+		errs     []error
+	)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, ok, err := store.Dequeue(context.Background(), "test", nil)
+			mu.Lock()
+			defer mu.Unlock()
+			dequeued = append(dequeued, ok)
+			errs = append(errs, err)
+		}()
+	}
+
+	require.Eventually(t, func() bool {
+		var waiting int
+		if err := db.QueryRow(`
+			SELECT COUNT(*) FROM pg_locks
+			WHERE locktype = 'advisory' AND NOT granted AND database = (SELECT oid FROM pg_database WHERE datname = current_database())
+		`).Scan(&waiting); err != nil {
+			t.Fatalf("unexpected error counting locks: %s", err)
+		}
+		return waiting == 2
+	}, 10*time.Second, 10*time.Millisecond)
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("unexpected error releasing lock: %s", err)
+	}
+	wg.Wait()
+
+	// The dequeue that loses the race dequeues nothing rather than failing.
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	sort.Slice(dequeued, func(i, j int) bool { return !dequeued[i] && dequeued[j] })
+	if diff := cmp.Diff([]bool{false, true}, dequeued); diff != "" {
+		t.Errorf("unexpected dequeue results (-want +got):\n%s", diff)
+	}
+
+	var processing int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM workerutil_test WHERE state = 'processing'`).Scan(&processing); err != nil {
+		t.Fatalf("unexpected error counting records: %s", err)
+	}
+	if processing != 1 {
+		t.Errorf("unexpected number of processing records. want=%d have=%d", 1, processing)
+	}
+}
+
 func TestStoreRequeue(t *testing.T) {
 	db := setupStoreTest(t)
 
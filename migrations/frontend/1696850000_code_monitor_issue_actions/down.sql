@@ -0,0 +1,12 @@
+DELETE FROM cm_action_jobs WHERE issue IS NOT NULL;
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END
+) = 1);
+
+ALTER TABLE cm_action_jobs DROP COLUMN IF EXISTS issue;
+
+DROP TABLE IF EXISTS cm_issues;
@@ -0,0 +1,35 @@
+CREATE TABLE IF NOT EXISTS cm_issues (
+    id bigserial PRIMARY KEY,
+    monitor bigint NOT NULL REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    enabled boolean NOT NULL,
+    include_results boolean NOT NULL DEFAULT false,
+    external_service_id bigint NOT NULL REFERENCES external_services(id) ON DELETE CASCADE,
+    repository text NOT NULL,
+    external_issue_number integer,
+    external_issue_url text,
+    created_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    changed_by integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    changed_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE cm_issues IS 'GitHub and GitLab issue actions configured on code monitors';
+COMMENT ON COLUMN cm_issues.monitor IS 'The code monitor that the action is defined on';
+COMMENT ON COLUMN cm_issues.external_service_id IS 'The code host connection whose credentials are used to open and comment on the issue';
+COMMENT ON COLUMN cm_issues.repository IS 'The repository to open the issue in, as owner/name on GitHub or the project path on GitLab';
+COMMENT ON COLUMN cm_issues.external_issue_number IS 'The number of the issue opened by this action. Subsequent triggers comment on this issue instead of opening a new one';
+COMMENT ON COLUMN cm_issues.external_issue_url IS 'The URL of the issue opened by this action';
+
+CREATE INDEX IF NOT EXISTS cm_issues_monitor ON cm_issues USING btree (monitor);
+
+ALTER TABLE cm_action_jobs ADD COLUMN IF NOT EXISTS issue bigint REFERENCES cm_issues(id) ON DELETE CASCADE;
+
+COMMENT ON COLUMN cm_action_jobs.issue IS 'The ID of the cm_issues action to execute if this is an issue job. Mutually exclusive with email, webhook and slack_webhook';
+
+ALTER TABLE cm_action_jobs DROP CONSTRAINT IF EXISTS cm_action_jobs_only_one_action_type;
+ALTER TABLE cm_action_jobs ADD CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((
+    CASE WHEN email IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN slack_webhook IS NULL THEN 0 ELSE 1 END +
+    CASE WHEN issue IS NULL THEN 0 ELSE 1 END
+) = 1);
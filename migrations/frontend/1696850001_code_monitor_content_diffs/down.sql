@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS cm_result_fingerprints;
+
+ALTER TABLE cm_trigger_jobs DROP COLUMN IF EXISTS result_diff;
+
+ALTER TABLE cm_queries DROP COLUMN IF EXISTS mode;
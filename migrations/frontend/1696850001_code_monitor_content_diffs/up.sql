@@ -0,0 +1,16 @@
+ALTER TABLE cm_queries ADD COLUMN IF NOT EXISTS mode text NOT NULL DEFAULT 'commits';
+
+COMMENT ON COLUMN cm_queries.mode IS 'How the query is evaluated: commits notifies on new commits matching a diff or commit search, content notifies when the result set of a content search changes between runs';
+
+ALTER TABLE cm_trigger_jobs ADD COLUMN IF NOT EXISTS result_diff jsonb;
+
+COMMENT ON COLUMN cm_trigger_jobs.result_diff IS 'The results that appeared and disappeared since the previous run of a content mode query. NULL if the result set did not change';
+
+CREATE TABLE IF NOT EXISTS cm_result_fingerprints (
+    monitor_id bigint PRIMARY KEY REFERENCES cm_monitors(id) ON DELETE CASCADE,
+    fingerprints jsonb NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE cm_result_fingerprints IS 'The fingerprints of the results of the last run of a content mode code monitor query';
+COMMENT ON COLUMN cm_result_fingerprints.fingerprints IS 'The fingerprint, repository, path and match count of every result, used to compute which results changed on the next run';
@@ -0,0 +1,5 @@
+ALTER TABLE outbound_webhook_logs DROP COLUMN IF EXISTS redelivery;
+
+ALTER TABLE outbound_webhook_jobs DROP COLUMN IF EXISTS redelivery_of_job_id;
+
+ALTER TABLE outbound_webhook_jobs DROP COLUMN IF EXISTS outbound_webhook_id;
@@ -0,0 +1,11 @@
+ALTER TABLE outbound_webhook_jobs ADD COLUMN IF NOT EXISTS outbound_webhook_id bigint REFERENCES outbound_webhooks(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+COMMENT ON COLUMN outbound_webhook_jobs.outbound_webhook_id IS 'The only outbound webhook the payload is sent to. NULL if the payload is sent to every webhook subscribed to the event type';
+
+ALTER TABLE outbound_webhook_jobs ADD COLUMN IF NOT EXISTS redelivery_of_job_id bigint;
+
+COMMENT ON COLUMN outbound_webhook_jobs.redelivery_of_job_id IS 'The job whose payload this job redelivers. Not a foreign key, since the original job may be deleted by the janitor before the redelivery is sent';
+
+ALTER TABLE outbound_webhook_logs ADD COLUMN IF NOT EXISTS redelivery boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN outbound_webhook_logs.redelivery IS 'Whether the request was a redelivery of a payload that was previously sent';
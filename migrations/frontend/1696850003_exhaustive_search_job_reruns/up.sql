@@ -0,0 +1,7 @@
+ALTER TABLE exhaustive_search_jobs ADD COLUMN IF NOT EXISTS previous_job_id integer REFERENCES exhaustive_search_jobs(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN exhaustive_search_jobs.previous_job_id IS 'The search job this job is a re-run of. Repository revisions whose resolved commit did not change since the previous job reuse its results';
+
+ALTER TABLE exhaustive_search_repo_revision_jobs ADD COLUMN IF NOT EXISTS commit text;
+
+COMMENT ON COLUMN exhaustive_search_repo_revision_jobs.commit IS 'The commit the revision resolved to when it was searched. NULL if the revision has not been searched yet';
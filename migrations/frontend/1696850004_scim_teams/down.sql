@@ -0,0 +1,3 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS scim_external_id;
+
+ALTER TABLE teams DROP COLUMN IF EXISTS scim_controlled;
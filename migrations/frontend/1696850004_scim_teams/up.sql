@@ -0,0 +1,7 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS scim_controlled boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN teams.scim_controlled IS 'Whether the team is provisioned from a SCIM group. Members and nested teams of SCIM-controlled teams are managed by the identity provider';
+
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS scim_external_id text;
+
+COMMENT ON COLUMN teams.scim_external_id IS 'The identifier of the SCIM group in the identity provider, if provided';
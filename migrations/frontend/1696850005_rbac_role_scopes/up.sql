@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS role_scopes (
+    id SERIAL PRIMARY KEY,
+    role_id integer NOT NULL REFERENCES roles(id) ON DELETE CASCADE DEFERRABLE,
+    org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+    repo_id integer REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    search_context_id bigint REFERENCES search_contexts(id) ON DELETE CASCADE DEFERRABLE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT role_scopes_has_one_target CHECK (num_nonnulls(org_id, repo_id, search_context_id) = 1)
+);
+
+COMMENT ON TABLE role_scopes IS 'Restricts the permissions of a role to an organization, a repository or the repositories of a search context. Roles without scopes apply globally.';
+
+CREATE INDEX IF NOT EXISTS role_scopes_role_id ON role_scopes(role_id);
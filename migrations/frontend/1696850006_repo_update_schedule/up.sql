@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS repo_update_schedule (
+    repo_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    interval_seconds integer NOT NULL,
+    due_at timestamp with time zone NOT NULL,
+    last_fetched_at timestamp with time zone,
+    claimed_by text,
+    lease_expires_at timestamp with time zone
+);
+
+COMMENT ON TABLE repo_update_schedule IS 'The update schedule of repositories managed by repo-updater, shared by all of its replicas.';
+COMMENT ON COLUMN repo_update_schedule.interval_seconds IS 'The fetch interval learned from the commit history of the repository.';
+COMMENT ON COLUMN repo_update_schedule.due_at IS 'The time at which the repository is next due for a scheduled fetch.';
+COMMENT ON COLUMN repo_update_schedule.claimed_by IS 'The repo-updater replica currently fetching the repository.';
+COMMENT ON COLUMN repo_update_schedule.lease_expires_at IS 'The time at which the claim of claimed_by expires and another replica may fetch the repository.';
+
+CREATE INDEX IF NOT EXISTS repo_update_schedule_due_at ON repo_update_schedule(due_at);
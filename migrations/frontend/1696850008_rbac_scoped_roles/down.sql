@@ -0,0 +1,3 @@
+ALTER TABLE roles DROP COLUMN IF EXISTS scoped;
+
+COMMENT ON TABLE role_scopes IS 'Restricts the permissions of a role to an organization, a repository or the repositories of a search context. Roles without scopes apply globally.';
@@ -0,0 +1,7 @@
+ALTER TABLE roles ADD COLUMN IF NOT EXISTS scoped boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN roles.scoped IS 'Whether the permissions of the role are restricted to its role_scopes. A scoped role without scopes grants no permissions.';
+
+UPDATE roles SET scoped = true WHERE EXISTS (SELECT 1 FROM role_scopes WHERE role_scopes.role_id = roles.id);
+
+COMMENT ON TABLE role_scopes IS 'Restricts the permissions of a scoped role to an organization, a repository or the repositories of a search context.';
@@ -0,0 +1 @@
+ALTER TABLE repo_update_schedule DROP COLUMN IF EXISTS force_pending;
@@ -0,0 +1,3 @@
+ALTER TABLE repo_update_schedule ADD COLUMN IF NOT EXISTS force_pending boolean NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN repo_update_schedule.force_pending IS 'Whether a forced fetch was requested while claimed_by held the lease. The holder fetches the repository again once it is done.';
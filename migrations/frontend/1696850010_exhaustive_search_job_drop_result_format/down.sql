@@ -0,0 +1,3 @@
+ALTER TABLE exhaustive_search_jobs ADD COLUMN IF NOT EXISTS result_format text NOT NULL DEFAULT 'csv';
+
+COMMENT ON COLUMN exhaustive_search_jobs.result_format IS 'The format the results of the job are stored and downloaded in: csv or jsonl';
@@ -1440,7 +1440,8 @@ CREATE TABLE cm_action_jobs (
     slack_webhook bigint,
     queued_at timestamp with time zone DEFAULT now(),
     cancel boolean DEFAULT false NOT NULL,
-    CONSTRAINT cm_action_jobs_only_one_action_type CHECK ((((
+    issue bigint,
+    CONSTRAINT cm_action_jobs_only_one_action_type CHECK (((((
 CASE
     WHEN (email IS NULL) THEN 0
     ELSE 1
@@ -1452,6 +1453,10 @@ END) +
 CASE
     WHEN (slack_webhook IS NULL) THEN 0
     ELSE 1
+END) +
+CASE
+    WHEN (issue IS NULL) THEN 0
+    ELSE 1
 END) = 1))
 );
 
@@ -1461,6 +1466,8 @@ COMMENT ON COLUMN cm_action_jobs.webhook IS 'The ID of the cm_webhooks action to
 
 COMMENT ON COLUMN cm_action_jobs.slack_webhook IS 'The ID of the cm_slack_webhook action to execute if this is a slack webhook job. Mutually exclusive with email and webhook';
 
+COMMENT ON COLUMN cm_action_jobs.issue IS 'The ID of the cm_issues action to execute if this is an issue job. Mutually exclusive with email, webhook and slack_webhook';
+
 COMMENT ON CONSTRAINT cm_action_jobs_only_one_action_type ON cm_action_jobs IS 'Constrains that each queued code monitor action has exactly one action type';
 
 CREATE SEQUENCE cm_action_jobs_id_seq
@@ -1495,6 +1502,42 @@ CREATE SEQUENCE cm_emails_id_seq
 
 ALTER SEQUENCE cm_emails_id_seq OWNED BY cm_emails.id;
 
+CREATE TABLE cm_issues (
+    id bigint NOT NULL,
+    monitor bigint NOT NULL,
+    enabled boolean NOT NULL,
+    include_results boolean DEFAULT false NOT NULL,
+    external_service_id bigint NOT NULL,
+    repository text NOT NULL,
+    external_issue_number integer,
+    external_issue_url text,
+    created_by integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    changed_by integer NOT NULL,
+    changed_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE cm_issues IS 'GitHub and GitLab issue actions configured on code monitors';
+
+COMMENT ON COLUMN cm_issues.monitor IS 'The code monitor that the action is defined on';
+
+COMMENT ON COLUMN cm_issues.external_service_id IS 'The code host connection whose credentials are used to open and comment on the issue';
+
+COMMENT ON COLUMN cm_issues.repository IS 'The repository to open the issue in, as owner/name on GitHub or the project path on GitLab';
+
+COMMENT ON COLUMN cm_issues.external_issue_number IS 'The number of the issue opened by this action. Subsequent triggers comment on this issue instead of opening a new one';
+
+COMMENT ON COLUMN cm_issues.external_issue_url IS 'The URL of the issue opened by this action';
+
+CREATE SEQUENCE cm_issues_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE cm_issues_id_seq OWNED BY cm_issues.id;
+
 CREATE TABLE cm_last_searched (
     monitor_id bigint NOT NULL,
     commit_oids text[] NOT NULL,
@@ -1537,9 +1580,12 @@ CREATE TABLE cm_queries (
     changed_by integer NOT NULL,
     changed_at timestamp with time zone DEFAULT now() NOT NULL,
     next_run timestamp with time zone DEFAULT now(),
-    latest_result timestamp with time zone
+    latest_result timestamp with time zone,
+    mode text DEFAULT 'commits'::text NOT NULL
 );
 
+COMMENT ON COLUMN cm_queries.mode IS 'How the query is evaluated: commits notifies on new commits matching a diff or commit search, content notifies when the result set of a content search changes between runs';
+
 CREATE SEQUENCE cm_queries_id_seq
     START WITH 1
     INCREMENT BY 1
@@ -1565,6 +1611,16 @@ CREATE SEQUENCE cm_recipients_id_seq
 
 ALTER SEQUENCE cm_recipients_id_seq OWNED BY cm_recipients.id;
 
+CREATE TABLE cm_result_fingerprints (
+    monitor_id bigint NOT NULL,
+    fingerprints jsonb NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE cm_result_fingerprints IS 'The fingerprints of the results of the last run of a content mode code monitor query';
+
+COMMENT ON COLUMN cm_result_fingerprints.fingerprints IS 'The fingerprint, repository, path and match count of every result, used to compute which results changed on the next run';
+
 CREATE TABLE cm_slack_webhooks (
     id bigint NOT NULL,
     monitor bigint NOT NULL,
@@ -1610,9 +1666,12 @@ CREATE TABLE cm_trigger_jobs (
     search_results jsonb,
     queued_at timestamp with time zone DEFAULT now(),
     cancel boolean DEFAULT false NOT NULL,
+    result_diff jsonb,
     CONSTRAINT search_results_is_array CHECK ((jsonb_typeof(search_results) = 'array'::text))
 );
 
+COMMENT ON COLUMN cm_trigger_jobs.result_diff IS 'The results that appeared and disappeared since the previous run of a content mode query. NULL if the result set did not change';
+
 CREATE SEQUENCE cm_trigger_jobs_id_seq
     AS integer
     START WITH 1
@@ -2451,9 +2510,12 @@ CREATE TABLE exhaustive_search_jobs (
     cancel boolean DEFAULT false NOT NULL,
     created_at timestamp with time zone DEFAULT now() NOT NULL,
     updated_at timestamp with time zone DEFAULT now() NOT NULL,
-    queued_at timestamp with time zone DEFAULT now()
+    queued_at timestamp with time zone DEFAULT now(),
+    previous_job_id integer
 );
 
+COMMENT ON COLUMN exhaustive_search_jobs.previous_job_id IS 'The search job this job is a re-run of. Repository revisions whose resolved commit did not change since the previous job reuse its results';
+
 CREATE SEQUENCE exhaustive_search_jobs_id_seq
     AS integer
     START WITH 1
@@ -2512,9 +2574,12 @@ CREATE TABLE exhaustive_search_repo_revision_jobs (
     cancel boolean DEFAULT false NOT NULL,
     created_at timestamp with time zone DEFAULT now() NOT NULL,
     updated_at timestamp with time zone DEFAULT now() NOT NULL,
-    queued_at timestamp with time zone DEFAULT now()
+    queued_at timestamp with time zone DEFAULT now(),
+    commit text
 );
 
+COMMENT ON COLUMN exhaustive_search_repo_revision_jobs.commit IS 'The commit the revision resolved to when it was searched. NULL if the revision has not been searched yet';
+
 CREATE SEQUENCE exhaustive_search_repo_revision_jobs_id_seq
     AS integer
     START WITH 1
@@ -3793,9 +3858,15 @@ CREATE TABLE outbound_webhook_jobs (
     last_heartbeat_at timestamp with time zone,
     execution_logs json[],
     worker_hostname text DEFAULT ''::text NOT NULL,
-    cancel boolean DEFAULT false NOT NULL
+    cancel boolean DEFAULT false NOT NULL,
+    outbound_webhook_id bigint,
+    redelivery_of_job_id bigint
 );
 
+COMMENT ON COLUMN outbound_webhook_jobs.outbound_webhook_id IS 'The only outbound webhook the payload is sent to. NULL if the payload is sent to every webhook subscribed to the event type';
+
+COMMENT ON COLUMN outbound_webhook_jobs.redelivery_of_job_id IS 'The job whose payload this job redelivers. Not a foreign key, since the original job may be deleted by the janitor before the redelivery is sent';
+
 CREATE SEQUENCE outbound_webhook_jobs_id_seq
     START WITH 1
     INCREMENT BY 1
@@ -3814,9 +3885,12 @@ CREATE TABLE outbound_webhook_logs (
     encryption_key_id text,
     request bytea NOT NULL,
     response bytea NOT NULL,
-    error bytea NOT NULL
+    error bytea NOT NULL,
+    redelivery boolean DEFAULT false NOT NULL
 );
 
+COMMENT ON COLUMN outbound_webhook_logs.redelivery IS 'Whether the request was a redelivery of a payload that was previously sent';
+
 CREATE SEQUENCE outbound_webhook_logs_id_seq
     START WITH 1
     INCREMENT BY 1
@@ -4453,19 +4527,66 @@ COMMENT ON COLUMN repo_statistics.failed_fetch IS 'Number of repositories that a
 
 COMMENT ON COLUMN repo_statistics.corrupted IS 'Number of repositories that are NOT soft-deleted and not blocked and have corrupted_at set in gitserver_repos table';
 
+CREATE TABLE repo_update_schedule (
+    repo_id integer NOT NULL,
+    interval_seconds integer NOT NULL,
+    due_at timestamp with time zone NOT NULL,
+    last_fetched_at timestamp with time zone,
+    claimed_by text,
+    lease_expires_at timestamp with time zone,
+    force_pending boolean DEFAULT false NOT NULL
+);
+
+COMMENT ON TABLE repo_update_schedule IS 'The update schedule of repositories managed by repo-updater, shared by all of its replicas.';
+
+COMMENT ON COLUMN repo_update_schedule.interval_seconds IS 'The fetch interval learned from the commit history of the repository.';
+
+COMMENT ON COLUMN repo_update_schedule.due_at IS 'The time at which the repository is next due for a scheduled fetch.';
+
+COMMENT ON COLUMN repo_update_schedule.claimed_by IS 'The repo-updater replica currently fetching the repository.';
+
+COMMENT ON COLUMN repo_update_schedule.lease_expires_at IS 'The time at which the claim of claimed_by expires and another replica may fetch the repository.';
+
+COMMENT ON COLUMN repo_update_schedule.force_pending IS 'Whether a forced fetch was requested while claimed_by held the lease. The holder fetches the repository again once it is done.';
+
 CREATE TABLE role_permissions (
     role_id integer NOT NULL,
     permission_id integer NOT NULL,
     created_at timestamp with time zone DEFAULT now() NOT NULL
 );
 
+CREATE TABLE role_scopes (
+    id integer NOT NULL,
+    role_id integer NOT NULL,
+    org_id integer,
+    repo_id integer,
+    search_context_id bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT role_scopes_has_one_target CHECK ((num_nonnulls(org_id, repo_id, search_context_id) = 1))
+);
+
+COMMENT ON TABLE role_scopes IS 'Restricts the permissions of a scoped role to an organization, a repository or the repositories of a search context.';
+
+CREATE SEQUENCE role_scopes_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+ALTER SEQUENCE role_scopes_id_seq OWNED BY role_scopes.id;
+
 CREATE TABLE roles (
     id integer NOT NULL,
     created_at timestamp with time zone DEFAULT now() NOT NULL,
     system boolean DEFAULT false NOT NULL,
-    name citext NOT NULL
+    name citext NOT NULL,
+    scoped boolean DEFAULT false NOT NULL
 );
 
+COMMENT ON COLUMN roles.scoped IS 'Whether the permissions of the role are restricted to its role_scopes. A scoped role without scopes grants no permissions.';
+
 COMMENT ON COLUMN roles.system IS 'This is used to indicate whether a role is read-only or can be modified.';
 
 CREATE SEQUENCE roles_id_seq
@@ -4662,11 +4783,17 @@ CREATE TABLE teams (
     creator_id integer,
     created_at timestamp with time zone DEFAULT now() NOT NULL,
     updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    scim_controlled boolean DEFAULT false NOT NULL,
+    scim_external_id text,
     CONSTRAINT teams_display_name_max_length CHECK ((char_length(display_name) <= 255)),
     CONSTRAINT teams_name_max_length CHECK ((char_length((name)::text) <= 255)),
     CONSTRAINT teams_name_valid_chars CHECK ((name OPERATOR(~) '^[a-zA-Z0-9](?:[a-zA-Z0-9]|[-.](?=[a-zA-Z0-9]))*-?$'::citext))
 );
 
+COMMENT ON COLUMN teams.scim_controlled IS 'Whether the team is provisioned from a SCIM group. Members and nested teams of SCIM-controlled teams are managed by the identity provider';
+
+COMMENT ON COLUMN teams.scim_external_id IS 'The identifier of the SCIM group in the identity provider, if provided';
+
 CREATE SEQUENCE teams_id_seq
     AS integer
     START WITH 1
@@ -5065,6 +5192,8 @@ ALTER TABLE ONLY cm_action_jobs ALTER COLUMN id SET DEFAULT nextval('cm_action_j
 
 ALTER TABLE ONLY cm_emails ALTER COLUMN id SET DEFAULT nextval('cm_emails_id_seq'::regclass);
 
+ALTER TABLE ONLY cm_issues ALTER COLUMN id SET DEFAULT nextval('cm_issues_id_seq'::regclass);
+
 ALTER TABLE ONLY cm_monitors ALTER COLUMN id SET DEFAULT nextval('cm_monitors_id_seq'::regclass);
 
 ALTER TABLE ONLY cm_queries ALTER COLUMN id SET DEFAULT nextval('cm_queries_id_seq'::regclass);
@@ -5239,6 +5368,8 @@ ALTER TABLE ONLY repo_embedding_jobs ALTER COLUMN id SET DEFAULT nextval('repo_e
 
 ALTER TABLE ONLY repo_paths ALTER COLUMN id SET DEFAULT nextval('repo_paths_id_seq'::regclass);
 
+ALTER TABLE ONLY role_scopes ALTER COLUMN id SET DEFAULT nextval('role_scopes_id_seq'::regclass);
+
 ALTER TABLE ONLY roles ALTER COLUMN id SET DEFAULT nextval('roles_id_seq'::regclass);
 
 ALTER TABLE ONLY saved_searches ALTER COLUMN id SET DEFAULT nextval('saved_searches_id_seq'::regclass);
@@ -5360,6 +5491,9 @@ ALTER TABLE ONLY cm_action_jobs
 ALTER TABLE ONLY cm_emails
     ADD CONSTRAINT cm_emails_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY cm_issues
+    ADD CONSTRAINT cm_issues_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY cm_last_searched
     ADD CONSTRAINT cm_last_searched_pkey PRIMARY KEY (monitor_id, repo_id);
 
@@ -5372,6 +5506,9 @@ ALTER TABLE ONLY cm_queries
 ALTER TABLE ONLY cm_recipients
     ADD CONSTRAINT cm_recipients_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY cm_result_fingerprints
+    ADD CONSTRAINT cm_result_fingerprints_pkey PRIMARY KEY (monitor_id);
+
 ALTER TABLE ONLY cm_slack_webhooks
     ADD CONSTRAINT cm_slack_webhooks_pkey PRIMARY KEY (id);
 
@@ -5732,9 +5869,15 @@ ALTER TABLE ONLY repo_permissions
 ALTER TABLE ONLY repo
     ADD CONSTRAINT repo_pkey PRIMARY KEY (id);
 
+ALTER TABLE ONLY repo_update_schedule
+    ADD CONSTRAINT repo_update_schedule_pkey PRIMARY KEY (repo_id);
+
 ALTER TABLE ONLY role_permissions
     ADD CONSTRAINT role_permissions_pkey PRIMARY KEY (permission_id, role_id);
 
+ALTER TABLE ONLY role_scopes
+    ADD CONSTRAINT role_scopes_pkey PRIMARY KEY (id);
+
 ALTER TABLE ONLY roles
     ADD CONSTRAINT roles_pkey PRIMARY KEY (id);
 
@@ -5929,6 +6072,8 @@ CREATE INDEX cm_action_jobs_state_idx ON cm_action_jobs USING btree (state);
 
 CREATE INDEX cm_action_jobs_trigger_event ON cm_action_jobs USING btree (trigger_event);
 
+CREATE INDEX cm_issues_monitor ON cm_issues USING btree (monitor);
+
 CREATE INDEX cm_slack_webhooks_monitor ON cm_slack_webhooks USING btree (monitor);
 
 CREATE INDEX cm_trigger_jobs_finished_at ON cm_trigger_jobs USING btree (finished_at);
@@ -6277,8 +6422,12 @@ CREATE INDEX repo_stars_desc_id_desc_idx ON repo USING btree (stars DESC NULLS L
 
 CREATE INDEX repo_stars_idx ON repo USING btree (stars DESC NULLS LAST);
 
+CREATE INDEX repo_update_schedule_due_at ON repo_update_schedule USING btree (due_at);
+
 CREATE INDEX repo_uri_idx ON repo USING btree (uri);
 
+CREATE INDEX role_scopes_role_id ON role_scopes USING btree (role_id);
+
 CREATE UNIQUE INDEX search_contexts_name_namespace_org_id_unique ON search_contexts USING btree (name, namespace_org_id) WHERE (namespace_org_id IS NOT NULL);
 
 CREATE UNIQUE INDEX search_contexts_name_namespace_user_id_unique ON search_contexts USING btree (name, namespace_user_id) WHERE (namespace_user_id IS NOT NULL);
@@ -6520,6 +6669,9 @@ ALTER TABLE ONLY changesets
 ALTER TABLE ONLY cm_action_jobs
     ADD CONSTRAINT cm_action_jobs_email_fk FOREIGN KEY (email) REFERENCES cm_emails(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY cm_action_jobs
+    ADD CONSTRAINT cm_action_jobs_issue_fkey FOREIGN KEY (issue) REFERENCES cm_issues(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY cm_action_jobs
     ADD CONSTRAINT cm_action_jobs_slack_webhook_fkey FOREIGN KEY (slack_webhook) REFERENCES cm_slack_webhooks(id) ON DELETE CASCADE;
 
@@ -6538,6 +6690,18 @@ ALTER TABLE ONLY cm_emails
 ALTER TABLE ONLY cm_emails
     ADD CONSTRAINT cm_emails_monitor FOREIGN KEY (monitor) REFERENCES cm_monitors(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY cm_issues
+    ADD CONSTRAINT cm_issues_changed_by_fkey FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY cm_issues
+    ADD CONSTRAINT cm_issues_created_by_fkey FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY cm_issues
+    ADD CONSTRAINT cm_issues_external_service_id_fkey FOREIGN KEY (external_service_id) REFERENCES external_services(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY cm_issues
+    ADD CONSTRAINT cm_issues_monitor_fkey FOREIGN KEY (monitor) REFERENCES cm_monitors(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY cm_last_searched
     ADD CONSTRAINT cm_last_searched_monitor_id_fkey FOREIGN KEY (monitor_id) REFERENCES cm_monitors(id) ON DELETE CASCADE;
 
@@ -6565,6 +6729,9 @@ ALTER TABLE ONLY cm_recipients
 ALTER TABLE ONLY cm_recipients
     ADD CONSTRAINT cm_recipients_user_id_fk FOREIGN KEY (namespace_user_id) REFERENCES users(id) ON DELETE CASCADE;
 
+ALTER TABLE ONLY cm_result_fingerprints
+    ADD CONSTRAINT cm_result_fingerprints_monitor_id_fkey FOREIGN KEY (monitor_id) REFERENCES cm_monitors(id) ON DELETE CASCADE;
+
 ALTER TABLE ONLY cm_slack_webhooks
     ADD CONSTRAINT cm_slack_webhooks_changed_by_fkey FOREIGN KEY (changed_by) REFERENCES users(id) ON DELETE CASCADE;
 
@@ -6661,6 +6828,9 @@ ALTER TABLE ONLY executor_secrets
 ALTER TABLE ONLY exhaustive_search_jobs
     ADD CONSTRAINT exhaustive_search_jobs_initiator_id_fkey FOREIGN KEY (initiator_id) REFERENCES users(id) ON UPDATE CASCADE ON DELETE CASCADE DEFERRABLE;
 
+ALTER TABLE ONLY exhaustive_search_jobs
+    ADD CONSTRAINT exhaustive_search_jobs_previous_job_id_fkey FOREIGN KEY (previous_job_id) REFERENCES exhaustive_search_jobs(id) ON DELETE SET NULL;
+
 ALTER TABLE ONLY exhaustive_search_repo_jobs
     ADD CONSTRAINT exhaustive_search_repo_jobs_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE;
 
@@ -6814,6 +6984,9 @@ ALTER TABLE ONLY out_of_band_migrations_errors
 ALTER TABLE ONLY outbound_webhook_event_types
     ADD CONSTRAINT outbound_webhook_event_types_outbound_webhook_id_fkey FOREIGN KEY (outbound_webhook_id) REFERENCES outbound_webhooks(id) ON UPDATE CASCADE ON DELETE CASCADE;
 
+ALTER TABLE ONLY outbound_webhook_jobs
+    ADD CONSTRAINT outbound_webhook_jobs_outbound_webhook_id_fkey FOREIGN KEY (outbound_webhook_id) REFERENCES outbound_webhooks(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
 ALTER TABLE ONLY outbound_webhook_logs
     ADD CONSTRAINT outbound_webhook_logs_job_id_fkey FOREIGN KEY (job_id) REFERENCES outbound_webhook_jobs(id) ON UPDATE CASCADE ON DELETE CASCADE;
 
@@ -6892,12 +7065,27 @@ ALTER TABLE ONLY repo_paths
 ALTER TABLE ONLY repo_paths
     ADD CONSTRAINT repo_paths_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE;
 
+ALTER TABLE ONLY repo_update_schedule
+    ADD CONSTRAINT repo_update_schedule_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE;
+
 ALTER TABLE ONLY role_permissions
     ADD CONSTRAINT role_permissions_permission_id_fkey FOREIGN KEY (permission_id) REFERENCES permissions(id) ON DELETE CASCADE DEFERRABLE;
 
 ALTER TABLE ONLY role_permissions
     ADD CONSTRAINT role_permissions_role_id_fkey FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE DEFERRABLE;
 
+ALTER TABLE ONLY role_scopes
+    ADD CONSTRAINT role_scopes_org_id_fkey FOREIGN KEY (org_id) REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE;
+
+ALTER TABLE ONLY role_scopes
+    ADD CONSTRAINT role_scopes_repo_id_fkey FOREIGN KEY (repo_id) REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE;
+
+ALTER TABLE ONLY role_scopes
+    ADD CONSTRAINT role_scopes_role_id_fkey FOREIGN KEY (role_id) REFERENCES roles(id) ON DELETE CASCADE DEFERRABLE;
+
+ALTER TABLE ONLY role_scopes
+    ADD CONSTRAINT role_scopes_search_context_id_fkey FOREIGN KEY (search_context_id) REFERENCES search_contexts(id) ON DELETE CASCADE DEFERRABLE;
+
 ALTER TABLE ONLY saved_searches
     ADD CONSTRAINT saved_searches_org_id_fkey FOREIGN KEY (org_id) REFERENCES orgs(id);
 
@@ -70,6 +70,18 @@ type AppNotifications struct {
 	VersionMin string `json:"version.min,omitempty"`
 }
 
+// ApproximateSearch description: Configures approximate nearest neighbor search of embedding indexes. When enabled, an HNSW graph is built next to each repository embedding index when the repository is fully indexed, and searched instead of scanning every embedding. Incremental updates discard the graph, so the repository is searched exactly until its next full index.
+type ApproximateSearch struct {
+	// EfConstruction description: The number of candidates considered when inserting an embedding into the graph. Larger values improve recall at the cost of a longer index build.
+	EfConstruction int `json:"efConstruction,omitempty"`
+	// EfSearch description: The number of candidates considered when searching the graph. Larger values improve recall at the cost of search latency.
+	EfSearch int `json:"efSearch,omitempty"`
+	// Enabled description: Whether to build and search HNSW graphs for embedding indexes.
+	Enabled bool `json:"enabled,omitempty"`
+	// M description: The maximum number of edges per embedding in the graph. Larger values improve recall at the cost of a larger index.
+	M int `json:"m,omitempty"`
+}
+
 // AuditLog description: EXPERIMENTAL: Configuration for audit logging (specially formatted log entries for tracking sensitive events)
 type AuditLog struct {
 	// GitserverAccess description: Capture gitserver access logs as part of the audit log.
@@ -582,7 +594,7 @@ type Completions struct {
 	CompletionModelMaxTokens int `json:"completionModelMaxTokens,omitempty"`
 	// Enabled description: DEPRECATED. Use cody.enabled instead to turn Cody on/off.
 	Enabled *bool `json:"enabled,omitempty"`
-	// Endpoint description: The endpoint under which to reach the provider. Currently only used for provider types "sourcegraph", "openai" and "anthropic". The default values are "https://cody-gateway.sourcegraph.com", "https://api.openai.com/v1/chat/completions", and "https://api.anthropic.com/v1/complete" for Sourcegraph, OpenAI, and Anthropic, respectively.
+	// Endpoint description: The endpoint under which to reach the provider. Currently only used for provider types "sourcegraph", "openai" and "anthropic". The default values are "https://cody-gateway.sourcegraph.com", "https://api.openai.com/v1/chat/completions", and "https://api.anthropic.com/v1/complete" for Sourcegraph, OpenAI, and Anthropic, respectively. For provider type "openai-compatible", this is the required base URL of the OpenAI-compatible API of a self-hosted model server, for example "http://localhost:8000/v1".
 	Endpoint string `json:"endpoint,omitempty"`
 	// FastChatModel description: The model used for fast chat completions.
 	FastChatModel string `json:"fastChatModel,omitempty"`
@@ -656,11 +668,13 @@ type EmailTemplates struct {
 type Embeddings struct {
 	// AccessToken description: The access token used to authenticate with the external embedding API service. For provider sourcegraph, this is optional.
 	AccessToken string `json:"accessToken,omitempty"`
+	// ApproximateSearch description: Configures approximate nearest neighbor search of embedding indexes. When enabled, an HNSW graph is built next to each repository embedding index when the repository is fully indexed, and searched instead of scanning every embedding. Incremental updates discard the graph, so the repository is searched exactly until its next full index.
+	ApproximateSearch *ApproximateSearch `json:"approximateSearch,omitempty"`
 	// Dimensions description: The dimensionality of the embedding vectors. Required field if not using the sourcegraph provider.
 	Dimensions int `json:"dimensions,omitempty"`
 	// Enabled description: Toggles whether embedding service is enabled.
 	Enabled *bool `json:"enabled,omitempty"`
-	// Endpoint description: The endpoint under which to reach the provider. Sensible default will be used for each provider.
+	// Endpoint description: The endpoint under which to reach the provider. Sensible default will be used for each provider. For provider openai-compatible, this is the required base URL of the OpenAI-compatible API of a self-hosted model server, for example "http://localhost:8000/v1".
 	Endpoint string `json:"endpoint,omitempty"`
 	// ExcludeChunkOnError description: Whether to cancel indexing a repo if embedding a single file fails. If true, the chunk that cannot generate embeddings is not indexed and the remainder of the repository proceeds with indexing.
 	ExcludeChunkOnError *bool `json:"excludeChunkOnError,omitempty"`
@@ -851,6 +865,8 @@ type ExperimentalFeatures struct {
 	EventLogging string `json:"eventLogging,omitempty"`
 	// GitServerPinnedRepos description: List of repositories pinned to specific gitserver instances. The specified repositories will remain at their pinned servers on scaling the cluster. If the specified pinned server differs from the current server that stores the repository, then it must be re-cloned to the specified server.
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
+	// GitServerReplicationFactor description: The number of gitserver instances each repository is cloned on. Reads fail over to a healthy replica when the primary instance of a repository is unavailable, while writes always go to the primary. Values larger than the number of gitserver instances are capped.
+	GitServerReplicationFactor int `json:"gitServerReplicationFactor,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
 	GoPackages string `json:"goPackages,omitempty"`
 	// InsightsAlternateLoadingStrategy description: Use an in-memory strategy of loading Code Insights. Should only be used for benchmarking on large instances, not for customer use currently.
@@ -865,6 +881,8 @@ type ExperimentalFeatures struct {
 	NpmPackages string `json:"npmPackages,omitempty"`
 	// Pagure description: Allow adding Pagure code host connections
 	Pagure string `json:"pagure,omitempty"`
+	// PartialClones description: JSON array of configuration that maps from Git clone URL domain/path to a partial clone filter. Matching repositories are cloned without the blobs excluded by the filter, which gitserver fetches from the code host when they are first read. A mapping applies to every repository whose clone URL domain/path starts with `domainPath`, so it can match a single repository, an organization or a whole code host. The longest matching `domainPath` wins. Changing the filter only affects repositories cloned afterwards.
+	PartialClones []*PartialCloneMapping `json:"partialClones,omitempty"`
 	// PasswordPolicy description: DEPRECATED: this is now a standard feature see: auth.passwordPolicy
 	PasswordPolicy *PasswordPolicy `json:"passwordPolicy,omitempty"`
 	// Perforce description: Allow adding Perforce code host connections
@@ -938,6 +956,7 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "enableStorm")
 	delete(m, "eventLogging")
 	delete(m, "gitServerPinnedRepos")
+	delete(m, "gitServerReplicationFactor")
 	delete(m, "goPackages")
 	delete(m, "insightsAlternateLoadingStrategy")
 	delete(m, "insightsBackfillerV2")
@@ -945,6 +964,7 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "jvmPackages")
 	delete(m, "npmPackages")
 	delete(m, "pagure")
+	delete(m, "partialClones")
 	delete(m, "passwordPolicy")
 	delete(m, "perforce")
 	delete(m, "perforceChangelistMapping")
@@ -1842,6 +1862,14 @@ type ParentSourcegraph struct {
 	Url string `json:"url,omitempty"`
 }
 
+// PartialCloneMapping description: Mapping from Git clone URL domain/path to a partial clone filter.
+type PartialCloneMapping struct {
+	// DomainPath description: Git clone URL domain/path prefix, such as `github.com`, `github.com/myorg` or `github.com/myorg/monorepo`.
+	DomainPath string `json:"domainPath"`
+	// Filter description: The object filter passed to `git fetch --filter`. `blob:none` omits all blobs, `blob:limit=<n>[kmg]` omits blobs larger than the given size.
+	Filter string `json:"filter"`
+}
+
 // PasswordPolicy description: DEPRECATED: this is now a standard feature see: auth.passwordPolicy
 type PasswordPolicy struct {
 	// Enabled description: Enables password policy